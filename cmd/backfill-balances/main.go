@@ -0,0 +1,34 @@
+package main
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"github.com/samber/do"
+	"ulascansenturk/service/internal/appbase"
+	"ulascansenturk/service/internal/transactions"
+)
+
+const (
+	serviceName = "backfill-balances"
+)
+
+// main writes the balance_after of the transactions posted before it was recorded. It is safe to run again, the
+// transactions that have one are left as they are.
+func main() {
+	app := appbase.New(
+		appbase.Init(serviceName),
+		appbase.WithDependencyInjector(),
+	)
+	defer app.Shutdown()
+
+	balanceHistory := do.MustInvoke[*transactions.BalanceHistoryService](app.Injector)
+
+	backfilled, err := balanceHistory.BackfillBalanceAfter(context.Background())
+	if err != nil {
+		log.Err(err).Int64("backfilled", backfilled).Msg("balance backfill failed")
+
+		return
+	}
+
+	log.Info().Int64("backfilled", backfilled).Msg("balance backfill finished")
+}
@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"ulascansenturk/service/cmd/utils"
 	"ulascansenturk/service/internal/api"
+	"ulascansenturk/service/internal/outbox"
 
 	"ulascansenturk/service/internal/appbase"
 )
@@ -53,6 +54,14 @@ func main() {
 		log.Info().Msgf("temporal worker started !")
 	}()
 
+	if app.Config.OutboxRelayEnabled {
+		relay := do.MustInvoke[*outbox.Relay](app.Injector)
+
+		go relay.Run(ctx)
+
+		log.Info().Msgf("outbox relay started !")
+	}
+
 	serverErr := httpServer.ListenAndServe()
 	if serverErr != nil {
 		log.Err(serverErr).Msg("server stopped")
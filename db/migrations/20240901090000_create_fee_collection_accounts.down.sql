@@ -0,0 +1,3 @@
+DELETE FROM accounts WHERE user_id = '5f1c3a6e-8d2b-4c7e-9a1f-000000000000';
+
+DELETE FROM users WHERE id = '5f1c3a6e-8d2b-4c7e-9a1f-000000000000';
@@ -0,0 +1,11 @@
+-- House user owning the accounts that collect transfer fees
+INSERT INTO users (id, email, password_hash, first_name, last_name, is_active)
+VALUES ('5f1c3a6e-8d2b-4c7e-9a1f-000000000000', 'fee-collection@service.local', '!', 'Fee', 'Collection', true)
+ON CONFLICT (id) DO NOTHING;
+
+-- One fee collection account per supported currency, see FEE_COLLECTION_ACCOUNTS
+INSERT INTO accounts (id, user_id, balance, currency, status)
+VALUES ('5f1c3a6e-8d2b-4c7e-9a1f-000000000949', '5f1c3a6e-8d2b-4c7e-9a1f-000000000000', 0, 'TRY', 'ACTIVE'),
+       ('5f1c3a6e-8d2b-4c7e-9a1f-000000000840', '5f1c3a6e-8d2b-4c7e-9a1f-000000000000', 0, 'USD', 'ACTIVE'),
+       ('5f1c3a6e-8d2b-4c7e-9a1f-000000000978', '5f1c3a6e-8d2b-4c7e-9a1f-000000000000', 0, 'EUR', 'ACTIVE')
+ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_accounts_product;
+
+ALTER TABLE accounts DROP COLUMN IF EXISTS product;
@@ -0,0 +1,3 @@
+ALTER TABLE accounts ADD COLUMN product VARCHAR(50) NOT NULL DEFAULT 'STANDARD';
+
+CREATE INDEX idx_accounts_product ON accounts(product);
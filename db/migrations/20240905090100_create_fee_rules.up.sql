@@ -0,0 +1,23 @@
+CREATE TABLE fee_rules (
+                           id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                           code VARCHAR(100) NOT NULL,
+                           version INT NOT NULL,
+                           rule_type VARCHAR(20) NOT NULL,
+                           currency VARCHAR(3),
+                           account_product VARCHAR(50),
+                           flat_amount BIGINT NOT NULL DEFAULT 0 CHECK (flat_amount >= 0),
+                           percentage_bps INT NOT NULL DEFAULT 0 CHECK (percentage_bps >= 0 AND percentage_bps <= 10000),
+                           min_amount BIGINT CHECK (min_amount >= 0),
+                           max_amount BIGINT CHECK (max_amount >= 0),
+                           tiers JSONB,
+                           effective_from TIMESTAMP WITH TIME ZONE NOT NULL,
+                           effective_to TIMESTAMP WITH TIME ZONE,
+                           created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                           CONSTRAINT uq_fee_rules_code_version UNIQUE (code, version)
+);
+
+-- Indexes
+CREATE INDEX idx_fee_rules_currency ON fee_rules(currency);
+CREATE INDEX idx_fee_rules_account_product ON fee_rules(account_product);
+CREATE INDEX idx_fee_rules_effective_from ON fee_rules(effective_from);
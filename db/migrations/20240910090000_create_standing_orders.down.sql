@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS standing_order_occurrences;
+
+DROP TABLE IF EXISTS standing_orders;
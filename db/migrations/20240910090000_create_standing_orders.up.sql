@@ -0,0 +1,38 @@
+CREATE TABLE standing_orders (
+                                 id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                 source_account_id UUID NOT NULL,
+                                 destination_account_id UUID NOT NULL,
+                                 amount BIGINT NOT NULL CHECK (amount > 0),
+                                 metadata JSONB,
+                                 frequency VARCHAR(20) NOT NULL,
+                                 cron_expression VARCHAR(100),
+                                 day_of_week INT CHECK (day_of_week BETWEEN 0 AND 6),
+                                 day_of_month INT CHECK (day_of_month BETWEEN 1 AND 31),
+                                 hour INT NOT NULL DEFAULT 0 CHECK (hour BETWEEN 0 AND 23),
+                                 minute INT NOT NULL DEFAULT 0 CHECK (minute BETWEEN 0 AND 59),
+                                 start_at TIMESTAMP WITH TIME ZONE,
+                                 end_at TIMESTAMP WITH TIME ZONE,
+                                 max_count INT CHECK (max_count > 0),
+                                 executed_count INT NOT NULL DEFAULT 0,
+                                 failed_count INT NOT NULL DEFAULT 0,
+                                 status VARCHAR(20) NOT NULL DEFAULT 'ACTIVE',
+                                 schedule_id VARCHAR(100) NOT NULL,
+                                 created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                 updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_standing_orders_source_account_id ON standing_orders(source_account_id);
+CREATE INDEX idx_standing_orders_status ON standing_orders(status);
+
+CREATE TABLE standing_order_occurrences (
+                                            id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                            standing_order_id UUID NOT NULL REFERENCES standing_orders(id),
+                                            reference_id UUID NOT NULL,
+                                            workflow_id VARCHAR(255) NOT NULL,
+                                            status VARCHAR(20) NOT NULL,
+                                            failure_reason TEXT,
+                                            created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                            CONSTRAINT uq_standing_order_occurrences_reference_id UNIQUE (reference_id)
+);
+
+CREATE INDEX idx_standing_order_occurrences_standing_order_id ON standing_order_occurrences(standing_order_id);
@@ -0,0 +1,15 @@
+CREATE TABLE transfer_approvals (
+                                    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                    reference_id UUID NOT NULL,
+                                    decision VARCHAR(20) NOT NULL,
+                                    approver VARCHAR(255) NOT NULL,
+                                    reason TEXT,
+                                    amount BIGINT NOT NULL,
+                                    currency VARCHAR(3) NOT NULL,
+                                    threshold BIGINT NOT NULL,
+                                    decided_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                                    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                    CONSTRAINT uq_transfer_approvals_reference_id UNIQUE (reference_id)
+);
+
+CREATE INDEX idx_transfer_approvals_approver ON transfer_approvals(approver);
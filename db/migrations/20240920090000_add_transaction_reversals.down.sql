@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_transactions_original_transaction_id;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS original_transaction_id;
+
+ALTER TABLE transactions ALTER COLUMN transaction_type TYPE VARCHAR(20);
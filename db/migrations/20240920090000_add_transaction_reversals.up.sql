@@ -0,0 +1,7 @@
+-- Reversal transaction types don't fit in 20 characters
+ALTER TABLE transactions ALTER COLUMN transaction_type TYPE VARCHAR(50);
+
+-- Links a reversal transaction to the transaction it reverses
+ALTER TABLE transactions ADD COLUMN original_transaction_id UUID REFERENCES transactions(id);
+
+CREATE INDEX idx_transactions_original_transaction_id ON transactions(original_transaction_id, transaction_type);
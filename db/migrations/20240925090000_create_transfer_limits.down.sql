@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_transactions_account_id_type_created_at;
+
+DROP TABLE IF EXISTS transfer_limits;
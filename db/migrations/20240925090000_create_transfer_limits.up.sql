@@ -0,0 +1,30 @@
+CREATE TABLE transfer_limits (
+                                 id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                                 account_id UUID REFERENCES accounts(id),
+                                 account_product VARCHAR(50),
+                                 currency VARCHAR(3),
+                                 max_single_amount BIGINT CHECK (max_single_amount >= 0),
+                                 daily_amount BIGINT CHECK (daily_amount >= 0),
+                                 monthly_amount BIGINT CHECK (monthly_amount >= 0),
+                                 daily_count INT CHECK (daily_count >= 0),
+                                 created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                                 updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- A scope has at most one limit, NULL means any
+CREATE UNIQUE INDEX uq_transfer_limits_scope ON transfer_limits (
+    COALESCE(account_id, '00000000-0000-0000-0000-000000000000'::uuid),
+    COALESCE(account_product, ''),
+    COALESCE(currency, '')
+);
+
+CREATE INDEX idx_transfer_limits_account_id ON transfer_limits(account_id);
+
+-- Outgoing totals are summed per account and day
+CREATE INDEX idx_transactions_account_id_type_created_at ON transactions(account_id, transaction_type, created_at);
+
+-- Default limits per currency, in minor units
+INSERT INTO transfer_limits (currency, max_single_amount, daily_amount, monthly_amount, daily_count) VALUES
+    ('TRY', 50000000, 100000000, 1000000000, 50),
+    ('USD', 1000000, 2500000, 25000000, 50),
+    ('EUR', 1000000, 2500000, 25000000, 50);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS holds;
+
+ALTER TABLE accounts DROP CONSTRAINT IF EXISTS chk_accounts_held_balance;
+ALTER TABLE accounts DROP COLUMN IF EXISTS held_balance;
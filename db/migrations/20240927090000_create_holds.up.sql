@@ -0,0 +1,22 @@
+-- Funds reserved by active holds, the available balance is balance - held_balance
+ALTER TABLE accounts ADD COLUMN held_balance BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE accounts ADD CONSTRAINT chk_accounts_held_balance CHECK (held_balance >= 0 AND held_balance <= balance);
+
+CREATE TABLE holds (
+                       id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                       reference_id UUID NOT NULL UNIQUE,
+                       account_id UUID NOT NULL REFERENCES accounts(id),
+                       destination_account_id UUID NOT NULL REFERENCES accounts(id),
+                       amount BIGINT NOT NULL CHECK (amount > 0),
+                       captured_amount BIGINT NOT NULL DEFAULT 0 CHECK (captured_amount >= 0 AND captured_amount <= amount),
+                       currency VARCHAR(3) NOT NULL,
+                       status VARCHAR(20) NOT NULL,
+                       description TEXT,
+                       release_reason TEXT,
+                       expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                       released_at TIMESTAMP WITH TIME ZONE,
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_holds_account_id_status ON holds(account_id, status);
@@ -0,0 +1,2 @@
+-- Highest fencing token of the account locks a balance update was made under, a lower token is from a lock that expired
+ALTER TABLE accounts ADD COLUMN fencing_token BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,7 @@
+-- Leases of the Postgres mutex backend, a row is kept after its release so the fencing token keeps growing
+CREATE TABLE mutex_leases (
+                       key TEXT PRIMARY KEY,
+                       owner TEXT NOT NULL,
+                       fencing_token BIGINT NOT NULL,
+                       expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
@@ -0,0 +1,78 @@
+-- Chart of accounts. Customer and fee income accounts share the ID of their accounts row, the balance column of
+-- the row caches the balance of their postings. House accounts have a name based ID, see ledger.HouseAccountID.
+CREATE TABLE ledger_accounts (
+                       id UUID PRIMARY KEY,
+                       account_type VARCHAR(20) NOT NULL,
+                       currency VARCHAR(3) NOT NULL,
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE journal_entries (
+                       id UUID PRIMARY KEY,
+                       entry_type VARCHAR(20) NOT NULL,
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE postings (
+                       id UUID PRIMARY KEY,
+                       journal_entry_id UUID NOT NULL REFERENCES journal_entries(id),
+                       ledger_account_id UUID NOT NULL REFERENCES ledger_accounts(id),
+                       transaction_id UUID REFERENCES transactions(id),
+                       direction VARCHAR(6) NOT NULL CHECK (direction IN ('DEBIT', 'CREDIT')),
+                       amount BIGINT NOT NULL CHECK (amount > 0),
+                       currency VARCHAR(3) NOT NULL,
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_postings_journal_entry_id ON postings(journal_entry_id);
+CREATE INDEX idx_postings_ledger_account_id ON postings(ledger_account_id, created_at);
+CREATE INDEX idx_postings_transaction_id ON postings(transaction_id);
+
+-- The debits of every currency of an entry must add up to its credits, checked once all its postings are in
+CREATE FUNCTION check_journal_entry_balanced() RETURNS trigger AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM postings
+        WHERE journal_entry_id = NEW.journal_entry_id
+        GROUP BY currency
+        HAVING SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.journal_entry_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE CONSTRAINT TRIGGER trg_postings_balanced
+    AFTER INSERT ON postings
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION check_journal_entry_balanced();
+
+-- Existing accounts, the fee collection accounts collect fee income
+INSERT INTO ledger_accounts (id, account_type, currency)
+SELECT id,
+       CASE WHEN user_id = '5f1c3a6e-8d2b-4c7e-9a1f-000000000000' THEN 'FEE_INCOME' ELSE 'CUSTOMER' END,
+       currency
+FROM accounts;
+
+INSERT INTO ledger_accounts (id, account_type, currency)
+SELECT uuid_generate_v5(uuid_ns_url(), 'ledger:FUNDING:' || currency), 'FUNDING', currency
+FROM accounts
+GROUP BY currency;
+
+-- Balances from before the ledger are funded by an opening entry
+WITH opening AS (
+    SELECT id AS account_id, balance, currency, uuid_generate_v4() AS entry_id
+    FROM accounts
+    WHERE balance > 0
+), entries AS (
+    INSERT INTO journal_entries (id, entry_type)
+    SELECT entry_id, 'OPENING_BALANCE' FROM opening
+)
+INSERT INTO postings (id, journal_entry_id, ledger_account_id, direction, amount, currency)
+SELECT uuid_generate_v4(), entry_id, uuid_generate_v5(uuid_ns_url(), 'ledger:FUNDING:' || currency), 'DEBIT', balance, currency
+FROM opening
+UNION ALL
+SELECT uuid_generate_v4(), entry_id, account_id, 'CREDIT', balance, currency
+FROM opening;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_transactions_account_id_posted_at;
+
+ALTER TABLE transactions DROP COLUMN IF EXISTS posted_at;
+ALTER TABLE transactions DROP COLUMN IF EXISTS balance_after;
@@ -0,0 +1,5 @@
+-- Balance of the account once the transaction was posted and when it was, the transactions posted together share both
+ALTER TABLE transactions ADD COLUMN balance_after BIGINT;
+ALTER TABLE transactions ADD COLUMN posted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_transactions_account_id_posted_at ON transactions(account_id, posted_at) WHERE posted_at IS NOT NULL;
@@ -0,0 +1,17 @@
+-- Domain events written in the transaction of the change they describe, the relay publishes them in sequence order
+CREATE TABLE outbox_events (
+                       id UUID PRIMARY KEY,
+                       sequence BIGSERIAL NOT NULL UNIQUE,
+                       event_type VARCHAR(50) NOT NULL,
+                       schema_version INT NOT NULL,
+                       aggregate_type VARCHAR(20) NOT NULL,
+                       aggregate_id UUID NOT NULL,
+                       payload JSONB NOT NULL,
+                       occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+                       published_at TIMESTAMP WITH TIME ZONE,
+                       attempts INT NOT NULL DEFAULT 0,
+                       last_error TEXT
+);
+
+CREATE INDEX idx_outbox_events_unpublished ON outbox_events(sequence) WHERE published_at IS NULL;
+CREATE INDEX idx_outbox_events_aggregate ON outbox_events(aggregate_type, aggregate_id, sequence);
@@ -0,0 +1,37 @@
+-- Webhook subscriptions of the accounts, a subscription is disabled after too many deliveries failed in a row
+CREATE TABLE webhook_subscriptions (
+                       id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                       account_id UUID NOT NULL REFERENCES accounts(id),
+                       url TEXT NOT NULL,
+                       secret VARCHAR(100) NOT NULL,
+                       event_types JSONB NOT NULL,
+                       description TEXT,
+                       status VARCHAR(20) NOT NULL,
+                       consecutive_failures INT NOT NULL DEFAULT 0,
+                       disabled_at TIMESTAMP WITH TIME ZONE,
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_webhook_subscriptions_account_id_status ON webhook_subscriptions(account_id, status);
+
+-- One delivery per subscription and outbox event, attempts counts the requests sent for it
+CREATE TABLE webhook_deliveries (
+                       id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+                       subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+                       event_id UUID NOT NULL,
+                       event_type VARCHAR(50) NOT NULL,
+                       payload JSONB NOT NULL,
+                       status VARCHAR(20) NOT NULL,
+                       attempts INT NOT NULL DEFAULT 0,
+                       redeliveries INT NOT NULL DEFAULT 0,
+                       last_response_status INT,
+                       last_error TEXT,
+                       last_attempted_at TIMESTAMP WITH TIME ZONE,
+                       delivered_at TIMESTAMP WITH TIME ZONE,
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       UNIQUE (subscription_id, event_id)
+);
+
+CREATE INDEX idx_webhook_deliveries_subscription_id_created_at ON webhook_deliveries(subscription_id, created_at DESC);
@@ -0,0 +1,15 @@
+-- Notification preferences of the users, a user without a row gets the defaults
+CREATE TABLE notification_preferences (
+                       user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+                       email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+                       sms_enabled BOOLEAN NOT NULL DEFAULT FALSE,
+                       phone_number VARCHAR(20),
+                       transfer_sent BOOLEAN NOT NULL DEFAULT TRUE,
+                       transfer_received BOOLEAN NOT NULL DEFAULT TRUE,
+                       transfer_failed BOOLEAN NOT NULL DEFAULT TRUE,
+                       low_balance BOOLEAN NOT NULL DEFAULT TRUE,
+                       low_balance_threshold BIGINT NOT NULL CHECK (low_balance_threshold >= 0),
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+                       CONSTRAINT chk_notification_preferences_sms_phone CHECK (NOT sms_enabled OR phone_number IS NOT NULL)
+);
@@ -0,0 +1,34 @@
+-- Append-only log of every change to the audited tables, the trigger rejects any change to a recorded event
+CREATE TABLE audit_events (
+                       id UUID PRIMARY KEY,
+                       sequence BIGSERIAL NOT NULL UNIQUE,
+                       action VARCHAR(20) NOT NULL,
+                       entity_type VARCHAR(100) NOT NULL,
+                       entity_id VARCHAR(100) NOT NULL,
+                       actor VARCHAR(255) NOT NULL,
+                       source_type VARCHAR(20) NOT NULL,
+                       source_id VARCHAR(255),
+                       before JSONB,
+                       after JSONB,
+                       changes JSONB NOT NULL,
+                       occurred_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events(entity_type, entity_id, sequence);
+CREATE INDEX idx_audit_events_actor ON audit_events(actor, sequence);
+CREATE INDEX idx_audit_events_source ON audit_events(source_id, sequence);
+CREATE INDEX idx_audit_events_occurred_at ON audit_events(occurred_at);
+
+CREATE FUNCTION reject_audit_event_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are immutable';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_immutable
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION reject_audit_event_change();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION reject_audit_event_change();
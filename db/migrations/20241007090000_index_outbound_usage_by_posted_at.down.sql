@@ -0,0 +1,3 @@
+CREATE INDEX IF NOT EXISTS idx_transactions_account_id_type_created_at ON transactions(account_id, transaction_type, created_at);
+
+DROP INDEX IF EXISTS idx_transactions_account_id_type_posted_at;
@@ -0,0 +1,4 @@
+-- Outgoing totals are summed per account and day of posting
+CREATE INDEX idx_transactions_account_id_type_posted_at ON transactions(account_id, transaction_type, posted_at) WHERE posted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS idx_transactions_account_id_type_created_at;
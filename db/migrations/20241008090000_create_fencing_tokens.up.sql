@@ -0,0 +1,6 @@
+-- Fencing tokens of the account locks, counted in the database so a Redis flush never hands out a token below the
+-- ones already used
+CREATE TABLE fencing_tokens (
+                       key TEXT PRIMARY KEY,
+                       token BIGINT NOT NULL
+);
@@ -0,0 +1,6 @@
+ALTER TABLE mutex_leases ADD COLUMN fencing_token BIGINT NOT NULL DEFAULT 0;
+
+UPDATE mutex_leases SET fencing_token = fencing_tokens.token
+FROM fencing_tokens WHERE fencing_tokens.key = mutex_leases.key;
+
+ALTER TABLE mutex_leases ALTER COLUMN fencing_token DROP DEFAULT;
@@ -0,0 +1,7 @@
+-- The postgres mutex backend counts its fencing tokens in fencing_tokens like the redis one, a switch of backend
+-- continues from the same counter
+INSERT INTO fencing_tokens (key, token)
+SELECT key, fencing_token FROM mutex_leases
+ON CONFLICT (key) DO UPDATE SET token = GREATEST(fencing_tokens.token, EXCLUDED.token);
+
+ALTER TABLE mutex_leases DROP COLUMN fencing_token;
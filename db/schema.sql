@@ -39,6 +39,71 @@ CREATE EXTENSION IF NOT EXISTS "uuid-ossp" WITH SCHEMA public;
 COMMENT ON EXTENSION "uuid-ossp" IS 'generate universally unique identifiers (UUIDs)';
 
 
+--
+-- Name: account_status; Type: TYPE; Schema: public; Owner: root
+--
+
+CREATE TYPE public.account_status AS ENUM (
+    'ACTIVE',
+    'BLACKLISTED',
+    'CLOSED'
+);
+
+
+ALTER TYPE public.account_status OWNER TO root;
+
+--
+-- Name: transaction_status; Type: TYPE; Schema: public; Owner: root
+--
+
+CREATE TYPE public.transaction_status AS ENUM (
+    'PENDING',
+    'SUCCESS',
+    'FAILURE'
+);
+
+
+ALTER TYPE public.transaction_status OWNER TO root;
+
+--
+-- Name: check_journal_entry_balanced(); Type: FUNCTION; Schema: public; Owner: root
+--
+
+CREATE FUNCTION public.check_journal_entry_balanced() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    IF EXISTS (
+        SELECT 1 FROM postings
+        WHERE journal_entry_id = NEW.journal_entry_id
+        GROUP BY currency
+        HAVING SUM(CASE WHEN direction = 'DEBIT' THEN amount ELSE -amount END) <> 0
+    ) THEN
+        RAISE EXCEPTION 'journal entry % is not balanced', NEW.journal_entry_id;
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+
+ALTER FUNCTION public.check_journal_entry_balanced() OWNER TO root;
+
+--
+-- Name: reject_audit_event_change(); Type: FUNCTION; Schema: public; Owner: root
+--
+
+CREATE FUNCTION public.reject_audit_event_change() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are immutable';
+END;
+$$;
+
+
+ALTER FUNCTION public.reject_audit_event_change() OWNER TO root;
+
 SET default_tablespace = '';
 
 SET default_table_access_method = heap;
@@ -48,19 +113,258 @@ SET default_table_access_method = heap;
 --
 
 CREATE TABLE public.accounts (
-    id uuid NOT NULL,
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
     user_id uuid NOT NULL,
-    balance numeric(15,2) NOT NULL,
+    balance bigint NOT NULL,
     currency character varying(3) NOT NULL,
-    status character varying(50) NOT NULL,
+    status public.account_status DEFAULT 'ACTIVE'::public.account_status NOT NULL,
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
     updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
-    CONSTRAINT accounts_balance_check CHECK ((balance >= (0)::numeric))
+    product character varying(50) DEFAULT 'STANDARD'::character varying NOT NULL,
+    held_balance bigint DEFAULT 0 NOT NULL,
+    fencing_token bigint DEFAULT 0 NOT NULL,
+    CONSTRAINT accounts_balance_check CHECK ((balance >= 0)),
+    CONSTRAINT chk_accounts_held_balance CHECK (((held_balance >= 0) AND (held_balance <= balance)))
 );
 
 
 ALTER TABLE public.accounts OWNER TO root;
 
+--
+-- Name: audit_events; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.audit_events (
+    id uuid NOT NULL,
+    sequence bigint NOT NULL,
+    action character varying(20) NOT NULL,
+    entity_type character varying(100) NOT NULL,
+    entity_id character varying(100) NOT NULL,
+    actor character varying(255) NOT NULL,
+    source_type character varying(20) NOT NULL,
+    source_id character varying(255),
+    before jsonb,
+    after jsonb,
+    changes jsonb NOT NULL,
+    occurred_at timestamp with time zone NOT NULL
+);
+
+
+ALTER TABLE public.audit_events OWNER TO root;
+
+--
+-- Name: audit_events_sequence_seq; Type: SEQUENCE; Schema: public; Owner: root
+--
+
+CREATE SEQUENCE public.audit_events_sequence_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER SEQUENCE public.audit_events_sequence_seq OWNER TO root;
+
+--
+-- Name: audit_events_sequence_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: root
+--
+
+ALTER SEQUENCE public.audit_events_sequence_seq OWNED BY public.audit_events.sequence;
+
+
+--
+-- Name: fee_rules; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.fee_rules (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    code character varying(100) NOT NULL,
+    version integer NOT NULL,
+    rule_type character varying(20) NOT NULL,
+    currency character varying(3),
+    account_product character varying(50),
+    flat_amount bigint DEFAULT 0 NOT NULL,
+    percentage_bps integer DEFAULT 0 NOT NULL,
+    min_amount bigint,
+    max_amount bigint,
+    tiers jsonb,
+    effective_from timestamp with time zone NOT NULL,
+    effective_to timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT fee_rules_flat_amount_check CHECK ((flat_amount >= 0)),
+    CONSTRAINT fee_rules_max_amount_check CHECK ((max_amount >= 0)),
+    CONSTRAINT fee_rules_min_amount_check CHECK ((min_amount >= 0)),
+    CONSTRAINT fee_rules_percentage_bps_check CHECK (((percentage_bps >= 0) AND (percentage_bps <= 10000)))
+);
+
+
+ALTER TABLE public.fee_rules OWNER TO root;
+
+--
+-- Name: fencing_tokens; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.fencing_tokens (
+    key text NOT NULL,
+    token bigint NOT NULL
+);
+
+
+ALTER TABLE public.fencing_tokens OWNER TO root;
+
+--
+-- Name: holds; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.holds (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    reference_id uuid NOT NULL,
+    account_id uuid NOT NULL,
+    destination_account_id uuid NOT NULL,
+    amount bigint NOT NULL,
+    captured_amount bigint DEFAULT 0 NOT NULL,
+    currency character varying(3) NOT NULL,
+    status character varying(20) NOT NULL,
+    description text,
+    release_reason text,
+    expires_at timestamp with time zone NOT NULL,
+    released_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT holds_amount_check CHECK ((amount > 0)),
+    CONSTRAINT holds_captured_amount_check CHECK (((captured_amount >= 0) AND (captured_amount <= amount)))
+);
+
+
+ALTER TABLE public.holds OWNER TO root;
+
+--
+-- Name: journal_entries; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.journal_entries (
+    id uuid NOT NULL,
+    entry_type character varying(20) NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.journal_entries OWNER TO root;
+
+--
+-- Name: ledger_accounts; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.ledger_accounts (
+    id uuid NOT NULL,
+    account_type character varying(20) NOT NULL,
+    currency character varying(3) NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.ledger_accounts OWNER TO root;
+
+--
+-- Name: mutex_leases; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.mutex_leases (
+    key text NOT NULL,
+    owner text NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+
+ALTER TABLE public.mutex_leases OWNER TO root;
+
+--
+-- Name: notification_preferences; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.notification_preferences (
+    user_id uuid NOT NULL,
+    email_enabled boolean DEFAULT true NOT NULL,
+    sms_enabled boolean DEFAULT false NOT NULL,
+    phone_number character varying(20),
+    transfer_sent boolean DEFAULT true NOT NULL,
+    transfer_received boolean DEFAULT true NOT NULL,
+    transfer_failed boolean DEFAULT true NOT NULL,
+    low_balance boolean DEFAULT true NOT NULL,
+    low_balance_threshold bigint NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT chk_notification_preferences_sms_phone CHECK (((NOT sms_enabled) OR (phone_number IS NOT NULL))),
+    CONSTRAINT notification_preferences_low_balance_threshold_check CHECK ((low_balance_threshold >= 0))
+);
+
+
+ALTER TABLE public.notification_preferences OWNER TO root;
+
+--
+-- Name: outbox_events; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.outbox_events (
+    id uuid NOT NULL,
+    sequence bigint NOT NULL,
+    event_type character varying(50) NOT NULL,
+    schema_version integer NOT NULL,
+    aggregate_type character varying(20) NOT NULL,
+    aggregate_id uuid NOT NULL,
+    payload jsonb NOT NULL,
+    occurred_at timestamp with time zone NOT NULL,
+    published_at timestamp with time zone,
+    attempts integer DEFAULT 0 NOT NULL,
+    last_error text
+);
+
+
+ALTER TABLE public.outbox_events OWNER TO root;
+
+--
+-- Name: outbox_events_sequence_seq; Type: SEQUENCE; Schema: public; Owner: root
+--
+
+CREATE SEQUENCE public.outbox_events_sequence_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+ALTER SEQUENCE public.outbox_events_sequence_seq OWNER TO root;
+
+--
+-- Name: outbox_events_sequence_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: root
+--
+
+ALTER SEQUENCE public.outbox_events_sequence_seq OWNED BY public.outbox_events.sequence;
+
+
+--
+-- Name: postings; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.postings (
+    id uuid NOT NULL,
+    journal_entry_id uuid NOT NULL,
+    ledger_account_id uuid NOT NULL,
+    transaction_id uuid,
+    direction character varying(6) NOT NULL,
+    amount bigint NOT NULL,
+    currency character varying(3) NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT postings_amount_check CHECK ((amount > 0)),
+    CONSTRAINT postings_direction_check CHECK (((direction)::text = ANY ((ARRAY['DEBIT'::character varying, 'CREDIT'::character varying])::text[])))
+);
+
+
+ALTER TABLE public.postings OWNER TO root;
+
 --
 -- Name: schema_migrations; Type: TABLE; Schema: public; Owner: root
 --
@@ -73,27 +377,127 @@ CREATE TABLE public.schema_migrations (
 
 ALTER TABLE public.schema_migrations OWNER TO root;
 
+--
+-- Name: standing_order_occurrences; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.standing_order_occurrences (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    standing_order_id uuid NOT NULL,
+    reference_id uuid NOT NULL,
+    workflow_id character varying(255) NOT NULL,
+    status character varying(20) NOT NULL,
+    failure_reason text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.standing_order_occurrences OWNER TO root;
+
+--
+-- Name: standing_orders; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.standing_orders (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    source_account_id uuid NOT NULL,
+    destination_account_id uuid NOT NULL,
+    amount bigint NOT NULL,
+    metadata jsonb,
+    frequency character varying(20) NOT NULL,
+    cron_expression character varying(100),
+    day_of_week integer,
+    day_of_month integer,
+    hour integer DEFAULT 0 NOT NULL,
+    minute integer DEFAULT 0 NOT NULL,
+    start_at timestamp with time zone,
+    end_at timestamp with time zone,
+    max_count integer,
+    executed_count integer DEFAULT 0 NOT NULL,
+    failed_count integer DEFAULT 0 NOT NULL,
+    status character varying(20) DEFAULT 'ACTIVE'::character varying NOT NULL,
+    schedule_id character varying(100) NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT standing_orders_amount_check CHECK ((amount > 0)),
+    CONSTRAINT standing_orders_day_of_month_check CHECK (((day_of_month >= 1) AND (day_of_month <= 31))),
+    CONSTRAINT standing_orders_day_of_week_check CHECK (((day_of_week >= 0) AND (day_of_week <= 6))),
+    CONSTRAINT standing_orders_hour_check CHECK (((hour >= 0) AND (hour <= 23))),
+    CONSTRAINT standing_orders_max_count_check CHECK ((max_count > 0)),
+    CONSTRAINT standing_orders_minute_check CHECK (((minute >= 0) AND (minute <= 59)))
+);
+
+
+ALTER TABLE public.standing_orders OWNER TO root;
+
 --
 -- Name: transactions; Type: TABLE; Schema: public; Owner: root
 --
 
 CREATE TABLE public.transactions (
-    id uuid NOT NULL,
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
     user_id uuid,
     amount integer NOT NULL,
     account_id uuid NOT NULL,
     currency_code character varying(10) NOT NULL,
     reference_id uuid NOT NULL,
     metadata jsonb,
-    status character varying(20) NOT NULL,
-    transaction_type character varying(20) NOT NULL,
+    status public.transaction_status NOT NULL,
+    transaction_type character varying(50) NOT NULL,
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
-    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    original_transaction_id uuid,
+    balance_after bigint,
+    posted_at timestamp with time zone
 );
 
 
 ALTER TABLE public.transactions OWNER TO root;
 
+--
+-- Name: transfer_approvals; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.transfer_approvals (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    reference_id uuid NOT NULL,
+    decision character varying(20) NOT NULL,
+    approver character varying(255) NOT NULL,
+    reason text,
+    amount bigint NOT NULL,
+    currency character varying(3) NOT NULL,
+    threshold bigint NOT NULL,
+    decided_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+
+ALTER TABLE public.transfer_approvals OWNER TO root;
+
+--
+-- Name: transfer_limits; Type: TABLE; Schema: public; Owner: root
+--
+
+CREATE TABLE public.transfer_limits (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    account_id uuid,
+    account_product character varying(50),
+    currency character varying(3),
+    max_single_amount bigint,
+    daily_amount bigint,
+    monthly_amount bigint,
+    daily_count integer,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    CONSTRAINT transfer_limits_daily_amount_check CHECK ((daily_amount >= 0)),
+    CONSTRAINT transfer_limits_daily_count_check CHECK ((daily_count >= 0)),
+    CONSTRAINT transfer_limits_max_single_amount_check CHECK ((max_single_amount >= 0)),
+    CONSTRAINT transfer_limits_monthly_amount_check CHECK ((monthly_amount >= 0))
+);
+
+
+ALTER TABLE public.transfer_limits OWNER TO root;
+
 --
 -- Name: users; Type: TABLE; Schema: public; Owner: root
 --
@@ -104,7 +508,6 @@ CREATE TABLE public.users (
     password_hash character varying(255) NOT NULL,
     first_name character varying(100) NOT NULL,
     last_name character varying(100) NOT NULL,
-    date_of_birth date NOT NULL,
     phone_number character varying(20),
     is_active boolean DEFAULT true NOT NULL,
     created_at timestamp with time zone DEFAULT now() NOT NULL,
@@ -115,134 +518,661 @@ CREATE TABLE public.users (
 ALTER TABLE public.users OWNER TO root;
 
 --
--- Name: accounts accounts_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+-- Name: webhook_deliveries; Type: TABLE; Schema: public; Owner: root
 --
 
-ALTER TABLE ONLY public.accounts
-    ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
+CREATE TABLE public.webhook_deliveries (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    subscription_id uuid NOT NULL,
+    event_id uuid NOT NULL,
+    event_type character varying(50) NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(20) NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    redeliveries integer DEFAULT 0 NOT NULL,
+    last_response_status integer,
+    last_error text,
+    last_attempted_at timestamp with time zone,
+    delivered_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
 
 
+ALTER TABLE public.webhook_deliveries OWNER TO root;
+
 --
--- Name: schema_migrations schema_migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+-- Name: webhook_subscriptions; Type: TABLE; Schema: public; Owner: root
 --
 
-ALTER TABLE ONLY public.schema_migrations
-    ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
+CREATE TABLE public.webhook_subscriptions (
+    id uuid DEFAULT public.uuid_generate_v4() NOT NULL,
+    account_id uuid NOT NULL,
+    url text NOT NULL,
+    secret character varying(100) NOT NULL,
+    event_types jsonb NOT NULL,
+    description text,
+    status character varying(20) NOT NULL,
+    consecutive_failures integer DEFAULT 0 NOT NULL,
+    disabled_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
 
+ALTER TABLE public.webhook_subscriptions OWNER TO root;
 
 --
--- Name: transactions transactions_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+-- Name: audit_events sequence; Type: DEFAULT; Schema: public; Owner: root
 --
 
-ALTER TABLE ONLY public.transactions
-    ADD CONSTRAINT transactions_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.audit_events ALTER COLUMN sequence SET DEFAULT nextval('public.audit_events_sequence_seq'::regclass);
 
 
 --
--- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: root
+-- Name: outbox_events sequence; Type: DEFAULT; Schema: public; Owner: root
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER TABLE ONLY public.outbox_events ALTER COLUMN sequence SET DEFAULT nextval('public.outbox_events_sequence_seq'::regclass);
 
 
 --
--- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+-- Name: accounts accounts_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
 
 
 --
--- Name: idx_accounts_currency; Type: INDEX; Schema: public; Owner: root
+-- Name: audit_events audit_events_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_accounts_currency ON public.accounts USING btree (currency);
+ALTER TABLE ONLY public.audit_events
+    ADD CONSTRAINT audit_events_pkey PRIMARY KEY (id);
 
 
 --
--- Name: idx_accounts_status; Type: INDEX; Schema: public; Owner: root
+-- Name: audit_events audit_events_sequence_key; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_accounts_status ON public.accounts USING btree (status);
+ALTER TABLE ONLY public.audit_events
+    ADD CONSTRAINT audit_events_sequence_key UNIQUE (sequence);
 
 
 --
--- Name: idx_accounts_user_id; Type: INDEX; Schema: public; Owner: root
+-- Name: fee_rules fee_rules_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_accounts_user_id ON public.accounts USING btree (user_id);
+ALTER TABLE ONLY public.fee_rules
+    ADD CONSTRAINT fee_rules_pkey PRIMARY KEY (id);
 
 
 --
--- Name: idx_transactions_account_id; Type: INDEX; Schema: public; Owner: root
+-- Name: fencing_tokens fencing_tokens_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_account_id ON public.transactions USING btree (account_id);
+ALTER TABLE ONLY public.fencing_tokens
+    ADD CONSTRAINT fencing_tokens_pkey PRIMARY KEY (key);
 
 
 --
--- Name: idx_transactions_account_id_status; Type: INDEX; Schema: public; Owner: root
+-- Name: holds holds_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_account_id_status ON public.transactions USING btree (account_id, status);
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT holds_pkey PRIMARY KEY (id);
 
 
 --
--- Name: idx_transactions_account_id_type; Type: INDEX; Schema: public; Owner: root
+-- Name: holds holds_reference_id_key; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_account_id_type ON public.transactions USING btree (account_id, transaction_type);
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT holds_reference_id_key UNIQUE (reference_id);
 
 
 --
--- Name: idx_transactions_currency_code; Type: INDEX; Schema: public; Owner: root
+-- Name: journal_entries journal_entries_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_currency_code ON public.transactions USING btree (currency_code);
+ALTER TABLE ONLY public.journal_entries
+    ADD CONSTRAINT journal_entries_pkey PRIMARY KEY (id);
 
 
 --
--- Name: idx_transactions_reference_id; Type: INDEX; Schema: public; Owner: root
+-- Name: ledger_accounts ledger_accounts_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_reference_id ON public.transactions USING btree (reference_id);
+ALTER TABLE ONLY public.ledger_accounts
+    ADD CONSTRAINT ledger_accounts_pkey PRIMARY KEY (id);
 
 
 --
--- Name: idx_transactions_status; Type: INDEX; Schema: public; Owner: root
+-- Name: mutex_leases mutex_leases_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_status ON public.transactions USING btree (status);
+ALTER TABLE ONLY public.mutex_leases
+    ADD CONSTRAINT mutex_leases_pkey PRIMARY KEY (key);
 
 
 --
--- Name: idx_transactions_transaction_type; Type: INDEX; Schema: public; Owner: root
+-- Name: notification_preferences notification_preferences_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_transaction_type ON public.transactions USING btree (transaction_type);
+ALTER TABLE ONLY public.notification_preferences
+    ADD CONSTRAINT notification_preferences_pkey PRIMARY KEY (user_id);
 
 
 --
--- Name: idx_transactions_user_id; Type: INDEX; Schema: public; Owner: root
+-- Name: outbox_events outbox_events_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_transactions_user_id ON public.transactions USING btree (user_id);
+ALTER TABLE ONLY public.outbox_events
+    ADD CONSTRAINT outbox_events_pkey PRIMARY KEY (id);
 
 
 --
--- Name: idx_users_email; Type: INDEX; Schema: public; Owner: root
+-- Name: outbox_events outbox_events_sequence_key; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_users_email ON public.users USING btree (email);
+ALTER TABLE ONLY public.outbox_events
+    ADD CONSTRAINT outbox_events_sequence_key UNIQUE (sequence);
 
 
 --
--- Name: idx_users_id; Type: INDEX; Schema: public; Owner: root
+-- Name: postings postings_pkey; Type: CONSTRAINT; Schema: public; Owner: root
 --
 
-CREATE INDEX idx_users_id ON public.users USING btree (id);
+ALTER TABLE ONLY public.postings
+    ADD CONSTRAINT postings_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: schema_migrations schema_migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.schema_migrations
+    ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
+
+
+--
+-- Name: standing_order_occurrences standing_order_occurrences_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.standing_order_occurrences
+    ADD CONSTRAINT standing_order_occurrences_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: standing_orders standing_orders_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.standing_orders
+    ADD CONSTRAINT standing_orders_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: transactions transactions_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.transactions
+    ADD CONSTRAINT transactions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: transfer_approvals transfer_approvals_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.transfer_approvals
+    ADD CONSTRAINT transfer_approvals_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: transfer_limits transfer_limits_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.transfer_limits
+    ADD CONSTRAINT transfer_limits_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: fee_rules uq_fee_rules_code_version; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.fee_rules
+    ADD CONSTRAINT uq_fee_rules_code_version UNIQUE (code, version);
+
+
+--
+-- Name: standing_order_occurrences uq_standing_order_occurrences_reference_id; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.standing_order_occurrences
+    ADD CONSTRAINT uq_standing_order_occurrences_reference_id UNIQUE (reference_id);
+
+
+--
+-- Name: transfer_approvals uq_transfer_approvals_reference_id; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.transfer_approvals
+    ADD CONSTRAINT uq_transfer_approvals_reference_id UNIQUE (reference_id);
+
+
+--
+-- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_email_key UNIQUE (email);
+
+
+--
+-- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_subscription_id_event_id_key; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_subscription_id_event_id_key UNIQUE (subscription_id, event_id);
+
+
+--
+-- Name: webhook_subscriptions webhook_subscriptions_pkey; Type: CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.webhook_subscriptions
+    ADD CONSTRAINT webhook_subscriptions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: idx_accounts_currency; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_accounts_currency ON public.accounts USING btree (currency);
+
+
+--
+-- Name: idx_accounts_product; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_accounts_product ON public.accounts USING btree (product);
+
+
+--
+-- Name: idx_accounts_status; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_accounts_status ON public.accounts USING btree (status);
+
+
+--
+-- Name: idx_accounts_user_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_accounts_user_id ON public.accounts USING btree (user_id);
+
+
+--
+-- Name: idx_audit_events_actor; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_audit_events_actor ON public.audit_events USING btree (actor, sequence);
+
+
+--
+-- Name: idx_audit_events_entity; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_audit_events_entity ON public.audit_events USING btree (entity_type, entity_id, sequence);
+
+
+--
+-- Name: idx_audit_events_occurred_at; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_audit_events_occurred_at ON public.audit_events USING btree (occurred_at);
+
+
+--
+-- Name: idx_audit_events_source; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_audit_events_source ON public.audit_events USING btree (source_id, sequence);
+
+
+--
+-- Name: idx_fee_rules_account_product; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_fee_rules_account_product ON public.fee_rules USING btree (account_product);
+
+
+--
+-- Name: idx_fee_rules_currency; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_fee_rules_currency ON public.fee_rules USING btree (currency);
+
+
+--
+-- Name: idx_fee_rules_effective_from; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_fee_rules_effective_from ON public.fee_rules USING btree (effective_from);
+
+
+--
+-- Name: idx_holds_account_id_status; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_holds_account_id_status ON public.holds USING btree (account_id, status);
+
+
+--
+-- Name: idx_outbox_events_aggregate; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_outbox_events_aggregate ON public.outbox_events USING btree (aggregate_type, aggregate_id, sequence);
+
+
+--
+-- Name: idx_outbox_events_unpublished; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_outbox_events_unpublished ON public.outbox_events USING btree (sequence) WHERE (published_at IS NULL);
+
+
+--
+-- Name: idx_postings_journal_entry_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_postings_journal_entry_id ON public.postings USING btree (journal_entry_id);
+
+
+--
+-- Name: idx_postings_ledger_account_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_postings_ledger_account_id ON public.postings USING btree (ledger_account_id, created_at);
+
+
+--
+-- Name: idx_postings_transaction_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_postings_transaction_id ON public.postings USING btree (transaction_id);
+
+
+--
+-- Name: idx_standing_order_occurrences_standing_order_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_standing_order_occurrences_standing_order_id ON public.standing_order_occurrences USING btree (standing_order_id);
+
+
+--
+-- Name: idx_standing_orders_source_account_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_standing_orders_source_account_id ON public.standing_orders USING btree (source_account_id);
+
+
+--
+-- Name: idx_standing_orders_status; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_standing_orders_status ON public.standing_orders USING btree (status);
+
+
+--
+-- Name: idx_transactions_account_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_account_id ON public.transactions USING btree (account_id);
+
+
+--
+-- Name: idx_transactions_account_id_posted_at; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_account_id_posted_at ON public.transactions USING btree (account_id, posted_at) WHERE (posted_at IS NOT NULL);
+
+
+--
+-- Name: idx_transactions_account_id_status; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_account_id_status ON public.transactions USING btree (account_id, status);
+
+
+--
+-- Name: idx_transactions_account_id_type; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_account_id_type ON public.transactions USING btree (account_id, transaction_type);
+
+
+--
+-- Name: idx_transactions_account_id_type_posted_at; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_account_id_type_posted_at ON public.transactions USING btree (account_id, transaction_type, posted_at) WHERE (posted_at IS NOT NULL);
+
+
+--
+-- Name: idx_transactions_currency_code; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_currency_code ON public.transactions USING btree (currency_code);
+
+
+--
+-- Name: idx_transactions_original_transaction_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_original_transaction_id ON public.transactions USING btree (original_transaction_id, transaction_type);
+
+
+--
+-- Name: idx_transactions_reference_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_reference_id ON public.transactions USING btree (reference_id);
+
+
+--
+-- Name: idx_transactions_status; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_status ON public.transactions USING btree (status);
+
+
+--
+-- Name: idx_transactions_transaction_type; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_transaction_type ON public.transactions USING btree (transaction_type);
+
+
+--
+-- Name: idx_transactions_user_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transactions_user_id ON public.transactions USING btree (user_id);
+
+
+--
+-- Name: idx_transfer_approvals_approver; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transfer_approvals_approver ON public.transfer_approvals USING btree (approver);
+
+
+--
+-- Name: idx_transfer_limits_account_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_transfer_limits_account_id ON public.transfer_limits USING btree (account_id);
+
+
+--
+-- Name: idx_users_email; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_users_email ON public.users USING btree (email);
+
+
+--
+-- Name: idx_users_id; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_users_id ON public.users USING btree (id);
+
+
+--
+-- Name: idx_webhook_deliveries_subscription_id_created_at; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_webhook_deliveries_subscription_id_created_at ON public.webhook_deliveries USING btree (subscription_id, created_at DESC);
+
+
+--
+-- Name: idx_webhook_subscriptions_account_id_status; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE INDEX idx_webhook_subscriptions_account_id_status ON public.webhook_subscriptions USING btree (account_id, status);
+
+
+--
+-- Name: uq_transfer_limits_scope; Type: INDEX; Schema: public; Owner: root
+--
+
+CREATE UNIQUE INDEX uq_transfer_limits_scope ON public.transfer_limits USING btree (COALESCE(account_id, '00000000-0000-0000-0000-000000000000'::uuid), COALESCE(account_product, ''::character varying), COALESCE(currency, ''::character varying));
+
+
+--
+-- Name: audit_events audit_events_immutable; Type: TRIGGER; Schema: public; Owner: root
+--
+
+CREATE TRIGGER audit_events_immutable BEFORE DELETE OR UPDATE ON public.audit_events FOR EACH ROW EXECUTE FUNCTION public.reject_audit_event_change();
+
+
+--
+-- Name: audit_events audit_events_no_truncate; Type: TRIGGER; Schema: public; Owner: root
+--
+
+CREATE TRIGGER audit_events_no_truncate BEFORE TRUNCATE ON public.audit_events FOR EACH STATEMENT EXECUTE FUNCTION public.reject_audit_event_change();
+
+
+--
+-- Name: postings trg_postings_balanced; Type: TRIGGER; Schema: public; Owner: root
+--
+
+CREATE CONSTRAINT TRIGGER trg_postings_balanced AFTER INSERT ON public.postings DEFERRABLE INITIALLY DEFERRED FOR EACH ROW EXECUTE FUNCTION public.check_journal_entry_balanced();
+
+
+--
+-- Name: holds holds_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT holds_account_id_fkey FOREIGN KEY (account_id) REFERENCES public.accounts(id);
+
+
+--
+-- Name: holds holds_destination_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.holds
+    ADD CONSTRAINT holds_destination_account_id_fkey FOREIGN KEY (destination_account_id) REFERENCES public.accounts(id);
+
+
+--
+-- Name: notification_preferences notification_preferences_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.notification_preferences
+    ADD CONSTRAINT notification_preferences_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: postings postings_journal_entry_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.postings
+    ADD CONSTRAINT postings_journal_entry_id_fkey FOREIGN KEY (journal_entry_id) REFERENCES public.journal_entries(id);
+
+
+--
+-- Name: postings postings_ledger_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.postings
+    ADD CONSTRAINT postings_ledger_account_id_fkey FOREIGN KEY (ledger_account_id) REFERENCES public.ledger_accounts(id);
+
+
+--
+-- Name: postings postings_transaction_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.postings
+    ADD CONSTRAINT postings_transaction_id_fkey FOREIGN KEY (transaction_id) REFERENCES public.transactions(id);
+
+
+--
+-- Name: standing_order_occurrences standing_order_occurrences_standing_order_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.standing_order_occurrences
+    ADD CONSTRAINT standing_order_occurrences_standing_order_id_fkey FOREIGN KEY (standing_order_id) REFERENCES public.standing_orders(id);
+
+
+--
+-- Name: transactions transactions_original_transaction_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.transactions
+    ADD CONSTRAINT transactions_original_transaction_id_fkey FOREIGN KEY (original_transaction_id) REFERENCES public.transactions(id);
+
+
+--
+-- Name: transfer_limits transfer_limits_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.transfer_limits
+    ADD CONSTRAINT transfer_limits_account_id_fkey FOREIGN KEY (account_id) REFERENCES public.accounts(id);
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_subscription_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_subscription_id_fkey FOREIGN KEY (subscription_id) REFERENCES public.webhook_subscriptions(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhook_subscriptions webhook_subscriptions_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: root
+--
+
+ALTER TABLE ONLY public.webhook_subscriptions
+    ADD CONSTRAINT webhook_subscriptions_account_id_fkey FOREIGN KEY (account_id) REFERENCES public.accounts(id);
 
 
 --
@@ -0,0 +1,33 @@
+package accounts
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// FeeCollectionAccounts maps a currency code to the house account that collects the transfer fees in that currency.
+type FeeCollectionAccounts map[string]uuid.UUID
+
+func NewFeeCollectionAccounts(accountIDsByCurrency map[string]string) (FeeCollectionAccounts, error) {
+	feeAccounts := make(FeeCollectionAccounts, len(accountIDsByCurrency))
+
+	for currency, rawAccountID := range accountIDsByCurrency {
+		accountID, err := uuid.Parse(rawAccountID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid fee collection account for %s: %w", currency, err)
+		}
+
+		feeAccounts[currency] = accountID
+	}
+
+	return feeAccounts, nil
+}
+
+func (f FeeCollectionAccounts) AccountIDFor(currency string) (uuid.UUID, error) {
+	accountID, ok := f[currency]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("no fee collection account configured for currency: %s", currency)
+	}
+
+	return accountID, nil
+}
@@ -48,6 +48,36 @@ func (_m *MockRepository) Create(ctx context.Context, account *accounts.Account)
 	return r0, r1
 }
 
+// CreateWithTx provides a mock function with given fields: ctx, account, tx
+func (_m *MockRepository) CreateWithTx(ctx context.Context, account *accounts.Account, tx *gorm.DB) (*accounts.Account, error) {
+	ret := _m.Called(ctx, account, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWithTx")
+	}
+
+	var r0 *accounts.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, *gorm.DB) (*accounts.Account, error)); ok {
+		return rf(ctx, account, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *accounts.Account, *gorm.DB) *accounts.Account); ok {
+		r0 = rf(ctx, account, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*accounts.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *accounts.Account, *gorm.DB) error); ok {
+		r1 = rf(ctx, account, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Delete provides a mock function with given fields: ctx, id
 func (_m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	ret := _m.Called(ctx, id)
@@ -192,6 +222,60 @@ func (_m *MockRepository) Update(ctx context.Context, account *accounts.Account)
 	return r0
 }
 
+// UpdateBalanceWithTx provides a mock function with given fields: ctx, accountID, balance, tx
+func (_m *MockRepository) UpdateBalanceWithTx(ctx context.Context, accountID uuid.UUID, balance int, tx *gorm.DB) error {
+	ret := _m.Called(ctx, accountID, balance, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateBalanceWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *gorm.DB) error); ok {
+		r0 = rf(ctx, accountID, balance, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateFencingTokenWithTx provides a mock function with given fields: ctx, accountID, fencingToken, tx
+func (_m *MockRepository) UpdateFencingTokenWithTx(ctx context.Context, accountID uuid.UUID, fencingToken int64, tx *gorm.DB) error {
+	ret := _m.Called(ctx, accountID, fencingToken, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateFencingTokenWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int64, *gorm.DB) error); ok {
+		r0 = rf(ctx, accountID, fencingToken, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateHeldBalanceWithTx provides a mock function with given fields: ctx, accountID, heldBalance, tx
+func (_m *MockRepository) UpdateHeldBalanceWithTx(ctx context.Context, accountID uuid.UUID, heldBalance int, tx *gorm.DB) error {
+	ret := _m.Called(ctx, accountID, heldBalance, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateHeldBalanceWithTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *gorm.DB) error); ok {
+		r0 = rf(ctx, accountID, heldBalance, tx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateWithTx provides a mock function with given fields: ctx, account, tx
 func (_m *MockRepository) UpdateWithTx(ctx context.Context, account *accounts.Account, tx *gorm.DB) error {
 	ret := _m.Called(ctx, account, tx)
@@ -5,12 +5,11 @@ package mocks
 import (
 	context "context"
 	accounts "ulascansenturk/service/internal/accounts"
-
-	gorm "gorm.io/gorm"
-
-	mock "github.com/stretchr/testify/mock"
+	constants "ulascansenturk/service/internal/constants"
 
 	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+	gorm "gorm.io/gorm"
 )
 
 // MockService is an autogenerated mock type for the Service type
@@ -144,6 +143,36 @@ func (_m *MockService) UpdateAccount(ctx context.Context, account *accounts.Acco
 	return r0
 }
 
+// UpdateAccountStatus provides a mock function with given fields: ctx, accountID, status
+func (_m *MockService) UpdateAccountStatus(ctx context.Context, accountID uuid.UUID, status constants.AccountStatus) (*accounts.Account, error) {
+	ret := _m.Called(ctx, accountID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateAccountStatus")
+	}
+
+	var r0 *accounts.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.AccountStatus) (*accounts.Account, error)); ok {
+		return rf(ctx, accountID, status)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.AccountStatus) *accounts.Account); ok {
+		r0 = rf(ctx, accountID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*accounts.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, constants.AccountStatus) error); ok {
+		r1 = rf(ctx, accountID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateBalance provides a mock function with given fields: ctx, accountID, amount, operation
 func (_m *MockService) UpdateBalance(ctx context.Context, accountID uuid.UUID, amount int, operation string) error {
 	ret := _m.Called(ctx, accountID, amount, operation)
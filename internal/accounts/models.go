@@ -6,12 +6,22 @@ import (
 	"ulascansenturk/service/internal/constants"
 )
 
+// Account holds its ledger balance in Balance, HeldBalance is the part of it reserved by active holds.
+// FencingToken is the highest fencing token of the account lock its balance was last updated under.
 type Account struct {
-	ID        uuid.UUID               `gorm:"type:uuid;primaryKey"`
-	UserID    uuid.UUID               `gorm:"type:uuid;not null;index" validate:"required"`
-	Balance   int                     `gorm:"not null;"`
-	Currency  string                  `gorm:"type:varchar(3);not null" validate:"required,len=3,iso4217"`
-	Status    constants.AccountStatus `gorm:"type:varchar(50);not null"`
-	CreatedAt time.Time               `gorm:"type:timestamp with time zone;not null" `
-	UpdatedAt time.Time               `gorm:"type:timestamp with time zone;not null" `
+	ID           uuid.UUID               `gorm:"type:uuid;primaryKey"`
+	UserID       uuid.UUID               `gorm:"type:uuid;not null;index" validate:"required"`
+	Balance      int                     `gorm:"not null;"`
+	HeldBalance  int                     `gorm:"not null;default:0"`
+	FencingToken int64                   `gorm:"type:bigint;not null;default:0"`
+	Currency     string                  `gorm:"type:varchar(3);not null" validate:"required,len=3,iso4217"`
+	Status       constants.AccountStatus `gorm:"type:varchar(50);not null"`
+	Product      string                  `gorm:"type:varchar(50);not null;default:STANDARD"`
+	CreatedAt    time.Time               `gorm:"type:timestamp with time zone;not null" `
+	UpdatedAt    time.Time               `gorm:"type:timestamp with time zone;not null" `
+}
+
+// AvailableBalance is what can be moved out of the account, the held funds stay on it until they are released.
+func (a *Account) AvailableBalance() int {
+	return a.Balance - a.HeldBalance
 }
@@ -10,11 +10,15 @@ import (
 
 type Repository interface {
 	Create(ctx context.Context, account *Account) (*Account, error)
+	CreateWithTx(ctx context.Context, account *Account, tx *gorm.DB) (*Account, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Account, error)
 	GetByIDForUpdate(ctx context.Context, accountID uuid.UUID, tx *gorm.DB) (*Account, error)
 	Update(ctx context.Context, account *Account) error
 	Delete(ctx context.Context, id uuid.UUID) error
 	UpdateWithTx(ctx context.Context, account *Account, tx *gorm.DB) error
+	UpdateBalanceWithTx(ctx context.Context, accountID uuid.UUID, balance int, tx *gorm.DB) error
+	UpdateHeldBalanceWithTx(ctx context.Context, accountID uuid.UUID, heldBalance int, tx *gorm.DB) error
+	UpdateFencingTokenWithTx(ctx context.Context, accountID uuid.UUID, fencingToken int64, tx *gorm.DB) error
 	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*Account, error)
 	Transaction(ctx context.Context, fn func(*gorm.DB) error) error
 }
@@ -35,6 +39,16 @@ func (r *SQLRepository) Create(ctx context.Context, account *Account) (*Account,
 	return account, nil
 }
 
+func (r *SQLRepository) CreateWithTx(ctx context.Context, account *Account, tx *gorm.DB) (*Account, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	if err := tx.WithContext(ctx).Create(account).Error; err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
 func (r *SQLRepository) GetByID(ctx context.Context, id uuid.UUID) (*Account, error) {
 	var account Account
 	if err := r.db.WithContext(ctx).First(&account, "id = ?", id).Error; err != nil {
@@ -76,6 +90,40 @@ func (r *SQLRepository) UpdateWithTx(ctx context.Context, account *Account, tx *
 	}
 	return nil
 }
+
+// UpdateBalanceWithTx sets the balance column explicitly, Updates with a struct would skip a zero balance.
+func (r *SQLRepository) UpdateBalanceWithTx(ctx context.Context, accountID uuid.UUID, balance int, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	if err := tx.WithContext(ctx).Model(&Account{}).Where("id = ?", accountID).Update("balance", balance).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateHeldBalanceWithTx sets the held_balance column explicitly, releasing the last hold sets it back to zero.
+func (r *SQLRepository) UpdateHeldBalanceWithTx(ctx context.Context, accountID uuid.UUID, heldBalance int, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	if err := tx.WithContext(ctx).Model(&Account{}).Where("id = ?", accountID).Update("held_balance", heldBalance).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// UpdateFencingTokenWithTx records the fencing token a balance update of the account was made under.
+func (r *SQLRepository) UpdateFencingTokenWithTx(ctx context.Context, accountID uuid.UUID, fencingToken int64, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	if err := tx.WithContext(ctx).Model(&Account{}).Where("id = ?", accountID).Update("fencing_token", fencingToken).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r *SQLRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) error) error {
 	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := fn(tx); err != nil {
@@ -6,8 +6,14 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"time"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/ledger"
+	"ulascansenturk/service/internal/outbox"
 )
 
+var ErrAccountNotFound = errors.New("account not found")
+
 type Service interface {
 	CreateAccount(ctx context.Context, account *Account) (*Account, error)
 	GetAccountByID(ctx context.Context, id uuid.UUID) (*Account, error)
@@ -15,23 +21,68 @@ type Service interface {
 	DeleteAccount(ctx context.Context, id uuid.UUID) error
 	GetAccountsByUserID(ctx context.Context, userID uuid.UUID) ([]*Account, error)
 	UpdateBalance(ctx context.Context, accountID uuid.UUID, amount int, operation string) error
+	UpdateAccountStatus(ctx context.Context, accountID uuid.UUID, status constants.AccountStatus) (*Account, error)
 }
 
 type AccountServiceImpl struct {
-	repo     Repository
-	validate *validator.Validate
+	repo          Repository
+	ledgerService ledger.Service
+	outboxService outbox.Service
+	validate      *validator.Validate
 }
 
-func NewUserBankAccountService(repo Repository, validate *validator.Validate) *AccountServiceImpl {
-	return &AccountServiceImpl{repo: repo, validate: validate}
+func NewUserBankAccountService(
+	repo Repository,
+	ledgerService ledger.Service,
+	outboxService outbox.Service,
+	validate *validator.Validate,
+) *AccountServiceImpl {
+	return &AccountServiceImpl{repo: repo, ledgerService: ledgerService, outboxService: outboxService, validate: validate}
 }
 
+// CreateAccount creates the account with an opening entry in the ledger for its initial balance, the balance is
+// funded by the funding house account of its currency. An AccountCreated event is written with it.
 func (s *AccountServiceImpl) CreateAccount(ctx context.Context, account *Account) (*Account, error) {
 	if err := s.validate.Struct(account); err != nil {
 		return nil, err
 	}
 
-	return s.repo.Create(ctx, account)
+	if account.ID == uuid.Nil {
+		account.ID = uuid.New()
+	}
+
+	var createdAccount *Account
+
+	err := s.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		var createErr error
+
+		createdAccount, createErr = s.repo.CreateWithTx(ctx, account, tx)
+		if createErr != nil {
+			return createErr
+		}
+
+		if account.Balance != 0 {
+			fundingErr := s.postFunding(ctx, tx, constants.JournalEntryTypeOPENINGBALANCE, account, account.Balance)
+			if fundingErr != nil {
+				return fundingErr
+			}
+		}
+
+		return s.outboxService.RecordWithTx(ctx, []outbox.Payload{&outbox.AccountCreated{
+			AccountID: createdAccount.ID,
+			UserID:    createdAccount.UserID,
+			Currency:  createdAccount.Currency,
+			Product:   createdAccount.Product,
+			Status:    createdAccount.Status.String(),
+			Balance:   createdAccount.Balance,
+			CreatedAt: createdAccount.CreatedAt.UTC(),
+		}}, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdAccount, nil
 }
 
 func (s *AccountServiceImpl) GetAccountByID(ctx context.Context, id uuid.UUID) (*Account, error) {
@@ -41,7 +92,7 @@ func (s *AccountServiceImpl) GetAccountByID(ctx context.Context, id uuid.UUID) (
 	}
 
 	if account == nil {
-		return nil, errors.New("account not found")
+		return nil, ErrAccountNotFound
 	}
 	return account, nil
 }
@@ -82,18 +133,112 @@ func (s *AccountServiceImpl) UpdateBalance(ctx context.Context, accountID uuid.U
 			return err
 		}
 
+		if account == nil {
+			return ErrAccountNotFound
+		}
+
 		switch operation {
 		case "INCREASE":
-			account.Balance += amount
 		case "DECREASE":
-			if account.Balance < amount {
+			if account.AvailableBalance() < amount {
 				return errors.New("insufficient funds")
 			}
-			account.Balance -= amount
+			amount = -amount
 		default:
 			return errors.New("invalid operation")
 		}
 
-		return s.repo.UpdateWithTx(ctx, account, tx)
+		if err = s.postFunding(ctx, tx, constants.JournalEntryTypeADJUSTMENT, account, amount); err != nil {
+			return err
+		}
+
+		previousBalance := account.Balance
+		account.Balance += amount
+
+		if err = s.repo.UpdateBalanceWithTx(ctx, account.ID, account.Balance, tx); err != nil {
+			return err
+		}
+
+		return s.outboxService.RecordWithTx(ctx, []outbox.Payload{&outbox.BalanceChanged{
+			AccountID:       account.ID,
+			Currency:        account.Currency,
+			PreviousBalance: previousBalance,
+			Balance:         account.Balance,
+			HeldBalance:     account.HeldBalance,
+			TransactionIDs:  []uuid.UUID{},
+			ChangedAt:       time.Now().UTC(),
+		}}, tx)
 	})
 }
+
+// UpdateAccountStatus moves the account to the status and writes an AccountStatusChanged event with it, an account
+// already in the status is returned unchanged.
+func (s *AccountServiceImpl) UpdateAccountStatus(ctx context.Context, accountID uuid.UUID, status constants.AccountStatus) (*Account, error) {
+	if !status.IsValid() {
+		return nil, errors.New("invalid account status")
+	}
+
+	var updatedAccount *Account
+
+	err := s.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		account, err := s.repo.GetByIDForUpdate(ctx, accountID, tx)
+		if err != nil {
+			return err
+		}
+
+		if account == nil {
+			return ErrAccountNotFound
+		}
+
+		updatedAccount = account
+
+		if account.Status == status {
+			return nil
+		}
+
+		previousStatus := account.Status
+		account.Status = status
+
+		if err = s.repo.UpdateWithTx(ctx, account, tx); err != nil {
+			return err
+		}
+
+		return s.outboxService.RecordWithTx(ctx, []outbox.Payload{&outbox.AccountStatusChanged{
+			AccountID:      account.ID,
+			PreviousStatus: previousStatus.String(),
+			Status:         status.String(),
+			ChangedAt:      time.Now().UTC(),
+		}}, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return updatedAccount, nil
+}
+
+// postFunding moves amount between the funding house account of the currency and the account, a negative amount
+// moves it back out of the account.
+func (s *AccountServiceImpl) postFunding(
+	ctx context.Context,
+	tx *gorm.DB,
+	entryType constants.JournalEntryType,
+	account *Account,
+	amount int,
+) error {
+	funding := ledger.HouseLeg(constants.LedgerAccountTypeFUNDING, account.Currency)
+	customer := ledger.Leg{
+		AccountID:   account.ID,
+		AccountType: constants.LedgerAccountTypeCUSTOMER,
+		Currency:    account.Currency,
+	}
+
+	entry := ledger.NewJournalEntry(entryType)
+	if amount > 0 {
+		entry.Move(funding, customer, amount, amount)
+	} else {
+		entry.Move(customer, funding, -amount, -amount)
+	}
+
+	return s.ledgerService.PostWithTx(ctx, []*ledger.JournalEntry{entry}, tx)
+}
@@ -29,6 +29,163 @@ func (a *Routes) V1CreateUser(w http.ResponseWriter, r *http.Request) {
 	a.v1.V1CreateUser(w, r)
 }
 
-func (a *Routes) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request) {
-	a.v1.V1RunTransferWorkflow(w, r)
+func (a *Routes) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request, params server.V1RunTransferWorkflowParams) {
+	a.v1.V1RunTransferWorkflow(w, r, params)
+}
+
+func (a *Routes) V1GetTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	a.v1.V1GetTransfer(w, r, referenceID)
+}
+
+func (a *Routes) V1CancelTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	a.v1.V1CancelTransfer(w, r, referenceID)
+}
+
+func (a *Routes) V1CreateTransferBatch(w http.ResponseWriter, r *http.Request) {
+	a.v1.V1CreateTransferBatch(w, r)
+}
+
+func (a *Routes) V1GetTransferBatch(w http.ResponseWriter, r *http.Request, batchID server.TransferBatchID) {
+	a.v1.V1GetTransferBatch(w, r, batchID)
+}
+
+func (a *Routes) V1ReverseTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	a.v1.V1ReverseTransfer(w, r, referenceID)
+}
+
+func (a *Routes) V1ApproveTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	a.v1.V1ApproveTransfer(w, r, referenceID)
+}
+
+func (a *Routes) V1RejectTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	a.v1.V1RejectTransfer(w, r, referenceID)
+}
+
+func (a *Routes) V1GetAccountBalance(w http.ResponseWriter, r *http.Request, accountID server.AccountID, params server.V1GetAccountBalanceParams) {
+	a.v1.V1GetAccountBalance(w, r, accountID, params)
+}
+
+func (a *Routes) V1PreviewFee(w http.ResponseWriter, r *http.Request) {
+	a.v1.V1PreviewFee(w, r)
+}
+
+func (a *Routes) V1ListFeeRules(w http.ResponseWriter, r *http.Request, params server.V1ListFeeRulesParams) {
+	a.v1.V1ListFeeRules(w, r, params)
+}
+
+func (a *Routes) V1CreateFeeRule(w http.ResponseWriter, r *http.Request) {
+	a.v1.V1CreateFeeRule(w, r)
+}
+
+func (a *Routes) V1GetFeeRule(w http.ResponseWriter, r *http.Request, feeRuleID server.FeeRuleID) {
+	a.v1.V1GetFeeRule(w, r, feeRuleID)
+}
+
+func (a *Routes) V1RetireFeeRule(w http.ResponseWriter, r *http.Request, feeRuleID server.FeeRuleID) {
+	a.v1.V1RetireFeeRule(w, r, feeRuleID)
+}
+
+func (a *Routes) V1GetAccountLimits(w http.ResponseWriter, r *http.Request, accountID server.AccountID) {
+	a.v1.V1GetAccountLimits(w, r, accountID)
+}
+
+func (a *Routes) V1SetTransferLimit(w http.ResponseWriter, r *http.Request) {
+	a.v1.V1SetTransferLimit(w, r)
+}
+
+func (a *Routes) V1ListHolds(w http.ResponseWriter, r *http.Request, accountID server.AccountID, params server.V1ListHoldsParams) {
+	a.v1.V1ListHolds(w, r, accountID, params)
+}
+
+func (a *Routes) V1PlaceHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID) {
+	a.v1.V1PlaceHold(w, r, accountID)
+}
+
+func (a *Routes) V1GetHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID, holdID server.HoldID) {
+	a.v1.V1GetHold(w, r, accountID, holdID)
+}
+
+func (a *Routes) V1CaptureHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID, holdID server.HoldID) {
+	a.v1.V1CaptureHold(w, r, accountID, holdID)
+}
+
+func (a *Routes) V1VoidHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID, holdID server.HoldID) {
+	a.v1.V1VoidHold(w, r, accountID, holdID)
+}
+
+func (a *Routes) V1ListScheduledTransfers(w http.ResponseWriter, r *http.Request) {
+	a.v1.V1ListScheduledTransfers(w, r)
+}
+
+func (a *Routes) V1RescheduleTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	a.v1.V1RescheduleTransfer(w, r, referenceID)
+}
+
+func (a *Routes) V1CancelScheduledTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	a.v1.V1CancelScheduledTransfer(w, r, referenceID)
+}
+
+func (a *Routes) V1ListStandingOrders(w http.ResponseWriter, r *http.Request, params server.V1ListStandingOrdersParams) {
+	a.v1.V1ListStandingOrders(w, r, params)
+}
+
+func (a *Routes) V1CreateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	a.v1.V1CreateStandingOrder(w, r)
+}
+
+func (a *Routes) V1GetStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderID server.StandingOrderID) {
+	a.v1.V1GetStandingOrder(w, r, standingOrderID)
+}
+
+func (a *Routes) V1CancelStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderID server.StandingOrderID) {
+	a.v1.V1CancelStandingOrder(w, r, standingOrderID)
+}
+
+func (a *Routes) V1ListStandingOrderOccurrences(w http.ResponseWriter, r *http.Request, standingOrderID server.StandingOrderID) {
+	a.v1.V1ListStandingOrderOccurrences(w, r, standingOrderID)
+}
+
+func (a *Routes) V1ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request, params server.V1ListWebhookSubscriptionsParams) {
+	a.v1.V1ListWebhookSubscriptions(w, r, params)
+}
+
+func (a *Routes) V1CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	a.v1.V1CreateWebhookSubscription(w, r)
+}
+
+func (a *Routes) V1GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID) {
+	a.v1.V1GetWebhookSubscription(w, r, webhookID)
+}
+
+func (a *Routes) V1DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID) {
+	a.v1.V1DeleteWebhookSubscription(w, r, webhookID)
+}
+
+func (a *Routes) V1EnableWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID) {
+	a.v1.V1EnableWebhookSubscription(w, r, webhookID)
+}
+
+func (a *Routes) V1ListWebhookDeliveries(
+	w http.ResponseWriter,
+	r *http.Request,
+	webhookID server.WebhookID,
+	params server.V1ListWebhookDeliveriesParams,
+) {
+	a.v1.V1ListWebhookDeliveries(w, r, webhookID, params)
+}
+
+func (a *Routes) V1RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID, deliveryID server.WebhookDeliveryID) {
+	a.v1.V1RedeliverWebhookDelivery(w, r, webhookID, deliveryID)
+}
+
+func (a *Routes) V1GetNotificationPreferences(w http.ResponseWriter, r *http.Request, userID server.UserID) {
+	a.v1.V1GetNotificationPreferences(w, r, userID)
+}
+
+func (a *Routes) V1UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request, userID server.UserID) {
+	a.v1.V1UpdateNotificationPreferences(w, r, userID)
+}
+
+func (a *Routes) V1ListAuditEvents(w http.ResponseWriter, r *http.Request, params server.V1ListAuditEventsParams) {
+	a.v1.V1ListAuditEvents(w, r, params)
 }
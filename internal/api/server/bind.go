@@ -9,3 +9,63 @@ func (b *V1RunTransferWorkflowJSONRequestBody) Bind(_ *http.Request) error {
 func (b *V1CreateUserJSONRequestBody) Bind(_ *http.Request) error {
 	return nil
 }
+
+func (b *V1PreviewFeeJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1CreateFeeRuleJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1RescheduleTransferJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1CreateStandingOrderJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1CancelTransferJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1ApproveTransferJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1RejectTransferJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1CreateTransferBatchJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1ReverseTransferJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1SetTransferLimitJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1PlaceHoldJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1CaptureHoldJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1VoidHoldJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1CreateWebhookSubscriptionJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
+
+func (b *V1UpdateNotificationPreferencesJSONRequestBody) Bind(_ *http.Request) error {
+	return nil
+}
@@ -11,6 +11,9 @@ const (
 	processingErrorTitle = "PROCESSING_ERROR"
 	timeoutErrorTitle    = "TIMEOUT"
 	notFoundErrorTitle   = "NOT_FOUND"
+	conflictErrorTitle   = "CONFLICT"
+
+	transferLimitExceededErrorTitle = "TRANSFER_LIMIT_EXCEEDED"
 )
 
 func BadRequestError(badRequestErr error, w http.ResponseWriter, r *http.Request) {
@@ -54,3 +57,67 @@ func ProcessingError(unprocessibleErr error, w http.ResponseWriter, r *http.Requ
 	render.Status(r, statusCode)
 	render.JSON(w, r, errResponse)
 }
+
+func NotFoundError(notFoundErr error, w http.ResponseWriter, r *http.Request) {
+	statusCode := http.StatusNotFound
+
+	errs := make([]Error, 0)
+
+	err := Error{
+		Code:   http.StatusText(statusCode),
+		Detail: notFoundErr.Error(),
+		Meta:   map[string]interface{}{},
+		Status: statusCode,
+		Title:  notFoundErrorTitle,
+	}
+
+	errs = append(errs, err)
+
+	errResponse := ErrorResponse{Errors: errs}
+
+	render.Status(r, statusCode)
+	render.JSON(w, r, errResponse)
+}
+
+func ConflictError(conflictErr error, w http.ResponseWriter, r *http.Request) {
+	statusCode := http.StatusConflict
+
+	errs := make([]Error, 0)
+
+	err := Error{
+		Code:   http.StatusText(statusCode),
+		Detail: conflictErr.Error(),
+		Meta:   map[string]interface{}{},
+		Status: statusCode,
+		Title:  conflictErrorTitle,
+	}
+
+	errs = append(errs, err)
+
+	errResponse := ErrorResponse{Errors: errs}
+
+	render.Status(r, statusCode)
+	render.JSON(w, r, errResponse)
+}
+
+// TransferLimitExceededError renders a transfer refused by a limit of its source account, meta tells which one.
+func TransferLimitExceededError(limitErr error, meta map[string]interface{}, w http.ResponseWriter, r *http.Request) {
+	statusCode := http.StatusUnprocessableEntity
+
+	errs := make([]Error, 0)
+
+	err := Error{
+		Code:   http.StatusText(statusCode),
+		Detail: limitErr.Error(),
+		Meta:   meta,
+		Status: statusCode,
+		Title:  transferLimitExceededErrorTitle,
+	}
+
+	errs = append(errs, err)
+
+	errResponse := ErrorResponse{Errors: errs}
+
+	render.Status(r, statusCode)
+	render.JSON(w, r, errResponse)
+}
@@ -16,166 +16,2593 @@ import (
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
+	"github.com/oapi-codegen/runtime"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
+// Defines values for ApprovalDecision.
+const (
+	ApprovalDecisionAPPROVED ApprovalDecision = "APPROVED"
+	ApprovalDecisionREJECTED ApprovalDecision = "REJECTED"
+)
+
+// Defines values for AuditAction.
+const (
+	AuditActionCREATE AuditAction = "CREATE"
+	AuditActionDELETE AuditAction = "DELETE"
+	AuditActionUPDATE AuditAction = "UPDATE"
+)
+
+// Defines values for AuditSourceType.
+const (
+	AuditSourceTypeHTTPREQUEST AuditSourceType = "HTTP_REQUEST"
+	AuditSourceTypeSYSTEM      AuditSourceType = "SYSTEM"
+	AuditSourceTypeWORKFLOW    AuditSourceType = "WORKFLOW"
+)
+
+// Defines values for FeeRuleType.
+const (
+	FeeRuleTypeFLAT       FeeRuleType = "FLAT"
+	FeeRuleTypePERCENTAGE FeeRuleType = "PERCENTAGE"
+	FeeRuleTypeTIERED     FeeRuleType = "TIERED"
+)
+
+// Defines values for HoldStatus.
+const (
+	HoldStatusACTIVE   HoldStatus = "ACTIVE"
+	HoldStatusCAPTURED HoldStatus = "CAPTURED"
+	HoldStatusEXPIRED  HoldStatus = "EXPIRED"
+	HoldStatusVOIDED   HoldStatus = "VOIDED"
+)
+
+// Defines values for StandingOrderFrequency.
+const (
+	StandingOrderFrequencyCRON    StandingOrderFrequency = "CRON"
+	StandingOrderFrequencyMONTHLY StandingOrderFrequency = "MONTHLY"
+	StandingOrderFrequencyWEEKLY  StandingOrderFrequency = "WEEKLY"
+)
+
+// Defines values for StandingOrderStatus.
+const (
+	StandingOrderStatusACTIVE    StandingOrderStatus = "ACTIVE"
+	StandingOrderStatusCANCELLED StandingOrderStatus = "CANCELLED"
+	StandingOrderStatusCOMPLETED StandingOrderStatus = "COMPLETED"
+)
+
+// Defines values for TransferBatchItemStatus.
+const (
+	TransferBatchItemStatusFAILED    TransferBatchItemStatus = "FAILED"
+	TransferBatchItemStatusQUEUED    TransferBatchItemStatus = "QUEUED"
+	TransferBatchItemStatusRUNNING   TransferBatchItemStatus = "RUNNING"
+	TransferBatchItemStatusSUCCEEDED TransferBatchItemStatus = "SUCCEEDED"
+)
+
+// Defines values for TransferBatchStatus.
+const (
+	TransferBatchStatusCOMPLETED       TransferBatchStatus = "COMPLETED"
+	TransferBatchStatusFAILED          TransferBatchStatus = "FAILED"
+	TransferBatchStatusPARTIALLYFAILED TransferBatchStatus = "PARTIALLY_FAILED"
+	TransferBatchStatusPROCESSING      TransferBatchStatus = "PROCESSING"
+)
+
+// Defines values for TransferFailureCode.
+const (
+	TRANSFERLIMITEXCEEDED TransferFailureCode = "TRANSFER_LIMIT_EXCEEDED"
+)
+
+// Defines values for TransferStatusCode.
+const (
+	TransferStatusAWAITINGAPPROVAL TransferStatusCode = "AWAITING_APPROVAL"
+	TransferStatusCANCELLED        TransferStatusCode = "CANCELLED"
+	TransferStatusFAILURE          TransferStatusCode = "FAILURE"
+	TransferStatusPENDING          TransferStatusCode = "PENDING"
+	TransferStatusREJECTED         TransferStatusCode = "REJECTED"
+	TransferStatusSCHEDULED        TransferStatusCode = "SCHEDULED"
+	TransferStatusSUCCESS          TransferStatusCode = "SUCCESS"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	WebhookDeliveryStatusFAILED    WebhookDeliveryStatus = "FAILED"
+	WebhookDeliveryStatusPENDING   WebhookDeliveryStatus = "PENDING"
+	WebhookDeliveryStatusSUCCEEDED WebhookDeliveryStatus = "SUCCEEDED"
+)
+
+// Defines values for WebhookEventType.
+const (
+	WebhookEventTypeAccountCreated       WebhookEventType = "AccountCreated"
+	WebhookEventTypeAccountStatusChanged WebhookEventType = "AccountStatusChanged"
+	WebhookEventTypeBalanceChanged       WebhookEventType = "BalanceChanged"
+	WebhookEventTypeTransferCompleted    WebhookEventType = "TransferCompleted"
+	WebhookEventTypeTransferFailed       WebhookEventType = "TransferFailed"
+)
+
+// Defines values for WebhookSubscriptionStatus.
+const (
+	WebhookSubscriptionStatusACTIVE   WebhookSubscriptionStatus = "ACTIVE"
+	WebhookSubscriptionStatusDISABLED WebhookSubscriptionStatus = "DISABLED"
+)
+
 // Account defines model for Account.
 type Account struct {
-	Balance  int32               `json:"balance"`
-	Currency string              `json:"currency"`
-	Id       *openapi_types.UUID `json:"id,omitempty"`
-	Status   string              `json:"status"`
-	UserId   openapi_types.UUID  `json:"user_id"`
+	// AvailableBalance Balance minus the held balance, what transfers can move out of the account.
+	AvailableBalance *int   `json:"available_balance,omitempty"`
+	Balance          int32  `json:"balance"`
+	Currency         string `json:"currency"`
+
+	// HeldBalance Part of the balance reserved by active holds.
+	HeldBalance *int                `json:"held_balance,omitempty"`
+	Id          *openapi_types.UUID `json:"id,omitempty"`
+	Product     *string             `json:"product,omitempty"`
+	Status      string              `json:"status"`
+	UserId      openapi_types.UUID  `json:"user_id"`
+}
+
+// AccountBalance defines model for AccountBalance.
+type AccountBalance struct {
+	AccountId openapi_types.UUID `json:"account_id"`
+	AsOf      time.Time          `json:"as_of"`
+	Balance   int                `json:"balance"`
+	Currency  string             `json:"currency"`
+}
+
+// AccountLimits defines model for AccountLimits.
+type AccountLimits struct {
+	AccountId     openapi_types.UUID `json:"account_id"`
+	Currency      string             `json:"currency"`
+	DayResetsAt   time.Time          `json:"day_resets_at"`
+	Limit         *TransferLimit     `json:"limit,omitempty"`
+	MonthResetsAt time.Time          `json:"month_resets_at"`
+
+	// Remaining A missing field has no cap.
+	Remaining TransferLimitRemaining `json:"remaining"`
+	Used      TransferLimitUsage     `json:"used"`
+}
+
+// ApprovalDecision defines model for ApprovalDecision.
+type ApprovalDecision string
+
+// AuditAction defines model for AuditAction.
+type AuditAction string
+
+// AuditChange defines model for AuditChange.
+type AuditChange struct {
+	// After Value of the column after the change, null for a deleted row.
+	After *interface{} `json:"after,omitempty"`
+
+	// Before Value of the column before the change, null for a created row.
+	Before *interface{} `json:"before,omitempty"`
+}
+
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	Action AuditAction `json:"action"`
+
+	// Actor Value of the X-Actor-ID header of the request, system for the changes made by the workflows.
+	Actor string `json:"actor"`
+
+	// After The row after the change, empty for a deleted row.
+	After *map[string]interface{} `json:"after,omitempty"`
+
+	// Before The row before the change, empty for a created row.
+	Before *map[string]interface{} `json:"before,omitempty"`
+
+	// Changes The changed columns.
+	Changes    map[string]AuditChange `json:"changes"`
+	EntityId   string                 `json:"entity_id"`
+	EntityType string                 `json:"entity_type"`
+	Id         openapi_types.UUID     `json:"id"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Sequence   int64                  `json:"sequence"`
+	SourceId   *string                `json:"source_id,omitempty"`
+	SourceType AuditSourceType        `json:"source_type"`
+}
+
+// AuditSourceType defines model for AuditSourceType.
+type AuditSourceType string
+
+// CancelTransferParams defines model for CancelTransferParams.
+type CancelTransferParams struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// CaptureHoldParams defines model for CaptureHoldParams.
+type CaptureHoldParams struct {
+	// Amount Defaults to the whole held amount.
+	Amount *int `json:"amount,omitempty"`
+}
+
+// CreateFeeRuleParams defines model for CreateFeeRuleParams.
+type CreateFeeRuleParams struct {
+	AccountProduct *string `json:"account_product,omitempty"`
+	Code           string  `json:"code"`
+	Currency       *string `json:"currency,omitempty"`
+
+	// EffectiveFrom Defaults to now.
+	EffectiveFrom *time.Time  `json:"effective_from,omitempty"`
+	EffectiveTo   *time.Time  `json:"effective_to,omitempty"`
+	FlatAmount    *int        `json:"flat_amount,omitempty"`
+	MaxAmount     *int        `json:"max_amount,omitempty"`
+	MinAmount     *int        `json:"min_amount,omitempty"`
+	PercentageBps *int        `json:"percentage_bps,omitempty"`
+	RuleType      FeeRuleType `json:"rule_type"`
+	Tiers         *[]FeeTier  `json:"tiers,omitempty"`
+}
+
+// CreateStandingOrderParams defines model for CreateStandingOrderParams.
+type CreateStandingOrderParams struct {
+	Amount int `json:"amount"`
+
+	// Cron Cron expression of a CRON standing order, evaluated in UTC.
+	Cron *string `json:"cron,omitempty"`
+
+	// DayOfMonth Day of a MONTHLY standing order, months without that day are skipped.
+	DayOfMonth *int `json:"day_of_month,omitempty"`
+
+	// DayOfWeek Day of a WEEKLY standing order, 0 is Sunday.
+	DayOfWeek            *int                   `json:"day_of_week,omitempty"`
+	DestinationAccountID openapi_types.UUID     `json:"destinationAccountID"`
+	EndAt                *time.Time             `json:"end_at,omitempty"`
+	Frequency            StandingOrderFrequency `json:"frequency"`
+
+	// Hour UTC hour of WEEKLY and MONTHLY standing orders.
+	Hour            *int                    `json:"hour,omitempty"`
+	MaxCount        *int                    `json:"max_count,omitempty"`
+	Metadata        *map[string]interface{} `json:"metadata,omitempty"`
+	Minute          *int                    `json:"minute,omitempty"`
+	SourceAccountID openapi_types.UUID      `json:"sourceAccountID"`
+	StartAt         *time.Time              `json:"start_at,omitempty"`
+}
+
+// CreateUserParams defines model for CreateUserParams.
+type CreateUserParams struct {
+	// AccountProduct Product of the created account, used to select the fee rules. Defaults to STANDARD.
+	AccountProduct *string `json:"accountProduct,omitempty"`
+	Balance        *int    `json:"balance,omitempty"`
+	CurrencyCode   string  `json:"currencyCode"`
+	Email          string  `json:"email"`
+	FirstName      string  `json:"firstName"`
+	LastName       string  `json:"lastName"`
+	Password       string  `json:"password"`
+}
+
+// CreateWebhookSubscriptionParams defines model for CreateWebhookSubscriptionParams.
+type CreateWebhookSubscriptionParams struct {
+	AccountId   openapi_types.UUID `json:"account_id"`
+	Description *string            `json:"description,omitempty"`
+	EventTypes  []WebhookEventType `json:"event_types"`
+
+	// Url Absolute http or https URL the events are posted to.
+	Url string `json:"url"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Code   string                 `json:"code"`
+	Detail string                 `json:"detail"`
+	Meta   map[string]interface{} `json:"meta"`
+	Status int                    `json:"status"`
+	Title  string                 `json:"title"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse struct {
+	Errors []Error `json:"errors"`
+}
+
+// FXQuote Currency conversion of a cross-currency transfer, the rate is locked for the whole transfer.
+type FXQuote struct {
+	// AppliedRate Rate after the spread, used to compute the target amount.
+	AppliedRate *float32   `json:"applied_rate,omitempty"`
+	QuotedAt    *time.Time `json:"quoted_at,omitempty"`
+
+	// Rate Mid-market rate, one unit of the source currency in the target currency.
+	Rate           *float32 `json:"rate,omitempty"`
+	SourceAmount   *int     `json:"source_amount,omitempty"`
+	SourceCurrency *string  `json:"source_currency,omitempty"`
+
+	// SpreadAmount Amount kept back by the spread, in the target currency.
+	SpreadAmount   *int    `json:"spread_amount,omitempty"`
+	SpreadBps      *int    `json:"spread_bps,omitempty"`
+	TargetAmount   *int    `json:"target_amount,omitempty"`
+	TargetCurrency *string `json:"target_currency,omitempty"`
+}
+
+// FeePreviewParams defines model for FeePreviewParams.
+type FeePreviewParams struct {
+	Amount          int                `json:"amount"`
+	SourceAccountId openapi_types.UUID `json:"source_account_id"`
+}
+
+// FeeQuote defines model for FeeQuote.
+type FeeQuote struct {
+	Currency    string              `json:"currency"`
+	FeeAmount   int                 `json:"fee_amount"`
+	RuleCode    *string             `json:"rule_code,omitempty"`
+	RuleId      *openapi_types.UUID `json:"rule_id,omitempty"`
+	RuleVersion *int                `json:"rule_version,omitempty"`
+}
+
+// FeeRule defines model for FeeRule.
+type FeeRule struct {
+	AccountProduct *string            `json:"account_product,omitempty"`
+	Code           string             `json:"code"`
+	CreatedAt      *time.Time         `json:"created_at,omitempty"`
+	Currency       *string            `json:"currency,omitempty"`
+	EffectiveFrom  time.Time          `json:"effective_from"`
+	EffectiveTo    *time.Time         `json:"effective_to,omitempty"`
+	FlatAmount     int                `json:"flat_amount"`
+	Id             openapi_types.UUID `json:"id"`
+	MaxAmount      *int               `json:"max_amount,omitempty"`
+	MinAmount      *int               `json:"min_amount,omitempty"`
+	PercentageBps  int                `json:"percentage_bps"`
+	RuleType       FeeRuleType        `json:"rule_type"`
+	Tiers          *[]FeeTier         `json:"tiers,omitempty"`
+	Version        int                `json:"version"`
+}
+
+// FeeRuleType defines model for FeeRuleType.
+type FeeRuleType string
+
+// FeeTier defines model for FeeTier.
+type FeeTier struct {
+	FlatAmount    *int `json:"flat_amount,omitempty"`
+	PercentageBps *int `json:"percentage_bps,omitempty"`
+
+	// UpTo Largest amount the tier applies to, omitted on the last, open ended tier.
+	UpTo *int `json:"up_to,omitempty"`
+}
+
+// Hold defines model for Hold.
+type Hold struct {
+	AccountId            openapi_types.UUID `json:"account_id"`
+	Amount               int                `json:"amount"`
+	CapturedAmount       int                `json:"captured_amount"`
+	CreatedAt            time.Time          `json:"created_at"`
+	Currency             string             `json:"currency"`
+	Description          *string            `json:"description,omitempty"`
+	DestinationAccountId openapi_types.UUID `json:"destination_account_id"`
+	ExpiresAt            time.Time          `json:"expires_at"`
+	Id                   openapi_types.UUID `json:"id"`
+	ReferenceId          openapi_types.UUID `json:"reference_id"`
+	ReleaseReason        *string            `json:"release_reason,omitempty"`
+	ReleasedAt           *time.Time         `json:"released_at,omitempty"`
+	Status               HoldStatus         `json:"status"`
+}
+
+// HoldStatus defines model for HoldStatus.
+type HoldStatus string
+
+// NotificationPreferences defines model for NotificationPreferences.
+type NotificationPreferences struct {
+	EmailEnabled bool `json:"email_enabled"`
+	LowBalance   bool `json:"low_balance"`
+
+	// LowBalanceThreshold Balance in minor units a transfer out of an account must leave it below to send a low balance notification.
+	LowBalanceThreshold int `json:"low_balance_threshold"`
+
+	// PhoneNumber Phone number in E.164 format, e.g. +905551112233.
+	PhoneNumber *string `json:"phone_number,omitempty"`
+
+	// SmsEnabled SMS notifications are only sent with a phone_number.
+	SmsEnabled       bool `json:"sms_enabled"`
+	TransferFailed   bool `json:"transfer_failed"`
+	TransferReceived bool `json:"transfer_received"`
+	TransferSent     bool `json:"transfer_sent"`
+
+	// UpdatedAt Empty for a user who never set their preferences.
+	UpdatedAt *time.Time         `json:"updated_at,omitempty"`
+	UserId    openapi_types.UUID `json:"user_id"`
+}
+
+// NotificationPreferencesParams defines model for NotificationPreferencesParams.
+type NotificationPreferencesParams struct {
+	EmailEnabled bool `json:"email_enabled"`
+	LowBalance   bool `json:"low_balance"`
+
+	// LowBalanceThreshold Balance in minor units a transfer out of an account must leave it below to send a low balance notification.
+	LowBalanceThreshold int `json:"low_balance_threshold"`
+
+	// PhoneNumber Phone number in E.164 format, e.g. +905551112233.
+	PhoneNumber *string `json:"phone_number,omitempty"`
+
+	// SmsEnabled SMS notifications are only sent with a phone_number.
+	SmsEnabled       bool `json:"sms_enabled"`
+	TransferFailed   bool `json:"transfer_failed"`
+	TransferReceived bool `json:"transfer_received"`
+	TransferSent     bool `json:"transfer_sent"`
+}
+
+// PlaceHoldParams defines model for PlaceHoldParams.
+type PlaceHoldParams struct {
+	Amount      int     `json:"amount"`
+	Description *string `json:"description,omitempty"`
+
+	// DestinationAccountId Account credited when the hold is captured, in the currency of the held account.
+	DestinationAccountId openapi_types.UUID `json:"destination_account_id"`
+
+	// ReferenceId Placing a hold again with the same reference ID returns the hold already placed.
+	ReferenceId openapi_types.UUID `json:"reference_id"`
+
+	// TtlSeconds Defaults to HOLD_DEFAULT_TTL_SECONDS.
+	TtlSeconds *int `json:"ttl_seconds,omitempty"`
+}
+
+// RescheduleTransferParams defines model for RescheduleTransferParams.
+type RescheduleTransferParams struct {
+	ExecuteAt time.Time `json:"execute_at"`
+}
+
+// Reversal defines model for Reversal.
+type Reversal struct {
+	CreditTransaction    Transaction        `json:"credit_transaction"`
+	DebitTransaction     Transaction        `json:"debit_transaction"`
+	FeeCreditTransaction *Transaction       `json:"fee_credit_transaction,omitempty"`
+	FeeDebitTransaction  *Transaction       `json:"fee_debit_transaction,omitempty"`
+	ReferenceId          openapi_types.UUID `json:"reference_id"`
+	TransferReferenceId  openapi_types.UUID `json:"transfer_reference_id"`
+}
+
+// ReverseTransferParams defines model for ReverseTransferParams.
+type ReverseTransferParams struct {
+	// Amount Amount to reverse in the currency of the source account, the remaining amount when unset.
+	Amount      *int               `json:"amount,omitempty"`
+	IncludeFee  *bool              `json:"include_fee,omitempty"`
+	Reason      *string            `json:"reason,omitempty"`
+	ReferenceId openapi_types.UUID `json:"reference_id"`
+}
+
+// ScheduledTransfer defines model for ScheduledTransfer.
+type ScheduledTransfer struct {
+	Amount               int                `json:"amount"`
+	DestinationAccountID openapi_types.UUID `json:"destinationAccountID"`
+	ExecuteAt            time.Time          `json:"execute_at"`
+	ReferenceId          openapi_types.UUID `json:"reference_id"`
+	SourceAccountID      openapi_types.UUID `json:"sourceAccountID"`
+	Status               TransferStatusCode `json:"status"`
+}
+
+// StandingOrder defines model for StandingOrder.
+type StandingOrder struct {
+	Amount               int                     `json:"amount"`
+	CreatedAt            *time.Time              `json:"created_at,omitempty"`
+	Cron                 *string                 `json:"cron,omitempty"`
+	DayOfMonth           *int                    `json:"day_of_month,omitempty"`
+	DayOfWeek            *int                    `json:"day_of_week,omitempty"`
+	DestinationAccountID openapi_types.UUID      `json:"destinationAccountID"`
+	EndAt                *time.Time              `json:"end_at,omitempty"`
+	ExecutedCount        int                     `json:"executed_count"`
+	FailedCount          int                     `json:"failed_count"`
+	Frequency            StandingOrderFrequency  `json:"frequency"`
+	Hour                 int                     `json:"hour"`
+	Id                   openapi_types.UUID      `json:"id"`
+	MaxCount             *int                    `json:"max_count,omitempty"`
+	Metadata             *map[string]interface{} `json:"metadata,omitempty"`
+	Minute               int                     `json:"minute"`
+	SourceAccountID      openapi_types.UUID      `json:"sourceAccountID"`
+	StartAt              *time.Time              `json:"start_at,omitempty"`
+	Status               StandingOrderStatus     `json:"status"`
+}
+
+// StandingOrderFrequency defines model for StandingOrderFrequency.
+type StandingOrderFrequency string
+
+// StandingOrderOccurrence defines model for StandingOrderOccurrence.
+type StandingOrderOccurrence struct {
+	CreatedAt     time.Time          `json:"created_at"`
+	FailureReason *string            `json:"failure_reason,omitempty"`
+	ReferenceId   openapi_types.UUID `json:"reference_id"`
+	Status        TransferStatusCode `json:"status"`
+}
+
+// StandingOrderStatus defines model for StandingOrderStatus.
+type StandingOrderStatus string
+
+// Transaction defines model for Transaction.
+type Transaction struct {
+	AccountId    *openapi_types.UUID     `json:"account_id,omitempty"`
+	Amount       *int                    `json:"amount,omitempty"`
+	CreatedAt    *time.Time              `json:"created_at,omitempty"`
+	CurrencyCode *string                 `json:"currency_code,omitempty"`
+	Id           *openapi_types.UUID     `json:"id,omitempty"`
+	Metadata     *map[string]interface{} `json:"metadata,omitempty"`
+
+	// OriginalTransactionId Transaction reversed by this reversal transaction.
+	OriginalTransactionId *openapi_types.UUID `json:"original_transaction_id,omitempty"`
+	ReferenceId           *openapi_types.UUID `json:"reference_id,omitempty"`
+	Status                *string             `json:"status,omitempty"`
+	TransactionType       *string             `json:"transaction_type,omitempty"`
+	UpdatedAt             *time.Time          `json:"updated_at,omitempty"`
+	UserId                *openapi_types.UUID `json:"user_id,omitempty"`
+}
+
+// TransferAccepted defines model for TransferAccepted.
+type TransferAccepted struct {
+	ReferenceId openapi_types.UUID `json:"reference_id"`
+	Status      TransferStatusCode `json:"status"`
+	WorkflowId  string             `json:"workflow_id"`
+}
+
+// TransferApproval Approval required for the transfer and, once taken, the decision on it.
+type TransferApproval struct {
+	Approver  *string           `json:"approver,omitempty"`
+	DecidedAt *time.Time        `json:"decided_at,omitempty"`
+	Decision  *ApprovalDecision `json:"decision,omitempty"`
+	Reason    *string           `json:"reason,omitempty"`
+
+	// Threshold Amount above which transfers in this currency need an approval.
+	Threshold int `json:"threshold"`
+}
+
+// TransferApprovalParams defines model for TransferApprovalParams.
+type TransferApprovalParams struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// TransferBatch defines model for TransferBatch.
+type TransferBatch struct {
+	BatchId    openapi_types.UUID  `json:"batch_id"`
+	Failed     int                 `json:"failed"`
+	Items      []TransferBatchItem `json:"items"`
+	Queued     int                 `json:"queued"`
+	Running    int                 `json:"running"`
+	Status     TransferBatchStatus `json:"status"`
+	Succeeded  int                 `json:"succeeded"`
+	Total      int                 `json:"total"`
+	WorkflowId string              `json:"workflow_id"`
+}
+
+// TransferBatchItem defines model for TransferBatchItem.
+type TransferBatchItem struct {
+	FailureReason *string                 `json:"failure_reason,omitempty"`
+	ReferenceId   openapi_types.UUID      `json:"reference_id"`
+	Status        TransferBatchItemStatus `json:"status"`
+}
+
+// TransferBatchItemStatus defines model for TransferBatchItemStatus.
+type TransferBatchItemStatus string
+
+// TransferBatchParams defines model for TransferBatchParams.
+type TransferBatchParams struct {
+	BatchId openapi_types.UUID `json:"batch_id"`
+
+	// Items The transfers of the batch, split a larger run into several batches.
+	Items []TransferWorkflowParams `json:"items"`
+
+	// MaxConcurrency Caps the transfers of the batch running at the same time, bounded by the server limit.
+	MaxConcurrency *int `json:"max_concurrency,omitempty"`
+}
+
+// TransferBatchStatus defines model for TransferBatchStatus.
+type TransferBatchStatus string
+
+// TransferFailureCode Machine readable reason of a failed transfer, unset when the failure has no dedicated code.
+type TransferFailureCode string
+
+// TransferLimit defines model for TransferLimit.
+type TransferLimit struct {
+	AccountId       *openapi_types.UUID `json:"account_id,omitempty"`
+	AccountProduct  *string             `json:"account_product,omitempty"`
+	Currency        *string             `json:"currency,omitempty"`
+	DailyAmount     *int                `json:"daily_amount,omitempty"`
+	DailyCount      *int                `json:"daily_count,omitempty"`
+	Id              openapi_types.UUID  `json:"id"`
+	MaxSingleAmount *int                `json:"max_single_amount,omitempty"`
+	MonthlyAmount   *int                `json:"monthly_amount,omitempty"`
+	UpdatedAt       *time.Time          `json:"updated_at,omitempty"`
+}
+
+// TransferLimitParams Caps are in the minor units of the account currency, a missing cap is not enforced.
+type TransferLimitParams struct {
+	AccountId       *openapi_types.UUID `json:"account_id,omitempty"`
+	AccountProduct  *string             `json:"account_product,omitempty"`
+	Currency        *string             `json:"currency,omitempty"`
+	DailyAmount     *int                `json:"daily_amount,omitempty"`
+	DailyCount      *int                `json:"daily_count,omitempty"`
+	MaxSingleAmount *int                `json:"max_single_amount,omitempty"`
+	MonthlyAmount   *int                `json:"monthly_amount,omitempty"`
+}
+
+// TransferLimitRemaining A missing field has no cap.
+type TransferLimitRemaining struct {
+	DailyAmount   *int `json:"daily_amount,omitempty"`
+	DailyCount    *int `json:"daily_count,omitempty"`
+	MonthlyAmount *int `json:"monthly_amount,omitempty"`
+}
+
+// TransferLimitUsage defines model for TransferLimitUsage.
+type TransferLimitUsage struct {
+	DailyAmount   int `json:"daily_amount"`
+	DailyCount    int `json:"daily_count"`
+	MonthlyAmount int `json:"monthly_amount"`
+}
+
+// TransferResult defines model for TransferResult.
+type TransferResult struct {
+	DestinationTransaction *Transaction `json:"destination_transaction,omitempty"`
+	FeeTransaction         *Transaction `json:"fee_transaction,omitempty"`
+
+	// FxQuote Currency conversion of a cross-currency transfer, the rate is locked for the whole transfer.
+	FxQuote                *FXQuote            `json:"fx_quote,omitempty"`
+	IncomingFeeTransaction *Transaction        `json:"incoming_fee_transaction,omitempty"`
+	ReferenceId            *openapi_types.UUID `json:"reference_id,omitempty"`
+	SourceTransaction      *Transaction        `json:"source_transaction,omitempty"`
+}
+
+// TransferStatus defines model for TransferStatus.
+type TransferStatus struct {
+	// Approval Approval required for the transfer and, once taken, the decision on it.
+	Approval               *TransferApproval `json:"approval,omitempty"`
+	CancellationReason     *string           `json:"cancellation_reason,omitempty"`
+	DestinationTransaction *Transaction      `json:"destination_transaction,omitempty"`
+	ExecuteAt              *time.Time        `json:"execute_at,omitempty"`
+
+	// FailureCode Machine readable reason of a failed transfer, unset when the failure has no dedicated code.
+	FailureCode            *TransferFailureCode `json:"failure_code,omitempty"`
+	FailureReason          *string              `json:"failure_reason,omitempty"`
+	FeeTransaction         *Transaction         `json:"fee_transaction,omitempty"`
+	IncomingFeeTransaction *Transaction         `json:"incoming_fee_transaction,omitempty"`
+	ReferenceId            openapi_types.UUID   `json:"reference_id"`
+	SourceTransaction      *Transaction         `json:"source_transaction,omitempty"`
+	Status                 TransferStatusCode   `json:"status"`
+}
+
+// TransferStatusCode defines model for TransferStatusCode.
+type TransferStatusCode string
+
+// TransferWorkflowParams defines model for TransferWorkflowParams.
+type TransferWorkflowParams struct {
+	Amount               int                `json:"amount"`
+	DestinationAccountID openapi_types.UUID `json:"destinationAccountID"`
+
+	// ExecuteAt Schedules the transfer, it waits until this time before moving money. The transfer is always asynchronous when the time is in the future.
+	ExecuteAt *time.Time `json:"execute_at,omitempty"`
+
+	// FeeAmount Ignored, the fee is computed from the fee schedule.
+	// Deprecated:
+	FeeAmount       *int                    `json:"fee_amount,omitempty"`
+	Metadata        *map[string]interface{} `json:"metadata,omitempty"`
+	ReferenceId     openapi_types.UUID      `json:"reference_id"`
+	SourceAccountID openapi_types.UUID      `json:"sourceAccountID"`
+}
+
+// User defines model for User.
+type User struct {
+	Email     openapi_types.Email `json:"email"`
+	FirstName string              `json:"first_name"`
+	Id        *openapi_types.UUID `json:"id,omitempty"`
+	LastName  string              `json:"last_name"`
+}
+
+// UserResult defines model for UserResult.
+type UserResult struct {
+	BankAccount *Account `json:"bankAccount,omitempty"`
+	User        *User    `json:"user,omitempty"`
+}
+
+// VoidHoldParams defines model for VoidHoldParams.
+type VoidHoldParams struct {
+	Reason *string `json:"reason,omitempty"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts           int                `json:"attempts"`
+	CreatedAt          time.Time          `json:"created_at"`
+	DeliveredAt        *time.Time         `json:"delivered_at,omitempty"`
+	EventId            openapi_types.UUID `json:"event_id"`
+	EventType          WebhookEventType   `json:"event_type"`
+	Id                 openapi_types.UUID `json:"id"`
+	LastAttemptedAt    *time.Time         `json:"last_attempted_at,omitempty"`
+	LastError          *string            `json:"last_error,omitempty"`
+	LastResponseStatus *int               `json:"last_response_status,omitempty"`
+
+	// Payload Event envelope posted to the URL.
+	Payload      map[string]interface{} `json:"payload"`
+	Redeliveries int                    `json:"redeliveries"`
+	Status       WebhookDeliveryStatus  `json:"status"`
+	WebhookId    openapi_types.UUID     `json:"webhook_id"`
+}
+
+// WebhookDeliveryStatus defines model for WebhookDeliveryStatus.
+type WebhookDeliveryStatus string
+
+// WebhookEventType defines model for WebhookEventType.
+type WebhookEventType string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	AccountId openapi_types.UUID `json:"account_id"`
+
+	// ConsecutiveFailures Deliveries failed since the last one that went through, the subscription is disabled at WEBHOOK_MAX_CONSECUTIVE_FAILURES.
+	ConsecutiveFailures int                `json:"consecutive_failures"`
+	CreatedAt           time.Time          `json:"created_at"`
+	Description         *string            `json:"description,omitempty"`
+	DisabledAt          *time.Time         `json:"disabled_at,omitempty"`
+	EventTypes          []WebhookEventType `json:"event_types"`
+	Id                  openapi_types.UUID `json:"id"`
+
+	// Secret Key of the X-Webhook-Signature HMAC, only returned when the subscription is created.
+	Secret *string                   `json:"secret,omitempty"`
+	Status WebhookSubscriptionStatus `json:"status"`
+	Url    string                    `json:"url"`
+}
+
+// WebhookSubscriptionStatus defines model for WebhookSubscriptionStatus.
+type WebhookSubscriptionStatus string
+
+// AccountID defines model for AccountID.
+type AccountID = openapi_types.UUID
+
+// FeeRuleID defines model for FeeRuleID.
+type FeeRuleID = openapi_types.UUID
+
+// HoldID defines model for HoldID.
+type HoldID = openapi_types.UUID
+
+// StandingOrderID defines model for StandingOrderID.
+type StandingOrderID = openapi_types.UUID
+
+// TransferBatchID defines model for TransferBatchID.
+type TransferBatchID = openapi_types.UUID
+
+// TransferReferenceID defines model for TransferReferenceID.
+type TransferReferenceID = openapi_types.UUID
+
+// UserID defines model for UserID.
+type UserID = openapi_types.UUID
+
+// WebhookDeliveryID defines model for WebhookDeliveryID.
+type WebhookDeliveryID = openapi_types.UUID
+
+// WebhookID defines model for WebhookID.
+type WebhookID = openapi_types.UUID
+
+// AccountBalanceResponseBody defines model for AccountBalanceResponseBody.
+type AccountBalanceResponseBody struct {
+	Data AccountBalance `json:"data"`
+}
+
+// AccountLimitsResponseBody defines model for AccountLimitsResponseBody.
+type AccountLimitsResponseBody struct {
+	Data AccountLimits `json:"data"`
+}
+
+// AuditEventListResponseBody defines model for AuditEventListResponseBody.
+type AuditEventListResponseBody struct {
+	Data []AuditEvent `json:"data"`
+
+	// NextCursor Cursor of the next page, empty on the last page.
+	NextCursor *int64 `json:"next_cursor,omitempty"`
+}
+
+// CreateUserResponseBody defines model for CreateUserResponseBody.
+type CreateUserResponseBody struct {
+	Data UserResult `json:"data"`
+}
+
+// FeeQuoteResponseBody defines model for FeeQuoteResponseBody.
+type FeeQuoteResponseBody struct {
+	Data FeeQuote `json:"data"`
+}
+
+// FeeRuleListResponseBody defines model for FeeRuleListResponseBody.
+type FeeRuleListResponseBody struct {
+	Data []FeeRule `json:"data"`
+}
+
+// FeeRuleResponseBody defines model for FeeRuleResponseBody.
+type FeeRuleResponseBody struct {
+	Data FeeRule `json:"data"`
 }
 
-// CreateUserParams defines model for CreateUserParams.
-type CreateUserParams struct {
-	Balance      *int   `json:"balance,omitempty"`
-	CurrencyCode string `json:"currencyCode"`
-	Email        string `json:"email"`
-	FirstName    string `json:"firstName"`
-	LastName     string `json:"lastName"`
-	Password     string `json:"password"`
-}
+// HoldListResponseBody defines model for HoldListResponseBody.
+type HoldListResponseBody struct {
+	Data []Hold `json:"data"`
+}
+
+// HoldResponseBody defines model for HoldResponseBody.
+type HoldResponseBody struct {
+	Data Hold `json:"data"`
+}
+
+// NotificationPreferencesResponseBody defines model for NotificationPreferencesResponseBody.
+type NotificationPreferencesResponseBody struct {
+	Data NotificationPreferences `json:"data"`
+}
+
+// ReversalResponseBody defines model for ReversalResponseBody.
+type ReversalResponseBody struct {
+	Data Reversal `json:"data"`
+}
+
+// ScheduledTransferListResponseBody defines model for ScheduledTransferListResponseBody.
+type ScheduledTransferListResponseBody struct {
+	Data []ScheduledTransfer `json:"data"`
+}
+
+// ScheduledTransferResponseBody defines model for ScheduledTransferResponseBody.
+type ScheduledTransferResponseBody struct {
+	Data ScheduledTransfer `json:"data"`
+}
+
+// StandingOrderListResponseBody defines model for StandingOrderListResponseBody.
+type StandingOrderListResponseBody struct {
+	Data []StandingOrder `json:"data"`
+}
+
+// StandingOrderOccurrenceListResponseBody defines model for StandingOrderOccurrenceListResponseBody.
+type StandingOrderOccurrenceListResponseBody struct {
+	Data []StandingOrderOccurrence `json:"data"`
+}
+
+// StandingOrderResponseBody defines model for StandingOrderResponseBody.
+type StandingOrderResponseBody struct {
+	Data StandingOrder `json:"data"`
+}
+
+// TransferAcceptedResponseBody defines model for TransferAcceptedResponseBody.
+type TransferAcceptedResponseBody struct {
+	Data TransferAccepted `json:"data"`
+}
+
+// TransferBatchResponseBody defines model for TransferBatchResponseBody.
+type TransferBatchResponseBody struct {
+	Data TransferBatch `json:"data"`
+}
+
+// TransferLimitResponseBody defines model for TransferLimitResponseBody.
+type TransferLimitResponseBody struct {
+	Data TransferLimit `json:"data"`
+}
+
+// TransferStatusResponseBody defines model for TransferStatusResponseBody.
+type TransferStatusResponseBody struct {
+	Data TransferStatus `json:"data"`
+}
+
+// TransferWorkflowResponseBody defines model for TransferWorkflowResponseBody.
+type TransferWorkflowResponseBody struct {
+	Data TransferResult `json:"data"`
+}
+
+// WebhookDeliveryListResponseBody defines model for WebhookDeliveryListResponseBody.
+type WebhookDeliveryListResponseBody struct {
+	Data []WebhookDelivery `json:"data"`
+}
+
+// WebhookDeliveryResponseBody defines model for WebhookDeliveryResponseBody.
+type WebhookDeliveryResponseBody struct {
+	Data WebhookDelivery `json:"data"`
+}
+
+// WebhookSubscriptionListResponseBody defines model for WebhookSubscriptionListResponseBody.
+type WebhookSubscriptionListResponseBody struct {
+	Data []WebhookSubscription `json:"data"`
+}
+
+// WebhookSubscriptionResponseBody defines model for WebhookSubscriptionResponseBody.
+type WebhookSubscriptionResponseBody struct {
+	Data WebhookSubscription `json:"data"`
+}
+
+// CancelTransferRequestBody defines model for CancelTransferRequestBody.
+type CancelTransferRequestBody struct {
+	Data CancelTransferParams `json:"data"`
+}
+
+// CaptureHoldRequestBody defines model for CaptureHoldRequestBody.
+type CaptureHoldRequestBody struct {
+	Data CaptureHoldParams `json:"data"`
+}
+
+// FeePreviewRequestBody defines model for FeePreviewRequestBody.
+type FeePreviewRequestBody struct {
+	Data FeePreviewParams `json:"data"`
+}
+
+// FeeRuleCreateRequestBody defines model for FeeRuleCreateRequestBody.
+type FeeRuleCreateRequestBody struct {
+	Data CreateFeeRuleParams `json:"data"`
+}
+
+// NotificationPreferencesUpdateRequestBody defines model for NotificationPreferencesUpdateRequestBody.
+type NotificationPreferencesUpdateRequestBody struct {
+	Data NotificationPreferencesParams `json:"data"`
+}
+
+// PlaceHoldRequestBody defines model for PlaceHoldRequestBody.
+type PlaceHoldRequestBody struct {
+	Data PlaceHoldParams `json:"data"`
+}
+
+// RescheduleTransferRequestBody defines model for RescheduleTransferRequestBody.
+type RescheduleTransferRequestBody struct {
+	Data RescheduleTransferParams `json:"data"`
+}
+
+// ReverseTransferRequestBody defines model for ReverseTransferRequestBody.
+type ReverseTransferRequestBody struct {
+	Data ReverseTransferParams `json:"data"`
+}
+
+// StandingOrderCreateRequestBody defines model for StandingOrderCreateRequestBody.
+type StandingOrderCreateRequestBody struct {
+	Data CreateStandingOrderParams `json:"data"`
+}
+
+// TransferApprovalRequestBody defines model for TransferApprovalRequestBody.
+type TransferApprovalRequestBody struct {
+	Data TransferApprovalParams `json:"data"`
+}
+
+// TransferBatchRequestBody defines model for TransferBatchRequestBody.
+type TransferBatchRequestBody struct {
+	Data TransferBatchParams `json:"data"`
+}
+
+// TransferLimitRequestBody defines model for TransferLimitRequestBody.
+type TransferLimitRequestBody struct {
+	// Data Caps are in the minor units of the account currency, a missing cap is not enforced.
+	Data TransferLimitParams `json:"data"`
+}
+
+// TransferWorkflowRequestBody defines model for TransferWorkflowRequestBody.
+type TransferWorkflowRequestBody struct {
+	Data TransferWorkflowParams `json:"data"`
+}
+
+// UserCreateRequestBody defines model for UserCreateRequestBody.
+type UserCreateRequestBody struct {
+	Data CreateUserParams `json:"data"`
+}
+
+// VoidHoldRequestBody defines model for VoidHoldRequestBody.
+type VoidHoldRequestBody struct {
+	Data VoidHoldParams `json:"data"`
+}
+
+// WebhookSubscriptionCreateRequestBody defines model for WebhookSubscriptionCreateRequestBody.
+type WebhookSubscriptionCreateRequestBody struct {
+	Data CreateWebhookSubscriptionParams `json:"data"`
+}
+
+// V1GetAccountBalanceParams defines parameters for V1GetAccountBalance.
+type V1GetAccountBalanceParams struct {
+	AsOf *time.Time `form:"as_of,omitempty" json:"as_of,omitempty"`
+}
+
+// V1ListHoldsParams defines parameters for V1ListHolds.
+type V1ListHoldsParams struct {
+	Status *HoldStatus `form:"status,omitempty" json:"status,omitempty"`
+}
+
+// V1PlaceHoldJSONBody defines parameters for V1PlaceHold.
+type V1PlaceHoldJSONBody struct {
+	Data PlaceHoldParams `json:"data"`
+}
+
+// V1CaptureHoldJSONBody defines parameters for V1CaptureHold.
+type V1CaptureHoldJSONBody struct {
+	Data CaptureHoldParams `json:"data"`
+}
+
+// V1VoidHoldJSONBody defines parameters for V1VoidHold.
+type V1VoidHoldJSONBody struct {
+	Data VoidHoldParams `json:"data"`
+}
+
+// V1ListFeeRulesParams defines parameters for V1ListFeeRules.
+type V1ListFeeRulesParams struct {
+	// Code Only return the versions of the rule with this code.
+	Code *string `form:"code,omitempty" json:"code,omitempty"`
+}
+
+// V1CreateFeeRuleJSONBody defines parameters for V1CreateFeeRule.
+type V1CreateFeeRuleJSONBody struct {
+	Data CreateFeeRuleParams `json:"data"`
+}
+
+// V1SetTransferLimitJSONBody defines parameters for V1SetTransferLimit.
+type V1SetTransferLimitJSONBody struct {
+	// Data Caps are in the minor units of the account currency, a missing cap is not enforced.
+	Data TransferLimitParams `json:"data"`
+}
+
+// V1ListAuditEventsParams defines parameters for V1ListAuditEvents.
+type V1ListAuditEventsParams struct {
+	// EntityType Table of the changed rows, e.g. accounts.
+	EntityType *string `form:"entity_type,omitempty" json:"entity_type,omitempty"`
+
+	// EntityId Primary key of the changed row.
+	EntityId *string `form:"entity_id,omitempty" json:"entity_id,omitempty"`
+	Actor    *string `form:"actor,omitempty" json:"actor,omitempty"`
+
+	// SourceId ID of the HTTP request or the workflow the changes were made by.
+	SourceId *string      `form:"source_id,omitempty" json:"source_id,omitempty"`
+	Action   *AuditAction `form:"action,omitempty" json:"action,omitempty"`
+	From     *time.Time   `form:"from,omitempty" json:"from,omitempty"`
+	To       *time.Time   `form:"to,omitempty" json:"to,omitempty"`
+	Cursor   *int64       `form:"cursor,omitempty" json:"cursor,omitempty"`
+	Limit    *int         `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// V1PreviewFeeJSONBody defines parameters for V1PreviewFee.
+type V1PreviewFeeJSONBody struct {
+	Data FeePreviewParams `json:"data"`
+}
+
+// V1RescheduleTransferJSONBody defines parameters for V1RescheduleTransfer.
+type V1RescheduleTransferJSONBody struct {
+	Data RescheduleTransferParams `json:"data"`
+}
+
+// V1ListStandingOrdersParams defines parameters for V1ListStandingOrders.
+type V1ListStandingOrdersParams struct {
+	SourceAccountId *openapi_types.UUID `form:"source_account_id,omitempty" json:"source_account_id,omitempty"`
+}
+
+// V1CreateStandingOrderJSONBody defines parameters for V1CreateStandingOrder.
+type V1CreateStandingOrderJSONBody struct {
+	Data CreateStandingOrderParams `json:"data"`
+}
+
+// V1CreateTransferBatchJSONBody defines parameters for V1CreateTransferBatch.
+type V1CreateTransferBatchJSONBody struct {
+	Data TransferBatchParams `json:"data"`
+}
+
+// V1RunTransferWorkflowJSONBody defines parameters for V1RunTransferWorkflow.
+type V1RunTransferWorkflowJSONBody struct {
+	Data TransferWorkflowParams `json:"data"`
+}
+
+// V1RunTransferWorkflowParams defines parameters for V1RunTransferWorkflow.
+type V1RunTransferWorkflowParams struct {
+	// Async When true, the transfer is started and 202 is returned without waiting for the workflow result.
+	Async *bool `form:"async,omitempty" json:"async,omitempty"`
+}
+
+// V1ApproveTransferJSONBody defines parameters for V1ApproveTransfer.
+type V1ApproveTransferJSONBody struct {
+	Data TransferApprovalParams `json:"data"`
+}
+
+// V1CancelTransferJSONBody defines parameters for V1CancelTransfer.
+type V1CancelTransferJSONBody struct {
+	Data CancelTransferParams `json:"data"`
+}
+
+// V1RejectTransferJSONBody defines parameters for V1RejectTransfer.
+type V1RejectTransferJSONBody struct {
+	Data TransferApprovalParams `json:"data"`
+}
+
+// V1ReverseTransferJSONBody defines parameters for V1ReverseTransfer.
+type V1ReverseTransferJSONBody struct {
+	Data ReverseTransferParams `json:"data"`
+}
+
+// V1CreateUserJSONBody defines parameters for V1CreateUser.
+type V1CreateUserJSONBody struct {
+	Data CreateUserParams `json:"data"`
+}
+
+// V1UpdateNotificationPreferencesJSONBody defines parameters for V1UpdateNotificationPreferences.
+type V1UpdateNotificationPreferencesJSONBody struct {
+	Data NotificationPreferencesParams `json:"data"`
+}
+
+// V1ListWebhookSubscriptionsParams defines parameters for V1ListWebhookSubscriptions.
+type V1ListWebhookSubscriptionsParams struct {
+	AccountId openapi_types.UUID `form:"account_id" json:"account_id"`
+}
+
+// V1CreateWebhookSubscriptionJSONBody defines parameters for V1CreateWebhookSubscription.
+type V1CreateWebhookSubscriptionJSONBody struct {
+	Data CreateWebhookSubscriptionParams `json:"data"`
+}
+
+// V1ListWebhookDeliveriesParams defines parameters for V1ListWebhookDeliveries.
+type V1ListWebhookDeliveriesParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// V1PlaceHoldJSONRequestBody defines body for V1PlaceHold for application/json ContentType.
+type V1PlaceHoldJSONRequestBody V1PlaceHoldJSONBody
+
+// V1CaptureHoldJSONRequestBody defines body for V1CaptureHold for application/json ContentType.
+type V1CaptureHoldJSONRequestBody V1CaptureHoldJSONBody
+
+// V1VoidHoldJSONRequestBody defines body for V1VoidHold for application/json ContentType.
+type V1VoidHoldJSONRequestBody V1VoidHoldJSONBody
+
+// V1CreateFeeRuleJSONRequestBody defines body for V1CreateFeeRule for application/json ContentType.
+type V1CreateFeeRuleJSONRequestBody V1CreateFeeRuleJSONBody
+
+// V1SetTransferLimitJSONRequestBody defines body for V1SetTransferLimit for application/json ContentType.
+type V1SetTransferLimitJSONRequestBody V1SetTransferLimitJSONBody
+
+// V1PreviewFeeJSONRequestBody defines body for V1PreviewFee for application/json ContentType.
+type V1PreviewFeeJSONRequestBody V1PreviewFeeJSONBody
+
+// V1RescheduleTransferJSONRequestBody defines body for V1RescheduleTransfer for application/json ContentType.
+type V1RescheduleTransferJSONRequestBody V1RescheduleTransferJSONBody
+
+// V1CreateStandingOrderJSONRequestBody defines body for V1CreateStandingOrder for application/json ContentType.
+type V1CreateStandingOrderJSONRequestBody V1CreateStandingOrderJSONBody
+
+// V1CreateTransferBatchJSONRequestBody defines body for V1CreateTransferBatch for application/json ContentType.
+type V1CreateTransferBatchJSONRequestBody V1CreateTransferBatchJSONBody
+
+// V1RunTransferWorkflowJSONRequestBody defines body for V1RunTransferWorkflow for application/json ContentType.
+type V1RunTransferWorkflowJSONRequestBody V1RunTransferWorkflowJSONBody
+
+// V1ApproveTransferJSONRequestBody defines body for V1ApproveTransfer for application/json ContentType.
+type V1ApproveTransferJSONRequestBody V1ApproveTransferJSONBody
+
+// V1CancelTransferJSONRequestBody defines body for V1CancelTransfer for application/json ContentType.
+type V1CancelTransferJSONRequestBody V1CancelTransferJSONBody
+
+// V1RejectTransferJSONRequestBody defines body for V1RejectTransfer for application/json ContentType.
+type V1RejectTransferJSONRequestBody V1RejectTransferJSONBody
+
+// V1ReverseTransferJSONRequestBody defines body for V1ReverseTransfer for application/json ContentType.
+type V1ReverseTransferJSONRequestBody V1ReverseTransferJSONBody
+
+// V1CreateUserJSONRequestBody defines body for V1CreateUser for application/json ContentType.
+type V1CreateUserJSONRequestBody V1CreateUserJSONBody
+
+// V1UpdateNotificationPreferencesJSONRequestBody defines body for V1UpdateNotificationPreferences for application/json ContentType.
+type V1UpdateNotificationPreferencesJSONRequestBody V1UpdateNotificationPreferencesJSONBody
+
+// V1CreateWebhookSubscriptionJSONRequestBody defines body for V1CreateWebhookSubscription for application/json ContentType.
+type V1CreateWebhookSubscriptionJSONRequestBody V1CreateWebhookSubscriptionJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Get the balance of an account
+	// (GET /v1/accounts/{account_id}/balance)
+	V1GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId AccountID, params V1GetAccountBalanceParams)
+	// List the holds of an account
+	// (GET /v1/accounts/{account_id}/holds)
+	V1ListHolds(w http.ResponseWriter, r *http.Request, accountId AccountID, params V1ListHoldsParams)
+	// Place a hold
+	// (POST /v1/accounts/{account_id}/holds)
+	V1PlaceHold(w http.ResponseWriter, r *http.Request, accountId AccountID)
+	// Get hold
+	// (GET /v1/accounts/{account_id}/holds/{hold_id})
+	V1GetHold(w http.ResponseWriter, r *http.Request, accountId AccountID, holdId HoldID)
+	// Capture a hold
+	// (POST /v1/accounts/{account_id}/holds/{hold_id}/capture)
+	V1CaptureHold(w http.ResponseWriter, r *http.Request, accountId AccountID, holdId HoldID)
+	// Void a hold
+	// (POST /v1/accounts/{account_id}/holds/{hold_id}/void)
+	V1VoidHold(w http.ResponseWriter, r *http.Request, accountId AccountID, holdId HoldID)
+	// Get the transfer limits of an account
+	// (GET /v1/accounts/{account_id}/limits)
+	V1GetAccountLimits(w http.ResponseWriter, r *http.Request, accountId AccountID)
+	// List fee rules
+	// (GET /v1/admin/fee-rules)
+	V1ListFeeRules(w http.ResponseWriter, r *http.Request, params V1ListFeeRulesParams)
+	// Create a fee rule version
+	// (POST /v1/admin/fee-rules)
+	V1CreateFeeRule(w http.ResponseWriter, r *http.Request)
+	// Retire fee rule
+	// (DELETE /v1/admin/fee-rules/{fee_rule_id})
+	V1RetireFeeRule(w http.ResponseWriter, r *http.Request, feeRuleId FeeRuleID)
+	// Get fee rule
+	// (GET /v1/admin/fee-rules/{fee_rule_id})
+	V1GetFeeRule(w http.ResponseWriter, r *http.Request, feeRuleId FeeRuleID)
+	// Set a transfer limit
+	// (PUT /v1/admin/transfer-limits)
+	V1SetTransferLimit(w http.ResponseWriter, r *http.Request)
+	// List audit events
+	// (GET /v1/audit-events)
+	V1ListAuditEvents(w http.ResponseWriter, r *http.Request, params V1ListAuditEventsParams)
+	// Preview the fee of a transfer
+	// (POST /v1/fees/preview)
+	V1PreviewFee(w http.ResponseWriter, r *http.Request)
+	// List scheduled transfers
+	// (GET /v1/scheduled-transfers)
+	V1ListScheduledTransfers(w http.ResponseWriter, r *http.Request)
+	// Cancel scheduled transfer
+	// (DELETE /v1/scheduled-transfers/{reference_id})
+	V1CancelScheduledTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID)
+	// Reschedule transfer
+	// (PATCH /v1/scheduled-transfers/{reference_id})
+	V1RescheduleTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID)
+	// List standing orders
+	// (GET /v1/standing-orders)
+	V1ListStandingOrders(w http.ResponseWriter, r *http.Request, params V1ListStandingOrdersParams)
+	// Create standing order
+	// (POST /v1/standing-orders)
+	V1CreateStandingOrder(w http.ResponseWriter, r *http.Request)
+	// Cancel standing order
+	// (DELETE /v1/standing-orders/{standing_order_id})
+	V1CancelStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderId StandingOrderID)
+	// Get standing order
+	// (GET /v1/standing-orders/{standing_order_id})
+	V1GetStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderId StandingOrderID)
+	// List standing order occurrences
+	// (GET /v1/standing-orders/{standing_order_id}/occurrences)
+	V1ListStandingOrderOccurrences(w http.ResponseWriter, r *http.Request, standingOrderId StandingOrderID)
+	// Create transfer batch
+	// (POST /v1/transfer-batches)
+	V1CreateTransferBatch(w http.ResponseWriter, r *http.Request)
+	// Get transfer batch
+	// (GET /v1/transfer-batches/{batch_id})
+	V1GetTransferBatch(w http.ResponseWriter, r *http.Request, batchId TransferBatchID)
+	// Run transfer workflow
+	// (POST /v1/transfers)
+	V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request, params V1RunTransferWorkflowParams)
+	// Get transfer status
+	// (GET /v1/transfers/{reference_id})
+	V1GetTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID)
+	// Approve transfer
+	// (POST /v1/transfers/{reference_id}/approve)
+	V1ApproveTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID)
+	// Cancel transfer
+	// (POST /v1/transfers/{reference_id}/cancel)
+	V1CancelTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID)
+	// Reject transfer
+	// (POST /v1/transfers/{reference_id}/reject)
+	V1RejectTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID)
+	// Reverse transfer
+	// (POST /v1/transfers/{reference_id}/reverse)
+	V1ReverseTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID)
+	// Create user
+	// (POST /v1/users)
+	V1CreateUser(w http.ResponseWriter, r *http.Request)
+	// Get notification preferences
+	// (GET /v1/users/{user_id}/notification-preferences)
+	V1GetNotificationPreferences(w http.ResponseWriter, r *http.Request, userId UserID)
+	// Update notification preferences
+	// (PUT /v1/users/{user_id}/notification-preferences)
+	V1UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request, userId UserID)
+	// List webhook subscriptions
+	// (GET /v1/webhooks)
+	V1ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request, params V1ListWebhookSubscriptionsParams)
+	// Create webhook subscription
+	// (POST /v1/webhooks)
+	V1CreateWebhookSubscription(w http.ResponseWriter, r *http.Request)
+	// Delete webhook subscription
+	// (DELETE /v1/webhooks/{webhook_id})
+	V1DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId WebhookID)
+	// Get webhook subscription
+	// (GET /v1/webhooks/{webhook_id})
+	V1GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId WebhookID)
+	// List webhook deliveries
+	// (GET /v1/webhooks/{webhook_id}/deliveries)
+	V1ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookID, params V1ListWebhookDeliveriesParams)
+	// Redeliver webhook delivery
+	// (POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+	V1RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId WebhookID, deliveryId WebhookDeliveryID)
+	// Enable webhook subscription
+	// (POST /v1/webhooks/{webhook_id}/enable)
+	V1EnableWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId WebhookID)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Get the balance of an account
+// (GET /v1/accounts/{account_id}/balance)
+func (_ Unimplemented) V1GetAccountBalance(w http.ResponseWriter, r *http.Request, accountId AccountID, params V1GetAccountBalanceParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the holds of an account
+// (GET /v1/accounts/{account_id}/holds)
+func (_ Unimplemented) V1ListHolds(w http.ResponseWriter, r *http.Request, accountId AccountID, params V1ListHoldsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Place a hold
+// (POST /v1/accounts/{account_id}/holds)
+func (_ Unimplemented) V1PlaceHold(w http.ResponseWriter, r *http.Request, accountId AccountID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get hold
+// (GET /v1/accounts/{account_id}/holds/{hold_id})
+func (_ Unimplemented) V1GetHold(w http.ResponseWriter, r *http.Request, accountId AccountID, holdId HoldID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Capture a hold
+// (POST /v1/accounts/{account_id}/holds/{hold_id}/capture)
+func (_ Unimplemented) V1CaptureHold(w http.ResponseWriter, r *http.Request, accountId AccountID, holdId HoldID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Void a hold
+// (POST /v1/accounts/{account_id}/holds/{hold_id}/void)
+func (_ Unimplemented) V1VoidHold(w http.ResponseWriter, r *http.Request, accountId AccountID, holdId HoldID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the transfer limits of an account
+// (GET /v1/accounts/{account_id}/limits)
+func (_ Unimplemented) V1GetAccountLimits(w http.ResponseWriter, r *http.Request, accountId AccountID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List fee rules
+// (GET /v1/admin/fee-rules)
+func (_ Unimplemented) V1ListFeeRules(w http.ResponseWriter, r *http.Request, params V1ListFeeRulesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a fee rule version
+// (POST /v1/admin/fee-rules)
+func (_ Unimplemented) V1CreateFeeRule(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Retire fee rule
+// (DELETE /v1/admin/fee-rules/{fee_rule_id})
+func (_ Unimplemented) V1RetireFeeRule(w http.ResponseWriter, r *http.Request, feeRuleId FeeRuleID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get fee rule
+// (GET /v1/admin/fee-rules/{fee_rule_id})
+func (_ Unimplemented) V1GetFeeRule(w http.ResponseWriter, r *http.Request, feeRuleId FeeRuleID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set a transfer limit
+// (PUT /v1/admin/transfer-limits)
+func (_ Unimplemented) V1SetTransferLimit(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List audit events
+// (GET /v1/audit-events)
+func (_ Unimplemented) V1ListAuditEvents(w http.ResponseWriter, r *http.Request, params V1ListAuditEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Preview the fee of a transfer
+// (POST /v1/fees/preview)
+func (_ Unimplemented) V1PreviewFee(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List scheduled transfers
+// (GET /v1/scheduled-transfers)
+func (_ Unimplemented) V1ListScheduledTransfers(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel scheduled transfer
+// (DELETE /v1/scheduled-transfers/{reference_id})
+func (_ Unimplemented) V1CancelScheduledTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reschedule transfer
+// (PATCH /v1/scheduled-transfers/{reference_id})
+func (_ Unimplemented) V1RescheduleTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List standing orders
+// (GET /v1/standing-orders)
+func (_ Unimplemented) V1ListStandingOrders(w http.ResponseWriter, r *http.Request, params V1ListStandingOrdersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create standing order
+// (POST /v1/standing-orders)
+func (_ Unimplemented) V1CreateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel standing order
+// (DELETE /v1/standing-orders/{standing_order_id})
+func (_ Unimplemented) V1CancelStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderId StandingOrderID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get standing order
+// (GET /v1/standing-orders/{standing_order_id})
+func (_ Unimplemented) V1GetStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderId StandingOrderID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List standing order occurrences
+// (GET /v1/standing-orders/{standing_order_id}/occurrences)
+func (_ Unimplemented) V1ListStandingOrderOccurrences(w http.ResponseWriter, r *http.Request, standingOrderId StandingOrderID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create transfer batch
+// (POST /v1/transfer-batches)
+func (_ Unimplemented) V1CreateTransferBatch(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get transfer batch
+// (GET /v1/transfer-batches/{batch_id})
+func (_ Unimplemented) V1GetTransferBatch(w http.ResponseWriter, r *http.Request, batchId TransferBatchID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Run transfer workflow
+// (POST /v1/transfers)
+func (_ Unimplemented) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request, params V1RunTransferWorkflowParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get transfer status
+// (GET /v1/transfers/{reference_id})
+func (_ Unimplemented) V1GetTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve transfer
+// (POST /v1/transfers/{reference_id}/approve)
+func (_ Unimplemented) V1ApproveTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Cancel transfer
+// (POST /v1/transfers/{reference_id}/cancel)
+func (_ Unimplemented) V1CancelTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject transfer
+// (POST /v1/transfers/{reference_id}/reject)
+func (_ Unimplemented) V1RejectTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reverse transfer
+// (POST /v1/transfers/{reference_id}/reverse)
+func (_ Unimplemented) V1ReverseTransfer(w http.ResponseWriter, r *http.Request, referenceId TransferReferenceID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create user
+// (POST /v1/users)
+func (_ Unimplemented) V1CreateUser(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get notification preferences
+// (GET /v1/users/{user_id}/notification-preferences)
+func (_ Unimplemented) V1GetNotificationPreferences(w http.ResponseWriter, r *http.Request, userId UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update notification preferences
+// (PUT /v1/users/{user_id}/notification-preferences)
+func (_ Unimplemented) V1UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request, userId UserID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhook subscriptions
+// (GET /v1/webhooks)
+func (_ Unimplemented) V1ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request, params V1ListWebhookSubscriptionsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create webhook subscription
+// (POST /v1/webhooks)
+func (_ Unimplemented) V1CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete webhook subscription
+// (DELETE /v1/webhooks/{webhook_id})
+func (_ Unimplemented) V1DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId WebhookID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get webhook subscription
+// (GET /v1/webhooks/{webhook_id})
+func (_ Unimplemented) V1GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId WebhookID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List webhook deliveries
+// (GET /v1/webhooks/{webhook_id}/deliveries)
+func (_ Unimplemented) V1ListWebhookDeliveries(w http.ResponseWriter, r *http.Request, webhookId WebhookID, params V1ListWebhookDeliveriesParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Redeliver webhook delivery
+// (POST /v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver)
+func (_ Unimplemented) V1RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookId WebhookID, deliveryId WebhookDeliveryID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Enable webhook subscription
+// (POST /v1/webhooks/{webhook_id}/enable)
+func (_ Unimplemented) V1EnableWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookId WebhookID) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// V1GetAccountBalance operation middleware
+func (siw *ServerInterfaceWrapper) V1GetAccountBalance(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account_id", runtime.ParamLocationPath, chi.URLParam(r, "account_id"), &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1GetAccountBalanceParams
+
+	// ------------- Optional query parameter "as_of" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "as_of", r.URL.Query(), &params.AsOf)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "as_of", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetAccountBalance(w, r, accountId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ListHolds operation middleware
+func (siw *ServerInterfaceWrapper) V1ListHolds(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account_id", runtime.ParamLocationPath, chi.URLParam(r, "account_id"), &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1ListHoldsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListHolds(w, r, accountId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1PlaceHold operation middleware
+func (siw *ServerInterfaceWrapper) V1PlaceHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account_id", runtime.ParamLocationPath, chi.URLParam(r, "account_id"), &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1PlaceHold(w, r, accountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1GetHold operation middleware
+func (siw *ServerInterfaceWrapper) V1GetHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account_id", runtime.ParamLocationPath, chi.URLParam(r, "account_id"), &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "hold_id" -------------
+	var holdId HoldID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "hold_id", runtime.ParamLocationPath, chi.URLParam(r, "hold_id"), &holdId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hold_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetHold(w, r, accountId, holdId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CaptureHold operation middleware
+func (siw *ServerInterfaceWrapper) V1CaptureHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account_id", runtime.ParamLocationPath, chi.URLParam(r, "account_id"), &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "hold_id" -------------
+	var holdId HoldID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "hold_id", runtime.ParamLocationPath, chi.URLParam(r, "hold_id"), &holdId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hold_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CaptureHold(w, r, accountId, holdId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1VoidHold operation middleware
+func (siw *ServerInterfaceWrapper) V1VoidHold(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account_id", runtime.ParamLocationPath, chi.URLParam(r, "account_id"), &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "hold_id" -------------
+	var holdId HoldID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "hold_id", runtime.ParamLocationPath, chi.URLParam(r, "hold_id"), &holdId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "hold_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1VoidHold(w, r, accountId, holdId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1GetAccountLimits operation middleware
+func (siw *ServerInterfaceWrapper) V1GetAccountLimits(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "account_id" -------------
+	var accountId AccountID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "account_id", runtime.ParamLocationPath, chi.URLParam(r, "account_id"), &accountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetAccountLimits(w, r, accountId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ListFeeRules operation middleware
+func (siw *ServerInterfaceWrapper) V1ListFeeRules(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1ListFeeRulesParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListFeeRules(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CreateFeeRule operation middleware
+func (siw *ServerInterfaceWrapper) V1CreateFeeRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CreateFeeRule(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1RetireFeeRule operation middleware
+func (siw *ServerInterfaceWrapper) V1RetireFeeRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "fee_rule_id" -------------
+	var feeRuleId FeeRuleID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "fee_rule_id", runtime.ParamLocationPath, chi.URLParam(r, "fee_rule_id"), &feeRuleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fee_rule_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1RetireFeeRule(w, r, feeRuleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1GetFeeRule operation middleware
+func (siw *ServerInterfaceWrapper) V1GetFeeRule(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "fee_rule_id" -------------
+	var feeRuleId FeeRuleID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "fee_rule_id", runtime.ParamLocationPath, chi.URLParam(r, "fee_rule_id"), &feeRuleId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "fee_rule_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetFeeRule(w, r, feeRuleId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1SetTransferLimit operation middleware
+func (siw *ServerInterfaceWrapper) V1SetTransferLimit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1SetTransferLimit(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ListAuditEvents operation middleware
+func (siw *ServerInterfaceWrapper) V1ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1ListAuditEventsParams
+
+	// ------------- Optional query parameter "entity_type" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_type", r.URL.Query(), &params.EntityType)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entity_type", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "entity_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "entity_id", r.URL.Query(), &params.EntityId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "entity_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "actor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "actor", r.URL.Query(), &params.Actor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "actor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "source_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source_id", r.URL.Query(), &params.SourceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source_id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "action" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "action", r.URL.Query(), &params.Action)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "action", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListAuditEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1PreviewFee operation middleware
+func (siw *ServerInterfaceWrapper) V1PreviewFee(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1PreviewFee(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ListScheduledTransfers operation middleware
+func (siw *ServerInterfaceWrapper) V1ListScheduledTransfers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListScheduledTransfers(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CancelScheduledTransfer operation middleware
+func (siw *ServerInterfaceWrapper) V1CancelScheduledTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reference_id" -------------
+	var referenceId TransferReferenceID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reference_id", runtime.ParamLocationPath, chi.URLParam(r, "reference_id"), &referenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CancelScheduledTransfer(w, r, referenceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1RescheduleTransfer operation middleware
+func (siw *ServerInterfaceWrapper) V1RescheduleTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reference_id" -------------
+	var referenceId TransferReferenceID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reference_id", runtime.ParamLocationPath, chi.URLParam(r, "reference_id"), &referenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1RescheduleTransfer(w, r, referenceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ListStandingOrders operation middleware
+func (siw *ServerInterfaceWrapper) V1ListStandingOrders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1ListStandingOrdersParams
+
+	// ------------- Optional query parameter "source_account_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "source_account_id", r.URL.Query(), &params.SourceAccountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "source_account_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListStandingOrders(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CreateStandingOrder operation middleware
+func (siw *ServerInterfaceWrapper) V1CreateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CreateStandingOrder(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CancelStandingOrder operation middleware
+func (siw *ServerInterfaceWrapper) V1CancelStandingOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "standing_order_id" -------------
+	var standingOrderId StandingOrderID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "standing_order_id", runtime.ParamLocationPath, chi.URLParam(r, "standing_order_id"), &standingOrderId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "standing_order_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CancelStandingOrder(w, r, standingOrderId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1GetStandingOrder operation middleware
+func (siw *ServerInterfaceWrapper) V1GetStandingOrder(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "standing_order_id" -------------
+	var standingOrderId StandingOrderID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "standing_order_id", runtime.ParamLocationPath, chi.URLParam(r, "standing_order_id"), &standingOrderId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "standing_order_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetStandingOrder(w, r, standingOrderId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ListStandingOrderOccurrences operation middleware
+func (siw *ServerInterfaceWrapper) V1ListStandingOrderOccurrences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "standing_order_id" -------------
+	var standingOrderId StandingOrderID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "standing_order_id", runtime.ParamLocationPath, chi.URLParam(r, "standing_order_id"), &standingOrderId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "standing_order_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListStandingOrderOccurrences(w, r, standingOrderId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CreateTransferBatch operation middleware
+func (siw *ServerInterfaceWrapper) V1CreateTransferBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CreateTransferBatch(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1GetTransferBatch operation middleware
+func (siw *ServerInterfaceWrapper) V1GetTransferBatch(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "batch_id" -------------
+	var batchId TransferBatchID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "batch_id", runtime.ParamLocationPath, chi.URLParam(r, "batch_id"), &batchId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "batch_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetTransferBatch(w, r, batchId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1RunTransferWorkflow operation middleware
+func (siw *ServerInterfaceWrapper) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1RunTransferWorkflowParams
+
+	// ------------- Optional query parameter "async" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "async", r.URL.Query(), &params.Async)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "async", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1RunTransferWorkflow(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1GetTransfer operation middleware
+func (siw *ServerInterfaceWrapper) V1GetTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reference_id" -------------
+	var referenceId TransferReferenceID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reference_id", runtime.ParamLocationPath, chi.URLParam(r, "reference_id"), &referenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetTransfer(w, r, referenceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ApproveTransfer operation middleware
+func (siw *ServerInterfaceWrapper) V1ApproveTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reference_id" -------------
+	var referenceId TransferReferenceID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reference_id", runtime.ParamLocationPath, chi.URLParam(r, "reference_id"), &referenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ApproveTransfer(w, r, referenceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CancelTransfer operation middleware
+func (siw *ServerInterfaceWrapper) V1CancelTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reference_id" -------------
+	var referenceId TransferReferenceID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reference_id", runtime.ParamLocationPath, chi.URLParam(r, "reference_id"), &referenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CancelTransfer(w, r, referenceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1RejectTransfer operation middleware
+func (siw *ServerInterfaceWrapper) V1RejectTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reference_id" -------------
+	var referenceId TransferReferenceID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reference_id", runtime.ParamLocationPath, chi.URLParam(r, "reference_id"), &referenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1RejectTransfer(w, r, referenceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ReverseTransfer operation middleware
+func (siw *ServerInterfaceWrapper) V1ReverseTransfer(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "reference_id" -------------
+	var referenceId TransferReferenceID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "reference_id", runtime.ParamLocationPath, chi.URLParam(r, "reference_id"), &referenceId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reference_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ReverseTransfer(w, r, referenceId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1CreateUser operation middleware
+func (siw *ServerInterfaceWrapper) V1CreateUser(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CreateUser(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1GetNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) V1GetNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, chi.URLParam(r, "user_id"), &userId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetNotificationPreferences(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1UpdateNotificationPreferences operation middleware
+func (siw *ServerInterfaceWrapper) V1UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "user_id" -------------
+	var userId UserID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "user_id", runtime.ParamLocationPath, chi.URLParam(r, "user_id"), &userId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "user_id", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1UpdateNotificationPreferences(w, r, userId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1ListWebhookSubscriptions operation middleware
+func (siw *ServerInterfaceWrapper) V1ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1ListWebhookSubscriptionsParams
 
-// Error defines model for Error.
-type Error struct {
-	Code   string                 `json:"code"`
-	Detail string                 `json:"detail"`
-	Meta   map[string]interface{} `json:"meta"`
-	Status int                    `json:"status"`
-	Title  string                 `json:"title"`
-}
+	// ------------- Required query parameter "account_id" -------------
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	Errors []Error `json:"errors"`
-}
+	if paramValue := r.URL.Query().Get("account_id"); paramValue != "" {
 
-// Transaction defines model for Transaction.
-type Transaction struct {
-	AccountId       *openapi_types.UUID     `json:"account_id,omitempty"`
-	Amount          *int                    `json:"amount,omitempty"`
-	CreatedAt       *time.Time              `json:"created_at,omitempty"`
-	CurrencyCode    *string                 `json:"currency_code,omitempty"`
-	Id              *openapi_types.UUID     `json:"id,omitempty"`
-	Metadata        *map[string]interface{} `json:"metadata,omitempty"`
-	ReferenceId     *openapi_types.UUID     `json:"reference_id,omitempty"`
-	Status          *string                 `json:"status,omitempty"`
-	TransactionType *string                 `json:"transaction_type,omitempty"`
-	UpdatedAt       *time.Time              `json:"updated_at,omitempty"`
-	UserId          *openapi_types.UUID     `json:"user_id,omitempty"`
-}
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "account_id"})
+		return
+	}
 
-// TransferResult defines model for TransferResult.
-type TransferResult struct {
-	DestinationTransaction *Transaction        `json:"destination_transaction,omitempty"`
-	FeeTransaction         *Transaction        `json:"fee_transaction,omitempty"`
-	ReferenceId            *openapi_types.UUID `json:"reference_id,omitempty"`
-	SourceTransaction      *Transaction        `json:"source_transaction,omitempty"`
-}
+	err = runtime.BindQueryParameter("form", true, true, "account_id", r.URL.Query(), &params.AccountId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "account_id", Err: err})
+		return
+	}
 
-// TransferWorkflowParams defines model for TransferWorkflowParams.
-type TransferWorkflowParams struct {
-	Amount               int                     `json:"amount"`
-	DestinationAccountID openapi_types.UUID      `json:"destinationAccountID"`
-	FeeAmount            *int                    `json:"fee_amount,omitempty"`
-	Metadata             *map[string]interface{} `json:"metadata,omitempty"`
-	ReferenceId          openapi_types.UUID      `json:"reference_id"`
-	SourceAccountID      openapi_types.UUID      `json:"sourceAccountID"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListWebhookSubscriptions(w, r, params)
+	}))
 
-// User defines model for User.
-type User struct {
-	Email     openapi_types.Email `json:"email"`
-	FirstName string              `json:"first_name"`
-	Id        *openapi_types.UUID `json:"id,omitempty"`
-	LastName  string              `json:"last_name"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// UserResult defines model for UserResult.
-type UserResult struct {
-	BankAccount *Account `json:"bankAccount,omitempty"`
-	User        *User    `json:"user,omitempty"`
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// CreateUserResponseBody defines model for CreateUserResponseBody.
-type CreateUserResponseBody struct {
-	Data UserResult `json:"data"`
-}
+// V1CreateWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) V1CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// TransferWorkflowResponseBody defines model for TransferWorkflowResponseBody.
-type TransferWorkflowResponseBody struct {
-	Data TransferResult `json:"data"`
-}
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1CreateWebhookSubscription(w, r)
+	}))
 
-// TransferWorkflowRequestBody defines model for TransferWorkflowRequestBody.
-type TransferWorkflowRequestBody struct {
-	Data TransferWorkflowParams `json:"data"`
-}
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// UserCreateRequestBody defines model for UserCreateRequestBody.
-type UserCreateRequestBody struct {
-	Data CreateUserParams `json:"data"`
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// V1RunTransferWorkflowJSONBody defines parameters for V1RunTransferWorkflow.
-type V1RunTransferWorkflowJSONBody struct {
-	Data TransferWorkflowParams `json:"data"`
-}
+// V1DeleteWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) V1DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// V1CreateUserJSONBody defines parameters for V1CreateUser.
-type V1CreateUserJSONBody struct {
-	Data CreateUserParams `json:"data"`
-}
+	var err error
 
-// V1RunTransferWorkflowJSONRequestBody defines body for V1RunTransferWorkflow for application/json ContentType.
-type V1RunTransferWorkflowJSONRequestBody V1RunTransferWorkflowJSONBody
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
 
-// V1CreateUserJSONRequestBody defines body for V1CreateUser for application/json ContentType.
-type V1CreateUserJSONRequestBody V1CreateUserJSONBody
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, chi.URLParam(r, "webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Run transfer workflow
-	// (POST /v1/transfers)
-	V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request)
-	// Create user
-	// (POST /v1/users)
-	V1CreateUser(w http.ResponseWriter, r *http.Request)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1DeleteWebhookSubscription(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+// V1GetWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) V1GetWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-type Unimplemented struct{}
+	var err error
 
-// Run transfer workflow
-// (POST /v1/transfers)
-func (_ Unimplemented) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
 
-// Create user
-// (POST /v1/users)
-func (_ Unimplemented) V1CreateUser(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, chi.URLParam(r, "webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1GetWebhookSubscription(w, r, webhookId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-type MiddlewareFunc func(http.Handler) http.Handler
+// V1ListWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) V1ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
 
-// V1RunTransferWorkflow operation middleware
-func (siw *ServerInterfaceWrapper) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request) {
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, chi.URLParam(r, "webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params V1ListWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.V1ListWebhookDeliveries(w, r, webhookId, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// V1RedeliverWebhookDelivery operation middleware
+func (siw *ServerInterfaceWrapper) V1RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, chi.URLParam(r, "webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "delivery_id" -------------
+	var deliveryId WebhookDeliveryID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "delivery_id", runtime.ParamLocationPath, chi.URLParam(r, "delivery_id"), &deliveryId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "delivery_id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.V1RunTransferWorkflow(w, r)
+		siw.Handler.V1RedeliverWebhookDelivery(w, r, webhookId, deliveryId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -185,12 +2612,23 @@ func (siw *ServerInterfaceWrapper) V1RunTransferWorkflow(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// V1CreateUser operation middleware
-func (siw *ServerInterfaceWrapper) V1CreateUser(w http.ResponseWriter, r *http.Request) {
+// V1EnableWebhookSubscription operation middleware
+func (siw *ServerInterfaceWrapper) V1EnableWebhookSubscription(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
+	// ------------- Path parameter "webhook_id" -------------
+	var webhookId WebhookID
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "webhook_id", runtime.ParamLocationPath, chi.URLParam(r, "webhook_id"), &webhookId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "webhook_id", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.V1CreateUser(w, r)
+		siw.Handler.V1EnableWebhookSubscription(w, r, webhookId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -313,12 +2751,126 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/accounts/{account_id}/balance", wrapper.V1GetAccountBalance)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/accounts/{account_id}/holds", wrapper.V1ListHolds)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/accounts/{account_id}/holds", wrapper.V1PlaceHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/accounts/{account_id}/holds/{hold_id}", wrapper.V1GetHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/accounts/{account_id}/holds/{hold_id}/capture", wrapper.V1CaptureHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/accounts/{account_id}/holds/{hold_id}/void", wrapper.V1VoidHold)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/accounts/{account_id}/limits", wrapper.V1GetAccountLimits)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/admin/fee-rules", wrapper.V1ListFeeRules)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/admin/fee-rules", wrapper.V1CreateFeeRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/admin/fee-rules/{fee_rule_id}", wrapper.V1RetireFeeRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/admin/fee-rules/{fee_rule_id}", wrapper.V1GetFeeRule)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/admin/transfer-limits", wrapper.V1SetTransferLimit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/audit-events", wrapper.V1ListAuditEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/fees/preview", wrapper.V1PreviewFee)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/scheduled-transfers", wrapper.V1ListScheduledTransfers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/scheduled-transfers/{reference_id}", wrapper.V1CancelScheduledTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v1/scheduled-transfers/{reference_id}", wrapper.V1RescheduleTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/standing-orders", wrapper.V1ListStandingOrders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/standing-orders", wrapper.V1CreateStandingOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/standing-orders/{standing_order_id}", wrapper.V1CancelStandingOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/standing-orders/{standing_order_id}", wrapper.V1GetStandingOrder)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/standing-orders/{standing_order_id}/occurrences", wrapper.V1ListStandingOrderOccurrences)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/transfer-batches", wrapper.V1CreateTransferBatch)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/transfer-batches/{batch_id}", wrapper.V1GetTransferBatch)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v1/transfers", wrapper.V1RunTransferWorkflow)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/transfers/{reference_id}", wrapper.V1GetTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/transfers/{reference_id}/approve", wrapper.V1ApproveTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/transfers/{reference_id}/cancel", wrapper.V1CancelTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/transfers/{reference_id}/reject", wrapper.V1RejectTransfer)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/transfers/{reference_id}/reverse", wrapper.V1ReverseTransfer)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/v1/users", wrapper.V1CreateUser)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/users/{user_id}/notification-preferences", wrapper.V1GetNotificationPreferences)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/v1/users/{user_id}/notification-preferences", wrapper.V1UpdateNotificationPreferences)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhooks", wrapper.V1ListWebhookSubscriptions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/webhooks", wrapper.V1CreateWebhookSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/v1/webhooks/{webhook_id}", wrapper.V1DeleteWebhookSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhooks/{webhook_id}", wrapper.V1GetWebhookSubscription)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/webhooks/{webhook_id}/deliveries", wrapper.V1ListWebhookDeliveries)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/webhooks/{webhook_id}/deliveries/{delivery_id}/redeliver", wrapper.V1RedeliverWebhookDelivery)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/webhooks/{webhook_id}/enable", wrapper.V1EnableWebhookSubscription)
+	})
 
 	return r
 }
@@ -326,27 +2878,159 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xYS2/jNhD+KwLboxxLtvPSaV85pCjaINttD4vAGFNjm7sSqZJUdo1A/70gKVmyRDtO",
-	"tjB66M0mh/P4ZubjUE+EirwQHLlWJHkiEv8uUel3ImVoF/6QwNUS5V9Cfl1m4tv9VmBjtqngGrk2P6Eo",
-	"MkZBM8HHX5TgZk3RNeZgfhVSFCh1rTUFbVd/lrgkCflp3HoxdmfUuG/5DiTkilRVaL1kElOSfHaqHkKi",
-	"NwWShIjFF6SaVJWR+6RQvpcIGk/ntrNnLL/YYSuoCsGVM9equq+XT+B/ba7M9PGehyRFRSUrjBcksbgH",
-	"TSikCj1VdLJ4GtM/GtPNd8iLDDthVWHtmPXlLaWidCHsOrmADDhF8xOdDpLEURSFZClkDpokhHE9nZCQ",
-	"5IyzvMxJEm0dYlzjCqUBkZZSIqebHU3k08cP5iR8/xX5Sq9JMrV6Ov9qTUpLxldGEUt3VVxfXeLF+Ww6",
-	"wkm8GM3OlxcjuLy6HsWT6ez84vLqGhaUdNwtS5YSj16lQZdqVzdQzR7RJ10qlPO+K/FkisbmCK+uF6N4",
-	"kk5HMDu/GM0mFxfxLL6cRVH0vCu9HDeWwm0uOmBu3R4WQUgGzXwou/sz9l6kXYkWA8yBZd6dJZNK/wa5",
-	"/1wGBzYLUOqbkKlnsweMM9850bXbsdKLxCDFtM3XACAPiDdSCjlEjtagtMlXKB9RztHKeyomRV3D1Z75",
-	"iPKRUQw05oWQIFm2CUoOj8AyWGQYBhK13AQZaPTqzNFRyFbjE6FQKkzni42JLwOlLAaVJzJPwZ9H3t6t",
-	"8ep7jjLYE20vU+78FoKt6dDBWMfRSczNrtpeNhr+HWbFumN/MY2u4A+xqzPTYgNSwmZYZ06pr8MsPRuS",
-	"cEy/6ww4Tq1Z4ln+gbxhYE8r2kJN56B3VKWgcaRZ7mWopujndF//HumYSU5zV0GaMhMtZHedWLUs0YOO",
-	"xCUaF/BYCNqKHGzpFum52/QIlUX6Ypg6RP48Lzf12c37vrJob+3hzY9KMw4umN0SenYYqEUNySL+wOmX",
-	"J0eUkr7eYh+9Fp4DAPam52GLbXtmO3zEPgLrAF5POrcfjgraYOwx4mXJE/aJTcVLIulR2o7FLfUMFe9B",
-	"7mGYy16mPFGaa9bD2PngUvwi1vwsFfimXjqjIu9OTc2975865ryeLFqFv4g1J0dMkv/O+OYmD48bHwQ+",
-	"m5gmtk4oXX0Pe3DdRzQL4F87o/2hZm3Eako85qG109QdN7zvEcaXonksAdWd3JMyA0WBK+S6lF/jNyuz",
-	"brM+eMeoemD6vUD+9u42UAVStqxfXKaAyzwHuelIvr27Ja2X9SoJySNK5XTGZ9FZZEyJAjkUjCRkehaf",
-	"RXa01GsL5PgxHuu61B0PCWVjMGhb47cpScif8X3J+y1Bws6Hic0+YHe+XYwPfbjoP7QnUbxfaS03PviG",
-	"rUIyi6IXPWWfHavu25fmIIvvIA3qiKztyeR0tj/xQgqKSpkBO7jhmmkLwPkpAbjlGiWHLKjH6HoQrboF",
-	"fF/yoCm54FtTS0bGFKPp0YOF2L5tXlN//m9Pr6q8Pd+B/q+5/2DNuVwFpSN3s2VlFUk+P/XUZYKCualK",
-	"aSh8rXWRjMd2cS2UTqbmrqzMfQUrd9xdiKRl0SrcLopSrwTjq1FnulSkeqj+CQAA//8/EhrI4hUAAA==",
+	"H4sIAAAAAAAC/+x9a3PbOLLoX0Hx3qrz4dLPPHbHn47HVmZ81rG9sjyZrc2UApMtixsK4ACgHVXK//0W",
+	"XiRIgiL1iOw5pU+JRTy6ge5Go7vR/T2I6CyjBIjgwcn3IMMMz0AAU3+dRhHNibg4l38kJDgJMiymQRgQ",
+	"PIPgJMD6+ziJgzBg8GeeMIiDE8FyCAMeTWGGZc8JZTMsgpMgz1VLMc9kby5YQh6C5+cw+AAwzFNonWgC",
+	"MGZ5CuvP9CtN49ZppjSN15/iVmASJ+ThmsXAWufiptWYymbrzzpimPAJsJ+xiKats97Lr5ubbAgTYECi",
+	"9p1jtsX6k97xBcuZ800s4ie4n1L69RzS5BHYvHW22DTY2IytMz3p7+tO9Kw7Axc/0zgBxd1nmESQlltp",
+	"P8/lx4gSAUTI/+IsS5MIi4SSg/9wSuRv5cwZoxkwYcaMsVC//l8Gk+Ak+D8HpXg50H34QXXeGylxePD8",
+	"7KL3bz3QHwUi9P4/EAmJyHMYnOFM5AwkK28T6mLSFUD+AHDD4DGBp+1BXM65GsBSIp8xwAK2uMpqPjP5",
+	"CmBfUZFMDEA3hezhd1m8VTRawFgBoZsUR1um9GLKFcAdghwlzlPYvlxpzr0SAo/A+ItAX5l4BdAresfL",
+	"MG4FhBVQsNifZhmjjzjdHvz1mdcAXmlg24dcTbsG2JfJLBHbB1tNuwbYnyj7Oknp0/YhtzOvALxUZV+G",
+	"Q+XMKwD8G03i7Z5CdsYVgDU69W1+zyOWZBKkl1lsDyBLo6Ma8owSDu6d/GecYhLB0HzaAkrViftjEAYx",
+	"FOgHJ4EZANEJwgQZEwLCAmGU0YQIlBAkkhkEz6FFVskIvn1c9byro2olBUrVQFWUFX55nIjBI8ipuNgw",
+	"gomAGe/EtIAgeC4wwozhufybwDcxjnLGKVMjV7A7U79LpMQUkGyKMvwAIYJZJuaIEvV7irn+fT8Iy2tq",
+	"QsT7t+U9NSECHoCtvtIKDQQSDy4BL4XdFqnGTJenYnVE5BjIMnygL2L/zKnYJqfbKVfH4ozOslxAjCZg",
+	"kZAXupcicjN9k8JXxe8DAJL2SO4gt90N0visC39gLKIvtTFy7s3tihyNW5S2uB0ai3WgDtpNF1vEowWC",
+	"1VFzB0SZO6K9aON0i/jZKTdwoLNiqDC4NVaHuLzSvAw3NQDZHGsVQyNhxuZe1Le4mx5sN4ddULenvNie",
+	"ukBscD/NsEh5n3gD3esoypli1leBeAnOj1oCRIspmquxTaqu7vdmkAtc01oUQSZgm+djfeoNyF9shkIT",
+	"yhDmcxJNGSU05yhjNALOpdsrDKaAY+PLvqQao+Y9ZjQFxAUWOUdAYn0HNZcaKw5C9OXg8ejA/skPvrt+",
+	"zecv8m5TrlDD+dY0Dm597dW8G1j4ez1Ow2y4dYTUvJu6mrsI3SpSeAGM9MQbQImbgXxG0q1jte6FePAN",
+	"z7IUKnfimpv+pY6oGhibO5rMwMiEGST6SKpNt8W9bCC6IcTmgd9a+8Ib6oKy+U3lzui8ZQG2v7dVnDeJ",
+	"oxrNzOYYsJvQ4kecpPg+hfG9sTGffG+xHc8SknN1Qk8hjZFpH6KnKRblDQVFmKAZfQRE8+JIN9ZXeWIz",
+	"wPE1Sec2nKZujAwDBxDQcig4OTo8PKxaMt8cB2EwS0gyy2fByaFvIKNbzisjBXe357In/nYJ5EFMg5M3",
+	"ahznr5oqITWaNG5fnxvMCkRNIyk4gT1CjO7nCEcieQQk49x4vxVI4irIP/39b/D+3ds3e3B8dL/39t3k",
+	"/R7+299/2js6fvP23fu//f0nfB8FYVc8Uii3Ps4jUR38dnR6dX46PPd1MIdapb1Gx9fahoNVmh8dvwEJ",
+	"5B78/af7vaPj+M0efvvu/d7b4/fvj94e/e3t4eFhN+w13igDz+yuOLtdgN1knrDmyfHwQxld2R3hFQaY",
+	"j+mk0jLGAvaUK8XT3KGhxeS6GP1KCKiDd7kYGi65AIlQ21DDu31ljPtl3YVZgEwYxHg+ZsBB8DEW/VdP",
+	"a43LqaphMKNETFeZjsEMJ0T+scyUw6KX5oh4qd53HD9A7/1Ww7uA1pe2iX2TJMyG+yjChGWcQ5Rwc4sD",
+	"IsXtv4PTm5vh9W8DKTWGg/8ZnI0G55Wx613r6xsG3/bkWHuPmBE8kzT270YvZ5L6p3JS69E7jUQNxrPh",
+	"4HQ0CMLg7uZc/+d8cDkYDSqAOl37wVh2KMZ3fiumcn6zs1pIz6aYPPhkz0SAx+X3G05zsAdMRNN8RpBq",
+	"qn9Qg4WI5GmqL+ZS0QN5TWf0aV9JHZhQBv0G1m3bRo4Y4HLk59oyGrx8lFQ6PD2Cxe5bp8vU7JOUu5Gg",
+	"XUv1+96pbLV3cY60ScJ+MEG5IeJzLmCmcCsR5miGY5AHt/ztyVzi+H6JlyP+/TsmjRuMPnl2SXtpPdvk",
+	"WbS2bbOje7bKHb6yV57hDbZqC+I4kYPj9KayNZ0bYna8eSsuYIoNYXEvDEBEIubmTGmsrvmqf/d873kU",
+	"GSNjvJTw55JGzFHd6UAPA05zpsPtfYCarxaRzmW9Ve1Hsnn9KFAoFsCFlnuqi+UurOWVKhTl9lfXpy4Z",
+	"Nc+2cbQDpyN2fx2NbsbDwT/vBrejIAw+XQ//8eHy+lMQBrf/uh0NPjYmcYbpL4LLTnK+crraR2f22hcL",
+	"jIpt9wTHNwQVA2yufs7l4fjdO5+yavHzjuxZzmage2N6PLM3uCqvncME56ngSFAtsaY0NVc03WXfvSgd",
+	"ecM/SnDrYPhg9YSLt6qLzpXDWbV3hz6VkcZQa3d0eFi5nB11aJpL3epgMgF1lxlPGJ0tXliihWg/2VEO",
+	"LGh/iTNJsRiXm7z4ajvD3/q3TUjvthmwCIjADzC+z7hZUkM5h4dmNxb0V+/F+sg5Qz5axkkCNB6DvqEl",
+	"o6SHZ05RlAuUI3l8VNxK7L4Q6wUMuojbwiBiPofIGaMEwbeMAZf6rQpbQ2fD6yvEK26lEMEjTnN1tCcE",
+	"3Y3OvJqJvAfQyVip/x7SxnM9wcfrq9Gvl/9qzKH6cfSUiKk04whp4onxHGEGiH9NsgzifW1F0Yi+OQo7",
+	"sDYAPQF8XQDPp8HgHx5wDlHC0W1OYjyvTPu+ix5j4CIhynBXeVfZqTQAWU5fmDB9Js+X8jV+KHpJKxPN",
+	"Pbrk3egMyS9yfczqYBK3bByvrM7xm7CHGIn6Ue0MBLamTb++WLFmlRwkzYYCKqLk3U9dgGldZbkt4wIz",
+	"scSm1YRFfcoW4gktn7t73pArPoHRKl3u+AKhoie+KY/RmvFRfyhucUbpN91CJE0E8gDjkEIkVJuJjaPb",
+	"R+4RZ+2A+ysbrs5o7LYoe8MMJ6n3yyRhXFypV5eeryle8DHDnD9RFneby/T0Tg93XmeWGiaNTXX2qXUv",
+	"26PP17WoVfbdt8hSVVfHHF/W96K0fHsUzxJyofseNWOTc5Y2afD0ntM0F4CmQmSIMvUvR3fDS0VvCjCu",
+	"jo6McqHosaJM5Szp5M6KCUxCUUW4sVft++DZugFjlDU3yCqkpTFbGfXZGFR77x4JQ+lln1tgj0kESMAs",
+	"owyzJJ2jnBR+lxAxEGyOUizAO6YUvJURvwcRlmw9vp9LdFPMuSLfZw9mHgP+u0OvxDXLV4ccGGrBtrZB",
+	"un+xBMXUodXCFB7OPg2qw9Z2wzrjmruiwOlP4nqaLk3RDOrzGHz4Xcdl+0LxlbBAESUyMLHQ2iJGOd+z",
+	"ssSJZ1HGJyxAajMpjb6aUJry1mabSvaoCQvpgYR4LLs3QRnKQUtrE88Y4LgU/pGODFffBGYPIJyroUGY",
+	"5LN7TQl/SnSX0378UH1M4r0ZZl9BKKxDRAmgnCTFaaWPXFSsVEJcEO3PXiB1V+de02qTWeiJ0Cs1brtb",
+	"n6rf0VfIBLrH0VdrFbQL3AmwC46eylyrmt/1IAsxMk0WO4osf1m69ZF0/ZH6yncZuw3LHGVevWtcEfBm",
+	"ekdaNED2o1Wwak2SL6KBCSymI3V9jNp0G5shpc8RrtoaUeGbq7YyDmCOmaO6KIv2WD1X6GOZaTXFND9o",
+	"/XIp4bBw8ZtGmC3aWNo8750bWTW/dJlc+phZXp0pJQz6E6r2S+pj3vZyEagufAP9BhVUSVyRcTuF183P",
+	"Hy5PR0EY3AyGZ4Or0ekv0hk3uhgMqw5Kt3Mvo7PTwczg/FKZzPndzquhVQvd4Mel7H7r2ufyzPBL9Zy7",
+	"lAcLt1qBPtMSYEhrHfJ6GCI6S4RU3p0nhiGiGRAEJJZ6RqL1lp6mZrseno1Vj3PWjsxYwH6RNnPHC3l0",
+	"46Ku6wLnmBvGSyIL37KEwXLhDT2HruSS6tchBcxhXLpL2pos6ZErrjJdz8JaYnqTOKihE1avlS0b4Fh8",
+	"6oTjizqq7EaFjBzxo0i8hfRvC0SLYIuz0cVvUrScnd6M7oYqIOK364tz9Z/B7zcXNeHmjOJZyLYXZ82b",
+	"lrSbjIHIa6prZrmnNAWs/PApfRo3bUP+BmMxZcCnhrn9MYYJkWGGlKlrAke4uBHZaELn9fos5wKlgB8B",
+	"JQLdQ0qftKWLxAgj+ZeZGBEH4/3OsMFsSgmMzVWjaXCTX5H+KsEd7B+9f4s0CYcI9h/20f/76fDdu3dH",
+	"R0fHx2/eeM1pfMbdda09I/l4W4FYm04oSecSN6GM8vLdvgOmM4mz9nbtxhOctO5g0YhBBMljZzMORPib",
+	"5FlsKb2B08AJTMg5MHnfRQSkeYGDOnAS5j5W7O9sc4IOV40hrNJ5dXfqmPsWrLnSVcZo4wKHY9t40iMi",
+	"Fqe52jHxjolXZ2Kf4fxVMka7EaCeSG1l08bq6lrNhmQoPWIQJ1KBfpqCVqElrtIUaLWKwp5UGMSMnUwH",
+	"c5Qx9EsrbTXqT3EkXYZYQ4AfcEI0QcrJOJ4BKgZAF+eIgcgZ4SXMOGWA4znK5FrHvSASIh1ziCiJ+eJI",
+	"i1+vL8/H54MPp3eXo/FodDm+HZxdX53f9rhauKRb0/O6VDuH4uoE5KGx1qR3Ten7DaJcwOpeSWcAB8ZW",
+	"ALzAmlfzDeA0RY4Vj/aLwhw5TRUL3K/VXxq41gZCDrIuIEtfchxZt1TPxVTqH9W3zqFv8yoEYja9lSA6",
+	"SbfDJi6oyccAbVLLWPcLV7RQobcmVN2aGpQwzAmHzjC1MEhIlOYxjCdgnA1KagQnE5xy8J2dvSP2lqaA",
+	"RfvY2IYeTNrMq7BgPzYZ5rK0fFqBWVYM5uhx26++4lVe+w4Wcy7pBebOBb9nEIizxc2d821vJcHAUlu7",
+	"khmKtekstaiwzjCtF4yoMhsUlwFKTWC0VrmwxebistbyFiwAcQORVS8TQNWXSytLu8g4t04IltmnYlUa",
+	"BFSjlsorQcvLFTbt4uMPLmmVb46ur2TQu4rUC8LAxOm1zvLBQaCHF8Lf10zq/1iA4v9cANiejcarOS4t",
+	"lOTq56zDLrzsyfKjTwm/9bZtnbooZqFl9+pscHmpLLpn1x9v5Iux89Y5W4y73SSjOxazer65gPg+l7DZ",
+	"VBelxv3jHDZr+GLaXfd9xfeq8pmy5CEhOHWVdO+F3FlGq1THOtQk4eYHnCJnlJWMAEtwk/++YzBofY9V",
+	"NQD/APOt5YVR5SbUWPdG6iPPU56tCZowsE8I/a/DFgsit6/vxGqgumg5zBNaz5XOfEEWkiIsrbDcYhLL",
+	"+K0IkMBfgejbXGwe40qfcCK8QWuMPgJrsZ9FSbwktcTOS+SFr+jqz48rl8EmdbfbtM1tF9/LjBZP0ySa",
+	"Oqku1MVXGvDszZcAxMq6bebf786267V6Nrasx7Zu8tlay9gLoNCprhqTF6WP+jBZw8Ltqto2mKVXVEu1",
+	"LpOAmS++5c8c8rbpWE7sy//mx+WEgYLhtsgOxfMoAojbJhZU4NT/aSk54tSc8soQO1OxDCXOLozFptgd",
+	"8BCp3vou2lC70Ax+eXGlsACu5WLi1wzblkFh2WcpmqrgP+8Gdzqfwt3V1cXVL1L/ujs7Gwy0m//D6cVl",
+	"VSNsG7OXVtjSuQCi5XsJW0sDF+SWJhaT+rK0CbClZEghHpoP0ku5XSTrEdE0RDxLEyGdjZg9AEMsJygh",
+	"ygf5CAynupl2SS8lfOqlKpQhwD6tsLFa7U8ttNWAuCFFtfhznHEkWhFDhp8RFqVfRx6sIbqnOYmtiglI",
+	"v2rQSfmWdLM4YmaxfOh5gjTZ4mZ4fTa4vdVEV94+wuDmdDi6OL28/NfYEFQnj6zMH7pjBRLPdxc4X3cL",
+	"bwGup5GHNz5oEWkfV9VC7HE0TQggBjiWflmkBal+h6BFt/P+QNnZS/+jEb5oijkiFMUQJ5F6PSZvTJIQ",
+	"7B6MhqdXtx8Gw/HlxceL0Xjwu2Fxz0K70HrYs5qdZ+0rY59Q5sWJiJJ0vjAKULeINhIxLPOjpotjzZVl",
+	"tgOk5a9ZTVubZ+f0lixgULdokF8YYVY4g9wAkGoSuEJbDhFGs0RljZUucOkJJ1QgIBPKjFt509TRmYJg",
+	"1VwCdULqeJVcJaruN7oNyuno0qCiRe2fW2ihW2TXEl41L0/F/k4SGcJgBE2Es+bmboAVu5mnDdUShy5s",
+	"dYIuT9LH7UBfTRDpzFmdoDFaG79rdBYgbTLJNhF2AirW892v0fvb+E/73mfhGwfzEkq7kOlMFgBeb+YV",
+	"XaArz+ihW7MxC7au1KV8Vhmc9oLANUKoyHkSQZrqfV9wadsMeazinbY3Smv17YOhq7T081SsRz5/USr8",
+	"4f4Wj5Sq6+xtZG7VY6uy3p79Oji/03r2zeDq3LlQ396aq8LdsO55Of10ejG6uPplrDMPnl62pDf0zL3U",
+	"rcLclR0Yq19KiGs9Cvirv5fY1CBzcKt+sZg6iFYbVBIstlxp14mt3ES8ir+gCK9VEUgEesJSD82JSFJt",
+	"sZXSwybQm9FHqaLMKIH5PnItBVIlxekTnvNquYPiBqWGSbhVeie5yBn0j12vvjyNIWOgLmDWo1TF7+KB",
+	"UBUlapN4JNy+7I6RfDdXfLDBgd1h0iv7trYQBLRYZKwRstNCzR4sZcaPlpD6aqqE/9Ap2Y8p/Lf5aT+i",
+	"M5cMdJewJQHKmJgkJ+WA/0OnpP3V1qbTK+skKB4wzmn3ddLi5qDijvdHy7q2aZb3mHx10pX3qBhp/Yh9",
+	"SgVWdCkHDA+QtWKoCxwr7Y6U2hieWepp/hvTYCFglgm+Oc+4KQSwZC+dbqUnt5e5WVZJQdNzEkVjZnmW",
+	"REZ1BZv2xf/ZFr8YN/zizvpneJ5S7HEcKoQQkEdIaeZkvlFC+m546c2HysCpPrGG76lGVKX36Ul/WCnI",
+	"VTuTygEckqhseLkojrepoOIakm0hNnW26OYcj+m4qve1OlL8A/VS6Lxdy3m9n11gvA0cI3CDOxz07DEm",
+	"636qTMJB1WisfjAiUidFcn4w+qHO0RuE9u2W/aG5OiUIyyxM0csHbFubAvh6gwYyLQ3qyNWb1ZH11wNZ",
+	"Pwe+lB5RrvMa6Dul9/GLZQZrsOeJirOwdXwpAZ2A8QnU23xG84ep1gLdkh9SHYwTrl5nSZ/Pp8HPv15f",
+	"/2P88fT38dn11e3g7E5GfY3NXeHWn6VmtfOk46WUgWqF42b9dGZ1t1pfdRUiBp5Lxj9gXuYXN/Pt3SYP",
+	"BAvpSvn14+lZqJ/56VdT7nuv+m6Ztd5f3YntodpS1ptcbT2EekdytUouLw9Jd8jwCk+1y3EPEr5AyfOL",
+	"29Of/QLcM0LzTFPmlwm1BX6wKYeiVfogTzGPMOFARM6+Hv33g/xdKfN1Og+4yet2nQE5vblAPIOoeKqo",
+	"gihmM8zmTsvTm4ugBNr86qRMOQmO9g/3D+VUNAOCsyQ4Cd7sH+0fqjNVTNWKyJp4ZsP4wfdy654PnDe8",
+	"Dz7aHTrv+EzbimNmipXg+KKKh3wJZSY/eW3WThFRdLFJYROxj24wLz5o/w8m/AmYeyO1/aQ7fV4oQk7I",
+	"YwoTUQFEziC5QspetZwXsdSlj34B0ahjkknNGgQweez4uaVsclDeB5/D70EiV+XPXOsX+vJjKqe4lQV7",
+	"edj+CAOrL6pdOj48bGPeol2tun+l/tRzGLw9PLRU2qsMVWcWvGFRza1ZROpnHKOhroug5367vbmvqEAf",
+	"ZEiCnPndNrG+IAIYwSkyqQ5VB107q+DeX0BUyLjyfj0IA4EfuJOhkgd/yP7tXKrqQDk8WidyWYdNF9Xe",
+	"PHEXcrzf+lWSqaxE4d46569xlyWQxQNn3rrJ6qtSGKUc84lYKdaNCdI8cTQJk1zhJsWnMTtKKTryPAaX",
+	"0veRJjHEOjWoEscms4xJ9SiF82h06ZOUxSPmdYjoD60qABe2JJ5/s22TBPhBMfHQ6fncIJ2jfqSzE4lq",
+	"5uPj7c18R0wxXxVONFB1RF4lxypKMzkMPBzaLYMPvst/5N8LpPEvINZlo7CzsZyhYLhVRGyTT3bHt3N8",
+	"b4BEDoxcljD7Rf9HKuU+TlMpqDOnJqRTisXa/xwPRdFKyn9MYmSSo+kjhAE350NxLpiRNMw6eYc5ukSl",
+	"vHI97mofndpBtDkBvkUAsczPo9pLsCNM/kug+8JcKQ+gMu+H76BxKsZskUeWPJQcIBcfS4e7Y2mJY+nw",
+	"p+3NfEbJJE0isTsP2wSdIfLNnIgHkvPbZd3QlVGufGsqtx6ZYZ1ir1dgWAh30mInLf53SgtJ4SuKirSo",
+	"ENxpZVRNTRbhwvlJc/FApXRovFuxV2SpBqmq4gnXxkE6kVE8gqoSVCTWMTwqxHWxmbCobbveHXhFu56e",
+	"faebd5rWPIqr1/qiPzv0Gc8ScjAB2FP1jDqMaiYvt4ccqpBfl94bBZ0xzxdUKuey6fISXjyW8dnbTE70",
+	"ckk3YjY2iPjsajth12rcK8peOQQ1AVhkzdOOZi3KCHwTyCnzUqcEQA/JIxBFDiGCb4m84T2UxIMZmIS3",
+	"pjCu9zblFiUMVlBcTFc9ztomODPaq1JgdgTu0/3VfiNcEDkqyzDUaN0vOg++TwDGppbJs+aEFHxFfQbE",
+	"WATcaWR9VBVom3BdLKeeP4LrKFxepA4tdQIfHwxBJMzhg+WOb9Nv5eO7lex3BuDXRPSaSAqS94n1dqPu",
+	"jrZes1LYvqcV8WWly155JcnyjnPc2DonzpHNI5qBNH8yUBmNuQ57wBmXNlP9NeGOSqp8ceWfyLwwVVeT",
+	"IhfM0xSUNab6BHKmkwkgTOY+wXcLov4ed2kdoPaycU0LRm20nSbwuoXiLQg34X5qiKj1BpXHidjTFTpb",
+	"b/TK/aCUVo5mOIbCi4EFDhGBJ5DqdcK4DgjSfPZFKsyyVB2n7IvOR5DhB0CYoy/2V0HRg7kCytaIEvAx",
+	"hVTfVfH+gYaz4/42UntkONwo24jRJ24y+1urRtulDdTu2ljm9rtb2KzFm8hdQF9h7pm+Y7ok7prM1xlH",
+	"grLloLw4t8D9OhrdICM7kK1Dad7EONBz9AQM9M7fz9vQMG8LV0bDBM314hRFDKfF21j/mKqS2NJxXC2D",
+	"CbqxoTTx+4dLiHj/1pszzD+WZe9yqCIptsxvUFYCr1QG86V1Wc3OVTCl1xbxgofD6zRBKGlr6iG7kWPy",
+	"51ImTwD4QaaLXboumEaMj27yAVa1FJgB1lYRbBnKnXbw6mNm9I4XD0XpxFEWWpVt+5w03rNN23UFSef1",
+	"rFQq2gAzQFwkaaoe4yqFWB85iQxrUzHllKgHtW0qQCPTun5MtCy1NobZ2VGXEmIFNZQ77FBO+dsi8jn4",
+	"7r7mrdmbmiEmJILUl2d/uat7mTnDTL3yJb4By85U9NpjIyQJeUi3hXIlaZl8pk3TZL30zgYJcckzvAnL",
+	"2md5D9LeheDu2MpaYC39dTGUPQpMQvk9yuKqFuE98N30855r/4JraOVFm+ei1fLgebXTwAVzp0wsp0yY",
+	"tUPU7rGlnjqpuF5av9u0Xr1jaXFaGWBDLtTKmLsL0l/EkVoly4VU6ZdsB9/tD2P1Q5dX9Vz9ri9OhUy1",
+	"ddQqwISIFgVHSneqKt0DsblhReaRvT9gW2nTNV5ZToGp9F5di17MGruj/jVq0P0ZY5H3dUd+fy1/7A+R",
+	"hweOIFtGE7x2ur0C0inB8RvBd4TUrvC5Z1kfqioc/iYnf/sLhVuBmao9rlqqo7SwR+ZETU1sovyLc+sZ",
+	"RBmeM5qmiOVkHw1UwoJEwEz+zRHWgQH0iRSDhZ8Jlq8duEC1fP0IK0estGeiU5vZRY0VU+Dkv+RS0Myt",
+	"QyDHzhh9YMC5iXJWwdIRnUld4DMBHE31EJhBmV/EZPGXnlQgcUYT/dZL46afM1sYZdkBNQJH9xDhnIN+",
+	"sKYUjZkpfSBX5jMpHIEsJ2FZHUGP+gBqbd8eHipAVRH3ezCVFLzFEz6T1nDHeh2RlQMe1ACLNfbj/gEP",
+	"ZrSdxv6X0NiLiId7Q0ULrBB1KXLw3ZaxeO71qgE/PDB4MPcEkdfKbljOLb8pvlVPGLhmvpZHC3VGWM2q",
+	"qHqvfLR1UP9OKX917ydWIHy+yK08zEk9QWpXxM0nleGK5RBWn3MkvLiYSq44PjxGqqChTYxlHgvWHIJl",
+	"DApTuTnbIk5USl5/8ENLRfCVjOz1xVjbJNQcsMplS51StvDf7qB69cbyvFQbCxIPGqzpcY2236NfgS+0",
+	"ljV8d2L8dU4MXla8W0SCB6aMZ/tlS1eIAO5GngrzftMV7rbyRHH5kBqReTmecFNkU50gtiimUZs+F9eq",
+	"WoJ2MVVProhISA7cBqbauOyURl/VySPBTsjDPnLVOIl+YWS1I34m1KbFK44hWbaGAY5krkY1vVkQdcDp",
+	"+QRldqQvv++dyr/3Ls6/oClgZbPVlUOLexKoWPHPpMi3UY7yNKXFoekC5r9BmZV/eVd0vVjIxuLO2+TK",
+	"7g39lt7Qa52uIHkfbb42ebu8XDR81NON3SopdXmcBYKSf60Gx0mJJe1ANsdPWV1HypYpJVTl/LGVK6DM",
+	"tTmjj2DMVk/TJIWaFNMVLyYmhWc1wM7GertiUgOQgTbOOdk59ctVJWeNEcs+eP1MKvDqdPg9RCzqIWEZ",
+	"/AcidW5oqZhwXXf6MynSivaTsy2GJwX4y0vNKhw7mbnTA7frzltT3GkuXZQmSH7vrRZ2yKCa+LEygpLP",
+	"5K8ke/Si7DS2nfR59VqRJtX1pYTMEtCZOVFVjaVEv53DRQxL7Fo4o69SYZIajY7zKx/kGkUIiE00wEFe",
+	"KgkCidf+Z6I5W0KCU22FjCiLIZZePQJPehYrbtKEfHUSFhEN3twMAPvokzFdYlJkdpzCZ8JsHVH7a138",
+	"JNyOEYeuWEy4SQ5ivyrBOKM6V6NCSip88f5nkpAozWMYT0A67zhFDCa5TcowAbBCrNyCwj9B4zlKYiAi",
+	"mSRFgkm9JBIaBoIlEH8m9k1gIWpV7fDKkNaJaeBF4imJoE3gqSavIVy6AsjahtyhWbudqNspWu0i1PBH",
+	"Lxma8w7njPZ2qkJmKzCA7LehuNYSkJ3n4S/iIs9t+buC0g6+y3/UIU2oKIqX7GWFqO+X5s/tjJzO9uCR",
+	"s2gzkvHQlSU05+bc4zoPQMLanONXzhw3DnzLniaSZFd2drQAsQv6WuhwaCMPRxa6TUysf+4lOpOgpT/V",
+	"3X68lXY1XZPpHhAQXSFL6TYYZVNKAJF8dg/MR3l3WYwF/BDiW1J4t8CgAVz78tabsnc1aV4BV+lNX4Wx",
+	"jPg3ZRy74m49hbV6vsOqPMAqC47pasI/+EGWB+rds6ylonQNeSBe23hLVQX5tOfONKt/r7zCd8NLeZnW",
+	"mS+kn9dcnW1lAnlj1RX3zKVUMb02+/HkwQYLyWtytbbeFBi0J9L0F6BbWvJ6htmQFu0ZeSdxd7zZocj7",
+	"uNPPnDVpf/C9LN/b/zmaM4vhQsGRKeE7Ryl9sKp9UcdUv0K7B2kIM0Yl7eX0cKqeys+pyylXZgy/cv+2",
+	"ieQVRWdma3fahUtmekuWILNFT71++M4ebkjM7rbfubJtRsQcVKuZtyYXdIVJ8erVmbmWZLAlP1C1lnay",
+	"wvXMobM1E64d/ZiEa1UU56/rwdnuzO7UpyuF71dnpoPvlmPUh6Kg/oLncEBUpa+C05QfnudRBBDrYpPG",
+	"va4KioUN149QBzs2XibJhq25uoYWnGYN/3XYsV9jO5n/rDhemsVeEXu9VAGdV+hTMQRW56v5Clyl7YEL",
+	"QvYikTyq7NG4qgqXtfZVMVYGmaqBXrKYrY+uX3aav3TdPh1FyRF99FsdBwqoner0V1Od9L4toz3J7mo8",
+	"37uqlEY4NSX5T4KpENnJwYH6cUq5OHlzeHiottUM/L3IVlt4Fp/D4kdbdmnPjXRwG6ikj87f9VfYzqcC",
+	"A+c3ncX0+Y/n/z8AyemO9t8MAQA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
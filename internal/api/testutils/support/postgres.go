@@ -0,0 +1,66 @@
+package support
+
+import (
+	"context"
+	"time"
+
+	"github.com/samber/lo"
+	"github.com/testcontainers/testcontainers-go"
+	postgresContainer "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const (
+	postgresImage         = "public.ecr.aws/docker/library/postgres:16"
+	postgresReadyLogLines = 2
+)
+
+type Postgres struct {
+	DB        *gorm.DB
+	Container *postgresContainer.PostgresContainer
+}
+
+func NewPostgres() *Postgres {
+	return &Postgres{}
+}
+
+// SetUp starts the container and runs the given SQL files, e.g. the migrations of the tables a test needs.
+func (p *Postgres) SetUp(initScripts ...string) {
+	ctx := context.Background()
+
+	var postgresCtn *postgresContainer.PostgresContainer
+
+	_, _, attemptErr := lo.AttemptWithDelay(attempts, 1*time.Second, func(_ int, _ time.Duration) error {
+		ctn, err := postgresContainer.RunContainer(
+			ctx,
+			testcontainers.WithImage(postgresImage),
+			postgresContainer.WithInitScripts(initScripts...),
+			testcontainers.WithWaitStrategy(
+				wait.ForLog("database system is ready to accept connections").
+					WithOccurrence(postgresReadyLogLines).
+					WithStartupTimeout(time.Minute),
+			),
+		)
+		if err != nil {
+			return err
+		}
+
+		postgresCtn = ctn
+
+		return nil
+	})
+	if attemptErr != nil {
+		panic(attemptErr)
+	}
+
+	dsn := lo.Must(postgresCtn.ConnectionString(ctx, "sslmode=disable"))
+
+	p.Container = postgresCtn
+	p.DB = lo.Must(gorm.Open(postgres.Open(dsn), &gorm.Config{}))
+}
+
+func (p *Postgres) TearDown() {
+	lo.Must0(p.Container.Terminate(context.Background()))
+}
@@ -1,13 +1,29 @@
 package v1
 
 type API struct {
-	transfersService *TransfersService
-	usersService     *UsersService
+	transfersService      *TransfersService
+	usersService          *UsersService
+	feesService           *FeesService
+	standingOrdersService *StandingOrdersService
+	limitsService         *LimitsService
+	holdsService          *HoldsService
+	balancesService       *BalancesService
+	webhooksService       *WebhooksService
+	notificationsService  *NotificationsService
+	auditService          *AuditService
 }
 
-func NewAPI(transfersService *TransfersService, usersService *UsersService) *API {
+func NewAPI(transfersService *TransfersService, usersService *UsersService, feesService *FeesService, standingOrdersService *StandingOrdersService, limitsService *LimitsService, holdsService *HoldsService, balancesService *BalancesService, webhooksService *WebhooksService, notificationsService *NotificationsService, auditService *AuditService) *API {
 	return &API{
-		transfersService: transfersService,
-		usersService:     usersService,
+		transfersService:      transfersService,
+		usersService:          usersService,
+		feesService:           feesService,
+		standingOrdersService: standingOrdersService,
+		limitsService:         limitsService,
+		holdsService:          holdsService,
+		balancesService:       balancesService,
+		webhooksService:       webhooksService,
+		notificationsService:  notificationsService,
+		auditService:          auditService,
 	}
 }
@@ -0,0 +1,137 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/rs/zerolog/log"
+	"gorm.io/datatypes"
+	"net/http"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/audit"
+	"ulascansenturk/service/internal/constants"
+)
+
+type AuditService struct {
+	service audit.Service
+}
+
+func NewAuditService(service audit.Service) *AuditService {
+	return &AuditService{service: service}
+}
+
+func (a *API) V1ListAuditEvents(w http.ResponseWriter, r *http.Request, params server.V1ListAuditEventsParams) {
+	result, nextCursor, err := a.auditService.ListEvents(r.Context(), params)
+	if err != nil {
+		renderAuditError(err, "audit events listing failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.AuditEventListResponseBody{Data: result, NextCursor: nextCursor})
+}
+
+func renderAuditError(err error, msg string, w http.ResponseWriter, r *http.Request) {
+	switch {
+	case errors.Is(err, audit.ErrInvalidFilter):
+		server.BadRequestError(err, w, r)
+	default:
+		log.Err(err).Msg(msg)
+
+		server.ProcessingError(err, w, r)
+	}
+}
+
+func (s *AuditService) ListEvents(ctx context.Context, params server.V1ListAuditEventsParams) ([]server.AuditEvent, *int64, error) {
+	filter := audit.Filter{
+		EntityType: params.EntityType,
+		EntityID:   params.EntityId,
+		Actor:      params.Actor,
+		SourceID:   params.SourceId,
+		From:       params.From,
+		To:         params.To,
+		Cursor:     params.Cursor,
+	}
+
+	if params.Action != nil {
+		action, err := constants.ParseAuditAction(string(*params.Action))
+		if err != nil {
+			return nil, nil, errors.Join(audit.ErrInvalidFilter, err)
+		}
+
+		filter.Action = &action
+	}
+
+	if params.Limit != nil {
+		filter.Limit = *params.Limit
+	}
+
+	events, nextCursor, err := s.service.ListEvents(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result := make([]server.AuditEvent, 0, len(events))
+	for _, event := range events {
+		response, err := toAuditEventResponse(event)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result = append(result, *response)
+	}
+
+	return result, nextCursor, nil
+}
+
+func toAuditEventResponse(event *audit.Event) (*server.AuditEvent, error) {
+	before, err := unmarshalAuditRow(event.Before)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := unmarshalAuditRow(event.After)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes map[string]audit.Change
+	if err = json.Unmarshal(event.Changes, &changes); err != nil {
+		return nil, err
+	}
+
+	changesResponse := make(map[string]server.AuditChange, len(changes))
+	for column, change := range changes {
+		changesResponse[column] = server.AuditChange{Before: &change.Before, After: &change.After}
+	}
+
+	return &server.AuditEvent{
+		Id:         event.ID,
+		Sequence:   event.Sequence,
+		Action:     server.AuditAction(event.Action.String()),
+		EntityType: event.EntityType,
+		EntityId:   event.EntityID,
+		Actor:      event.Actor,
+		SourceType: server.AuditSourceType(event.SourceType.String()),
+		SourceId:   event.SourceID,
+		Before:     before,
+		After:      after,
+		Changes:    changesResponse,
+		OccurredAt: event.OccurredAt,
+	}, nil
+}
+
+func unmarshalAuditRow(row datatypes.JSON) (*map[string]interface{}, error) {
+	if len(row) == 0 || string(row) == "null" {
+		return nil, nil
+	}
+
+	var result map[string]interface{}
+	if err := json.Unmarshal(row, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
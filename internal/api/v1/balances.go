@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/transactions"
+)
+
+type BalancesService struct {
+	balanceHistory transactions.BalanceHistory
+}
+
+func NewBalancesService(balanceHistory transactions.BalanceHistory) *BalancesService {
+	return &BalancesService{balanceHistory: balanceHistory}
+}
+
+func (a *API) V1GetAccountBalance(w http.ResponseWriter, r *http.Request, accountID server.AccountID, params server.V1GetAccountBalanceParams) {
+	asOf := time.Now().UTC()
+	if params.AsOf != nil {
+		asOf = *params.AsOf
+	}
+
+	result, err := a.balancesService.GetAccountBalance(r.Context(), accountID, asOf)
+	if err != nil {
+		if errors.Is(err, accounts.ErrAccountNotFound) {
+			server.NotFoundError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("account balance lookup failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.AccountBalanceResponseBody{Data: *result})
+}
+
+func (s *BalancesService) GetAccountBalance(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*server.AccountBalance, error) {
+	balance, err := s.balanceHistory.GetBalanceAsOf(ctx, accountID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	return &server.AccountBalance{
+		AccountId: balance.AccountID,
+		Currency:  balance.Currency,
+		Balance:   balance.Balance,
+		AsOf:      balance.AsOf,
+	}, nil
+}
@@ -0,0 +1,274 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/datatypes"
+	"net/http"
+	"time"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/fees"
+)
+
+type FeesService struct {
+	service fees.Service
+}
+
+func NewFeesService(service fees.Service) *FeesService {
+	return &FeesService{service: service}
+}
+
+func (a *API) V1PreviewFee(w http.ResponseWriter, r *http.Request) {
+	reqBody := new(server.V1PreviewFeeJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.feesService.PreviewFee(r.Context(), reqBody)
+	if err != nil {
+		log.Err(err).Msg("fee preview failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.FeeQuoteResponseBody{Data: *result})
+}
+
+func (a *API) V1ListFeeRules(w http.ResponseWriter, r *http.Request, params server.V1ListFeeRulesParams) {
+	code := ""
+	if params.Code != nil {
+		code = *params.Code
+	}
+
+	result, err := a.feesService.ListFeeRules(r.Context(), code)
+	if err != nil {
+		log.Err(err).Msg("fee rules listing failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.FeeRuleListResponseBody{Data: result})
+}
+
+func (a *API) V1CreateFeeRule(w http.ResponseWriter, r *http.Request) {
+	reqBody := new(server.V1CreateFeeRuleJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.feesService.CreateFeeRule(r.Context(), reqBody)
+	if err != nil {
+		if errors.Is(err, fees.ErrInvalidRule) {
+			server.BadRequestError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("fee rule creation failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, server.FeeRuleResponseBody{Data: *result})
+}
+
+func (a *API) V1GetFeeRule(w http.ResponseWriter, r *http.Request, feeRuleID server.FeeRuleID) {
+	result, err := a.feesService.GetFeeRule(r.Context(), feeRuleID)
+	if err != nil {
+		renderFeeRuleError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.FeeRuleResponseBody{Data: *result})
+}
+
+func (a *API) V1RetireFeeRule(w http.ResponseWriter, r *http.Request, feeRuleID server.FeeRuleID) {
+	result, err := a.feesService.RetireFeeRule(r.Context(), feeRuleID)
+	if err != nil {
+		renderFeeRuleError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.FeeRuleResponseBody{Data: *result})
+}
+
+// PreviewFee computes the fee the Transfer workflow would charge if it started now.
+func (s *FeesService) PreviewFee(ctx context.Context, reqBody *server.V1PreviewFeeJSONRequestBody) (*server.FeeQuote, error) {
+	quote, err := s.service.CalculateFee(ctx, fees.QuoteRequest{
+		SourceAccountID: reqBody.Data.SourceAccountId,
+		Amount:          reqBody.Data.Amount,
+		At:              time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &server.FeeQuote{
+		Currency:  quote.Currency,
+		FeeAmount: quote.Amount,
+	}
+
+	if quote.Rule != nil {
+		result.RuleId = &quote.Rule.ID
+		result.RuleCode = &quote.Rule.Code
+		result.RuleVersion = &quote.Rule.Version
+	}
+
+	return result, nil
+}
+
+func (s *FeesService) ListFeeRules(ctx context.Context, code string) ([]server.FeeRule, error) {
+	rules, err := s.service.ListRules(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]server.FeeRule, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, toFeeRuleResponse(rule))
+	}
+
+	return result, nil
+}
+
+func (s *FeesService) CreateFeeRule(ctx context.Context, reqBody *server.V1CreateFeeRuleJSONRequestBody) (*server.FeeRule, error) {
+	rule, err := s.service.CreateRule(ctx, toFeeRule(reqBody.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	result := toFeeRuleResponse(rule)
+
+	return &result, nil
+}
+
+func (s *FeesService) GetFeeRule(ctx context.Context, id uuid.UUID) (*server.FeeRule, error) {
+	rule, err := s.service.GetRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toFeeRuleResponse(rule)
+
+	return &result, nil
+}
+
+// RetireFeeRule ends the rule version now, transfers started later no longer use it.
+func (s *FeesService) RetireFeeRule(ctx context.Context, id uuid.UUID) (*server.FeeRule, error) {
+	rule, err := s.service.RetireRule(ctx, id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := toFeeRuleResponse(rule)
+
+	return &result, nil
+}
+
+func renderFeeRuleError(err error, w http.ResponseWriter, r *http.Request) {
+	if errors.Is(err, fees.ErrRuleNotFound) {
+		server.NotFoundError(err, w, r)
+		return
+	}
+
+	log.Err(err).Msg("fee rule processing failed")
+
+	server.ProcessingError(err, w, r)
+}
+
+func toFeeRule(params server.CreateFeeRuleParams) *fees.Rule {
+	rule := &fees.Rule{
+		Code:           params.Code,
+		RuleType:       constants.FeeRuleType(params.RuleType),
+		Currency:       params.Currency,
+		AccountProduct: params.AccountProduct,
+		FlatAmount:     valueOrZero(params.FlatAmount),
+		PercentageBps:  valueOrZero(params.PercentageBps),
+		MinAmount:      params.MinAmount,
+		MaxAmount:      params.MaxAmount,
+		EffectiveFrom:  time.Now(),
+		EffectiveTo:    params.EffectiveTo,
+	}
+
+	if params.EffectiveFrom != nil {
+		rule.EffectiveFrom = *params.EffectiveFrom
+	}
+
+	if params.Tiers != nil {
+		tiers := make([]fees.Tier, 0, len(*params.Tiers))
+		for _, tier := range *params.Tiers {
+			tiers = append(tiers, fees.Tier{
+				UpTo:          tier.UpTo,
+				FlatAmount:    valueOrZero(tier.FlatAmount),
+				PercentageBps: valueOrZero(tier.PercentageBps),
+			})
+		}
+
+		rule.Tiers = datatypes.NewJSONSlice(tiers)
+	}
+
+	return rule
+}
+
+func toFeeRuleResponse(rule *fees.Rule) server.FeeRule {
+	result := server.FeeRule{
+		Id:             rule.ID,
+		Code:           rule.Code,
+		Version:        rule.Version,
+		RuleType:       server.FeeRuleType(rule.RuleType),
+		Currency:       rule.Currency,
+		AccountProduct: rule.AccountProduct,
+		FlatAmount:     rule.FlatAmount,
+		PercentageBps:  rule.PercentageBps,
+		MinAmount:      rule.MinAmount,
+		MaxAmount:      rule.MaxAmount,
+		EffectiveFrom:  rule.EffectiveFrom,
+		EffectiveTo:    rule.EffectiveTo,
+		CreatedAt:      &rule.CreatedAt,
+	}
+
+	if len(rule.Tiers) > 0 {
+		tiers := make([]server.FeeTier, 0, len(rule.Tiers))
+		for _, tier := range rule.Tiers {
+			flatAmount := tier.FlatAmount
+			percentageBps := tier.PercentageBps
+
+			tiers = append(tiers, server.FeeTier{
+				UpTo:          tier.UpTo,
+				FlatAmount:    &flatAmount,
+				PercentageBps: &percentageBps,
+			})
+		}
+
+		result.Tiers = &tiers
+	}
+
+	return result
+}
+
+func valueOrZero(value *int) int {
+	if value == nil {
+		return 0
+	}
+
+	return *value
+}
@@ -0,0 +1,340 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"net/http"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/holds"
+	"ulascansenturk/service/internal/temporalworkflows"
+)
+
+var ErrHoldTTLNotAllowed = errors.New("ttl_seconds must be positive and within the maximum hold TTL")
+
+type HoldsService struct {
+	service                holds.Service
+	temporalClient         client.Client
+	transfersTaskQueueName string
+	defaultTTL             time.Duration
+	maxTTL                 time.Duration
+}
+
+func NewHoldsService(
+	service holds.Service,
+	temporalClient client.Client,
+	transfersTaskQueueName string,
+	defaultTTL time.Duration,
+	maxTTL time.Duration,
+) *HoldsService {
+	return &HoldsService{
+		service:                service,
+		temporalClient:         temporalClient,
+		transfersTaskQueueName: transfersTaskQueueName,
+		defaultTTL:             defaultTTL,
+		maxTTL:                 maxTTL,
+	}
+}
+
+func (a *API) V1PlaceHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID) {
+	reqBody := new(server.V1PlaceHoldJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.holdsService.PlaceHold(r.Context(), accountID, reqBody.Data)
+	if err != nil {
+		renderHoldError(err, "hold placement failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, server.HoldResponseBody{Data: *result})
+}
+
+func (a *API) V1ListHolds(w http.ResponseWriter, r *http.Request, accountID server.AccountID, params server.V1ListHoldsParams) {
+	result, err := a.holdsService.ListHolds(r.Context(), accountID, params)
+	if err != nil {
+		log.Err(err).Msg("holds listing failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.HoldListResponseBody{Data: result})
+}
+
+func (a *API) V1GetHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID, holdID server.HoldID) {
+	result, err := a.holdsService.GetHold(r.Context(), accountID, holdID)
+	if err != nil {
+		renderHoldError(err, "hold lookup failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.HoldResponseBody{Data: *result})
+}
+
+func (a *API) V1CaptureHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID, holdID server.HoldID) {
+	reqBody := new(server.V1CaptureHoldJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.holdsService.CaptureHold(r.Context(), accountID, holdID, reqBody.Data)
+	if err != nil {
+		if exceeded, ok := transferLimitExceeded(err); ok {
+			server.TransferLimitExceededError(err, transferLimitExceededMeta(exceeded), w, r)
+
+			return
+		}
+
+		renderHoldError(err, "hold capture failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.HoldResponseBody{Data: *result})
+}
+
+func (a *API) V1VoidHold(w http.ResponseWriter, r *http.Request, accountID server.AccountID, holdID server.HoldID) {
+	reqBody := new(server.V1VoidHoldJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.holdsService.VoidHold(r.Context(), accountID, holdID, reqBody.Data)
+	if err != nil {
+		renderHoldError(err, "hold void failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.HoldResponseBody{Data: *result})
+}
+
+func renderHoldError(err error, msg string, w http.ResponseWriter, r *http.Request) {
+	switch {
+	case errors.Is(err, holds.ErrInvalidHold), errors.Is(err, holds.ErrCaptureExceedsHold), errors.Is(err, ErrHoldTTLNotAllowed):
+		server.BadRequestError(err, w, r)
+	case errors.Is(err, accounts.ErrAccountNotFound), errors.Is(err, holds.ErrHoldNotFound):
+		server.NotFoundError(err, w, r)
+	case errors.Is(err, holds.ErrHoldNotActive):
+		server.ConflictError(err, w, r)
+	default:
+		log.Err(err).Msg(msg)
+
+		server.ProcessingError(err, w, r)
+	}
+}
+
+// PlaceHold reserves the funds right away and starts the Hold workflow that captures, voids or expires the hold.
+// Placing a hold again with the same reference ID returns the hold already placed.
+func (s *HoldsService) PlaceHold(ctx context.Context, accountID uuid.UUID, params server.PlaceHoldParams) (*server.Hold, error) {
+	ttl := s.defaultTTL
+	if params.TtlSeconds != nil {
+		ttl = time.Duration(*params.TtlSeconds) * time.Second
+	}
+
+	if ttl <= 0 || ttl > s.maxTTL {
+		return nil, ErrHoldTTLNotAllowed
+	}
+
+	hold, err := s.service.PlaceHold(ctx, &holds.Hold{
+		ReferenceID:          params.ReferenceId,
+		AccountID:            accountID,
+		DestinationAccountID: params.DestinationAccountId,
+		Amount:               params.Amount,
+		Description:          params.Description,
+		ExpiresAt:            time.Now().Add(ttl),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.IsActive() {
+		_, err = s.temporalClient.ExecuteWorkflow(
+			ctx,
+			client.StartWorkflowOptions{
+				ID:                    temporalworkflows.HoldWorkflowID(hold.ReferenceID),
+				TaskQueue:             s.transfersTaskQueueName,
+				WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+			},
+			temporalworkflows.Hold,
+			&temporalworkflows.HoldParams{
+				HoldID:      hold.ID,
+				ReferenceID: hold.ReferenceID,
+				AccountID:   hold.AccountID,
+				ExpiresAt:   hold.ExpiresAt,
+			},
+		)
+		if err != nil {
+			var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
+			if !errors.As(err, &alreadyStartedErr) {
+				return nil, err
+			}
+		}
+	}
+
+	return toHoldResponse(hold), nil
+}
+
+func (s *HoldsService) ListHolds(ctx context.Context, accountID uuid.UUID, params server.V1ListHoldsParams) ([]server.Hold, error) {
+	var status *constants.HoldStatus
+
+	if params.Status != nil {
+		parsed, err := constants.ParseHoldStatus(string(*params.Status))
+		if err != nil {
+			return nil, err
+		}
+
+		status = &parsed
+	}
+
+	accountHolds, err := s.service.ListHolds(ctx, accountID, status)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]server.Hold, 0, len(accountHolds))
+	for _, hold := range accountHolds {
+		result = append(result, *toHoldResponse(hold))
+	}
+
+	return result, nil
+}
+
+func (s *HoldsService) GetHold(ctx context.Context, accountID, holdID uuid.UUID) (*server.Hold, error) {
+	hold, err := s.getAccountHold(ctx, accountID, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toHoldResponse(hold), nil
+}
+
+// CaptureHold signals the Hold workflow to capture the hold and waits for the capture to be posted.
+func (s *HoldsService) CaptureHold(
+	ctx context.Context,
+	accountID, holdID uuid.UUID,
+	params server.CaptureHoldParams,
+) (*server.Hold, error) {
+	hold, err := s.getAccountHold(ctx, accountID, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hold.IsActive() {
+		return nil, fmt.Errorf("%w: hold is %s", holds.ErrHoldNotActive, hold.Status)
+	}
+
+	if params.Amount != nil && (*params.Amount < 1 || *params.Amount > hold.Amount) {
+		return nil, holds.ErrCaptureExceedsHold
+	}
+
+	return s.signalHold(ctx, hold, temporalworkflows.CaptureHoldSignal, temporalworkflows.CaptureHoldRequest{Amount: params.Amount})
+}
+
+// VoidHold signals the Hold workflow to release the hold and waits for the funds to be available again.
+func (s *HoldsService) VoidHold(ctx context.Context, accountID, holdID uuid.UUID, params server.VoidHoldParams) (*server.Hold, error) {
+	hold, err := s.getAccountHold(ctx, accountID, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !hold.IsActive() {
+		return nil, fmt.Errorf("%w: hold is %s", holds.ErrHoldNotActive, hold.Status)
+	}
+
+	request := temporalworkflows.VoidHoldRequest{}
+	if params.Reason != nil {
+		request.Reason = *params.Reason
+	}
+
+	return s.signalHold(ctx, hold, temporalworkflows.VoidHoldSignal, request)
+}
+
+func (s *HoldsService) getAccountHold(ctx context.Context, accountID, holdID uuid.UUID) (*holds.Hold, error) {
+	hold, err := s.service.GetHold(ctx, holdID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold.AccountID != accountID {
+		return nil, holds.ErrHoldNotFound
+	}
+
+	return hold, nil
+}
+
+func (s *HoldsService) signalHold(ctx context.Context, hold *holds.Hold, signalName string, arg interface{}) (*server.Hold, error) {
+	workflowID := temporalworkflows.HoldWorkflowID(hold.ReferenceID)
+
+	err := s.temporalClient.SignalWorkflow(ctx, workflowID, "", signalName, arg)
+	if err != nil {
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return nil, holds.ErrHoldNotActive
+		}
+
+		return nil, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, transferSignalWaitTimeout)
+	defer cancel()
+
+	waitErr := s.temporalClient.GetWorkflow(waitCtx, workflowID, "").Get(waitCtx, nil)
+	if waitErr != nil {
+		if waitCtx.Err() == nil {
+			return nil, waitErr
+		}
+
+		log.Warn().Str("workflow_id", workflowID).Str("signal", signalName).Msg("hold did not end after the signal")
+	}
+
+	return s.GetHold(ctx, hold.AccountID, hold.ID)
+}
+
+func toHoldResponse(hold *holds.Hold) *server.Hold {
+	return &server.Hold{
+		Id:                   hold.ID,
+		ReferenceId:          hold.ReferenceID,
+		AccountId:            hold.AccountID,
+		DestinationAccountId: hold.DestinationAccountID,
+		Amount:               hold.Amount,
+		CapturedAmount:       hold.CapturedAmount,
+		Currency:             hold.Currency,
+		Status:               server.HoldStatus(hold.Status.String()),
+		Description:          hold.Description,
+		ReleaseReason:        hold.ReleaseReason,
+		ExpiresAt:            hold.ExpiresAt,
+		ReleasedAt:           hold.ReleasedAt,
+		CreatedAt:            hold.CreatedAt,
+	}
+}
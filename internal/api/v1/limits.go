@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/sdk/temporal"
+	"net/http"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/limits"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+)
+
+type LimitsService struct {
+	service limits.Service
+}
+
+func NewLimitsService(service limits.Service) *LimitsService {
+	return &LimitsService{service: service}
+}
+
+func (a *API) V1GetAccountLimits(w http.ResponseWriter, r *http.Request, accountID server.AccountID) {
+	result, err := a.limitsService.GetAccountLimits(r.Context(), accountID)
+	if err != nil {
+		if errors.Is(err, accounts.ErrAccountNotFound) {
+			server.NotFoundError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("account limits lookup failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.AccountLimitsResponseBody{Data: *result})
+}
+
+func (a *API) V1SetTransferLimit(w http.ResponseWriter, r *http.Request) {
+	reqBody := new(server.V1SetTransferLimitJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.limitsService.SetTransferLimit(r.Context(), reqBody.Data)
+	if err != nil {
+		if errors.Is(err, limits.ErrInvalidLimit) {
+			server.BadRequestError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("transfer limit update failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.TransferLimitResponseBody{Data: *result})
+}
+
+// GetAccountLimits returns what the account can still send out now.
+func (s *LimitsService) GetAccountLimits(ctx context.Context, accountID uuid.UUID) (*server.AccountLimits, error) {
+	accountLimits, err := s.service.GetAccountLimits(ctx, accountID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	result := &server.AccountLimits{
+		AccountId:     accountLimits.AccountID,
+		Currency:      accountLimits.Currency,
+		DayResetsAt:   accountLimits.DayResetsAt,
+		MonthResetsAt: accountLimits.MonthResetsAt,
+		Used: server.TransferLimitUsage{
+			DailyAmount:   accountLimits.Usage.DailyAmount,
+			DailyCount:    accountLimits.Usage.DailyCount,
+			MonthlyAmount: accountLimits.Usage.MonthlyAmount,
+		},
+		Remaining: server.TransferLimitRemaining{
+			DailyAmount:   accountLimits.Remaining.DailyAmount,
+			DailyCount:    accountLimits.Remaining.DailyCount,
+			MonthlyAmount: accountLimits.Remaining.MonthlyAmount,
+		},
+	}
+
+	if accountLimits.Limit != nil {
+		limit := toTransferLimitResponse(accountLimits.Limit)
+		result.Limit = &limit
+	}
+
+	return result, nil
+}
+
+func (s *LimitsService) SetTransferLimit(ctx context.Context, params server.TransferLimitParams) (*server.TransferLimit, error) {
+	limit, err := s.service.SaveLimit(ctx, &limits.Limit{
+		AccountID:       params.AccountId,
+		AccountProduct:  params.AccountProduct,
+		Currency:        params.Currency,
+		MaxSingleAmount: params.MaxSingleAmount,
+		DailyAmount:     params.DailyAmount,
+		MonthlyAmount:   params.MonthlyAmount,
+		DailyCount:      params.DailyCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toTransferLimitResponse(limit)
+
+	return &result, nil
+}
+
+func toTransferLimitResponse(limit *limits.Limit) server.TransferLimit {
+	return server.TransferLimit{
+		Id:              limit.ID,
+		AccountId:       limit.AccountID,
+		AccountProduct:  limit.AccountProduct,
+		Currency:        limit.Currency,
+		MaxSingleAmount: limit.MaxSingleAmount,
+		DailyAmount:     limit.DailyAmount,
+		MonthlyAmount:   limit.MonthlyAmount,
+		DailyCount:      limit.DailyCount,
+		UpdatedAt:       &limit.UpdatedAt,
+	}
+}
+
+// transferLimitExceeded tells whether the transfer failed on a limit of its source account and which one.
+func transferLimitExceeded(err error) (*limits.ExceededError, bool) {
+	var applicationErr *temporal.ApplicationError
+	if !errors.As(err, &applicationErr) || applicationErr.Type() != activities.TransferLimitExceededErrorType {
+		return nil, false
+	}
+
+	var exceeded limits.ExceededError
+
+	detailsErr := applicationErr.Details(&exceeded)
+	if detailsErr != nil {
+		log.Err(detailsErr).Msg("transfer limit details decoding failed")
+
+		return nil, true
+	}
+
+	return &exceeded, true
+}
+
+func transferLimitExceededMeta(exceeded *limits.ExceededError) map[string]interface{} {
+	if exceeded == nil {
+		return map[string]interface{}{}
+	}
+
+	return map[string]interface{}{
+		"limit_type": exceeded.LimitType,
+		"allowed":    exceeded.Allowed,
+		"used":       exceeded.Used,
+		"requested":  exceeded.Requested,
+	}
+}
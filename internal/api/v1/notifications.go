@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"net/http"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/notifications"
+)
+
+type NotificationsService struct {
+	service notifications.Service
+}
+
+func NewNotificationsService(service notifications.Service) *NotificationsService {
+	return &NotificationsService{service: service}
+}
+
+func (a *API) V1GetNotificationPreferences(w http.ResponseWriter, r *http.Request, userID server.UserID) {
+	result, err := a.notificationsService.GetPreferences(r.Context(), userID)
+	if err != nil {
+		renderNotificationError(err, "notification preferences lookup failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.NotificationPreferencesResponseBody{Data: *result})
+}
+
+func (a *API) V1UpdateNotificationPreferences(w http.ResponseWriter, r *http.Request, userID server.UserID) {
+	reqBody := new(server.V1UpdateNotificationPreferencesJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.notificationsService.UpdatePreferences(r.Context(), userID, reqBody.Data)
+	if err != nil {
+		renderNotificationError(err, "notification preferences update failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.NotificationPreferencesResponseBody{Data: *result})
+}
+
+func renderNotificationError(err error, msg string, w http.ResponseWriter, r *http.Request) {
+	switch {
+	case errors.Is(err, notifications.ErrInvalidPreferences):
+		server.BadRequestError(err, w, r)
+	case errors.Is(err, notifications.ErrUserNotFound):
+		server.NotFoundError(err, w, r)
+	default:
+		log.Err(err).Msg(msg)
+
+		server.ProcessingError(err, w, r)
+	}
+}
+
+func (s *NotificationsService) GetPreferences(ctx context.Context, userID uuid.UUID) (*server.NotificationPreferences, error) {
+	preferences, err := s.service.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return toNotificationPreferencesResponse(preferences), nil
+}
+
+func (s *NotificationsService) UpdatePreferences(
+	ctx context.Context,
+	userID uuid.UUID,
+	params server.NotificationPreferencesParams,
+) (*server.NotificationPreferences, error) {
+	preferences, err := s.service.UpdatePreferences(ctx, &notifications.Preferences{
+		UserID:              userID,
+		EmailEnabled:        params.EmailEnabled,
+		SMSEnabled:          params.SmsEnabled,
+		PhoneNumber:         params.PhoneNumber,
+		TransferSent:        params.TransferSent,
+		TransferReceived:    params.TransferReceived,
+		TransferFailed:      params.TransferFailed,
+		LowBalance:          params.LowBalance,
+		LowBalanceThreshold: params.LowBalanceThreshold,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toNotificationPreferencesResponse(preferences), nil
+}
+
+func toNotificationPreferencesResponse(preferences *notifications.Preferences) *server.NotificationPreferences {
+	result := &server.NotificationPreferences{
+		UserId:              preferences.UserID,
+		EmailEnabled:        preferences.EmailEnabled,
+		SmsEnabled:          preferences.SMSEnabled,
+		PhoneNumber:         preferences.PhoneNumber,
+		TransferSent:        preferences.TransferSent,
+		TransferReceived:    preferences.TransferReceived,
+		TransferFailed:      preferences.TransferFailed,
+		LowBalance:          preferences.LowBalance,
+		LowBalanceThreshold: preferences.LowBalanceThreshold,
+	}
+
+	if !preferences.UpdatedAt.IsZero() {
+		result.UpdatedAt = &preferences.UpdatedAt
+	}
+
+	return result
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"net/http"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/temporalworkflows"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+)
+
+var ErrReversalAmountNotPositive = errors.New("amount must be positive")
+
+func (a *API) V1ReverseTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	reqBody := new(server.V1ReverseTransferJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	if reqBody.Data.Amount != nil && *reqBody.Data.Amount < 1 {
+		server.BadRequestError(ErrReversalAmountNotPositive, w, r)
+
+		return
+	}
+
+	result, err := a.transfersService.ReverseTransfer(r.Context(), referenceID, reqBody.Data)
+	if err != nil {
+		if errors.Is(err, ErrTransferNotFound) {
+			server.NotFoundError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("transfer reversal failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, server.ReversalResponseBody{Data: *result})
+}
+
+// ReverseTransfer runs the Reversal workflow of the transfer and waits for its result. A reversal that already
+// ran with the same reference ID returns its result instead of reversing again.
+func (s *TransfersService) ReverseTransfer(
+	ctx context.Context,
+	transferReferenceID uuid.UUID,
+	params server.ReverseTransferParams,
+) (*server.Reversal, error) {
+	transferParams := temporalworkflows.TransferParams{ReferenceId: transferReferenceID}
+
+	sourceTransaction, err := s.transactionsRepo.GetByReferenceID(ctx, transferParams.SourceTransactionReferenceID())
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceTransaction == nil {
+		return nil, ErrTransferNotFound
+	}
+
+	reversalParams := &temporalworkflows.ReversalParams{
+		ReferenceID:         params.ReferenceId,
+		TransferReferenceID: transferReferenceID,
+		Amount:              params.Amount,
+	}
+
+	if params.IncludeFee != nil {
+		reversalParams.IncludeFee = *params.IncludeFee
+	}
+
+	if params.Reason != nil {
+		reversalParams.Reason = *params.Reason
+	}
+
+	workflowID := temporalworkflows.ReversalWorkflowID(params.ReferenceId)
+
+	var result activities.ReversalResult
+
+	we, err := s.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			ID:                    workflowID,
+			TaskQueue:             s.transfersTaskQueueName,
+			WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+		},
+		temporalworkflows.Reversal,
+		reversalParams,
+	)
+	if err != nil {
+		var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
+		if !errors.As(err, &alreadyStartedErr) {
+			return nil, err
+		}
+
+		err = s.temporalClient.GetWorkflow(ctx, workflowID, "").Get(ctx, &result)
+	} else {
+		err = we.Get(ctx, &result)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return toReversalResponse(&result), nil
+}
+
+func toReversalResponse(result *activities.ReversalResult) *server.Reversal {
+	reversal := &server.Reversal{
+		ReferenceId:         result.ReferenceID,
+		TransferReferenceId: result.TransferReferenceID,
+		DebitTransaction:    *toTransactionResponse(result.OutboundTransaction),
+		CreditTransaction:   *toTransactionResponse(result.InboundTransaction),
+	}
+
+	if result.FeeOutbound != nil {
+		reversal.FeeDebitTransaction = toTransactionResponse(result.FeeOutbound)
+		reversal.FeeCreditTransaction = toTransactionResponse(result.FeeInbound)
+	}
+
+	return reversal
+}
@@ -0,0 +1,207 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/workflowservice/v1"
+	"net/http"
+	"sort"
+	"time"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/temporalworkflows"
+)
+
+const runningTransfersQuery = "WorkflowType = 'Transfer' AND ExecutionStatus = 'Running'"
+
+var (
+	ErrTransferNotScheduled = errors.New("transfer is not scheduled")
+	ErrExecuteAtInPast      = errors.New("execute_at must be in the future")
+)
+
+func (a *API) V1ListScheduledTransfers(w http.ResponseWriter, r *http.Request) {
+	result, err := a.transfersService.ListScheduledTransfers(r.Context())
+	if err != nil {
+		log.Err(err).Msg("scheduled transfers listing failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.ScheduledTransferListResponseBody{Data: result})
+}
+
+func (a *API) V1RescheduleTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	reqBody := new(server.V1RescheduleTransferJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	if !reqBody.Data.ExecuteAt.After(time.Now()) {
+		server.BadRequestError(ErrExecuteAtInPast, w, r)
+
+		return
+	}
+
+	result, err := a.transfersService.RescheduleTransfer(r.Context(), referenceID, reqBody.Data.ExecuteAt)
+	if err != nil {
+		renderScheduledTransferError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.ScheduledTransferResponseBody{Data: *result})
+}
+
+func (a *API) V1CancelScheduledTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	result, err := a.transfersService.CancelScheduledTransfer(r.Context(), referenceID)
+	if err != nil {
+		renderScheduledTransferError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.ScheduledTransferResponseBody{Data: *result})
+}
+
+// ListScheduledTransfers returns the running Transfer workflows that are still waiting for their execution time,
+// the soonest first.
+func (s *TransfersService) ListScheduledTransfers(ctx context.Context) ([]server.ScheduledTransfer, error) {
+	result := make([]server.ScheduledTransfer, 0)
+
+	var nextPageToken []byte
+
+	for {
+		response, err := s.temporalClient.ListWorkflow(ctx, &workflowservice.ListWorkflowExecutionsRequest{
+			Query:         runningTransfersQuery,
+			NextPageToken: nextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		for _, execution := range response.GetExecutions() {
+			workflowID := execution.GetExecution().GetWorkflowId()
+
+			state, queryErr := s.queryTransferState(ctx, workflowID)
+			if queryErr != nil {
+				log.Err(queryErr).Str("workflow_id", workflowID).Msg("transfer state query failed")
+
+				continue
+			}
+
+			if state.Status == constants.TransferStateSCHEDULED {
+				result = append(result, toScheduledTransferResponse(state))
+			}
+		}
+
+		nextPageToken = response.GetNextPageToken()
+		if len(nextPageToken) == 0 {
+			break
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ExecuteAt.Before(result[j].ExecuteAt)
+	})
+
+	return result, nil
+}
+
+// RescheduleTransfer moves the execution time of a transfer that is still waiting for it.
+func (s *TransfersService) RescheduleTransfer(ctx context.Context, referenceID uuid.UUID, executeAt time.Time) (*server.ScheduledTransfer, error) {
+	state, err := s.getScheduledTransferState(ctx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.temporalClient.SignalWorkflow(ctx, referenceID.String(), "", temporalworkflows.RescheduleTransferSignal, temporalworkflows.RescheduleTransferRequest{
+		ExecuteAt: executeAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state.ExecuteAt = &executeAt
+
+	result := toScheduledTransferResponse(state)
+
+	return &result, nil
+}
+
+// CancelScheduledTransfer cancels a transfer that is still waiting for its execution time, no money is moved.
+func (s *TransfersService) CancelScheduledTransfer(ctx context.Context, referenceID uuid.UUID) (*server.ScheduledTransfer, error) {
+	state, err := s.getScheduledTransferState(ctx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.temporalClient.SignalWorkflow(ctx, referenceID.String(), "", temporalworkflows.CancelTransferSignal, temporalworkflows.CancelTransferRequest{
+		Reason: "scheduled transfer cancelled by client",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	state.Status = constants.TransferStateCANCELLED
+
+	result := toScheduledTransferResponse(state)
+
+	return &result, nil
+}
+
+// getScheduledTransferState returns the state of the transfer if it is still waiting for its execution time.
+// The timer may still fire between this check and the signal, in which case the signal is ignored by the workflow.
+func (s *TransfersService) getScheduledTransferState(ctx context.Context, referenceID uuid.UUID) (*temporalworkflows.TransferState, error) {
+	state, err := s.queryTransferState(ctx, referenceID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Status != constants.TransferStateSCHEDULED {
+		return nil, fmt.Errorf("%w: %s", ErrTransferNotScheduled, state.Status)
+	}
+
+	return state, nil
+}
+
+func renderScheduledTransferError(err error, w http.ResponseWriter, r *http.Request) {
+	if errors.Is(err, ErrTransferNotFound) {
+		server.NotFoundError(err, w, r)
+		return
+	}
+
+	log.Err(err).Msg("scheduled transfer processing failed")
+
+	server.ProcessingError(err, w, r)
+}
+
+func toScheduledTransferResponse(state *temporalworkflows.TransferState) server.ScheduledTransfer {
+	status := server.TransferStatusSCHEDULED
+	if state.Status == constants.TransferStateCANCELLED {
+		status = server.TransferStatusCANCELLED
+	}
+
+	result := server.ScheduledTransfer{
+		ReferenceId:          state.ReferenceID,
+		Status:               status,
+		Amount:               state.Amount,
+		SourceAccountID:      state.SourceAccountID,
+		DestinationAccountID: state.DestinationAccountID,
+	}
+
+	if state.ExecuteAt != nil {
+		result.ExecuteAt = *state.ExecuteAt
+	}
+
+	return result
+}
@@ -0,0 +1,337 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"net/http"
+	"time"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/standingorders"
+	"ulascansenturk/service/internal/temporalworkflows"
+)
+
+// standingOrderCatchupWindow is how late an occurrence missed during an outage is still run.
+const standingOrderCatchupWindow = 24 * time.Hour
+
+type StandingOrdersService struct {
+	service                standingorders.Service
+	temporalClient         client.Client
+	transfersTaskQueueName string
+}
+
+func NewStandingOrdersService(service standingorders.Service, temporalClient client.Client, transfersTaskQueueName string) *StandingOrdersService {
+	return &StandingOrdersService{
+		service:                service,
+		temporalClient:         temporalClient,
+		transfersTaskQueueName: transfersTaskQueueName,
+	}
+}
+
+func (a *API) V1CreateStandingOrder(w http.ResponseWriter, r *http.Request) {
+	reqBody := new(server.V1CreateStandingOrderJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.standingOrdersService.CreateStandingOrder(r.Context(), reqBody)
+	if err != nil {
+		if errors.Is(err, standingorders.ErrInvalidStandingOrder) {
+			server.BadRequestError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("standing order creation failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, server.StandingOrderResponseBody{Data: *result})
+}
+
+func (a *API) V1ListStandingOrders(w http.ResponseWriter, r *http.Request, params server.V1ListStandingOrdersParams) {
+	result, err := a.standingOrdersService.ListStandingOrders(r.Context(), params.SourceAccountId)
+	if err != nil {
+		log.Err(err).Msg("standing orders listing failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.StandingOrderListResponseBody{Data: result})
+}
+
+func (a *API) V1GetStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderID server.StandingOrderID) {
+	result, err := a.standingOrdersService.GetStandingOrder(r.Context(), standingOrderID)
+	if err != nil {
+		renderStandingOrderError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.StandingOrderResponseBody{Data: *result})
+}
+
+func (a *API) V1CancelStandingOrder(w http.ResponseWriter, r *http.Request, standingOrderID server.StandingOrderID) {
+	result, err := a.standingOrdersService.CancelStandingOrder(r.Context(), standingOrderID)
+	if err != nil {
+		renderStandingOrderError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.StandingOrderResponseBody{Data: *result})
+}
+
+func (a *API) V1ListStandingOrderOccurrences(w http.ResponseWriter, r *http.Request, standingOrderID server.StandingOrderID) {
+	result, err := a.standingOrdersService.ListOccurrences(r.Context(), standingOrderID)
+	if err != nil {
+		renderStandingOrderError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.StandingOrderOccurrenceListResponseBody{Data: result})
+}
+
+// CreateStandingOrder stores the standing order and creates the Temporal schedule running its occurrences.
+// The standing order is cancelled if the schedule can't be created.
+func (s *StandingOrdersService) CreateStandingOrder(
+	ctx context.Context,
+	reqBody *server.V1CreateStandingOrderJSONRequestBody,
+) (*server.StandingOrder, error) {
+	standingOrderID := uuid.New()
+
+	standingOrder, err := s.service.CreateStandingOrder(ctx, toStandingOrder(standingOrderID, reqBody.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	remainingActions := 0
+	if standingOrder.MaxCount != nil {
+		remainingActions = *standingOrder.MaxCount
+	}
+
+	_, err = s.temporalClient.ScheduleClient().Create(ctx, client.ScheduleOptions{
+		ID:   standingOrder.ScheduleID,
+		Spec: scheduleSpec(standingOrder),
+		Action: &client.ScheduleWorkflowAction{
+			ID:        standingOrder.ScheduleID,
+			Workflow:  temporalworkflows.StandingOrderOccurrence,
+			TaskQueue: s.transfersTaskQueueName,
+			Args: []interface{}{temporalworkflows.StandingOrderOccurrenceParams{
+				StandingOrderID:      standingOrder.ID,
+				Amount:               standingOrder.Amount,
+				Metadata:             standingOrder.Metadata,
+				SourceAccountID:      standingOrder.SourceAccountID,
+				DestinationAccountID: standingOrder.DestinationAccountID,
+			}},
+		},
+		RemainingActions: remainingActions,
+		CatchupWindow:    standingOrderCatchupWindow,
+	})
+	if err != nil {
+		if updateErr := s.service.UpdateStatus(ctx, standingOrder.ID, constants.StandingOrderStatusCANCELLED); updateErr != nil {
+			log.Err(updateErr).Str("standing_order_id", standingOrder.ID.String()).Msg("standing order cancellation failed")
+		}
+
+		return nil, err
+	}
+
+	result := toStandingOrderResponse(standingOrder)
+
+	return &result, nil
+}
+
+func (s *StandingOrdersService) ListStandingOrders(ctx context.Context, sourceAccountID *uuid.UUID) ([]server.StandingOrder, error) {
+	standingOrders, err := s.service.ListStandingOrders(ctx, sourceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]server.StandingOrder, 0, len(standingOrders))
+	for _, standingOrder := range standingOrders {
+		result = append(result, toStandingOrderResponse(standingOrder))
+	}
+
+	return result, nil
+}
+
+func (s *StandingOrdersService) GetStandingOrder(ctx context.Context, id uuid.UUID) (*server.StandingOrder, error) {
+	standingOrder, err := s.service.GetStandingOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := toStandingOrderResponse(standingOrder)
+
+	return &result, nil
+}
+
+// CancelStandingOrder deletes the schedule of an active standing order, other standing orders are returned as is.
+func (s *StandingOrdersService) CancelStandingOrder(ctx context.Context, id uuid.UUID) (*server.StandingOrder, error) {
+	standingOrder, err := s.service.GetStandingOrder(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if standingOrder.Status == constants.StandingOrderStatusACTIVE {
+		err = s.temporalClient.ScheduleClient().GetHandle(ctx, standingOrder.ScheduleID).Delete(ctx)
+		if err != nil {
+			var notFoundErr *serviceerror.NotFound
+			if !errors.As(err, &notFoundErr) {
+				return nil, err
+			}
+		}
+
+		err = s.service.UpdateStatus(ctx, standingOrder.ID, constants.StandingOrderStatusCANCELLED)
+		if err != nil {
+			return nil, err
+		}
+
+		standingOrder.Status = constants.StandingOrderStatusCANCELLED
+	}
+
+	result := toStandingOrderResponse(standingOrder)
+
+	return &result, nil
+}
+
+func (s *StandingOrdersService) ListOccurrences(ctx context.Context, standingOrderID uuid.UUID) ([]server.StandingOrderOccurrence, error) {
+	if _, err := s.service.GetStandingOrder(ctx, standingOrderID); err != nil {
+		return nil, err
+	}
+
+	occurrences, err := s.service.ListOccurrences(ctx, standingOrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]server.StandingOrderOccurrence, 0, len(occurrences))
+	for _, occurrence := range occurrences {
+		status := server.TransferStatusSUCCESS
+		if occurrence.Status != constants.TransferStateCOMPLETED {
+			status = server.TransferStatusFAILURE
+		}
+
+		result = append(result, server.StandingOrderOccurrence{
+			ReferenceId:   occurrence.ReferenceID,
+			Status:        status,
+			FailureReason: occurrence.FailureReason,
+			CreatedAt:     occurrence.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+// scheduleSpec translates the rule of the standing order to a Temporal schedule spec, times are in UTC.
+func scheduleSpec(standingOrder *standingorders.StandingOrder) client.ScheduleSpec {
+	spec := client.ScheduleSpec{}
+
+	if standingOrder.StartAt != nil {
+		spec.StartAt = *standingOrder.StartAt
+	}
+
+	if standingOrder.EndAt != nil {
+		spec.EndAt = *standingOrder.EndAt
+	}
+
+	calendar := client.ScheduleCalendarSpec{
+		Hour:   []client.ScheduleRange{{Start: standingOrder.Hour}},
+		Minute: []client.ScheduleRange{{Start: standingOrder.Minute}},
+	}
+
+	switch standingOrder.Frequency {
+	case constants.StandingOrderFrequencyCRON:
+		spec.CronExpressions = []string{*standingOrder.CronExpression}
+
+		return spec
+	case constants.StandingOrderFrequencyWEEKLY:
+		calendar.DayOfWeek = []client.ScheduleRange{{Start: *standingOrder.DayOfWeek}}
+	case constants.StandingOrderFrequencyMONTHLY:
+		calendar.DayOfMonth = []client.ScheduleRange{{Start: *standingOrder.DayOfMonth}}
+	}
+
+	spec.Calendars = []client.ScheduleCalendarSpec{calendar}
+
+	return spec
+}
+
+func renderStandingOrderError(err error, w http.ResponseWriter, r *http.Request) {
+	if errors.Is(err, standingorders.ErrStandingOrderNotFound) {
+		server.NotFoundError(err, w, r)
+		return
+	}
+
+	log.Err(err).Msg("standing order processing failed")
+
+	server.ProcessingError(err, w, r)
+}
+
+func toStandingOrder(id uuid.UUID, params server.CreateStandingOrderParams) *standingorders.StandingOrder {
+	standingOrder := &standingorders.StandingOrder{
+		ID:                   id,
+		SourceAccountID:      params.SourceAccountID,
+		DestinationAccountID: params.DestinationAccountID,
+		Amount:               params.Amount,
+		Frequency:            constants.StandingOrderFrequency(params.Frequency),
+		CronExpression:       params.Cron,
+		DayOfWeek:            params.DayOfWeek,
+		DayOfMonth:           params.DayOfMonth,
+		Hour:                 valueOrZero(params.Hour),
+		Minute:               valueOrZero(params.Minute),
+		StartAt:              params.StartAt,
+		EndAt:                params.EndAt,
+		MaxCount:             params.MaxCount,
+		Status:               constants.StandingOrderStatusACTIVE,
+		ScheduleID:           "standing-order-" + id.String(),
+	}
+
+	if params.Metadata != nil {
+		standingOrder.Metadata = *params.Metadata
+	}
+
+	return standingOrder
+}
+
+func toStandingOrderResponse(standingOrder *standingorders.StandingOrder) server.StandingOrder {
+	result := server.StandingOrder{
+		Id:                   standingOrder.ID,
+		SourceAccountID:      standingOrder.SourceAccountID,
+		DestinationAccountID: standingOrder.DestinationAccountID,
+		Amount:               standingOrder.Amount,
+		Frequency:            server.StandingOrderFrequency(standingOrder.Frequency),
+		Cron:                 standingOrder.CronExpression,
+		DayOfWeek:            standingOrder.DayOfWeek,
+		DayOfMonth:           standingOrder.DayOfMonth,
+		Hour:                 standingOrder.Hour,
+		Minute:               standingOrder.Minute,
+		StartAt:              standingOrder.StartAt,
+		EndAt:                standingOrder.EndAt,
+		MaxCount:             standingOrder.MaxCount,
+		ExecutedCount:        standingOrder.ExecutedCount,
+		FailedCount:          standingOrder.FailedCount,
+		Status:               server.StandingOrderStatus(standingOrder.Status),
+		CreatedAt:            &standingOrder.CreatedAt,
+	}
+
+	if standingOrder.Metadata != nil {
+		metadata := map[string]interface{}(standingOrder.Metadata)
+		result.Metadata = &metadata
+	}
+
+	return result
+}
@@ -0,0 +1,215 @@
+package v1
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"net/http"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/temporalworkflows"
+)
+
+// maxTransferBatchItems caps the items of a batch. Every item runs in the same TransferBatch workflow run, so the cap
+// keeps its history and its result within the Temporal limits.
+const maxTransferBatchItems = 1000
+
+var (
+	ErrTransferBatchNotFound      = errors.New("transfer batch not found")
+	ErrTransferBatchAlreadyExists = errors.New("transfer batch already exists")
+	ErrTransferBatchEmpty         = errors.New("transfer batch has no items")
+	ErrTransferBatchTooLarge      = fmt.Errorf("transfer batch has more than %d items", maxTransferBatchItems)
+	ErrTransferBatchDuplicateItem = errors.New("transfer batch has duplicate reference_id")
+)
+
+func (a *API) V1CreateTransferBatch(w http.ResponseWriter, r *http.Request) {
+	reqBody := new(server.V1CreateTransferBatchJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	err = validateTransferBatch(reqBody.Data)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.transfersService.StartTransferBatch(r.Context(), reqBody.Data)
+	if err != nil {
+		log.Err(err).Msg("transfer batch start failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, server.TransferBatchResponseBody{Data: *result})
+}
+
+func (a *API) V1GetTransferBatch(w http.ResponseWriter, r *http.Request, batchID server.TransferBatchID) {
+	result, err := a.transfersService.GetTransferBatch(r.Context(), batchID)
+	if err != nil {
+		if errors.Is(err, ErrTransferBatchNotFound) {
+			server.NotFoundError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("transfer batch lookup failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.TransferBatchResponseBody{Data: *result})
+}
+
+// StartTransferBatch starts the TransferBatch workflow of the batch and returns its initial state, every item queued.
+func (s *TransfersService) StartTransferBatch(ctx context.Context, params server.TransferBatchParams) (*server.TransferBatch, error) {
+	batchParams := temporalworkflows.TransferBatchParams{
+		BatchID: params.BatchId,
+		Items:   make([]temporalworkflows.TransferParams, len(params.Items)),
+	}
+
+	if params.MaxConcurrency != nil {
+		batchParams.MaxConcurrency = *params.MaxConcurrency
+	}
+
+	for i, item := range params.Items {
+		batchParams.Items[i] = temporalworkflows.TransferParams(item)
+	}
+
+	we, err := s.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			ID:                    temporalworkflows.TransferBatchWorkflowID(params.BatchId),
+			TaskQueue:             s.transfersTaskQueueName,
+			WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+		},
+		temporalworkflows.TransferBatch,
+		batchParams,
+	)
+	if err != nil {
+		var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
+		if errors.As(err, &alreadyStartedErr) {
+			return nil, ErrTransferBatchAlreadyExists
+		}
+
+		return nil, err
+	}
+
+	result := &server.TransferBatch{
+		BatchId:    params.BatchId,
+		WorkflowId: we.GetID(),
+		Status:     server.TransferBatchStatusPROCESSING,
+		Total:      len(params.Items),
+		Queued:     len(params.Items),
+		Items:      make([]server.TransferBatchItem, len(params.Items)),
+	}
+
+	for i, item := range params.Items {
+		result.Items[i] = server.TransferBatchItem{
+			ReferenceId: item.ReferenceId,
+			Status:      server.TransferBatchItemStatusQUEUED,
+		}
+	}
+
+	return result, nil
+}
+
+// GetTransferBatch reports the progress of a batch, read from the result of a finished batch and through the
+// TransferBatchStateQuery otherwise.
+func (s *TransfersService) GetTransferBatch(ctx context.Context, batchID uuid.UUID) (*server.TransferBatch, error) {
+	workflowID := temporalworkflows.TransferBatchWorkflowID(batchID)
+
+	description, err := s.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	if err != nil {
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return nil, ErrTransferBatchNotFound
+		}
+
+		return nil, err
+	}
+
+	var state temporalworkflows.TransferBatchState
+
+	if description.GetWorkflowExecutionInfo().GetStatus() == enums.WORKFLOW_EXECUTION_STATUS_COMPLETED {
+		err = s.temporalClient.GetWorkflow(ctx, workflowID, "").Get(ctx, &state)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		response, queryErr := s.temporalClient.QueryWorkflow(ctx, workflowID, "", temporalworkflows.TransferBatchStateQuery)
+		if queryErr != nil {
+			return nil, queryErr
+		}
+
+		err = response.Get(&state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return toTransferBatch(workflowID, &state), nil
+}
+
+func validateTransferBatch(params server.TransferBatchParams) error {
+	if len(params.Items) == 0 {
+		return ErrTransferBatchEmpty
+	}
+
+	if len(params.Items) > maxTransferBatchItems {
+		return ErrTransferBatchTooLarge
+	}
+
+	referenceIDs := make(map[uuid.UUID]struct{}, len(params.Items))
+
+	for _, item := range params.Items {
+		if item.Amount < 1 {
+			return fmt.Errorf("amount of %s must be positive", item.ReferenceId)
+		}
+
+		if _, ok := referenceIDs[item.ReferenceId]; ok {
+			return fmt.Errorf("%w: %s", ErrTransferBatchDuplicateItem, item.ReferenceId)
+		}
+
+		referenceIDs[item.ReferenceId] = struct{}{}
+	}
+
+	return nil
+}
+
+func toTransferBatch(workflowID string, state *temporalworkflows.TransferBatchState) *server.TransferBatch {
+	result := &server.TransferBatch{
+		BatchId:    state.BatchID,
+		WorkflowId: workflowID,
+		Status:     server.TransferBatchStatus(state.Status.String()),
+		Total:      state.Total,
+		Queued:     state.Queued,
+		Running:    state.Running,
+		Succeeded:  state.Succeeded,
+		Failed:     state.Failed,
+		Items:      make([]server.TransferBatchItem, len(state.Items)),
+	}
+
+	for i, item := range state.Items {
+		result.Items[i] = server.TransferBatchItem{
+			ReferenceId:   item.ReferenceID,
+			Status:        server.TransferBatchItemStatus(item.Status.String()),
+			FailureReason: item.FailureReason,
+		}
+	}
+
+	return result
+}
@@ -2,26 +2,63 @@ package v1
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/go-chi/render"
+	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
 	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
 	"net/http"
+	"time"
 	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/audit"
 	"ulascansenturk/service/internal/constants"
 	"ulascansenturk/service/internal/temporalworkflows"
 	"ulascansenturk/service/internal/temporalworkflows/activities"
+	"ulascansenturk/service/internal/transactions"
+)
+
+// transferSignalWaitTimeout bounds how long a cancel or approval request waits for the workflow to react.
+const transferSignalWaitTimeout = 30 * time.Second
+
+// transferInitiatorMemo is the memo of the Transfer workflow that records the actor who started it.
+const transferInitiatorMemo = "initiator"
+
+var (
+	ErrTransferNotFound            = errors.New("transfer not found")
+	ErrTransferNotCancellable      = errors.New("transfer can no longer be cancelled")
+	ErrTransferNotAwaitingApproval = errors.New("transfer is not awaiting approval")
+	ErrTransferSelfApproval        = errors.New("transfer can't be approved by its initiator")
+	ErrApproverRequired            = errors.New("the approver must be named with the X-Actor-ID header")
 )
 
 type TransfersService struct {
 	transfersTaskQueueName string
 	temporalClient         client.Client
+	transactionsRepo       transactions.Repository
+	approvalService        approvals.Service
 }
 
-func NewTransfersService(transfersTaskQueueName string, temporalClient client.Client) *TransfersService {
-	return &TransfersService{transfersTaskQueueName: transfersTaskQueueName, temporalClient: temporalClient}
+func NewTransfersService(
+	transfersTaskQueueName string,
+	temporalClient client.Client,
+	transactionsRepo transactions.Repository,
+	approvalService approvals.Service,
+) *TransfersService {
+	return &TransfersService{
+		transfersTaskQueueName: transfersTaskQueueName,
+		temporalClient:         temporalClient,
+		transactionsRepo:       transactionsRepo,
+		approvalService:        approvalService,
+	}
 }
 
-func (a *API) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request) {
+func (a *API) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request, params server.V1RunTransferWorkflowParams) {
 	reqBody := new(server.V1RunTransferWorkflowJSONRequestBody)
 
 	err := render.Bind(r, reqBody)
@@ -31,8 +68,28 @@ func (a *API) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if (params.Async != nil && *params.Async) || isScheduled(reqBody.Data.ExecuteAt) {
+		accepted, startErr := a.transfersService.StartTransferWorkflow(r.Context(), reqBody)
+		if startErr != nil {
+			log.Err(startErr).Msg("transfer start failed")
+
+			server.ProcessingError(startErr, w, r)
+			return
+		}
+
+		w.Header().Set("Location", fmt.Sprintf("/v1/transfers/%s", accepted.ReferenceId))
+		render.Status(r, http.StatusAccepted)
+		render.JSON(w, r, server.TransferAcceptedResponseBody{Data: *accepted})
+		return
+	}
+
 	result, err := a.transfersService.RunRouteTransferWorkflow(r.Context(), reqBody)
 	if err != nil {
+		if exceeded, ok := transferLimitExceeded(err); ok {
+			server.TransferLimitExceededError(err, transferLimitExceededMeta(exceeded), w, r)
+			return
+		}
+
 		log.Err(err).Msg("transfer processing failed")
 
 		server.ProcessingError(err, w, r)
@@ -43,34 +100,472 @@ func (a *API) V1RunTransferWorkflow(w http.ResponseWriter, r *http.Request) {
 	render.JSON(w, r, result)
 }
 
+func (a *API) V1GetTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	result, err := a.transfersService.GetTransferStatus(r.Context(), referenceID)
+	if err != nil {
+		if errors.Is(err, ErrTransferNotFound) {
+			server.NotFoundError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("transfer status lookup failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.TransferStatusResponseBody{Data: *result})
+}
+
+func (a *API) V1CancelTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	reqBody := new(server.V1CancelTransferJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	reason := "cancelled by client"
+	if reqBody.Data.Reason != nil && *reqBody.Data.Reason != "" {
+		reason = *reqBody.Data.Reason
+	}
+
+	result, err := a.transfersService.CancelTransfer(r.Context(), referenceID, reason)
+	if err != nil {
+		if errors.Is(err, ErrTransferNotFound) {
+			server.NotFoundError(err, w, r)
+			return
+		}
+
+		log.Err(err).Msg("transfer cancellation failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.TransferStatusResponseBody{Data: *result})
+}
+
+func (a *API) V1ApproveTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	reqBody := new(server.V1ApproveTransferJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	a.decideTransfer(w, r, referenceID, temporalworkflows.ApproveTransferSignal, reqBody.Data)
+}
+
+func (a *API) V1RejectTransfer(w http.ResponseWriter, r *http.Request, referenceID server.TransferReferenceID) {
+	reqBody := new(server.V1RejectTransferJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	a.decideTransfer(w, r, referenceID, temporalworkflows.RejectTransferSignal, reqBody.Data)
+}
+
+func (a *API) decideTransfer(
+	w http.ResponseWriter,
+	r *http.Request,
+	referenceID server.TransferReferenceID,
+	signalName string,
+	params server.TransferApprovalParams,
+) {
+	approver := audit.ActorFrom(r.Context())
+	if approver == audit.AnonymousActor || approver == audit.SystemActor {
+		server.BadRequestError(ErrApproverRequired, w, r)
+
+		return
+	}
+
+	request := temporalworkflows.TransferApprovalRequest{Approver: approver}
+	if params.Reason != nil {
+		request.Reason = *params.Reason
+	}
+
+	result, err := a.transfersService.DecideTransfer(r.Context(), referenceID, signalName, request)
+	if err != nil {
+		if errors.Is(err, ErrTransferNotFound) {
+			server.NotFoundError(err, w, r)
+			return
+		}
+
+		if errors.Is(err, ErrTransferSelfApproval) {
+			server.ConflictError(err, w, r)
+			return
+		}
+
+		log.Err(err).Str("signal", signalName).Msg("transfer approval decision failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.TransferStatusResponseBody{Data: *result})
+}
+
 func (s *TransfersService) RunRouteTransferWorkflow(
 	ctx context.Context,
 	reqBody *server.V1RunTransferWorkflowJSONRequestBody,
 ) (*activities.TransferResult, error) {
+	we, err := s.executeTransferWorkflow(ctx, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	var result activities.TransferResult
+
+	err = we.Get(ctx, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// StartTransferWorkflow starts the Transfer workflow without waiting for its result.
+func (s *TransfersService) StartTransferWorkflow(
+	ctx context.Context,
+	reqBody *server.V1RunTransferWorkflowJSONRequestBody,
+) (*server.TransferAccepted, error) {
+	we, err := s.executeTransferWorkflow(ctx, reqBody)
+	if err != nil {
+		return nil, err
+	}
+
+	status := server.TransferStatusPENDING
+	if isScheduled(reqBody.Data.ExecuteAt) {
+		status = server.TransferStatusSCHEDULED
+	}
+
+	return &server.TransferAccepted{
+		ReferenceId: reqBody.Data.ReferenceId,
+		WorkflowId:  we.GetID(),
+		Status:      status,
+	}, nil
+}
+
+// GetTransferStatus reports the state of the Transfer workflow started with the given reference ID.
+// Transactions are only loaded once the workflow is finished.
+func (s *TransfersService) GetTransferStatus(ctx context.Context, referenceID uuid.UUID) (*server.TransferStatus, error) {
+	workflowID := referenceID.String()
+
+	description, err := s.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	if err != nil {
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return nil, ErrTransferNotFound
+		}
+
+		return nil, err
+	}
+
+	result := &server.TransferStatus{
+		ReferenceId: referenceID,
+		Status:      server.TransferStatusPENDING,
+	}
+
+	switch description.GetWorkflowExecutionInfo().GetStatus() {
+	case enums.WORKFLOW_EXECUTION_STATUS_RUNNING:
+		state, queryErr := s.queryTransferState(ctx, workflowID)
+		if queryErr != nil {
+			log.Err(queryErr).Str("workflow_id", workflowID).Msg("transfer state query failed")
+
+			return result, nil
+		}
+
+		result.ExecuteAt = state.ExecuteAt
+		result.Approval = toTransferApproval(state.ApprovalThreshold, state.Approval)
+
+		switch state.Status {
+		case constants.TransferStateSCHEDULED:
+			result.Status = server.TransferStatusSCHEDULED
+		case constants.TransferStateAWAITINGAPPROVAL:
+			result.Status = server.TransferStatusAWAITINGAPPROVAL
+		}
+
+		return result, nil
+	case enums.WORKFLOW_EXECUTION_STATUS_COMPLETED:
+		result.Status = server.TransferStatusSUCCESS
+	default:
+		result.Status = server.TransferStatusFAILURE
+
+		workflowErr := s.temporalClient.GetWorkflow(ctx, workflowID, "").Get(ctx, nil)
+		if workflowErr != nil {
+			var applicationErr *temporal.ApplicationError
+			if errors.As(workflowErr, &applicationErr) {
+				switch applicationErr.Type() {
+				case temporalworkflows.TransferCancelledErrorType:
+					result.Status = server.TransferStatusCANCELLED
+				case temporalworkflows.TransferRejectedErrorType:
+					result.Status = server.TransferStatusREJECTED
+				case activities.TransferLimitExceededErrorType:
+					failureCode := server.TRANSFERLIMITEXCEEDED
+					result.FailureCode = &failureCode
+				}
+			}
+
+			failureReason := workflowErr.Error()
+			result.FailureReason = &failureReason
+		}
+	}
+
+	decision, err := s.approvalService.GetDecision(ctx, referenceID)
+	if err != nil && !errors.Is(err, approvals.ErrDecisionNotFound) {
+		return nil, err
+	}
+
+	if decision != nil {
+		result.Approval = toTransferApproval(&decision.Threshold, decision)
+	}
+
+	transferParams := temporalworkflows.TransferParams{ReferenceId: referenceID}
+
+	result.SourceTransaction, err = s.findTransaction(ctx, transferParams.SourceTransactionReferenceID())
+	if err != nil {
+		return nil, err
+	}
+
+	result.DestinationTransaction, err = s.findTransaction(ctx, transferParams.DestinationTransactionReferenceID())
+	if err != nil {
+		return nil, err
+	}
+
+	result.FeeTransaction, err = s.findTransaction(ctx, transferParams.FeeTransactionReferenceID())
+	if err != nil {
+		return nil, err
+	}
+
+	result.IncomingFeeTransaction, err = s.findTransaction(ctx, transferParams.IncomingFeeTransactionReferenceID())
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CancelTransfer signals the Transfer workflow to cancel and waits a bounded time for it to react.
+// The workflow ignores the signal once the balances have moved, the returned status tells which way it went.
+func (s *TransfersService) CancelTransfer(ctx context.Context, referenceID uuid.UUID, reason string) (*server.TransferStatus, error) {
+	state, err := s.queryTransferState(ctx, referenceID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	switch state.Status {
+	case constants.TransferStateSCHEDULED, constants.TransferStateAWAITINGAPPROVAL, constants.TransferStatePROCESSING:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrTransferNotCancellable, state.Status)
+	}
+
+	return s.signalTransfer(ctx, referenceID, temporalworkflows.CancelTransferSignal, temporalworkflows.CancelTransferRequest{
+		Reason: reason,
+	}, ErrTransferNotCancellable)
+}
+
+// DecideTransfer approves or rejects, depending on the signal, a transfer awaiting approval and waits a bounded
+// time for the workflow to react.
+func (s *TransfersService) DecideTransfer(
+	ctx context.Context,
+	referenceID uuid.UUID,
+	signalName string,
+	request temporalworkflows.TransferApprovalRequest,
+) (*server.TransferStatus, error) {
+	state, err := s.queryTransferState(ctx, referenceID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if state.Status != constants.TransferStateAWAITINGAPPROVAL {
+		return nil, fmt.Errorf("%w: %s", ErrTransferNotAwaitingApproval, state.Status)
+	}
+
+	if signalName == temporalworkflows.ApproveTransferSignal {
+		initiator, err := s.transferInitiator(ctx, referenceID.String())
+		if err != nil {
+			return nil, err
+		}
+
+		if initiator == request.Approver {
+			return nil, ErrTransferSelfApproval
+		}
+	}
+
+	return s.signalTransfer(ctx, referenceID, signalName, request, ErrTransferNotAwaitingApproval)
+}
+
+// signalTransfer sends the signal to the Transfer workflow and waits a bounded time for it to finish, a workflow
+// that is already closed is reported with closedErr.
+func (s *TransfersService) signalTransfer(
+	ctx context.Context,
+	referenceID uuid.UUID,
+	signalName string,
+	payload interface{},
+	closedErr error,
+) (*server.TransferStatus, error) {
+	workflowID := referenceID.String()
+
+	err := s.temporalClient.SignalWorkflow(ctx, workflowID, "", signalName, payload)
+	if err != nil {
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return nil, closedErr
+		}
+
+		return nil, err
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, transferSignalWaitTimeout)
+	defer cancel()
+
+	waitErr := s.temporalClient.GetWorkflow(waitCtx, workflowID, "").Get(waitCtx, nil)
+	if waitErr != nil && waitCtx.Err() != nil {
+		log.Warn().Str("workflow_id", workflowID).Str("signal", signalName).Msg("transfer did not finish after the signal")
+	}
+
+	return s.GetTransferStatus(ctx, referenceID)
+}
+
+func (s *TransfersService) executeTransferWorkflow(
+	ctx context.Context,
+	reqBody *server.V1RunTransferWorkflowJSONRequestBody,
+) (client.WorkflowRun, error) {
 	params := temporalworkflows.TransferParams(reqBody.Data)
 	workflowReferenceID := reqBody.Data.ReferenceId.String()
 
 	ctx = context.WithValue(ctx, constants.ContextKeyWorkflowReferenceId.String(), workflowReferenceID)
 
-	we, err := s.temporalClient.ExecuteWorkflow(
+	return s.temporalClient.ExecuteWorkflow(
 		ctx,
 		client.StartWorkflowOptions{
 			ID:        workflowReferenceID,
 			TaskQueue: s.transfersTaskQueueName,
+			Memo:      map[string]interface{}{transferInitiatorMemo: audit.ActorFrom(ctx)},
 		},
 		temporalworkflows.Transfer,
 		&params,
 	)
+}
+
+// transferInitiator returns the actor who started the Transfer workflow, empty for a transfer started before the
+// initiator was recorded.
+func (s *TransfersService) transferInitiator(ctx context.Context, workflowID string) (string, error) {
+	response, err := s.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
 	if err != nil {
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return "", ErrTransferNotFound
+		}
+
+		return "", err
+	}
+
+	payload, ok := response.GetWorkflowExecutionInfo().GetMemo().GetFields()[transferInitiatorMemo]
+	if !ok {
+		return "", nil
+	}
+
+	var initiator string
+
+	err = converter.GetDefaultDataConverter().FromPayload(payload, &initiator)
+	if err != nil {
+		return "", err
+	}
+
+	return initiator, nil
+}
+
+// queryTransferState asks the running Transfer workflow for its TransferState.
+func (s *TransfersService) queryTransferState(ctx context.Context, workflowID string) (*temporalworkflows.TransferState, error) {
+	response, err := s.temporalClient.QueryWorkflow(ctx, workflowID, "", temporalworkflows.TransferStateQuery)
+	if err != nil {
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return nil, ErrTransferNotFound
+		}
+
 		return nil, err
 	}
 
-	var result activities.TransferResult
+	var state temporalworkflows.TransferState
 
-	err = we.Get(ctx, &result)
+	err = response.Get(&state)
 	if err != nil {
 		return nil, err
 	}
 
-	return &result, nil
+	return &state, nil
+}
+
+func (s *TransfersService) findTransaction(ctx context.Context, referenceID uuid.UUID) (*server.Transaction, error) {
+	transaction, err := s.transactionsRepo.GetByReferenceID(ctx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transaction == nil {
+		return nil, nil
+	}
+
+	return toTransactionResponse(transaction), nil
+}
+
+func toTransactionResponse(transaction *transactions.Transaction) *server.Transaction {
+	currencyCode := transaction.CurrencyCode.String()
+	status := transaction.Status.String()
+	transactionType := transaction.TransactionType.String()
+	metadata := map[string]interface{}(transaction.Metadata)
+
+	return &server.Transaction{
+		AccountId:       &transaction.AccountID,
+		Amount:          &transaction.Amount,
+		CreatedAt:       &transaction.CreatedAt,
+		CurrencyCode:    &currencyCode,
+		Id:              &transaction.ID,
+		Metadata:        &metadata,
+		ReferenceId:     &transaction.ReferenceID,
+		Status:          &status,
+		TransactionType: &transactionType,
+		UpdatedAt:       &transaction.UpdatedAt,
+		UserId:          transaction.UserID,
+
+		OriginalTransactionId: transaction.OriginalTransactionID,
+	}
+}
+
+func toTransferApproval(threshold *int, decision *approvals.Decision) *server.TransferApproval {
+	if threshold == nil {
+		return nil
+	}
+
+	approval := &server.TransferApproval{Threshold: *threshold}
+
+	if decision != nil {
+		decisionCode := server.ApprovalDecision(decision.Decision.String())
+		decidedAt := decision.DecidedAt
+
+		approval.Decision = &decisionCode
+		approval.Approver = &decision.Approver
+		approval.Reason = decision.Reason
+		approval.DecidedAt = &decidedAt
+	}
+
+	return approval
+}
+
+func isScheduled(executeAt *time.Time) bool {
+	return executeAt != nil && executeAt.After(time.Now())
 }
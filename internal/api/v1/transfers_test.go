@@ -0,0 +1,265 @@
+//go:build tests_unit
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	commonpb "go.temporal.io/api/common/v1"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/api/workflow/v1"
+	"go.temporal.io/api/workflowservice/v1"
+	"go.temporal.io/sdk/client"
+	"go.temporal.io/sdk/converter"
+	temporalMocks "go.temporal.io/sdk/mocks"
+
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/approvals"
+	approvalMocks "ulascansenturk/service/internal/approvals/mocks"
+	"ulascansenturk/service/internal/audit"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/temporalworkflows"
+	"ulascansenturk/service/internal/transactions"
+	transactionMocks "ulascansenturk/service/internal/transactions/mocks"
+)
+
+func TestTransfers(t *testing.T) {
+	referenceID := uuid.New()
+	workflowID := referenceID.String()
+
+	newAPI := func(t *testing.T) (*API, *temporalMocks.Client, *transactionMocks.MockRepository, *approvalMocks.MockService) {
+		temporalClient := temporalMocks.NewClient(t)
+		transactionsRepo := transactionMocks.NewMockRepository(t)
+		approvalService := approvalMocks.NewMockService(t)
+
+		transfersService := NewTransfersService("transfers", temporalClient, transactionsRepo, approvalService)
+
+		return &API{transfersService: transfersService}, temporalClient, transactionsRepo, approvalService
+	}
+
+	describe := func(status enums.WorkflowExecutionStatus) *workflowservice.DescribeWorkflowExecutionResponse {
+		return &workflowservice.DescribeWorkflowExecutionResponse{
+			WorkflowExecutionInfo: &workflow.WorkflowExecutionInfo{Status: status},
+		}
+	}
+
+	getTransfer := func(api *API) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		api.V1GetTransfer(w, httptest.NewRequest(http.MethodGet, "/v1/transfers/"+workflowID, nil), referenceID)
+
+		return w
+	}
+
+	awaitingApproval := func(t *testing.T, temporalClient *temporalMocks.Client, initiator string) {
+		payload, err := converter.GetDefaultDataConverter().ToPayload(initiator)
+		require.NoError(t, err)
+
+		response := describe(enums.WORKFLOW_EXECUTION_STATUS_RUNNING)
+		response.WorkflowExecutionInfo.Memo = &commonpb.Memo{Fields: map[string]*commonpb.Payload{"initiator": payload}}
+
+		state := temporalMocks.NewEncodedValue(t)
+		state.On("Get", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*temporalworkflows.TransferState) = temporalworkflows.TransferState{Status: constants.TransferStateAWAITINGAPPROVAL}
+		}).Return(nil)
+
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").Return(response, nil)
+		temporalClient.On("QueryWorkflow", mock.Anything, workflowID, "", temporalworkflows.TransferStateQuery).Return(state, nil)
+	}
+
+	approveTransfer := func(api *API, actor string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodPost, "/v1/transfers/"+workflowID+"/approve",
+			bytes.NewReader([]byte(`{"data":{"reason":"checked with the customer"}}`)))
+		r.Header.Set("Content-Type", "application/json")
+
+		if actor != "" {
+			r = r.WithContext(audit.WithActor(r.Context(), actor))
+		}
+
+		w := httptest.NewRecorder()
+		api.V1ApproveTransfer(w, r, referenceID)
+
+		return w
+	}
+
+	decodeStatus := func(t *testing.T, w *httptest.ResponseRecorder) server.TransferStatus {
+		var body server.TransferStatusResponseBody
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+
+		return body.Data
+	}
+
+	t.Run("Async transfer is accepted with its reference and status endpoint", func(t *testing.T) {
+		api, temporalClient, _, _ := newAPI(t)
+
+		workflowRun := temporalMocks.NewWorkflowRun(t)
+		workflowRun.On("GetID").Return(workflowID)
+
+		temporalClient.On("ExecuteWorkflow", mock.Anything, mock.MatchedBy(func(options client.StartWorkflowOptions) bool {
+			return options.ID == workflowID && options.TaskQueue == "transfers" && options.Memo["initiator"] == "jane.doe"
+		}), mock.Anything, mock.Anything).Return(workflowRun, nil).Once()
+
+		body, err := json.Marshal(server.TransferWorkflowRequestBody{Data: server.TransferWorkflowParams{
+			Amount:               1000,
+			ReferenceId:          referenceID,
+			SourceAccountID:      uuid.New(),
+			DestinationAccountID: uuid.New(),
+		}})
+		require.NoError(t, err)
+
+		r := httptest.NewRequest(http.MethodPost, "/v1/transfers?async=true", bytes.NewReader(body))
+		r.Header.Set("Content-Type", "application/json")
+		r = r.WithContext(audit.WithActor(r.Context(), "jane.doe"))
+
+		async := true
+		w := httptest.NewRecorder()
+		api.V1RunTransferWorkflow(w, r, server.V1RunTransferWorkflowParams{Async: &async})
+
+		require.Equal(t, http.StatusAccepted, w.Code)
+		assert.Equal(t, "/v1/transfers/"+workflowID, w.Header().Get("Location"))
+
+		var accepted server.TransferAcceptedResponseBody
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &accepted))
+		assert.Equal(t, server.TransferAccepted{
+			ReferenceId: referenceID,
+			WorkflowId:  workflowID,
+			Status:      server.TransferStatusPENDING,
+		}, accepted.Data)
+	})
+
+	t.Run("Running transfer is PENDING", func(t *testing.T) {
+		api, temporalClient, _, _ := newAPI(t)
+
+		state := temporalMocks.NewEncodedValue(t)
+		state.On("Get", mock.Anything).Run(func(args mock.Arguments) {
+			*args.Get(0).(*temporalworkflows.TransferState) = temporalworkflows.TransferState{Status: constants.TransferStatePROCESSING}
+		}).Return(nil)
+
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").
+			Return(describe(enums.WORKFLOW_EXECUTION_STATUS_RUNNING), nil)
+		temporalClient.On("QueryWorkflow", mock.Anything, workflowID, "", temporalworkflows.TransferStateQuery).Return(state, nil)
+
+		w := getTransfer(api)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		status := decodeStatus(t, w)
+		assert.Equal(t, server.TransferStatusPENDING, status.Status)
+		assert.Nil(t, status.SourceTransaction)
+	})
+
+	t.Run("Completed transfer is SUCCESS with its transactions", func(t *testing.T) {
+		api, temporalClient, transactionsRepo, approvalService := newAPI(t)
+
+		transferParams := temporalworkflows.TransferParams{ReferenceId: referenceID}
+		sourceTransaction := &transactions.Transaction{
+			ID:              uuid.New(),
+			ReferenceID:     transferParams.SourceTransactionReferenceID(),
+			Amount:          1000,
+			Status:          constants.TransactionStatusSUCCESS,
+			TransactionType: constants.TransactionTypeOUTBOUND,
+		}
+		destinationTransaction := &transactions.Transaction{
+			ID:              uuid.New(),
+			ReferenceID:     transferParams.DestinationTransactionReferenceID(),
+			Amount:          1000,
+			Status:          constants.TransactionStatusSUCCESS,
+			TransactionType: constants.TransactionTypeINBOUND,
+		}
+
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").
+			Return(describe(enums.WORKFLOW_EXECUTION_STATUS_COMPLETED), nil)
+		approvalService.On("GetDecision", mock.Anything, referenceID).Return(nil, approvals.ErrDecisionNotFound)
+		transactionsRepo.On("GetByReferenceID", mock.Anything, transferParams.SourceTransactionReferenceID()).Return(sourceTransaction, nil)
+		transactionsRepo.On("GetByReferenceID", mock.Anything, transferParams.DestinationTransactionReferenceID()).Return(destinationTransaction, nil)
+		transactionsRepo.On("GetByReferenceID", mock.Anything, transferParams.FeeTransactionReferenceID()).Return(nil, nil)
+		transactionsRepo.On("GetByReferenceID", mock.Anything, transferParams.IncomingFeeTransactionReferenceID()).Return(nil, nil)
+
+		w := getTransfer(api)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		status := decodeStatus(t, w)
+		assert.Equal(t, server.TransferStatusSUCCESS, status.Status)
+		require.NotNil(t, status.SourceTransaction)
+		assert.Equal(t, sourceTransaction.ID, *status.SourceTransaction.Id)
+		require.NotNil(t, status.DestinationTransaction)
+		assert.Equal(t, destinationTransaction.ID, *status.DestinationTransaction.Id)
+		assert.Nil(t, status.FeeTransaction)
+	})
+
+	t.Run("Failed transfer is FAILURE with the failure reason", func(t *testing.T) {
+		api, temporalClient, transactionsRepo, approvalService := newAPI(t)
+
+		workflowRun := temporalMocks.NewWorkflowRun(t)
+		workflowRun.On("Get", mock.Anything, nil).Return(errors.New("posting failed"))
+
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").
+			Return(describe(enums.WORKFLOW_EXECUTION_STATUS_FAILED), nil)
+		temporalClient.On("GetWorkflow", mock.Anything, workflowID, "").Return(workflowRun)
+		approvalService.On("GetDecision", mock.Anything, referenceID).Return(nil, approvals.ErrDecisionNotFound)
+		transactionsRepo.On("GetByReferenceID", mock.Anything, mock.Anything).Return(nil, nil)
+
+		w := getTransfer(api)
+
+		require.Equal(t, http.StatusOK, w.Code)
+
+		status := decodeStatus(t, w)
+		assert.Equal(t, server.TransferStatusFAILURE, status.Status)
+		require.NotNil(t, status.FailureReason)
+		assert.Equal(t, "posting failed", *status.FailureReason)
+	})
+
+	t.Run("Unknown transfer is not found", func(t *testing.T) {
+		api, temporalClient, _, _ := newAPI(t)
+
+		temporalClient.On("DescribeWorkflowExecution", mock.Anything, workflowID, "").
+			Return(nil, serviceerror.NewNotFound("workflow not found"))
+
+		w := getTransfer(api)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("Transfer is approved by the actor of the request", func(t *testing.T) {
+		api, temporalClient, _, _ := newAPI(t)
+		awaitingApproval(t, temporalClient, "jane.doe")
+
+		workflowRun := temporalMocks.NewWorkflowRun(t)
+		workflowRun.On("Get", mock.Anything, nil).Return(nil)
+
+		temporalClient.On("SignalWorkflow", mock.Anything, workflowID, "", temporalworkflows.ApproveTransferSignal,
+			temporalworkflows.TransferApprovalRequest{Approver: "john.doe", Reason: "checked with the customer"}).Return(nil).Once()
+		temporalClient.On("GetWorkflow", mock.Anything, workflowID, "").Return(workflowRun)
+
+		w := approveTransfer(api, "john.doe")
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("Transfer can't be approved by its initiator", func(t *testing.T) {
+		api, temporalClient, _, _ := newAPI(t)
+		awaitingApproval(t, temporalClient, "jane.doe")
+
+		w := approveTransfer(api, "jane.doe")
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		temporalClient.AssertNotCalled(t, "SignalWorkflow", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Transfer can't be approved without an actor", func(t *testing.T) {
+		api, _, _, _ := newAPI(t)
+
+		assert.Equal(t, http.StatusBadRequest, approveTransfer(api, "").Code)
+		assert.Equal(t, http.StatusBadRequest, approveTransfer(api, audit.AnonymousActor).Code)
+	})
+}
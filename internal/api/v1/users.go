@@ -64,13 +64,18 @@ func (a *UsersService) createUserWithBankAccount(ctx context.Context, reqBody *s
 		return nil, userCreateErr
 	}
 
-	bankAccount, bankAccountErr := a.accountService.CreateAccount(ctx, &accounts.Account{
+	account := &accounts.Account{
 		ID:       uuid.New(),
 		UserID:   user.ID,
 		Balance:  *reqBody.Data.Balance,
 		Status:   constants.AccountStatusACTIVE,
 		Currency: reqBody.Data.CurrencyCode,
-	})
+	}
+	if reqBody.Data.AccountProduct != nil {
+		account.Product = *reqBody.Data.AccountProduct
+	}
+
+	bankAccount, bankAccountErr := a.accountService.CreateAccount(ctx, account)
 	if bankAccountErr != nil {
 		tx.Rollback()
 		return nil, bankAccountErr
@@ -80,13 +85,18 @@ func (a *UsersService) createUserWithBankAccount(ctx context.Context, reqBody *s
 		return nil, err
 	}
 
+	availableBalance := bankAccount.AvailableBalance()
+
 	return &server.UserResult{
 		BankAccount: &server.Account{
-			Balance:  int32(bankAccount.Balance),
-			Currency: bankAccount.Currency,
-			Id:       &bankAccount.ID,
-			Status:   bankAccount.Status.String(),
-			UserId:   user.ID,
+			Balance:          int32(bankAccount.Balance),
+			Currency:         bankAccount.Currency,
+			Id:               &bankAccount.ID,
+			Status:           bankAccount.Status.String(),
+			Product:          &bankAccount.Product,
+			UserId:           user.ID,
+			HeldBalance:      &bankAccount.HeldBalance,
+			AvailableBalance: &availableBalance,
 		},
 		User: &server.User{
 			Email:     types.Email(user.Email),
@@ -0,0 +1,301 @@
+package v1
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/go-chi/render"
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"gorm.io/datatypes"
+	"net/http"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/webhooks"
+)
+
+const defaultWebhookDeliveriesLimit = 50
+
+type WebhooksService struct {
+	service    webhooks.Service
+	dispatcher *webhooks.Dispatcher
+}
+
+func NewWebhooksService(service webhooks.Service, dispatcher *webhooks.Dispatcher) *WebhooksService {
+	return &WebhooksService{service: service, dispatcher: dispatcher}
+}
+
+func (a *API) V1CreateWebhookSubscription(w http.ResponseWriter, r *http.Request) {
+	reqBody := new(server.V1CreateWebhookSubscriptionJSONRequestBody)
+
+	err := render.Bind(r, reqBody)
+	if err != nil {
+		server.BadRequestError(err, w, r)
+
+		return
+	}
+
+	result, err := a.webhooksService.CreateSubscription(r.Context(), reqBody.Data)
+	if err != nil {
+		renderWebhookError(err, "webhook subscription creation failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusCreated)
+	render.JSON(w, r, server.WebhookSubscriptionResponseBody{Data: *result})
+}
+
+func (a *API) V1ListWebhookSubscriptions(w http.ResponseWriter, r *http.Request, params server.V1ListWebhookSubscriptionsParams) {
+	result, err := a.webhooksService.ListSubscriptions(r.Context(), params.AccountId)
+	if err != nil {
+		log.Err(err).Msg("webhook subscriptions listing failed")
+
+		server.ProcessingError(err, w, r)
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.WebhookSubscriptionListResponseBody{Data: result})
+}
+
+func (a *API) V1GetWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID) {
+	result, err := a.webhooksService.GetSubscription(r.Context(), webhookID)
+	if err != nil {
+		renderWebhookError(err, "webhook subscription lookup failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.WebhookSubscriptionResponseBody{Data: *result})
+}
+
+func (a *API) V1DeleteWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID) {
+	err := a.webhooksService.DeleteSubscription(r.Context(), webhookID)
+	if err != nil {
+		renderWebhookError(err, "webhook subscription deletion failed", w, r)
+
+		return
+	}
+
+	render.NoContent(w, r)
+}
+
+func (a *API) V1EnableWebhookSubscription(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID) {
+	result, err := a.webhooksService.EnableSubscription(r.Context(), webhookID)
+	if err != nil {
+		renderWebhookError(err, "webhook subscription enabling failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.WebhookSubscriptionResponseBody{Data: *result})
+}
+
+func (a *API) V1ListWebhookDeliveries(
+	w http.ResponseWriter,
+	r *http.Request,
+	webhookID server.WebhookID,
+	params server.V1ListWebhookDeliveriesParams,
+) {
+	result, err := a.webhooksService.ListDeliveries(r.Context(), webhookID, params)
+	if err != nil {
+		renderWebhookError(err, "webhook deliveries listing failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusOK)
+	render.JSON(w, r, server.WebhookDeliveryListResponseBody{Data: result})
+}
+
+func (a *API) V1RedeliverWebhookDelivery(w http.ResponseWriter, r *http.Request, webhookID server.WebhookID, deliveryID server.WebhookDeliveryID) {
+	result, err := a.webhooksService.Redeliver(r.Context(), webhookID, deliveryID)
+	if err != nil {
+		renderWebhookError(err, "webhook redelivery failed", w, r)
+
+		return
+	}
+
+	render.Status(r, http.StatusAccepted)
+	render.JSON(w, r, server.WebhookDeliveryResponseBody{Data: *result})
+}
+
+func renderWebhookError(err error, msg string, w http.ResponseWriter, r *http.Request) {
+	switch {
+	case errors.Is(err, webhooks.ErrInvalidSubscription):
+		server.BadRequestError(err, w, r)
+	case errors.Is(err, accounts.ErrAccountNotFound),
+		errors.Is(err, webhooks.ErrSubscriptionNotFound),
+		errors.Is(err, webhooks.ErrDeliveryNotFound):
+		server.NotFoundError(err, w, r)
+	case errors.Is(err, webhooks.ErrSubscriptionDisabled), errors.Is(err, webhooks.ErrDeliveryInProgress):
+		server.ConflictError(err, w, r)
+	default:
+		log.Err(err).Msg(msg)
+
+		server.ProcessingError(err, w, r)
+	}
+}
+
+// CreateSubscription returns the created subscription with its secret, the only response that carries it.
+func (s *WebhooksService) CreateSubscription(
+	ctx context.Context,
+	params server.CreateWebhookSubscriptionParams,
+) (*server.WebhookSubscription, error) {
+	eventTypes := make([]constants.OutboxEventType, 0, len(params.EventTypes))
+	for _, eventType := range params.EventTypes {
+		eventTypes = append(eventTypes, constants.OutboxEventType(eventType))
+	}
+
+	subscription, err := s.service.CreateSubscription(ctx, &webhooks.Subscription{
+		AccountID:   params.AccountId,
+		URL:         params.Url,
+		EventTypes:  datatypes.NewJSONSlice(eventTypes),
+		Description: params.Description,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := toWebhookSubscriptionResponse(subscription)
+	result.Secret = &subscription.Secret
+
+	return result, nil
+}
+
+func (s *WebhooksService) ListSubscriptions(ctx context.Context, accountID uuid.UUID) ([]server.WebhookSubscription, error) {
+	subscriptions, err := s.service.ListSubscriptions(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]server.WebhookSubscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		result = append(result, *toWebhookSubscriptionResponse(subscription))
+	}
+
+	return result, nil
+}
+
+func (s *WebhooksService) GetSubscription(ctx context.Context, id uuid.UUID) (*server.WebhookSubscription, error) {
+	subscription, err := s.service.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWebhookSubscriptionResponse(subscription), nil
+}
+
+func (s *WebhooksService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return s.service.DeleteSubscription(ctx, id)
+}
+
+func (s *WebhooksService) EnableSubscription(ctx context.Context, id uuid.UUID) (*server.WebhookSubscription, error) {
+	subscription, err := s.service.EnableSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toWebhookSubscriptionResponse(subscription), nil
+}
+
+func (s *WebhooksService) ListDeliveries(
+	ctx context.Context,
+	webhookID uuid.UUID,
+	params server.V1ListWebhookDeliveriesParams,
+) ([]server.WebhookDelivery, error) {
+	if _, err := s.service.GetSubscription(ctx, webhookID); err != nil {
+		return nil, err
+	}
+
+	limit := defaultWebhookDeliveriesLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	deliveries, err := s.service.ListDeliveries(ctx, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]server.WebhookDelivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		response, err := toWebhookDeliveryResponse(delivery)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, *response)
+	}
+
+	return result, nil
+}
+
+// Redeliver puts the delivery back to PENDING and starts a new WebhookDelivery workflow for it.
+func (s *WebhooksService) Redeliver(ctx context.Context, webhookID, deliveryID uuid.UUID) (*server.WebhookDelivery, error) {
+	delivery, err := s.service.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.SubscriptionID != webhookID {
+		return nil, webhooks.ErrDeliveryNotFound
+	}
+
+	delivery, err = s.service.Redeliver(ctx, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.dispatcher.StartDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return toWebhookDeliveryResponse(delivery)
+}
+
+func toWebhookSubscriptionResponse(subscription *webhooks.Subscription) *server.WebhookSubscription {
+	eventTypes := make([]server.WebhookEventType, 0, len(subscription.EventTypes))
+	for _, eventType := range subscription.EventTypes {
+		eventTypes = append(eventTypes, server.WebhookEventType(eventType.String()))
+	}
+
+	return &server.WebhookSubscription{
+		Id:                  subscription.ID,
+		AccountId:           subscription.AccountID,
+		Url:                 subscription.URL,
+		EventTypes:          eventTypes,
+		Description:         subscription.Description,
+		Status:              server.WebhookSubscriptionStatus(subscription.Status.String()),
+		ConsecutiveFailures: subscription.ConsecutiveFailures,
+		DisabledAt:          subscription.DisabledAt,
+		CreatedAt:           subscription.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryResponse(delivery *webhooks.Delivery) (*server.WebhookDelivery, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(delivery.Payload, &payload); err != nil {
+		return nil, err
+	}
+
+	return &server.WebhookDelivery{
+		Id:                 delivery.ID,
+		WebhookId:          delivery.SubscriptionID,
+		EventId:            delivery.EventID,
+		EventType:          server.WebhookEventType(delivery.EventType.String()),
+		Payload:            payload,
+		Status:             server.WebhookDeliveryStatus(delivery.Status.String()),
+		Attempts:           delivery.Attempts,
+		Redeliveries:       delivery.Redeliveries,
+		LastResponseStatus: delivery.LastResponseStatus,
+		LastError:          delivery.LastError,
+		LastAttemptedAt:    delivery.LastAttemptedAt,
+		DeliveredAt:        delivery.DeliveredAt,
+		CreatedAt:          delivery.CreatedAt,
+	}, nil
+}
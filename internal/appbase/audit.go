@@ -0,0 +1,35 @@
+package appbase
+
+import (
+	"net/http"
+	"ulascansenturk/service/internal/audit"
+	"ulascansenturk/service/internal/constants"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+)
+
+// AuditActorHeader names who a request is made for, the changes it makes are recorded for them.
+const AuditActorHeader = "X-Actor-ID"
+
+// WithAuditContext records the changes made by a request for the actor of its X-Actor-ID header, anonymous without
+// one, and with the ID of the request as their source. It must run after the RequestID middleware.
+func WithAuditContext() func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			actor := r.Header.Get(AuditActorHeader)
+			if actor == "" {
+				actor = audit.AnonymousActor
+			}
+
+			ctx := audit.WithActor(r.Context(), actor)
+			ctx = audit.WithSource(ctx, audit.Source{
+				Type: constants.AuditSourceTypeHTTPREQUEST,
+				ID:   chiMiddleware.GetReqID(r.Context()),
+			})
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
@@ -0,0 +1,48 @@
+//go:build tests_unit
+
+package appbase
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"ulascansenturk/service/internal/audit"
+	"ulascansenturk/service/internal/constants"
+
+	chiMiddleware "github.com/go-chi/chi/v5/middleware"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAuditContext(t *testing.T) {
+	serve := func(r *http.Request) (string, audit.Source) {
+		var actor string
+		var source audit.Source
+
+		handler := chiMiddleware.RequestID(WithAuditContext()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			actor = audit.ActorFrom(r.Context())
+			source = audit.SourceFrom(r.Context())
+		})))
+
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+
+		return actor, source
+	}
+
+	t.Run("Changes are recorded for the actor and the ID of the request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodPut, "/v1/users/1/notification-preferences", nil)
+		r.Header.Set(AuditActorHeader, "admin@example.com")
+		r.Header.Set(chiMiddleware.RequestIDHeader, "request-1")
+
+		actor, source := serve(r)
+
+		require.Equal(t, "admin@example.com", actor)
+		require.Equal(t, audit.Source{Type: constants.AuditSourceTypeHTTPREQUEST, ID: "request-1"}, source)
+	})
+
+	t.Run("Request without an actor is anonymous", func(t *testing.T) {
+		actor, source := serve(httptest.NewRequest(http.MethodPost, "/v1/users", nil))
+
+		require.Equal(t, audit.AnonymousActor, actor)
+		require.NotEmpty(t, source.ID)
+	})
+}
@@ -13,6 +13,11 @@ import (
 	"github.com/rs/zerolog"
 )
 
+const (
+	MutexBackendRedis    = "redis"
+	MutexBackendPostgres = "postgres"
+)
+
 // Config represents common configuration for all applications.
 type Config struct {
 	// application config
@@ -39,6 +44,57 @@ type Config struct {
 	RedisEndpoint           string `env:"REDIS_ENDPOINT" env-required:"true"`
 	RedisPort               string `env:"REDIS_PORT" env-required:"true"`
 	TransferMutexTTLSeconds int    `env:"TRANSFER_MUTEX_TTL_SECONDS" env-default:"300"`
+
+	// TransferMutexBackend is where the account locks are kept, MutexBackendRedis or MutexBackendPostgres
+	TransferMutexBackend string `env:"TRANSFER_MUTEX_BACKEND" env-default:"redis"`
+
+	// Idempotency-Key, responses are replayed for IdempotencyKeyTTLSeconds after the first request
+	IdempotencyKeyTTLSeconds int `env:"IDEMPOTENCY_KEY_TTL_SECONDS" env-default:"86400"`
+
+	// Fees
+	FeeCollectionAccounts map[string]string `env:"FEE_COLLECTION_ACCOUNTS" env-default:"TRY:5f1c3a6e-8d2b-4c7e-9a1f-000000000949,USD:5f1c3a6e-8d2b-4c7e-9a1f-000000000840,EUR:5f1c3a6e-8d2b-4c7e-9a1f-000000000978"`
+
+	// FX, FXRatesFile takes precedence over FXRates and FXSpreadBps when set
+	FXRatesFile string             `env:"FX_RATES_FILE"`
+	FXRates     map[string]float64 `env:"FX_RATES" env-default:"USD/EUR:0.92,USD/TRY:34.1,EUR/TRY:37.05"`
+	FXSpreadBps int                `env:"FX_SPREAD_BPS" env-default:"50"`
+
+	// Approvals, transfers above the threshold of their currency wait for an approval
+	TransferApprovalThresholds map[string]int `env:"TRANSFER_APPROVAL_THRESHOLDS" env-default:"TRY:50000000,USD:1000000,EUR:1000000"`
+
+	// Holds expire after the TTL given when they are placed, bounded by HoldMaxTTLSeconds
+	HoldDefaultTTLSeconds int `env:"HOLD_DEFAULT_TTL_SECONDS" env-default:"604800"`
+	HoldMaxTTLSeconds     int `env:"HOLD_MAX_TTL_SECONDS" env-default:"2592000"`
+
+	// Outbox, the relay publishes the events with the OutboxPublisher: log, file or http
+	OutboxRelayEnabled            bool   `env:"OUTBOX_RELAY_ENABLED" env-default:"true"`
+	OutboxPublisher               string `env:"OUTBOX_PUBLISHER" env-default:"log"`
+	OutboxFilePath                string `env:"OUTBOX_FILE_PATH" env-default:"outbox-events.jsonl"`
+	OutboxHTTPURL                 string `env:"OUTBOX_HTTP_URL"`
+	OutboxRelayBatchSize          int    `env:"OUTBOX_RELAY_BATCH_SIZE" env-default:"100"`
+	OutboxRelayPollIntervalMillis int    `env:"OUTBOX_RELAY_POLL_INTERVAL_MS" env-default:"1000"`
+
+	// Webhooks, the relay hands the events to the subscriptions too, a subscription is disabled once
+	// WebhookMaxConsecutiveFailures deliveries failed in a row
+	WebhookMaxConsecutiveFailures int `env:"WEBHOOK_MAX_CONSECUTIVE_FAILURES" env-default:"5"`
+	WebhookRequestTimeoutSeconds  int `env:"WEBHOOK_REQUEST_TIMEOUT_SECONDS" env-default:"10"`
+
+	// Notifications, emails are sent through the SMTP server, without SMTP_USERNAME they are sent unauthenticated
+	// as a local mail catcher expects. NotificationLowBalanceThreshold is in minor units, it applies to the users
+	// who never set their preferences
+	SMTPHost                        string `env:"SMTP_HOST" env-default:"localhost"`
+	SMTPPort                        int    `env:"SMTP_PORT" env-default:"1025"`
+	SMTPUsername                    string `env:"SMTP_USERNAME"`
+	SMTPPassword                    string `env:"SMTP_PASSWORD"`
+	NotificationEmailFrom           string `env:"NOTIFICATION_EMAIL_FROM" env-default:"notifications@service.local"`
+	NotificationLowBalanceThreshold int    `env:"NOTIFICATION_LOW_BALANCE_THRESHOLD" env-default:"10000"`
+
+	// Audit log, every change made through GORM is recorded with who made it, except the changes to the
+	// AuditIgnoredTables. The values of the AuditRedactedColumns are recorded as [REDACTED], password_hash
+	// and secret always are.
+	AuditLogEnabled      bool     `env:"AUDIT_LOG_ENABLED" env-default:"true"`
+	AuditIgnoredTables   []string `env:"AUDIT_IGNORED_TABLES" env-default:"outbox_events"`
+	AuditRedactedColumns []string `env:"AUDIT_REDACTED_COLUMNS" env-default:"password_hash,secret"`
 }
 
 func (c *Config) HTTPTimeoutDuration() time.Duration {
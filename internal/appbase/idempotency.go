@@ -0,0 +1,278 @@
+package appbase
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+	"ulascansenturk/service/internal/api/server"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyKeyPrefix     = "idempotency:"
+	idempotencyMaxKeyLength  = 255
+)
+
+var (
+	ErrIdempotencyKeyInvalid    = errors.New("idempotency key must be at most 255 characters")
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request")
+	ErrIdempotencyKeyInProgress = errors.New("a request with this Idempotency-Key is still in progress")
+)
+
+// IdempotentResponse is what is kept for an Idempotency-Key, Completed is false while the first request with the
+// key is still being served.
+type IdempotentResponse struct {
+	Fingerprint string      `json:"fingerprint"`
+	Completed   bool        `json:"completed"`
+	StatusCode  int         `json:"status_code,omitempty"`
+	Header      http.Header `json:"header,omitempty"`
+	Body        []byte      `json:"body,omitempty"`
+}
+
+type IdempotencyStore interface {
+	// Reserve claims the key for a request with the given fingerprint. It returns false and what is already kept
+	// for the key when the key was claimed before.
+	Reserve(ctx context.Context, key, fingerprint string) (bool, *IdempotentResponse, error)
+	// Complete keeps the response of the request that reserved the key.
+	Complete(ctx context.Context, key string, response *IdempotentResponse) error
+	// Release forgets the key so the request can be retried with it.
+	Release(ctx context.Context, key string) error
+}
+
+type RedisIdempotencyStore struct {
+	client *redis.Client
+
+	// reservationTTL bounds how long a key stays claimed by a request that never completes, it should outlast
+	// the HTTP timeout.
+	reservationTTL time.Duration
+	responseTTL    time.Duration
+}
+
+// NewRedisIdempotencyStore creates a new RedisIdempotencyStore
+func NewRedisIdempotencyStore(redisService *RedisService, reservationTTL, responseTTL time.Duration) *RedisIdempotencyStore {
+	return &RedisIdempotencyStore{
+		client:         redisService.Client,
+		reservationTTL: reservationTTL,
+		responseTTL:    responseTTL,
+	}
+}
+
+func (s *RedisIdempotencyStore) Reserve(ctx context.Context, key, fingerprint string) (bool, *IdempotentResponse, error) {
+	reservation, err := json.Marshal(&IdempotentResponse{Fingerprint: fingerprint})
+	if err != nil {
+		return false, nil, err
+	}
+
+	reserved, err := s.client.SetNX(ctx, idempotencyKeyPrefix+key, reservation, s.reservationTTL).Result()
+	if err != nil {
+		return false, nil, err
+	}
+
+	if reserved {
+		return true, nil, nil
+	}
+
+	stored, err := s.client.Get(ctx, idempotencyKeyPrefix+key).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			// the reservation expired in between, the caller can retry
+			return false, nil, ErrIdempotencyKeyInProgress
+		}
+
+		return false, nil, err
+	}
+
+	response := new(IdempotentResponse)
+
+	err = json.Unmarshal(stored, response)
+	if err != nil {
+		return false, nil, err
+	}
+
+	return false, response, nil
+}
+
+func (s *RedisIdempotencyStore) Complete(ctx context.Context, key string, response *IdempotentResponse) error {
+	stored, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	return s.client.Set(ctx, idempotencyKeyPrefix+key, stored, s.responseTTL).Err()
+}
+
+func (s *RedisIdempotencyStore) Release(ctx context.Context, key string) error {
+	return s.client.Del(ctx, idempotencyKeyPrefix+key).Err()
+}
+
+// WithIdempotency replays the response of a mutating request sent again with the same Idempotency-Key header.
+// The key is bound to a fingerprint of the method, the URI and the body of the first request, reusing it for
+// another request is a conflict. Server errors aren't kept so the request can be retried with the same key.
+func WithIdempotency(store IdempotencyStore) func(next http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" || !isMutatingMethod(r.Method) {
+				next.ServeHTTP(w, r)
+
+				return
+			}
+
+			if len(key) > idempotencyMaxKeyLength {
+				server.BadRequestError(ErrIdempotencyKeyInvalid, w, r)
+
+				return
+			}
+
+			body, err := io.ReadAll(r.Body)
+			if err != nil {
+				server.BadRequestError(err, w, r)
+
+				return
+			}
+
+			r.Body = io.NopCloser(bytes.NewReader(body))
+
+			fingerprint := requestFingerprint(r, body)
+
+			reserved, stored, err := store.Reserve(r.Context(), key, fingerprint)
+			if err != nil {
+				if errors.Is(err, ErrIdempotencyKeyInProgress) {
+					server.ConflictError(err, w, r)
+
+					return
+				}
+
+				log.Ctx(r.Context()).Err(err).Msg("idempotency key reservation failed")
+
+				server.ProcessingError(err, w, r)
+
+				return
+			}
+
+			if !reserved {
+				switch {
+				case stored.Fingerprint != fingerprint:
+					server.ConflictError(ErrIdempotencyKeyReused, w, r)
+				case !stored.Completed:
+					server.ConflictError(ErrIdempotencyKeyInProgress, w, r)
+				default:
+					replayResponse(w, stored)
+				}
+
+				return
+			}
+
+			recorder := &idempotencyRecorder{ResponseWriter: w, status: http.StatusOK}
+
+			defer func() {
+				// the request context may be cancelled or timed out by now
+				ctx := context.WithoutCancel(r.Context())
+
+				if recovered := recover(); recovered != nil {
+					releaseIdempotencyKey(ctx, store, key)
+
+					panic(recovered)
+				}
+
+				if recorder.status >= http.StatusInternalServerError {
+					releaseIdempotencyKey(ctx, store, key)
+
+					return
+				}
+
+				completeErr := store.Complete(ctx, key, &IdempotentResponse{
+					Fingerprint: fingerprint,
+					Completed:   true,
+					StatusCode:  recorder.status,
+					Header:      recorder.Header().Clone(),
+					Body:        recorder.body.Bytes(),
+				})
+				if completeErr != nil {
+					log.Ctx(ctx).Err(completeErr).Str("idempotency_key", key).Msg("idempotent response saving failed")
+				}
+			}()
+
+			next.ServeHTTP(recorder, r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
+func requestFingerprint(r *http.Request, body []byte) string {
+	hash := sha256.New()
+
+	hash.Write([]byte(r.Method))
+	hash.Write([]byte{0})
+	hash.Write([]byte(r.URL.RequestURI()))
+	hash.Write([]byte{0})
+	hash.Write(body)
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func replayResponse(w http.ResponseWriter, stored *IdempotentResponse) {
+	for name, values := range stored.Header {
+		w.Header()[name] = values
+	}
+
+	w.Header().Set(IdempotentReplayedHeader, "true")
+	w.WriteHeader(stored.StatusCode)
+
+	_, _ = w.Write(stored.Body)
+}
+
+func releaseIdempotencyKey(ctx context.Context, store IdempotencyStore, key string) {
+	err := store.Release(ctx, key)
+	if err != nil {
+		log.Ctx(ctx).Err(err).Str("idempotency_key", key).Msg("idempotency key release failed")
+	}
+}
+
+// idempotencyRecorder keeps the whole response on top of writing it, unlike responseRecorder it keeps every write.
+type idempotencyRecorder struct {
+	http.ResponseWriter
+
+	body        bytes.Buffer
+	status      int
+	wroteHeader bool
+}
+
+func (r *idempotencyRecorder) WriteHeader(statusCode int) {
+	if r.wroteHeader {
+		return
+	}
+
+	r.status = statusCode
+	r.wroteHeader = true
+
+	r.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (r *idempotencyRecorder) Write(body []byte) (int, error) {
+	r.wroteHeader = true
+
+	r.body.Write(body)
+
+	return r.ResponseWriter.Write(body)
+}
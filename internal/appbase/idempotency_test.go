@@ -0,0 +1,148 @@
+//go:build tests_unit
+
+package appbase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type memoryIdempotencyStore struct {
+	mu        sync.Mutex
+	responses map[string]*IdempotentResponse
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{responses: map[string]*IdempotentResponse{}}
+}
+
+func (s *memoryIdempotencyStore) Reserve(_ context.Context, key, fingerprint string) (bool, *IdempotentResponse, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.responses[key]; ok {
+		return false, stored, nil
+	}
+
+	s.responses[key] = &IdempotentResponse{Fingerprint: fingerprint}
+
+	return true, nil, nil
+}
+
+func (s *memoryIdempotencyStore) Complete(_ context.Context, key string, response *IdempotentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.responses[key] = response
+
+	return nil
+}
+
+func (s *memoryIdempotencyStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.responses, key)
+
+	return nil
+}
+
+func countingHandler(calls *int, status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls++
+
+		body, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", ApplicationJSONType)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(`{"data":` + string(body) + `}`))
+	})
+}
+
+func sendIdempotent(handler http.Handler, method, target, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestWithIdempotency_ReplaysTheStoredResponse(t *testing.T) {
+	calls := 0
+	handler := WithIdempotency(newMemoryIdempotencyStore())(countingHandler(&calls, http.StatusCreated))
+
+	first := sendIdempotent(handler, http.MethodPost, "/v1/users", "key-1", `{"email":"a@b.c"}`)
+	replay := sendIdempotent(handler, http.MethodPost, "/v1/users", "key-1", `{"email":"a@b.c"}`)
+
+	require.Equal(t, 1, calls)
+	require.Equal(t, http.StatusCreated, replay.Code)
+	require.Equal(t, first.Body.String(), replay.Body.String())
+	require.Equal(t, "true", replay.Header().Get(IdempotentReplayedHeader))
+	require.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestWithIdempotency_RejectsAKeyReusedForAnotherRequest(t *testing.T) {
+	calls := 0
+	handler := WithIdempotency(newMemoryIdempotencyStore())(countingHandler(&calls, http.StatusOK))
+
+	sendIdempotent(handler, http.MethodPost, "/v1/transfers", "key-1", `{"amount":100}`)
+
+	differentBody := sendIdempotent(handler, http.MethodPost, "/v1/transfers", "key-1", `{"amount":200}`)
+	differentPath := sendIdempotent(handler, http.MethodPost, "/v1/users", "key-1", `{"amount":100}`)
+
+	require.Equal(t, 1, calls)
+	require.Equal(t, http.StatusConflict, differentBody.Code)
+	require.Equal(t, http.StatusConflict, differentPath.Code)
+}
+
+func TestWithIdempotency_RejectsAKeyStillInProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+
+	reserved, _, err := store.Reserve(context.Background(), "key-1", requestFingerprint(
+		httptest.NewRequest(http.MethodPost, "/v1/transfers", nil), []byte(`{}`),
+	))
+	require.NoError(t, err)
+	require.True(t, reserved)
+
+	calls := 0
+	handler := WithIdempotency(store)(countingHandler(&calls, http.StatusOK))
+
+	rec := sendIdempotent(handler, http.MethodPost, "/v1/transfers", "key-1", `{}`)
+
+	require.Equal(t, 0, calls)
+	require.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestWithIdempotency_ServerErrorsCanBeRetried(t *testing.T) {
+	calls := 0
+	handler := WithIdempotency(newMemoryIdempotencyStore())(countingHandler(&calls, http.StatusInternalServerError))
+
+	sendIdempotent(handler, http.MethodPost, "/v1/transfers", "key-1", `{}`)
+	rec := sendIdempotent(handler, http.MethodPost, "/v1/transfers", "key-1", `{}`)
+
+	require.Equal(t, 2, calls)
+	require.Empty(t, rec.Header().Get(IdempotentReplayedHeader))
+}
+
+func TestWithIdempotency_IgnoresRequestsWithoutAKeyAndReads(t *testing.T) {
+	calls := 0
+	handler := WithIdempotency(newMemoryIdempotencyStore())(countingHandler(&calls, http.StatusOK))
+
+	sendIdempotent(handler, http.MethodPost, "/v1/transfers", "", `{}`)
+	sendIdempotent(handler, http.MethodPost, "/v1/transfers", "", `{}`)
+	sendIdempotent(handler, http.MethodGet, "/v1/transfers/1", "key-1", "")
+	sendIdempotent(handler, http.MethodGet, "/v1/transfers/1", "key-1", "")
+
+	require.Equal(t, 4, calls)
+}
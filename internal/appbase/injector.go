@@ -12,12 +12,24 @@ import (
 	"ulascansenturk/service/internal/accounts"
 	"ulascansenturk/service/internal/api"
 	v1 "ulascansenturk/service/internal/api/v1"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/audit"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/fees"
+	"ulascansenturk/service/internal/fx"
 	"ulascansenturk/service/internal/helpers"
+	"ulascansenturk/service/internal/holds"
+	"ulascansenturk/service/internal/ledger"
+	"ulascansenturk/service/internal/limits"
+	"ulascansenturk/service/internal/notifications"
+	"ulascansenturk/service/internal/outbox"
+	"ulascansenturk/service/internal/standingorders"
 	"ulascansenturk/service/internal/temporalworkflows"
 	"ulascansenturk/service/internal/temporalworkflows/activities"
 	"ulascansenturk/service/internal/temporalworkflows/temporalutils"
 	"ulascansenturk/service/internal/transactions"
 	"ulascansenturk/service/internal/users"
+	"ulascansenturk/service/internal/webhooks"
 	"ulascansenturk/service/openapi"
 
 	"github.com/go-chi/chi/v5"
@@ -92,7 +104,9 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 
 		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
 
-		return NewRouterMux(serviceName, logger, openAPIValidation, cfg.HTTPTimeoutDuration(), gormDB), nil
+		idempotencyStore := do.MustInvoke[*RedisIdempotencyStore](i)
+
+		return NewRouterMux(serviceName, logger, openAPIValidation, cfg.HTTPTimeoutDuration(), gormDB, idempotencyStore), nil
 	})
 	do.ProvideNamed(injector, InjectorDatabase, func(i *do.Injector) (*gorm.DB, error) {
 		credentials := Credentials{
@@ -119,6 +133,13 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 			return nil, err
 		}
 
+		if cfg.AuditLogEnabled {
+			err = database.GormDB.Use(audit.NewPlugin(&helpers.RealTimeProvider{}, cfg.AuditIgnoredTables, cfg.AuditRedactedColumns))
+			if err != nil {
+				return nil, err
+			}
+		}
+
 		return database.GormDB, nil
 	})
 
@@ -142,28 +163,106 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 		return users.NewSQLRepository(gormDB), nil
 	})
 
+	do.Provide(injector, func(i *do.Injector) (*fees.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+		return fees.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*standingorders.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+		return standingorders.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*approvals.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return approvals.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*holds.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return holds.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*limits.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return limits.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*ledger.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return ledger.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*outbox.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return outbox.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*webhooks.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return webhooks.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*audit.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return audit.NewSQLRepository(gormDB), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*notifications.SQLRepository, error) {
+		gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+		return notifications.NewSQLRepository(gormDB), nil
+	})
+
 	//Services
 
+	do.Provide(injector, func(i *do.Injector) (*outbox.OutboxServiceImpl, error) {
+		outboxRepo := do.MustInvoke[*outbox.SQLRepository](i)
+
+		return outbox.NewOutboxService(outboxRepo), nil
+	})
+
 	do.Provide(injector, func(i *do.Injector) (*users.UserServiceImpl, error) {
 		userRepo := do.MustInvoke[*users.SQLRepository](i)
 
-		return users.NewUserService(userRepo), nil
+		outboxService := do.MustInvoke[*outbox.OutboxServiceImpl](i)
+
+		return users.NewUserService(userRepo, outboxService), nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*transactions.TransactionServiceImpl, error) {
 		transactionsRepo := do.MustInvoke[*transactions.SQLRepository](i)
 
+		outboxService := do.MustInvoke[*outbox.OutboxServiceImpl](i)
+
 		validation := do.MustInvoke[*validator.Validate](i)
 
-		return transactions.NewTransactionService(transactionsRepo, validation), nil
+		return transactions.NewTransactionService(transactionsRepo, outboxService, validation), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*ledger.LedgerServiceImpl, error) {
+		ledgerRepo := do.MustInvoke[*ledger.SQLRepository](i)
+
+		return ledger.NewLedgerService(ledgerRepo), nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*accounts.AccountServiceImpl, error) {
 		accountRepo := do.MustInvoke[*accounts.SQLRepository](i)
 
+		ledgerService := do.MustInvoke[*ledger.LedgerServiceImpl](i)
+
+		outboxService := do.MustInvoke[*outbox.OutboxServiceImpl](i)
+
 		validation := do.MustInvoke[*validator.Validate](i)
 
-		return accounts.NewUserBankAccountService(accountRepo, validation), nil
+		return accounts.NewUserBankAccountService(accountRepo, ledgerService, outboxService, validation), nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*transactions.FinderOrCreatorService, error) {
@@ -177,6 +276,148 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 
 	})
 
+	do.Provide(injector, func(i *do.Injector) (*transactions.PostingService, error) {
+		transactionsRepo := do.MustInvoke[*transactions.SQLRepository](i)
+
+		accountsRepo := do.MustInvoke[*accounts.SQLRepository](i)
+
+		holdsRepo := do.MustInvoke[*holds.SQLRepository](i)
+
+		ledgerService := do.MustInvoke[*ledger.LedgerServiceImpl](i)
+
+		outboxService := do.MustInvoke[*outbox.OutboxServiceImpl](i)
+
+		return transactions.NewPostingService(transactionsRepo, accountsRepo, holdsRepo, ledgerService, outboxService), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*transactions.BalanceHistoryService, error) {
+		transactionsRepo := do.MustInvoke[*transactions.SQLRepository](i)
+
+		accountsRepo := do.MustInvoke[*accounts.SQLRepository](i)
+
+		return transactions.NewBalanceHistoryService(transactionsRepo, accountsRepo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*fees.FeeServiceImpl, error) {
+		feesRepo := do.MustInvoke[*fees.SQLRepository](i)
+
+		accountsService := do.MustInvoke[*accounts.AccountServiceImpl](i)
+
+		return fees.NewFeeService(feesRepo, accountsService), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*fx.StaticRateProvider, error) {
+		if cfg.FXRatesFile != "" {
+			return fx.NewFileRateProvider(cfg.FXRatesFile)
+		}
+
+		return fx.NewStaticRateProvider(cfg.FXRates, cfg.FXSpreadBps)
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*fx.FXServiceImpl, error) {
+		accountsService := do.MustInvoke[*accounts.AccountServiceImpl](i)
+
+		rateProvider := do.MustInvoke[*fx.StaticRateProvider](i)
+
+		return fx.NewFXService(accountsService, rateProvider), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*approvals.ApprovalServiceImpl, error) {
+		approvalsRepo := do.MustInvoke[*approvals.SQLRepository](i)
+
+		thresholds, err := approvals.NewThresholds(cfg.TransferApprovalThresholds)
+		if err != nil {
+			return nil, err
+		}
+
+		return approvals.NewApprovalService(approvalsRepo, thresholds), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*limits.LimitServiceImpl, error) {
+		limitsRepo := do.MustInvoke[*limits.SQLRepository](i)
+
+		accountsService := do.MustInvoke[*accounts.AccountServiceImpl](i)
+
+		return limits.NewLimitService(limitsRepo, accountsService), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*holds.HoldServiceImpl, error) {
+		holdsRepo := do.MustInvoke[*holds.SQLRepository](i)
+
+		accountsRepo := do.MustInvoke[*accounts.SQLRepository](i)
+
+		return holds.NewHoldService(holdsRepo, accountsRepo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*webhooks.WebhookServiceImpl, error) {
+		webhooksRepo := do.MustInvoke[*webhooks.SQLRepository](i)
+
+		accountsRepo := do.MustInvoke[*accounts.SQLRepository](i)
+
+		return webhooks.NewWebhookService(webhooksRepo, accountsRepo, cfg.WebhookMaxConsecutiveFailures), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*webhooks.Dispatcher, error) {
+		temporalService := do.MustInvoke[*TemporalService](i)
+
+		webhookService := do.MustInvoke[*webhooks.WebhookServiceImpl](i)
+
+		return webhooks.NewDispatcher(webhookService, temporalService.Client, cfg.TemporalTransfersTaskQueueName), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*audit.AuditServiceImpl, error) {
+		auditRepo := do.MustInvoke[*audit.SQLRepository](i)
+
+		return audit.NewAuditService(auditRepo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*notifications.NotificationServiceImpl, error) {
+		notificationsRepo := do.MustInvoke[*notifications.SQLRepository](i)
+
+		accountsRepo := do.MustInvoke[*accounts.SQLRepository](i)
+
+		usersRepo := do.MustInvoke[*users.SQLRepository](i)
+
+		logger := do.MustInvoke[*zerolog.Logger](i)
+
+		templates, err := notifications.LoadTemplates()
+		if err != nil {
+			return nil, err
+		}
+
+		channels := map[constants.NotificationChannel]notifications.Channel{
+			constants.NotificationChannelEMAIL: notifications.NewSMTPChannel(
+				cfg.SMTPHost,
+				cfg.SMTPPort,
+				cfg.SMTPUsername,
+				cfg.SMTPPassword,
+				cfg.NotificationEmailFrom,
+			),
+			constants.NotificationChannelSMS: notifications.NewLogSMSChannel(logger),
+		}
+
+		return notifications.NewNotificationService(
+			notificationsRepo,
+			accountsRepo,
+			usersRepo,
+			templates,
+			channels,
+			cfg.NotificationLowBalanceThreshold,
+		), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*standingorders.StandingOrderServiceImpl, error) {
+		standingOrdersRepo := do.MustInvoke[*standingorders.SQLRepository](i)
+
+		return standingorders.NewStandingOrderService(standingOrdersRepo), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*standingorders.LogNotifier, error) {
+		logger := do.MustInvoke[*zerolog.Logger](i)
+
+		return standingorders.NewLogNotifier(logger), nil
+	})
+
 	do.Provide(injector, func(i *do.Injector) (*v1.API, error) {
 
 		temporalService := do.MustInvoke[*TemporalService](i)
@@ -185,10 +426,55 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 
 		accountsServ := do.MustInvoke[*accounts.AccountServiceImpl](i)
 
-		transferService := v1.NewTransfersService(cfg.TemporalTransfersTaskQueueName, temporalService.Client)
+		transactionsRepo := do.MustInvoke[*transactions.SQLRepository](i)
+
+		approvalService := do.MustInvoke[*approvals.ApprovalServiceImpl](i)
+
+		transferService := v1.NewTransfersService(cfg.TemporalTransfersTaskQueueName, temporalService.Client, transactionsRepo, approvalService)
 
 		userService := v1.NewUsersService(userServ, accountsServ)
-		return v1.NewAPI(transferService, userService), nil
+
+		feesService := v1.NewFeesService(do.MustInvoke[*fees.FeeServiceImpl](i))
+
+		standingOrdersService := v1.NewStandingOrdersService(
+			do.MustInvoke[*standingorders.StandingOrderServiceImpl](i),
+			temporalService.Client,
+			cfg.TemporalTransfersTaskQueueName,
+		)
+
+		limitsService := v1.NewLimitsService(do.MustInvoke[*limits.LimitServiceImpl](i))
+
+		holdsService := v1.NewHoldsService(
+			do.MustInvoke[*holds.HoldServiceImpl](i),
+			temporalService.Client,
+			cfg.TemporalTransfersTaskQueueName,
+			time.Duration(cfg.HoldDefaultTTLSeconds)*time.Second,
+			time.Duration(cfg.HoldMaxTTLSeconds)*time.Second,
+		)
+
+		balancesService := v1.NewBalancesService(do.MustInvoke[*transactions.BalanceHistoryService](i))
+
+		webhooksService := v1.NewWebhooksService(
+			do.MustInvoke[*webhooks.WebhookServiceImpl](i),
+			do.MustInvoke[*webhooks.Dispatcher](i),
+		)
+
+		notificationsService := v1.NewNotificationsService(do.MustInvoke[*notifications.NotificationServiceImpl](i))
+
+		auditService := v1.NewAuditService(do.MustInvoke[*audit.AuditServiceImpl](i))
+
+		return v1.NewAPI(
+			transferService,
+			userService,
+			feesService,
+			standingOrdersService,
+			limitsService,
+			holdsService,
+			balancesService,
+			webhooksService,
+			notificationsService,
+			auditService,
+		), nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*api.Routes, error) {
@@ -208,15 +494,76 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 		return NewRedisService(cfg.RedisEndpoint, cfg.RedisPort), nil
 	})
 
+	do.Provide(injector, func(i *do.Injector) (*RedisIdempotencyStore, error) {
+		redisService := do.MustInvoke[*RedisService](i)
+
+		return NewRedisIdempotencyStore(
+			redisService,
+			cfg.HTTPTimeoutDuration(),
+			time.Duration(cfg.IdempotencyKeyTTLSeconds)*time.Second,
+		), nil
+	})
+
 	// API clients
 	do.ProvideNamed(injector, InjectorDefaultHTTPClient, func(i *do.Injector) (http.Client, error) {
 		return http.Client{}, nil
 	})
 
-	do.Provide(injector, func(i *do.Injector) (*activities.Mutex, error) {
-		locker := do.MustInvoke[*redsync.Redsync](i)
+	do.Provide(injector, func(i *do.Injector) (outbox.Publisher, error) {
+		switch cfg.OutboxPublisher {
+		case outbox.PublisherLog:
+			logger := do.MustInvoke[*zerolog.Logger](i)
+
+			return outbox.NewLogPublisher(logger), nil
+		case outbox.PublisherFile:
+			return outbox.NewFilePublisher(cfg.OutboxFilePath)
+		case outbox.PublisherHTTP:
+			if cfg.OutboxHTTPURL == "" {
+				return nil, fmt.Errorf("OUTBOX_HTTP_URL is required by the %s outbox publisher", outbox.PublisherHTTP)
+			}
+
+			return outbox.NewHTTPPublisher(&http.Client{Timeout: cfg.HTTPTimeoutDuration()}, cfg.OutboxHTTPURL), nil
+		default:
+			return nil, fmt.Errorf("unknown outbox publisher %q", cfg.OutboxPublisher)
+		}
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*outbox.Relay, error) {
+		outboxRepo := do.MustInvoke[*outbox.SQLRepository](i)
+
+		publisher := do.MustInvoke[outbox.Publisher](i)
+
+		dispatcher := do.MustInvoke[*webhooks.Dispatcher](i)
+
+		return outbox.NewRelay(
+			outboxRepo,
+			outbox.NewFanOutPublisher(publisher, dispatcher),
+			cfg.OutboxRelayBatchSize,
+			time.Duration(cfg.OutboxRelayPollIntervalMillis)*time.Millisecond,
+		), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (activities.Mutex, error) {
+		switch cfg.TransferMutexBackend {
+		case MutexBackendRedis:
+			locker := do.MustInvoke[*redsync.Redsync](i)
+
+			gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+			return activities.NewRedisMutex(locker, activities.NewFencingTokens(gormDB)), nil
+		case MutexBackendPostgres:
+			gormDB := do.MustInvokeNamed[*gorm.DB](injector, InjectorDatabase)
+
+			return activities.NewPostgresMutex(gormDB), nil
+		default:
+			return nil, fmt.Errorf("unknown transfer mutex backend %q", cfg.TransferMutexBackend)
+		}
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.MutexOperations, error) {
+		mutex := do.MustInvoke[activities.Mutex](i)
 
-		return activities.NewMutex(locker), nil
+		return activities.NewMutexOperations(mutex), nil
 	})
 
 	do.Provide(injector, func(i *do.Injector) (*activities.TransactionOperations, error) {
@@ -225,9 +572,90 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 		transactionsService := do.MustInvoke[*transactions.TransactionServiceImpl](i)
 
 		accountsService := do.MustInvoke[*accounts.AccountServiceImpl](i)
+
+		postingService := do.MustInvoke[*transactions.PostingService](i)
 		timeProvider := &helpers.RealTimeProvider{}
 
-		return activities.NewTransactionOperations(finderOrCreatorService, transactionsService, accountsService, timeProvider), nil
+		feeCollectionAccounts, err := accounts.NewFeeCollectionAccounts(cfg.FeeCollectionAccounts)
+		if err != nil {
+			return nil, err
+		}
+
+		return activities.NewTransactionOperations(finderOrCreatorService, transactionsService, accountsService, postingService, feeCollectionAccounts, timeProvider), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.FeeOperations, error) {
+		feesService := do.MustInvoke[*fees.FeeServiceImpl](i)
+
+		return activities.NewFeeOperations(feesService), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.FXOperations, error) {
+		fxService := do.MustInvoke[*fx.FXServiceImpl](i)
+
+		return activities.NewFXOperations(fxService), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.ReversalOperations, error) {
+		finderOrCreatorService := do.MustInvoke[*transactions.FinderOrCreatorService](i)
+
+		transactionsService := do.MustInvoke[*transactions.TransactionServiceImpl](i)
+
+		postingService := do.MustInvoke[*transactions.PostingService](i)
+
+		return activities.NewReversalOperations(finderOrCreatorService, transactionsService, postingService, &helpers.RealTimeProvider{}), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.ApprovalOperations, error) {
+		approvalService := do.MustInvoke[*approvals.ApprovalServiceImpl](i)
+
+		return activities.NewApprovalOperations(approvalService), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.LimitOperations, error) {
+		limitService := do.MustInvoke[*limits.LimitServiceImpl](i)
+
+		return activities.NewLimitOperations(limitService), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.HoldOperations, error) {
+		holdService := do.MustInvoke[*holds.HoldServiceImpl](i)
+
+		finderOrCreatorService := do.MustInvoke[*transactions.FinderOrCreatorService](i)
+
+		transactionsService := do.MustInvoke[*transactions.TransactionServiceImpl](i)
+
+		postingService := do.MustInvoke[*transactions.PostingService](i)
+
+		return activities.NewHoldOperations(holdService, finderOrCreatorService, transactionsService, postingService, &helpers.RealTimeProvider{}), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.AccountEntityOperations, error) {
+		temporalService := do.MustInvoke[*TemporalService](i)
+
+		return activities.NewAccountEntityOperations(temporalService.Client, cfg.TemporalTransfersTaskQueueName), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.StandingOrderOperations, error) {
+		standingOrdersService := do.MustInvoke[*standingorders.StandingOrderServiceImpl](i)
+
+		notifier := do.MustInvoke[*standingorders.LogNotifier](i)
+
+		return activities.NewStandingOrderOperations(standingOrdersService, notifier), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.WebhookOperations, error) {
+		webhookService := do.MustInvoke[*webhooks.WebhookServiceImpl](i)
+
+		sender := webhooks.NewSender(&http.Client{Timeout: time.Duration(cfg.WebhookRequestTimeoutSeconds) * time.Second})
+
+		return activities.NewWebhookOperations(webhookService, sender, &helpers.RealTimeProvider{}), nil
+	})
+
+	do.Provide(injector, func(i *do.Injector) (*activities.NotificationOperations, error) {
+		notificationService := do.MustInvoke[*notifications.NotificationServiceImpl](i)
+
+		return activities.NewNotificationOperations(notificationService), nil
 	})
 
 	do.ProvideNamed(injector, "transactions", func(i *do.Injector) (worker.Worker, error) {
@@ -239,11 +667,48 @@ func NewInjector(serviceName string, cfg *Config) *do.Injector {
 
 		transactionActivities := do.MustInvoke[*activities.TransactionOperations](i)
 
-		mutexActivity := do.MustInvoke[*activities.Mutex](i)
+		mutexActivity := do.MustInvoke[*activities.MutexOperations](i)
+
+		feeActivities := do.MustInvoke[*activities.FeeOperations](i)
+
+		fxActivities := do.MustInvoke[*activities.FXOperations](i)
+
+		approvalActivities := do.MustInvoke[*activities.ApprovalOperations](i)
+
+		reversalActivities := do.MustInvoke[*activities.ReversalOperations](i)
+
+		standingOrderActivities := do.MustInvoke[*activities.StandingOrderOperations](i)
+
+		limitActivities := do.MustInvoke[*activities.LimitOperations](i)
+
+		holdActivities := do.MustInvoke[*activities.HoldOperations](i)
+
+		accountEntityActivities := do.MustInvoke[*activities.AccountEntityOperations](i)
+
+		webhookActivities := do.MustInvoke[*activities.WebhookOperations](i)
+
+		notificationActivities := do.MustInvoke[*activities.NotificationOperations](i)
 
 		wrk.RegisterActivity(transactionActivities)
 		wrk.RegisterActivity(mutexActivity)
+		wrk.RegisterActivity(feeActivities)
+		wrk.RegisterActivity(fxActivities)
+		wrk.RegisterActivity(approvalActivities)
+		wrk.RegisterActivity(reversalActivities)
+		wrk.RegisterActivity(standingOrderActivities)
+		wrk.RegisterActivity(limitActivities)
+		wrk.RegisterActivity(holdActivities)
+		wrk.RegisterActivity(accountEntityActivities)
+		wrk.RegisterActivity(webhookActivities)
+		wrk.RegisterActivity(notificationActivities)
 		wrk.RegisterWorkflow(temporalworkflows.Transfer)
+		wrk.RegisterWorkflow(temporalworkflows.TransferBatch)
+		wrk.RegisterWorkflow(temporalworkflows.Reversal)
+		wrk.RegisterWorkflow(temporalworkflows.StandingOrderOccurrence)
+		wrk.RegisterWorkflow(temporalworkflows.Hold)
+		wrk.RegisterWorkflow(temporalworkflows.AccountEntity)
+		wrk.RegisterWorkflow(temporalworkflows.WebhookDelivery)
+		wrk.RegisterWorkflow(temporalworkflows.TransferNotifications)
 
 		return wrk, nil
 	})
@@ -17,9 +17,10 @@ import (
 
 const ApplicationJSONType = "application/json"
 
-func NewRouterMux(serviceName string, logger *zerolog.Logger, openAPIMiddleware *openapi.ValidationMiddleware, timeout time.Duration, db *gorm.DB) *chi.Mux {
+func NewRouterMux(serviceName string, logger *zerolog.Logger, openAPIMiddleware *openapi.ValidationMiddleware, timeout time.Duration, db *gorm.DB, idempotencyStore IdempotencyStore) *chi.Mux {
 	mux := chi.NewRouter()
 
+	mux.Use(chiMiddleware.RequestID)
 	mux.Use(chiMiddleware.Recoverer)
 	mux.Use(chiMiddleware.SetHeader("Content-Type", ApplicationJSONType))
 	mux.Use(WithLogger(*logger))
@@ -40,6 +41,10 @@ func NewRouterMux(serviceName string, logger *zerolog.Logger, openAPIMiddleware
 
 	mux.Use(openAPIMiddleware.Handler())
 
+	mux.Use(WithIdempotency(idempotencyStore))
+
+	mux.Use(WithAuditContext())
+
 	return mux
 }
 
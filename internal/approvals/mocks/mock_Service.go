@@ -0,0 +1,108 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	approvals "ulascansenturk/service/internal/approvals"
+
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockService is an autogenerated mock type for the Service type
+type MockService struct {
+	mock.Mock
+}
+
+// CheckApproval provides a mock function with given fields: ctx, currency, amount
+func (_m *MockService) CheckApproval(ctx context.Context, currency string, amount int) (*approvals.Requirement, error) {
+	ret := _m.Called(ctx, currency, amount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckApproval")
+	}
+
+	var r0 *approvals.Requirement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*approvals.Requirement, error)); ok {
+		return rf(ctx, currency, amount)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *approvals.Requirement); ok {
+		r0 = rf(ctx, currency, amount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*approvals.Requirement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, currency, amount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDecision provides a mock function with given fields: ctx, referenceID
+func (_m *MockService) GetDecision(ctx context.Context, referenceID uuid.UUID) (*approvals.Decision, error) {
+	ret := _m.Called(ctx, referenceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDecision")
+	}
+
+	var r0 *approvals.Decision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*approvals.Decision, error)); ok {
+		return rf(ctx, referenceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *approvals.Decision); ok {
+		r0 = rf(ctx, referenceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*approvals.Decision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, referenceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RecordDecision provides a mock function with given fields: ctx, decision
+func (_m *MockService) RecordDecision(ctx context.Context, decision *approvals.Decision) error {
+	ret := _m.Called(ctx, decision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordDecision")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *approvals.Decision) error); ok {
+		r0 = rf(ctx, decision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewMockService creates a new instance of MockService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockService {
+	mock := &MockService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
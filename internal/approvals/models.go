@@ -0,0 +1,35 @@
+package approvals
+
+import (
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// SystemApprover is recorded as the approver of the decisions taken without a person, e.g. on timeout.
+const SystemApprover = "system"
+
+// Decision is the approval decision taken on a high-value transfer, a transfer has at most one.
+type Decision struct {
+	ID          uuid.UUID                  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ReferenceID uuid.UUID                  `gorm:"type:uuid;not null"`
+	Decision    constants.ApprovalDecision `gorm:"type:varchar(20);not null"`
+	Approver    string                     `gorm:"type:varchar(255);not null"`
+	Reason      *string                    `gorm:"type:text"`
+	Amount      int                        `gorm:"type:bigint;not null"`
+	Currency    string                     `gorm:"type:varchar(3);not null"`
+	Threshold   int                        `gorm:"type:bigint;not null"`
+	DecidedAt   time.Time                  `gorm:"type:timestamp with time zone;not null"`
+	CreatedAt   time.Time                  `gorm:"type:timestamp with time zone;not null;default:CURRENT_TIMESTAMP"`
+}
+
+func (Decision) TableName() string {
+	return "transfer_approvals"
+}
+
+// Requirement tells whether a transfer needs an approval, Threshold is the amount it exceeds in its Currency.
+type Requirement struct {
+	Required  bool
+	Currency  string
+	Threshold int
+}
@@ -0,0 +1,42 @@
+package approvals
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	Create(ctx context.Context, decision *Decision) error
+	GetByReferenceID(ctx context.Context, referenceID uuid.UUID) (*Decision, error)
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// Create stores the decision, a decision already stored for the same transfer is kept as is.
+func (r *SQLRepository) Create(ctx context.Context, decision *Decision) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "reference_id"}},
+		DoNothing: true,
+	}).Create(decision).Error
+}
+
+func (r *SQLRepository) GetByReferenceID(ctx context.Context, referenceID uuid.UUID) (*Decision, error) {
+	var decision Decision
+	if err := r.db.WithContext(ctx).First(&decision, "reference_id = ?", referenceID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &decision, nil
+}
@@ -0,0 +1,46 @@
+package approvals
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+)
+
+var ErrDecisionNotFound = errors.New("approval decision not found")
+
+type Service interface {
+	CheckApproval(ctx context.Context, currency string, amount int) (*Requirement, error)
+	RecordDecision(ctx context.Context, decision *Decision) error
+	GetDecision(ctx context.Context, referenceID uuid.UUID) (*Decision, error)
+}
+
+type ApprovalServiceImpl struct {
+	repo       Repository
+	thresholds Thresholds
+}
+
+func NewApprovalService(repo Repository, thresholds Thresholds) *ApprovalServiceImpl {
+	return &ApprovalServiceImpl{repo: repo, thresholds: thresholds}
+}
+
+// CheckApproval tells whether a transfer of the amount needs an approval, based on the threshold of its currency.
+func (s *ApprovalServiceImpl) CheckApproval(_ context.Context, currency string, amount int) (*Requirement, error) {
+	return s.thresholds.RequirementFor(currency, amount), nil
+}
+
+func (s *ApprovalServiceImpl) RecordDecision(ctx context.Context, decision *Decision) error {
+	return s.repo.Create(ctx, decision)
+}
+
+func (s *ApprovalServiceImpl) GetDecision(ctx context.Context, referenceID uuid.UUID) (*Decision, error) {
+	decision, err := s.repo.GetByReferenceID(ctx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if decision == nil {
+		return nil, ErrDecisionNotFound
+	}
+
+	return decision, nil
+}
@@ -0,0 +1,33 @@
+package approvals
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Thresholds maps a currency code to the amount above which a transfer in that currency needs an approval.
+// Transfers in a currency without a threshold never need one.
+type Thresholds map[string]int
+
+func NewThresholds(thresholdsByCurrency map[string]int) (Thresholds, error) {
+	thresholds := make(Thresholds, len(thresholdsByCurrency))
+
+	for currency, threshold := range thresholdsByCurrency {
+		if threshold <= 0 {
+			return nil, fmt.Errorf("invalid approval threshold for %s: %d", currency, threshold)
+		}
+
+		thresholds[strings.ToUpper(currency)] = threshold
+	}
+
+	return thresholds, nil
+}
+
+func (t Thresholds) RequirementFor(currency string, amount int) *Requirement {
+	threshold, ok := t[currency]
+	if !ok || amount <= threshold {
+		return &Requirement{Currency: currency}
+	}
+
+	return &Requirement{Required: true, Currency: currency, Threshold: threshold}
+}
@@ -0,0 +1,40 @@
+//go:build tests_unit
+
+package approvals_test
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+
+	"ulascansenturk/service/internal/approvals"
+)
+
+func TestThresholds_RequirementFor(t *testing.T) {
+	thresholds, err := approvals.NewThresholds(map[string]int{"usd": 1000000})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		currency string
+		amount   int
+		want     bool
+	}{
+		{name: "below the threshold", currency: "USD", amount: 999999, want: false},
+		{name: "at the threshold", currency: "USD", amount: 1000000, want: false},
+		{name: "above the threshold", currency: "USD", amount: 1000001, want: true},
+		{name: "currency without a threshold", currency: "EUR", amount: 100000000, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requirement := thresholds.RequirementFor(tt.currency, tt.amount)
+
+			assert.Equal(t, tt.want, requirement.Required)
+			assert.Equal(t, tt.currency, requirement.Currency)
+		})
+	}
+
+	_, err = approvals.NewThresholds(map[string]int{"USD": 0})
+	require.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package audit
+
+import (
+	"context"
+	"ulascansenturk/service/internal/constants"
+
+	"go.temporal.io/sdk/activity"
+)
+
+const (
+	// SystemActor is the actor of the changes made by the workflows and the background jobs.
+	SystemActor = "system"
+	// AnonymousActor is the actor of the HTTP requests that didn't name one.
+	AnonymousActor = "anonymous"
+)
+
+// WithActor sets who the changes made with the context are recorded for.
+func WithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, constants.ContextKeyAuditActor, actor)
+}
+
+// WithSource sets the HTTP request or the workflow the changes made with the context are recorded for.
+func WithSource(ctx context.Context, source Source) context.Context {
+	return context.WithValue(ctx, constants.ContextKeyAuditSource, source)
+}
+
+// ActorFrom returns the actor set on the context, the system for a change made by an activity or without one.
+func ActorFrom(ctx context.Context) string {
+	if actor, ok := ctx.Value(constants.ContextKeyAuditActor).(string); ok && actor != "" {
+		return actor
+	}
+
+	return SystemActor
+}
+
+// SourceFrom returns the source set on the context. A change made by an activity comes from the workflow that ran
+// it, any other change from the system.
+func SourceFrom(ctx context.Context) Source {
+	if source, ok := ctx.Value(constants.ContextKeyAuditSource).(Source); ok {
+		return source
+	}
+
+	if activity.IsActivity(ctx) {
+		return Source{Type: constants.AuditSourceTypeWORKFLOW, ID: activity.GetInfo(ctx).WorkflowExecution.ID}
+	}
+
+	return Source{Type: constants.AuditSourceTypeSYSTEM}
+}
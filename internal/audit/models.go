@@ -0,0 +1,57 @@
+package audit
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// Event records one change of a row of an audited table. EntityType is the table and EntityID the primary key of
+// the row, Before and After are the row as it was and as it became, Before is empty for a created row and After for
+// a deleted one. Changes holds the columns that changed, each with its before and after value. Events are never
+// changed once they are written, Sequence is the order they were written in.
+type Event struct {
+	ID         uuid.UUID                 `gorm:"type:uuid;primaryKey"`
+	Sequence   int64                     `gorm:"type:bigserial;autoIncrement;<-:false"`
+	Action     constants.AuditAction     `gorm:"type:varchar(20);not null"`
+	EntityType string                    `gorm:"type:varchar(100);not null"`
+	EntityID   string                    `gorm:"type:varchar(100);not null"`
+	Actor      string                    `gorm:"type:varchar(255);not null"`
+	SourceType constants.AuditSourceType `gorm:"type:varchar(20);not null"`
+	SourceID   *string                   `gorm:"type:varchar(255)"`
+	Before     datatypes.JSON            `gorm:"type:jsonb"`
+	After      datatypes.JSON            `gorm:"type:jsonb"`
+	Changes    datatypes.JSON            `gorm:"type:jsonb;not null"`
+	OccurredAt time.Time                 `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Event) TableName() string {
+	return "audit_events"
+}
+
+// Change is the value of a column before and after a change, nil for a column the row didn't have.
+type Change struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Source is what made a change: the HTTP request or the workflow it was made by, ID is the request or workflow ID.
+type Source struct {
+	Type constants.AuditSourceType
+	ID   string
+}
+
+// Filter narrows the listed events, the empty fields match every event. Cursor is the sequence of the last event
+// of the previous page, the events before it are listed.
+type Filter struct {
+	EntityType *string
+	EntityID   *string
+	Actor      *string
+	SourceID   *string
+	Action     *constants.AuditAction
+	From       *time.Time
+	To         *time.Time
+	Cursor     *int64
+	Limit      int
+}
@@ -0,0 +1,524 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/helpers"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	pluginName = "audit"
+
+	beforeRowsKey = "audit:before_rows"
+
+	defaultPrimaryKey = "id"
+
+	// redactedValue replaces the value of a redacted column in the audit log.
+	redactedValue = "[REDACTED]"
+)
+
+// alwaysRedactedColumns hold credentials, their values are never written to the audit log.
+var alwaysRedactedColumns = []string{"password_hash", "secret"}
+
+// Plugin records an Event for every row created, updated or deleted through GORM. The rows are read before and
+// after the change in the transaction of the change and the events are written in it too, so a change is never
+// committed without its events. A change whose events can't be written fails.
+//
+// Only the changes made through the create, update and delete methods are seen, the raw statements run with Exec
+// are not.
+type Plugin struct {
+	timeProvider    helpers.TimeProvider
+	ignoredTables   map[string]bool
+	redactedColumns map[string]bool
+}
+
+// NewPlugin creates the Plugin, the changes to the ignored tables are not recorded and the values of the redacted
+// columns, in any table, are recorded as [REDACTED]. The audit_events table is always ignored, the password_hash
+// and secret columns are always redacted.
+func NewPlugin(timeProvider helpers.TimeProvider, ignoredTables, redactedColumns []string) *Plugin {
+	return &Plugin{
+		timeProvider:    timeProvider,
+		ignoredTables:   nameSet(ignoredTables, Event{}.TableName()),
+		redactedColumns: nameSet(redactedColumns, alwaysRedactedColumns...),
+	}
+}
+
+func nameSet(names []string, always ...string) map[string]bool {
+	set := make(map[string]bool, len(names)+len(always))
+	for _, name := range append(always, names...) {
+		if name = strings.TrimSpace(name); name != "" {
+			set[name] = true
+		}
+	}
+
+	return set
+}
+
+func (p *Plugin) Name() string {
+	return pluginName
+}
+
+// Initialize registers the callbacks reading the rows around the statement, after the user hooks that may still
+// change the row and before the transaction of the statement is committed.
+func (p *Plugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+
+	if err := callback.Create().After("gorm:before_create").Before("gorm:create").
+		Register("audit:before_create", p.beforeCreate); err != nil {
+		return err
+	}
+
+	if err := callback.Create().After("gorm:after_create").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_create", p.after(constants.AuditActionCREATE)); err != nil {
+		return err
+	}
+
+	if err := callback.Update().After("gorm:before_update").Before("gorm:update").
+		Register("audit:before_update", p.before); err != nil {
+		return err
+	}
+
+	if err := callback.Update().After("gorm:after_update").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_update", p.after(constants.AuditActionUPDATE)); err != nil {
+		return err
+	}
+
+	if err := callback.Delete().After("gorm:before_delete").Before("gorm:delete").
+		Register("audit:before_delete", p.before); err != nil {
+		return err
+	}
+
+	return callback.Delete().After("gorm:after_delete").Before("gorm:commit_or_rollback_transaction").
+		Register("audit:after_delete", p.after(constants.AuditActionDELETE))
+}
+
+// beforeCreate only reads the rows an upsert may overwrite, a plain insert has no row before it.
+func (p *Plugin) beforeCreate(db *gorm.DB) {
+	if _, upsert := db.Statement.Clauses["ON CONFLICT"]; !upsert {
+		return
+	}
+
+	p.readBefore(db, modelKeyConditions(db))
+}
+
+// before reads the rows the update or delete is about to change: the rows matching its conditions and the row of
+// its model.
+func (p *Plugin) before(db *gorm.DB) {
+	var conditions []clause.Expression
+
+	if where, ok := db.Statement.Clauses["WHERE"]; ok && where.Expression != nil {
+		conditions = append(conditions, where.Expression)
+	}
+
+	if keyConditions := modelKeyConditions(db); keyConditions != nil {
+		conditions = append(conditions, keyConditions)
+	}
+
+	p.readBefore(db, conditions...)
+}
+
+func (p *Plugin) readBefore(db *gorm.DB, conditions ...clause.Expression) {
+	if db.Error != nil || !p.audited(db) || len(conditions) == 0 {
+		return
+	}
+
+	rows, err := p.read(db, conditions...)
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("reading %s before the change for the audit log: %w", db.Statement.Table, err))
+
+		return
+	}
+
+	db.InstanceSet(beforeRowsKey, rows)
+}
+
+// after reads the rows the statement changed again and writes an event for every row that changed.
+func (p *Plugin) after(action constants.AuditAction) func(db *gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.Error != nil || !p.audited(db) || db.Statement.RowsAffected == 0 {
+			return
+		}
+
+		var beforeRows []map[string]interface{}
+		if rows, ok := db.InstanceGet(beforeRowsKey); ok {
+			beforeRows = rows.([]map[string]interface{})
+		}
+
+		primaryKeys := primaryKeysOf(db)
+
+		keys := make([][]interface{}, 0, len(beforeRows))
+		for _, row := range beforeRows {
+			keys = append(keys, keyOf(row, primaryKeys))
+		}
+
+		if action == constants.AuditActionCREATE {
+			keys = append(keys, modelKeys(db)...)
+		}
+
+		var afterRows []map[string]interface{}
+
+		if action != constants.AuditActionDELETE && len(keys) > 0 {
+			rows, err := p.read(db, keysCondition(primaryKeys, keys))
+			if err != nil {
+				_ = db.AddError(fmt.Errorf("reading %s after the change for the audit log: %w", db.Statement.Table, err))
+
+				return
+			}
+
+			afterRows = rows
+		}
+
+		events, err := p.events(db, primaryKeys, beforeRows, afterRows)
+		if err != nil {
+			_ = db.AddError(fmt.Errorf("recording the audit log of %s: %w", db.Statement.Table, err))
+
+			return
+		}
+
+		if len(events) == 0 {
+			return
+		}
+
+		if err = db.Session(&gorm.Session{NewDB: true}).Create(events).Error; err != nil {
+			_ = db.AddError(fmt.Errorf("writing the audit log of %s: %w", db.Statement.Table, err))
+		}
+	}
+}
+
+func (p *Plugin) audited(db *gorm.DB) bool {
+	return db.Statement.Table != "" && !p.ignoredTables[db.Statement.Table]
+}
+
+// read reads the rows of the table of the statement matching the conditions in the transaction of the statement,
+// every value is normalized to the one it has in JSON.
+func (p *Plugin) read(db *gorm.DB, conditions ...clause.Expression) ([]map[string]interface{}, error) {
+	var rows []map[string]interface{}
+
+	err := db.Session(&gorm.Session{NewDB: true}).
+		Table(db.Statement.Table).
+		Clauses(clause.Where{Exprs: conditions}).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		normalizedRow, err := normalize(row)
+		if err != nil {
+			return nil, err
+		}
+
+		normalized = append(normalized, normalizedRow)
+	}
+
+	return normalized, nil
+}
+
+// events pairs the rows before and after the change by their primary key. A row only found after the change was
+// created, one only found before was deleted and one found in both was updated, unless none of its columns changed.
+func (p *Plugin) events(
+	db *gorm.DB,
+	primaryKeys []string,
+	beforeRows, afterRows []map[string]interface{},
+) ([]*Event, error) {
+	ctx := db.Statement.Context
+	actor := ActorFrom(ctx)
+	source := SourceFrom(ctx)
+	occurredAt := p.timeProvider.Now().UTC()
+
+	var sourceID *string
+	if source.ID != "" {
+		sourceID = &source.ID
+	}
+
+	var order []string
+
+	before := make(map[string]map[string]interface{}, len(beforeRows))
+	for _, row := range beforeRows {
+		entityID := entityIDOf(row, primaryKeys)
+		if _, seen := before[entityID]; !seen {
+			order = append(order, entityID)
+		}
+		before[entityID] = row
+	}
+
+	after := make(map[string]map[string]interface{}, len(afterRows))
+	for _, row := range afterRows {
+		entityID := entityIDOf(row, primaryKeys)
+		if _, seen := before[entityID]; !seen {
+			order = append(order, entityID)
+		}
+		after[entityID] = row
+	}
+
+	var events []*Event
+
+	for _, entityID := range order {
+		beforeRow, afterRow := before[entityID], after[entityID]
+
+		changes := Diff(beforeRow, afterRow)
+		if len(changes) == 0 {
+			continue
+		}
+
+		action := constants.AuditActionUPDATE
+		switch {
+		case beforeRow == nil:
+			action = constants.AuditActionCREATE
+		case afterRow == nil:
+			action = constants.AuditActionDELETE
+		}
+
+		event := &Event{
+			ID:         uuid.New(),
+			Action:     action,
+			EntityType: db.Statement.Table,
+			EntityID:   entityID,
+			Actor:      actor,
+			SourceType: source.Type,
+			SourceID:   sourceID,
+			OccurredAt: occurredAt,
+		}
+
+		var err error
+
+		// The changes are found before redacting, a changed redacted column is still recorded as changed.
+		if event.Before, err = marshalRow(p.redactRow(beforeRow)); err != nil {
+			return nil, err
+		}
+
+		if event.After, err = marshalRow(p.redactRow(afterRow)); err != nil {
+			return nil, err
+		}
+
+		if event.Changes, err = json.Marshal(p.redactChanges(changes)); err != nil {
+			return nil, err
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// redactRow returns a copy of the row with the value of every redacted column replaced, a null value is kept.
+func (p *Plugin) redactRow(row map[string]interface{}) map[string]interface{} {
+	if row == nil {
+		return nil
+	}
+
+	redacted := make(map[string]interface{}, len(row))
+	for column, value := range row {
+		redacted[column] = p.redactValue(column, value)
+	}
+
+	return redacted
+}
+
+func (p *Plugin) redactChanges(changes map[string]Change) map[string]Change {
+	redacted := make(map[string]Change, len(changes))
+	for column, change := range changes {
+		redacted[column] = Change{
+			Before: p.redactValue(column, change.Before),
+			After:  p.redactValue(column, change.After),
+		}
+	}
+
+	return redacted
+}
+
+func (p *Plugin) redactValue(column string, value interface{}) interface{} {
+	if value == nil || !p.redactedColumns[column] {
+		return value
+	}
+
+	return redactedValue
+}
+
+// Diff returns the columns whose value differs between the rows, a nil row has no columns.
+func Diff(before, after map[string]interface{}) map[string]Change {
+	columns := make(map[string]bool, len(before)+len(after))
+	for column := range before {
+		columns[column] = true
+	}
+	for column := range after {
+		columns[column] = true
+	}
+
+	changes := make(map[string]Change)
+
+	for column := range columns {
+		beforeValue, afterValue := before[column], after[column]
+		if reflect.DeepEqual(beforeValue, afterValue) {
+			continue
+		}
+
+		changes[column] = Change{Before: beforeValue, After: afterValue}
+	}
+
+	return changes
+}
+
+// primaryKeysOf returns the primary key columns of the model of the statement, id for a statement without one.
+func primaryKeysOf(db *gorm.DB) []string {
+	if db.Statement.Schema == nil || len(db.Statement.Schema.PrimaryFields) == 0 {
+		return []string{defaultPrimaryKey}
+	}
+
+	columns := make([]string, 0, len(db.Statement.Schema.PrimaryFields))
+	for _, field := range db.Statement.Schema.PrimaryFields {
+		columns = append(columns, field.DBName)
+	}
+
+	return columns
+}
+
+// modelKeys returns the primary keys of the models of the statement that have one.
+func modelKeys(db *gorm.DB) [][]interface{} {
+	stmt := db.Statement
+	if stmt.Schema == nil || len(stmt.Schema.PrimaryFields) == 0 || !stmt.ReflectValue.IsValid() {
+		return nil
+	}
+
+	var values []reflect.Value
+
+	switch stmt.ReflectValue.Kind() {
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < stmt.ReflectValue.Len(); i++ {
+			values = append(values, reflect.Indirect(stmt.ReflectValue.Index(i)))
+		}
+	case reflect.Struct:
+		values = append(values, stmt.ReflectValue)
+	}
+
+	var keys [][]interface{}
+
+	for _, value := range values {
+		if value.Kind() != reflect.Struct {
+			continue
+		}
+
+		key := make([]interface{}, 0, len(stmt.Schema.PrimaryFields))
+
+		for _, field := range stmt.Schema.PrimaryFields {
+			fieldValue, zero := field.ValueOf(stmt.Context, value)
+			if zero {
+				key = nil
+
+				break
+			}
+
+			key = append(key, fieldValue)
+		}
+
+		if key != nil {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
+
+func modelKeyConditions(db *gorm.DB) clause.Expression {
+	keys := modelKeys(db)
+	if len(keys) == 0 {
+		return nil
+	}
+
+	return keysCondition(primaryKeysOf(db), keys)
+}
+
+// keysCondition matches the rows with one of the primary keys.
+func keysCondition(primaryKeys []string, keys [][]interface{}) clause.Expression {
+	if len(primaryKeys) == 1 {
+		values := make([]interface{}, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, key[0])
+		}
+
+		return clause.IN{Column: clause.Column{Name: primaryKeys[0]}, Values: values}
+	}
+
+	matches := make([]clause.Expression, 0, len(keys))
+	for _, key := range keys {
+		columns := make([]clause.Expression, 0, len(primaryKeys))
+		for i, primaryKey := range primaryKeys {
+			columns = append(columns, clause.Eq{Column: clause.Column{Name: primaryKey}, Value: key[i]})
+		}
+
+		matches = append(matches, clause.And(columns...))
+	}
+
+	return clause.Or(matches...)
+}
+
+func keyOf(row map[string]interface{}, primaryKeys []string) []interface{} {
+	key := make([]interface{}, 0, len(primaryKeys))
+	for _, primaryKey := range primaryKeys {
+		key = append(key, row[primaryKey])
+	}
+
+	return key
+}
+
+func entityIDOf(row map[string]interface{}, primaryKeys []string) string {
+	parts := make([]string, 0, len(primaryKeys))
+	for _, value := range keyOf(row, primaryKeys) {
+		parts = append(parts, fmt.Sprint(value))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// normalize turns the values of a row into the values they are recorded with: a JSON column into its document, a
+// binary UUID into its text form, and every value into what it decodes to from JSON, numbers kept exact.
+func normalize(row map[string]interface{}) (map[string]interface{}, error) {
+	converted := make(map[string]interface{}, len(row))
+
+	for column, value := range row {
+		switch v := value.(type) {
+		case []byte:
+			if json.Valid(v) {
+				converted[column] = json.RawMessage(v)
+			} else {
+				converted[column] = string(v)
+			}
+		case [16]byte:
+			converted[column] = uuid.UUID(v).String()
+		default:
+			converted[column] = value
+		}
+	}
+
+	data, err := json.Marshal(converted)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var normalized map[string]interface{}
+	if err = decoder.Decode(&normalized); err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+func marshalRow(row map[string]interface{}) ([]byte, error) {
+	if row == nil {
+		return nil, nil
+	}
+
+	return json.Marshal(row)
+}
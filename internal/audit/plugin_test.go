@@ -0,0 +1,255 @@
+//go:build tests_unit
+
+package audit_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/audit"
+	"ulascansenturk/service/internal/constants"
+	mockTime "ulascansenturk/service/internal/helpers/mocks"
+)
+
+// jsonArg matches a JSON argument equal to the expected document.
+type jsonArg struct {
+	expected string
+}
+
+func (a jsonArg) Match(value driver.Value) bool {
+	var actual []byte
+
+	switch v := value.(type) {
+	case []byte:
+		actual = v
+	case string:
+		actual = []byte(v)
+	default:
+		return false
+	}
+
+	var expected, got interface{}
+	if json.Unmarshal([]byte(a.expected), &expected) != nil || json.Unmarshal(actual, &got) != nil {
+		return false
+	}
+
+	expectedJSON, _ := json.Marshal(expected)
+	gotJSON, _ := json.Marshal(got)
+
+	return string(expectedJSON) == string(gotJSON)
+}
+
+func TestPlugin(t *testing.T) {
+	accountID := uuid.New()
+	userID := uuid.New()
+	now := time.Date(2024, 10, 6, 9, 0, 0, 0, time.UTC)
+
+	accountColumns := []string{"id", "user_id", "balance", "currency", "status"}
+
+	newDB := func(t *testing.T, redactedColumns ...string) (*gorm.DB, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		gormDB, err := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		require.NoError(t, err)
+
+		timeProvider := new(mockTime.MockTimeProvider)
+		timeProvider.On("Now").Return(now).Maybe()
+
+		require.NoError(t, gormDB.Use(audit.NewPlugin(timeProvider, []string{"outbox_events"}, redactedColumns)))
+
+		return gormDB, mock
+	}
+
+	ctx := audit.WithSource(
+		audit.WithActor(context.Background(), "admin@example.com"),
+		audit.Source{Type: constants.AuditSourceTypeHTTPREQUEST, ID: "request-1"},
+	)
+
+	t.Run("Update is recorded with the changed columns", func(t *testing.T) {
+		gormDB, mock := newDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1`).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(accountID.String(), userID.String(), 1000, "USD", "ACTIVE"))
+		mock.ExpectExec(`UPDATE "accounts" SET "balance"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE "id" = \$1`).
+			WithArgs(accountID.String()).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(accountID.String(), userID.String(), 400, "USD", "ACTIVE"))
+		mock.ExpectQuery(`INSERT INTO "audit_events"`).
+			WithArgs(
+				sqlmock.AnyArg(),
+				constants.AuditActionUPDATE,
+				"accounts",
+				accountID.String(),
+				"admin@example.com",
+				constants.AuditSourceTypeHTTPREQUEST,
+				"request-1",
+				sqlmock.AnyArg(),
+				sqlmock.AnyArg(),
+				jsonArg{expected: `{"balance":{"before":1000,"after":400}}`},
+				now,
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(1))
+		mock.ExpectCommit()
+
+		repo := accounts.NewSQLRepository(gormDB)
+		require.NoError(t, repo.UpdateBalanceWithTx(ctx, accountID, 400, gormDB))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Redacted columns are recorded without their values", func(t *testing.T) {
+		gormDB, mock := newDB(t, "balance")
+
+		columns := []string{"id", "balance", "secret", "status"}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1`).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(accountID.String(), 1000, "s3cr3t", "ACTIVE"))
+		mock.ExpectExec(`UPDATE "accounts" SET "balance"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE "id" = \$1`).
+			WithArgs(accountID.String()).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(accountID.String(), 400, "s3cr3t", "ACTIVE"))
+		mock.ExpectQuery(`INSERT INTO "audit_events"`).
+			WithArgs(
+				sqlmock.AnyArg(),
+				constants.AuditActionUPDATE,
+				"accounts",
+				accountID.String(),
+				"admin@example.com",
+				constants.AuditSourceTypeHTTPREQUEST,
+				"request-1",
+				jsonArg{expected: fmt.Sprintf(`{"id":%q,"balance":"[REDACTED]","secret":"[REDACTED]","status":"ACTIVE"}`, accountID)},
+				jsonArg{expected: fmt.Sprintf(`{"id":%q,"balance":"[REDACTED]","secret":"[REDACTED]","status":"ACTIVE"}`, accountID)},
+				jsonArg{expected: `{"balance":{"before":"[REDACTED]","after":"[REDACTED]"}}`},
+				now,
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(1))
+		mock.ExpectCommit()
+
+		repo := accounts.NewSQLRepository(gormDB)
+		require.NoError(t, repo.UpdateBalanceWithTx(ctx, accountID, 400, gormDB))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delete is recorded with the deleted row", func(t *testing.T) {
+		gormDB, mock := newDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1`).
+			WithArgs(accountID).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(accountID.String(), userID.String(), 0, "USD", "CLOSED"))
+		mock.ExpectExec(`DELETE FROM "accounts" WHERE id = \$1`).
+			WithArgs(accountID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`INSERT INTO "audit_events"`).
+			WithArgs(
+				sqlmock.AnyArg(),
+				constants.AuditActionDELETE,
+				"accounts",
+				accountID.String(),
+				audit.SystemActor,
+				constants.AuditSourceTypeSYSTEM,
+				nil,
+				sqlmock.AnyArg(),
+				sqlmock.AnyArg(),
+				now,
+			).
+			WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(2))
+		mock.ExpectCommit()
+
+		repo := accounts.NewSQLRepository(gormDB)
+		require.NoError(t, repo.Delete(context.Background(), accountID))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Update that changes nothing isn't recorded", func(t *testing.T) {
+		gormDB, mock := newDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "accounts"`).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(accountID.String(), userID.String(), 1000, "USD", "ACTIVE"))
+		mock.ExpectExec(`UPDATE "accounts"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "accounts"`).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(accountID.String(), userID.String(), 1000, "USD", "ACTIVE"))
+		mock.ExpectCommit()
+
+		repo := accounts.NewSQLRepository(gormDB)
+		require.NoError(t, repo.UpdateBalanceWithTx(ctx, accountID, 1000, gormDB))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Change is rolled back when its event can't be written", func(t *testing.T) {
+		gormDB, mock := newDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`SELECT \* FROM "accounts"`).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(accountID.String(), userID.String(), 1000, "USD", "ACTIVE"))
+		mock.ExpectExec(`UPDATE "accounts"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(`SELECT \* FROM "accounts"`).
+			WillReturnRows(sqlmock.NewRows(accountColumns).AddRow(accountID.String(), userID.String(), 400, "USD", "ACTIVE"))
+		mock.ExpectQuery(`INSERT INTO "audit_events"`).
+			WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		repo := accounts.NewSQLRepository(gormDB)
+		err := repo.UpdateBalanceWithTx(ctx, accountID, 400, gormDB)
+		assert.ErrorContains(t, err, "writing the audit log of accounts")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Ignored table isn't recorded", func(t *testing.T) {
+		gormDB, mock := newDB(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM "outbox_events"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		require.NoError(t, gormDB.Table("outbox_events").Where("id = ?", uuid.New()).Delete(nil).Error)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestDiff(t *testing.T) {
+	t.Run("Only the changed columns are returned", func(t *testing.T) {
+		changes := audit.Diff(
+			map[string]interface{}{"id": "1", "balance": json.Number("1000"), "status": "ACTIVE"},
+			map[string]interface{}{"id": "1", "balance": json.Number("400"), "status": "ACTIVE"},
+		)
+
+		assert.Equal(t, map[string]audit.Change{
+			"balance": {Before: json.Number("1000"), After: json.Number("400")},
+		}, changes)
+	})
+
+	t.Run("Every column of a created row changed", func(t *testing.T) {
+		changes := audit.Diff(nil, map[string]interface{}{"id": "1", "status": "ACTIVE"})
+
+		assert.Equal(t, map[string]audit.Change{
+			"id":     {After: "1"},
+			"status": {After: "ACTIVE"},
+		}, changes)
+	})
+}
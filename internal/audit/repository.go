@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+	"gorm.io/gorm"
+)
+
+type Repository interface {
+	List(ctx context.Context, filter Filter) ([]*Event, error)
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// List returns up to filter.Limit events matching the filter, newest first.
+func (r *SQLRepository) List(ctx context.Context, filter Filter) ([]*Event, error) {
+	query := r.db.WithContext(ctx).Model(&Event{})
+
+	if filter.EntityType != nil {
+		query = query.Where("entity_type = ?", *filter.EntityType)
+	}
+
+	if filter.EntityID != nil {
+		query = query.Where("entity_id = ?", *filter.EntityID)
+	}
+
+	if filter.Actor != nil {
+		query = query.Where("actor = ?", *filter.Actor)
+	}
+
+	if filter.SourceID != nil {
+		query = query.Where("source_id = ?", *filter.SourceID)
+	}
+
+	if filter.Action != nil {
+		query = query.Where("action = ?", *filter.Action)
+	}
+
+	if filter.From != nil {
+		query = query.Where("occurred_at >= ?", *filter.From)
+	}
+
+	if filter.To != nil {
+		query = query.Where("occurred_at < ?", *filter.To)
+	}
+
+	if filter.Cursor != nil {
+		query = query.Where("sequence < ?", *filter.Cursor)
+	}
+
+	var events []*Event
+	if err := query.Order("sequence DESC").Limit(filter.Limit).Find(&events).Error; err != nil {
+		return nil, err
+	}
+	return events, nil
+}
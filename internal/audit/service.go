@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+var ErrInvalidFilter = errors.New("invalid audit event filter")
+
+type Service interface {
+	ListEvents(ctx context.Context, filter Filter) ([]*Event, *int64, error)
+}
+
+type AuditServiceImpl struct {
+	repo Repository
+}
+
+func NewAuditService(repo Repository) *AuditServiceImpl {
+	return &AuditServiceImpl{repo: repo}
+}
+
+// ListEvents returns a page of the events matching the filter, newest first, and the cursor of the next page, nil
+// on the last page.
+func (s *AuditServiceImpl) ListEvents(ctx context.Context, filter Filter) ([]*Event, *int64, error) {
+	if filter.Limit == 0 {
+		filter.Limit = DefaultPageSize
+	}
+
+	if filter.Limit < 0 || filter.Limit > MaxPageSize {
+		return nil, nil, fmt.Errorf("%w: limit must be between 1 and %d", ErrInvalidFilter, MaxPageSize)
+	}
+
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, nil, fmt.Errorf("%w: from must be before to", ErrInvalidFilter)
+	}
+
+	pageSize := filter.Limit
+	filter.Limit++
+
+	events, err := s.repo.List(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if len(events) <= pageSize {
+		return events, nil, nil
+	}
+
+	events = events[:pageSize]
+	nextCursor := events[pageSize-1].Sequence
+
+	return events, &nextCursor, nil
+}
@@ -0,0 +1,76 @@
+//go:build tests_unit
+
+package audit_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/audit"
+)
+
+type fakeRepository struct {
+	events []*audit.Event
+	filter audit.Filter
+}
+
+func (r *fakeRepository) List(_ context.Context, filter audit.Filter) ([]*audit.Event, error) {
+	r.filter = filter
+
+	if len(r.events) > filter.Limit {
+		return r.events[:filter.Limit], nil
+	}
+
+	return r.events, nil
+}
+
+func TestAuditService_ListEvents(t *testing.T) {
+	ctx := context.Background()
+
+	newEvents := func(sequences ...int64) []*audit.Event {
+		events := make([]*audit.Event, 0, len(sequences))
+		for _, sequence := range sequences {
+			events = append(events, &audit.Event{Sequence: sequence})
+		}
+
+		return events
+	}
+
+	t.Run("Full page returns the cursor of the next one", func(t *testing.T) {
+		repo := &fakeRepository{events: newEvents(9, 8, 7)}
+
+		events, nextCursor, err := audit.NewAuditService(repo).ListEvents(ctx, audit.Filter{Limit: 2})
+		require.NoError(t, err)
+
+		assert.Len(t, events, 2)
+		require.NotNil(t, nextCursor)
+		assert.Equal(t, int64(8), *nextCursor)
+		assert.Equal(t, 3, repo.filter.Limit)
+	})
+
+	t.Run("Last page has no cursor", func(t *testing.T) {
+		repo := &fakeRepository{events: newEvents(2, 1)}
+
+		events, nextCursor, err := audit.NewAuditService(repo).ListEvents(ctx, audit.Filter{})
+		require.NoError(t, err)
+
+		assert.Len(t, events, 2)
+		assert.Nil(t, nextCursor)
+		assert.Equal(t, audit.DefaultPageSize+1, repo.filter.Limit)
+	})
+
+	t.Run("Limit above the maximum is rejected", func(t *testing.T) {
+		_, _, err := audit.NewAuditService(&fakeRepository{}).ListEvents(ctx, audit.Filter{Limit: audit.MaxPageSize + 1})
+		assert.ErrorIs(t, err, audit.ErrInvalidFilter)
+	})
+
+	t.Run("From must be before to", func(t *testing.T) {
+		now := time.Now()
+
+		_, _, err := audit.NewAuditService(&fakeRepository{}).ListEvents(ctx, audit.Filter{From: &now, To: &now})
+		assert.ErrorIs(t, err, audit.ErrInvalidFilter)
+	})
+}
@@ -0,0 +1,6 @@
+package constants
+
+// ApprovalDecision ENUM(APPROVED, REJECTED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type ApprovalDecision string
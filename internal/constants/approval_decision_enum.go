@@ -0,0 +1,45 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ApprovalDecisionAPPROVED is a ApprovalDecision of type APPROVED.
+	ApprovalDecisionAPPROVED ApprovalDecision = "APPROVED"
+	// ApprovalDecisionREJECTED is a ApprovalDecision of type REJECTED.
+	ApprovalDecisionREJECTED ApprovalDecision = "REJECTED"
+)
+
+var ErrInvalidApprovalDecision = errors.New("not a valid ApprovalDecision")
+
+// String implements the Stringer interface.
+func (x ApprovalDecision) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x ApprovalDecision) IsValid() bool {
+	_, err := ParseApprovalDecision(string(x))
+	return err == nil
+}
+
+var _ApprovalDecisionValue = map[string]ApprovalDecision{
+	"APPROVED": ApprovalDecisionAPPROVED,
+	"REJECTED": ApprovalDecisionREJECTED,
+}
+
+// ParseApprovalDecision attempts to convert a string to a ApprovalDecision.
+func ParseApprovalDecision(name string) (ApprovalDecision, error) {
+	if x, ok := _ApprovalDecisionValue[name]; ok {
+		return x, nil
+	}
+	return ApprovalDecision(""), fmt.Errorf("%s is %w", name, ErrInvalidApprovalDecision)
+}
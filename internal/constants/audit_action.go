@@ -0,0 +1,6 @@
+package constants
+
+// AuditAction ENUM(CREATE, UPDATE, DELETE)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type AuditAction string
@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// AuditActionCREATE is a AuditAction of type CREATE.
+	AuditActionCREATE AuditAction = "CREATE"
+	// AuditActionUPDATE is a AuditAction of type UPDATE.
+	AuditActionUPDATE AuditAction = "UPDATE"
+	// AuditActionDELETE is a AuditAction of type DELETE.
+	AuditActionDELETE AuditAction = "DELETE"
+)
+
+var ErrInvalidAuditAction = errors.New("not a valid AuditAction")
+
+// String implements the Stringer interface.
+func (x AuditAction) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x AuditAction) IsValid() bool {
+	_, err := ParseAuditAction(string(x))
+	return err == nil
+}
+
+var _AuditActionValue = map[string]AuditAction{
+	"CREATE": AuditActionCREATE,
+	"UPDATE": AuditActionUPDATE,
+	"DELETE": AuditActionDELETE,
+}
+
+// ParseAuditAction attempts to convert a string to a AuditAction.
+func ParseAuditAction(name string) (AuditAction, error) {
+	if x, ok := _AuditActionValue[name]; ok {
+		return x, nil
+	}
+	return AuditAction(""), fmt.Errorf("%s is %w", name, ErrInvalidAuditAction)
+}
@@ -0,0 +1,6 @@
+package constants
+
+// AuditSourceType ENUM(HTTP_REQUEST, WORKFLOW, SYSTEM)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type AuditSourceType string
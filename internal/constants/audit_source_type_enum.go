@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// AuditSourceTypeHTTPREQUEST is a AuditSourceType of type HTTP_REQUEST.
+	AuditSourceTypeHTTPREQUEST AuditSourceType = "HTTP_REQUEST"
+	// AuditSourceTypeWORKFLOW is a AuditSourceType of type WORKFLOW.
+	AuditSourceTypeWORKFLOW AuditSourceType = "WORKFLOW"
+	// AuditSourceTypeSYSTEM is a AuditSourceType of type SYSTEM.
+	AuditSourceTypeSYSTEM AuditSourceType = "SYSTEM"
+)
+
+var ErrInvalidAuditSourceType = errors.New("not a valid AuditSourceType")
+
+// String implements the Stringer interface.
+func (x AuditSourceType) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x AuditSourceType) IsValid() bool {
+	_, err := ParseAuditSourceType(string(x))
+	return err == nil
+}
+
+var _AuditSourceTypeValue = map[string]AuditSourceType{
+	"HTTP_REQUEST": AuditSourceTypeHTTPREQUEST,
+	"WORKFLOW":     AuditSourceTypeWORKFLOW,
+	"SYSTEM":       AuditSourceTypeSYSTEM,
+}
+
+// ParseAuditSourceType attempts to convert a string to a AuditSourceType.
+func ParseAuditSourceType(name string) (AuditSourceType, error) {
+	if x, ok := _AuditSourceTypeValue[name]; ok {
+		return x, nil
+	}
+	return AuditSourceType(""), fmt.Errorf("%s is %w", name, ErrInvalidAuditSourceType)
+}
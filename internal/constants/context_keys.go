@@ -4,6 +4,8 @@ package constants
 //
 //		workflow_logger
 //		workflow_reference_id,
+//		audit_actor,
+//		audit_source,
 //	)
 //
 //go:generate go run github.com/abice/go-enum@v0.5.5
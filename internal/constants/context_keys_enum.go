@@ -16,6 +16,10 @@ const (
 	ContextKeyWorkflowLogger ContextKey = "workflow_logger"
 	// ContextKeyWorkflowReferenceId is a ContextKey of type workflow_reference_id.
 	ContextKeyWorkflowReferenceId ContextKey = "workflow_reference_id"
+	// ContextKeyAuditActor is a ContextKey of type audit_actor.
+	ContextKeyAuditActor ContextKey = "audit_actor"
+	// ContextKeyAuditSource is a ContextKey of type audit_source.
+	ContextKeyAuditSource ContextKey = "audit_source"
 )
 
 var ErrInvalidContextKey = errors.New("not a valid ContextKey")
@@ -34,6 +38,8 @@ func (x ContextKey) IsValid() bool {
 var _ContextKeyValue = map[string]ContextKey{
 	"workflow_logger":       ContextKeyWorkflowLogger,
 	"workflow_reference_id": ContextKeyWorkflowReferenceId,
+	"audit_actor":           ContextKeyAuditActor,
+	"audit_source":          ContextKeyAuditSource,
 }
 
 // ParseContextKey attempts to convert a string to a ContextKey.
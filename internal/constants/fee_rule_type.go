@@ -0,0 +1,6 @@
+package constants
+
+// FeeRuleType ENUM(FLAT, PERCENTAGE, TIERED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type FeeRuleType string
@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// FeeRuleTypeFLAT is a FeeRuleType of type FLAT.
+	FeeRuleTypeFLAT FeeRuleType = "FLAT"
+	// FeeRuleTypePERCENTAGE is a FeeRuleType of type PERCENTAGE.
+	FeeRuleTypePERCENTAGE FeeRuleType = "PERCENTAGE"
+	// FeeRuleTypeTIERED is a FeeRuleType of type TIERED.
+	FeeRuleTypeTIERED FeeRuleType = "TIERED"
+)
+
+var ErrInvalidFeeRuleType = errors.New("not a valid FeeRuleType")
+
+// String implements the Stringer interface.
+func (x FeeRuleType) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x FeeRuleType) IsValid() bool {
+	_, err := ParseFeeRuleType(string(x))
+	return err == nil
+}
+
+var _FeeRuleTypeValue = map[string]FeeRuleType{
+	"FLAT":       FeeRuleTypeFLAT,
+	"PERCENTAGE": FeeRuleTypePERCENTAGE,
+	"TIERED":     FeeRuleTypeTIERED,
+}
+
+// ParseFeeRuleType attempts to convert a string to a FeeRuleType.
+func ParseFeeRuleType(name string) (FeeRuleType, error) {
+	if x, ok := _FeeRuleTypeValue[name]; ok {
+		return x, nil
+	}
+	return FeeRuleType(""), fmt.Errorf("%s is %w", name, ErrInvalidFeeRuleType)
+}
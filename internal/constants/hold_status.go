@@ -0,0 +1,6 @@
+package constants
+
+// HoldStatus ENUM(ACTIVE, CAPTURED, VOIDED, EXPIRED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type HoldStatus string
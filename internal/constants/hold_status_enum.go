@@ -0,0 +1,51 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// HoldStatusACTIVE is a HoldStatus of type ACTIVE.
+	HoldStatusACTIVE HoldStatus = "ACTIVE"
+	// HoldStatusCAPTURED is a HoldStatus of type CAPTURED.
+	HoldStatusCAPTURED HoldStatus = "CAPTURED"
+	// HoldStatusVOIDED is a HoldStatus of type VOIDED.
+	HoldStatusVOIDED HoldStatus = "VOIDED"
+	// HoldStatusEXPIRED is a HoldStatus of type EXPIRED.
+	HoldStatusEXPIRED HoldStatus = "EXPIRED"
+)
+
+var ErrInvalidHoldStatus = errors.New("not a valid HoldStatus")
+
+// String implements the Stringer interface.
+func (x HoldStatus) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x HoldStatus) IsValid() bool {
+	_, err := ParseHoldStatus(string(x))
+	return err == nil
+}
+
+var _HoldStatusValue = map[string]HoldStatus{
+	"ACTIVE":   HoldStatusACTIVE,
+	"CAPTURED": HoldStatusCAPTURED,
+	"VOIDED":   HoldStatusVOIDED,
+	"EXPIRED":  HoldStatusEXPIRED,
+}
+
+// ParseHoldStatus attempts to convert a string to a HoldStatus.
+func ParseHoldStatus(name string) (HoldStatus, error) {
+	if x, ok := _HoldStatusValue[name]; ok {
+		return x, nil
+	}
+	return HoldStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidHoldStatus)
+}
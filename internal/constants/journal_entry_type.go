@@ -0,0 +1,6 @@
+package constants
+
+// JournalEntryType ENUM(OPENING_BALANCE, ADJUSTMENT, TRANSFER, FEE, REVERSAL, FEE_REVERSAL, CAPTURE)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type JournalEntryType string
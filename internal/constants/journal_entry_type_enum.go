@@ -0,0 +1,60 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// JournalEntryTypeOPENINGBALANCE is a JournalEntryType of type OPENING_BALANCE.
+	JournalEntryTypeOPENINGBALANCE JournalEntryType = "OPENING_BALANCE"
+	// JournalEntryTypeADJUSTMENT is a JournalEntryType of type ADJUSTMENT.
+	JournalEntryTypeADJUSTMENT JournalEntryType = "ADJUSTMENT"
+	// JournalEntryTypeTRANSFER is a JournalEntryType of type TRANSFER.
+	JournalEntryTypeTRANSFER JournalEntryType = "TRANSFER"
+	// JournalEntryTypeFEE is a JournalEntryType of type FEE.
+	JournalEntryTypeFEE JournalEntryType = "FEE"
+	// JournalEntryTypeREVERSAL is a JournalEntryType of type REVERSAL.
+	JournalEntryTypeREVERSAL JournalEntryType = "REVERSAL"
+	// JournalEntryTypeFEEREVERSAL is a JournalEntryType of type FEE_REVERSAL.
+	JournalEntryTypeFEEREVERSAL JournalEntryType = "FEE_REVERSAL"
+	// JournalEntryTypeCAPTURE is a JournalEntryType of type CAPTURE.
+	JournalEntryTypeCAPTURE JournalEntryType = "CAPTURE"
+)
+
+var ErrInvalidJournalEntryType = errors.New("not a valid JournalEntryType")
+
+// String implements the Stringer interface.
+func (x JournalEntryType) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x JournalEntryType) IsValid() bool {
+	_, err := ParseJournalEntryType(string(x))
+	return err == nil
+}
+
+var _JournalEntryTypeValue = map[string]JournalEntryType{
+	"OPENING_BALANCE": JournalEntryTypeOPENINGBALANCE,
+	"ADJUSTMENT":      JournalEntryTypeADJUSTMENT,
+	"TRANSFER":        JournalEntryTypeTRANSFER,
+	"FEE":             JournalEntryTypeFEE,
+	"REVERSAL":        JournalEntryTypeREVERSAL,
+	"FEE_REVERSAL":    JournalEntryTypeFEEREVERSAL,
+	"CAPTURE":         JournalEntryTypeCAPTURE,
+}
+
+// ParseJournalEntryType attempts to convert a string to a JournalEntryType.
+func ParseJournalEntryType(name string) (JournalEntryType, error) {
+	if x, ok := _JournalEntryTypeValue[name]; ok {
+		return x, nil
+	}
+	return JournalEntryType(""), fmt.Errorf("%s is %w", name, ErrInvalidJournalEntryType)
+}
@@ -0,0 +1,6 @@
+package constants
+
+// LedgerAccountType ENUM(CUSTOMER, FEE_INCOME, FX_CLEARING, FUNDING)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type LedgerAccountType string
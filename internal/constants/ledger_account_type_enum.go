@@ -0,0 +1,51 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// LedgerAccountTypeCUSTOMER is a LedgerAccountType of type CUSTOMER.
+	LedgerAccountTypeCUSTOMER LedgerAccountType = "CUSTOMER"
+	// LedgerAccountTypeFEEINCOME is a LedgerAccountType of type FEE_INCOME.
+	LedgerAccountTypeFEEINCOME LedgerAccountType = "FEE_INCOME"
+	// LedgerAccountTypeFXCLEARING is a LedgerAccountType of type FX_CLEARING.
+	LedgerAccountTypeFXCLEARING LedgerAccountType = "FX_CLEARING"
+	// LedgerAccountTypeFUNDING is a LedgerAccountType of type FUNDING.
+	LedgerAccountTypeFUNDING LedgerAccountType = "FUNDING"
+)
+
+var ErrInvalidLedgerAccountType = errors.New("not a valid LedgerAccountType")
+
+// String implements the Stringer interface.
+func (x LedgerAccountType) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x LedgerAccountType) IsValid() bool {
+	_, err := ParseLedgerAccountType(string(x))
+	return err == nil
+}
+
+var _LedgerAccountTypeValue = map[string]LedgerAccountType{
+	"CUSTOMER":    LedgerAccountTypeCUSTOMER,
+	"FEE_INCOME":  LedgerAccountTypeFEEINCOME,
+	"FX_CLEARING": LedgerAccountTypeFXCLEARING,
+	"FUNDING":     LedgerAccountTypeFUNDING,
+}
+
+// ParseLedgerAccountType attempts to convert a string to a LedgerAccountType.
+func ParseLedgerAccountType(name string) (LedgerAccountType, error) {
+	if x, ok := _LedgerAccountTypeValue[name]; ok {
+		return x, nil
+	}
+	return LedgerAccountType(""), fmt.Errorf("%s is %w", name, ErrInvalidLedgerAccountType)
+}
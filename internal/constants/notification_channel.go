@@ -0,0 +1,6 @@
+package constants
+
+// NotificationChannel ENUM(EMAIL, SMS)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type NotificationChannel string
@@ -0,0 +1,45 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// NotificationChannelEMAIL is a NotificationChannel of type EMAIL.
+	NotificationChannelEMAIL NotificationChannel = "EMAIL"
+	// NotificationChannelSMS is a NotificationChannel of type SMS.
+	NotificationChannelSMS NotificationChannel = "SMS"
+)
+
+var ErrInvalidNotificationChannel = errors.New("not a valid NotificationChannel")
+
+// String implements the Stringer interface.
+func (x NotificationChannel) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x NotificationChannel) IsValid() bool {
+	_, err := ParseNotificationChannel(string(x))
+	return err == nil
+}
+
+var _NotificationChannelValue = map[string]NotificationChannel{
+	"EMAIL": NotificationChannelEMAIL,
+	"SMS":   NotificationChannelSMS,
+}
+
+// ParseNotificationChannel attempts to convert a string to a NotificationChannel.
+func ParseNotificationChannel(name string) (NotificationChannel, error) {
+	if x, ok := _NotificationChannelValue[name]; ok {
+		return x, nil
+	}
+	return NotificationChannel(""), fmt.Errorf("%s is %w", name, ErrInvalidNotificationChannel)
+}
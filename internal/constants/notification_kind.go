@@ -0,0 +1,6 @@
+package constants
+
+// NotificationKind ENUM(TransferSent, TransferReceived, TransferFailed, LowBalance)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type NotificationKind string
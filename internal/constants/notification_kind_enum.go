@@ -0,0 +1,51 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// NotificationKindTransferSent is a NotificationKind of type TransferSent.
+	NotificationKindTransferSent NotificationKind = "TransferSent"
+	// NotificationKindTransferReceived is a NotificationKind of type TransferReceived.
+	NotificationKindTransferReceived NotificationKind = "TransferReceived"
+	// NotificationKindTransferFailed is a NotificationKind of type TransferFailed.
+	NotificationKindTransferFailed NotificationKind = "TransferFailed"
+	// NotificationKindLowBalance is a NotificationKind of type LowBalance.
+	NotificationKindLowBalance NotificationKind = "LowBalance"
+)
+
+var ErrInvalidNotificationKind = errors.New("not a valid NotificationKind")
+
+// String implements the Stringer interface.
+func (x NotificationKind) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x NotificationKind) IsValid() bool {
+	_, err := ParseNotificationKind(string(x))
+	return err == nil
+}
+
+var _NotificationKindValue = map[string]NotificationKind{
+	"TransferSent":     NotificationKindTransferSent,
+	"TransferReceived": NotificationKindTransferReceived,
+	"TransferFailed":   NotificationKindTransferFailed,
+	"LowBalance":       NotificationKindLowBalance,
+}
+
+// ParseNotificationKind attempts to convert a string to a NotificationKind.
+func ParseNotificationKind(name string) (NotificationKind, error) {
+	if x, ok := _NotificationKindValue[name]; ok {
+		return x, nil
+	}
+	return NotificationKind(""), fmt.Errorf("%s is %w", name, ErrInvalidNotificationKind)
+}
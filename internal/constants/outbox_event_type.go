@@ -0,0 +1,6 @@
+package constants
+
+// OutboxEventType ENUM(TransferCompleted, TransferFailed, UserCreated, AccountCreated, AccountStatusChanged, BalanceChanged)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type OutboxEventType string
@@ -0,0 +1,57 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// OutboxEventTypeTransferCompleted is a OutboxEventType of type TransferCompleted.
+	OutboxEventTypeTransferCompleted OutboxEventType = "TransferCompleted"
+	// OutboxEventTypeTransferFailed is a OutboxEventType of type TransferFailed.
+	OutboxEventTypeTransferFailed OutboxEventType = "TransferFailed"
+	// OutboxEventTypeUserCreated is a OutboxEventType of type UserCreated.
+	OutboxEventTypeUserCreated OutboxEventType = "UserCreated"
+	// OutboxEventTypeAccountCreated is a OutboxEventType of type AccountCreated.
+	OutboxEventTypeAccountCreated OutboxEventType = "AccountCreated"
+	// OutboxEventTypeAccountStatusChanged is a OutboxEventType of type AccountStatusChanged.
+	OutboxEventTypeAccountStatusChanged OutboxEventType = "AccountStatusChanged"
+	// OutboxEventTypeBalanceChanged is a OutboxEventType of type BalanceChanged.
+	OutboxEventTypeBalanceChanged OutboxEventType = "BalanceChanged"
+)
+
+var ErrInvalidOutboxEventType = errors.New("not a valid OutboxEventType")
+
+// String implements the Stringer interface.
+func (x OutboxEventType) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x OutboxEventType) IsValid() bool {
+	_, err := ParseOutboxEventType(string(x))
+	return err == nil
+}
+
+var _OutboxEventTypeValue = map[string]OutboxEventType{
+	"TransferCompleted":    OutboxEventTypeTransferCompleted,
+	"TransferFailed":       OutboxEventTypeTransferFailed,
+	"UserCreated":          OutboxEventTypeUserCreated,
+	"AccountCreated":       OutboxEventTypeAccountCreated,
+	"AccountStatusChanged": OutboxEventTypeAccountStatusChanged,
+	"BalanceChanged":       OutboxEventTypeBalanceChanged,
+}
+
+// ParseOutboxEventType attempts to convert a string to a OutboxEventType.
+func ParseOutboxEventType(name string) (OutboxEventType, error) {
+	if x, ok := _OutboxEventTypeValue[name]; ok {
+		return x, nil
+	}
+	return OutboxEventType(""), fmt.Errorf("%s is %w", name, ErrInvalidOutboxEventType)
+}
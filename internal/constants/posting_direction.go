@@ -0,0 +1,6 @@
+package constants
+
+// PostingDirection ENUM(DEBIT, CREDIT)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type PostingDirection string
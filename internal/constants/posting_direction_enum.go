@@ -0,0 +1,45 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// PostingDirectionDEBIT is a PostingDirection of type DEBIT.
+	PostingDirectionDEBIT PostingDirection = "DEBIT"
+	// PostingDirectionCREDIT is a PostingDirection of type CREDIT.
+	PostingDirectionCREDIT PostingDirection = "CREDIT"
+)
+
+var ErrInvalidPostingDirection = errors.New("not a valid PostingDirection")
+
+// String implements the Stringer interface.
+func (x PostingDirection) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x PostingDirection) IsValid() bool {
+	_, err := ParsePostingDirection(string(x))
+	return err == nil
+}
+
+var _PostingDirectionValue = map[string]PostingDirection{
+	"DEBIT":  PostingDirectionDEBIT,
+	"CREDIT": PostingDirectionCREDIT,
+}
+
+// ParsePostingDirection attempts to convert a string to a PostingDirection.
+func ParsePostingDirection(name string) (PostingDirection, error) {
+	if x, ok := _PostingDirectionValue[name]; ok {
+		return x, nil
+	}
+	return PostingDirection(""), fmt.Errorf("%s is %w", name, ErrInvalidPostingDirection)
+}
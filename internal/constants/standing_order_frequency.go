@@ -0,0 +1,6 @@
+package constants
+
+// StandingOrderFrequency ENUM(CRON, WEEKLY, MONTHLY)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type StandingOrderFrequency string
@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// StandingOrderFrequencyCRON is a StandingOrderFrequency of type CRON.
+	StandingOrderFrequencyCRON StandingOrderFrequency = "CRON"
+	// StandingOrderFrequencyWEEKLY is a StandingOrderFrequency of type WEEKLY.
+	StandingOrderFrequencyWEEKLY StandingOrderFrequency = "WEEKLY"
+	// StandingOrderFrequencyMONTHLY is a StandingOrderFrequency of type MONTHLY.
+	StandingOrderFrequencyMONTHLY StandingOrderFrequency = "MONTHLY"
+)
+
+var ErrInvalidStandingOrderFrequency = errors.New("not a valid StandingOrderFrequency")
+
+// String implements the Stringer interface.
+func (x StandingOrderFrequency) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x StandingOrderFrequency) IsValid() bool {
+	_, err := ParseStandingOrderFrequency(string(x))
+	return err == nil
+}
+
+var _StandingOrderFrequencyValue = map[string]StandingOrderFrequency{
+	"CRON":    StandingOrderFrequencyCRON,
+	"WEEKLY":  StandingOrderFrequencyWEEKLY,
+	"MONTHLY": StandingOrderFrequencyMONTHLY,
+}
+
+// ParseStandingOrderFrequency attempts to convert a string to a StandingOrderFrequency.
+func ParseStandingOrderFrequency(name string) (StandingOrderFrequency, error) {
+	if x, ok := _StandingOrderFrequencyValue[name]; ok {
+		return x, nil
+	}
+	return StandingOrderFrequency(""), fmt.Errorf("%s is %w", name, ErrInvalidStandingOrderFrequency)
+}
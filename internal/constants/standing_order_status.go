@@ -0,0 +1,6 @@
+package constants
+
+// StandingOrderStatus ENUM(ACTIVE, CANCELLED, COMPLETED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type StandingOrderStatus string
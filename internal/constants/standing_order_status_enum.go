@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// StandingOrderStatusACTIVE is a StandingOrderStatus of type ACTIVE.
+	StandingOrderStatusACTIVE StandingOrderStatus = "ACTIVE"
+	// StandingOrderStatusCANCELLED is a StandingOrderStatus of type CANCELLED.
+	StandingOrderStatusCANCELLED StandingOrderStatus = "CANCELLED"
+	// StandingOrderStatusCOMPLETED is a StandingOrderStatus of type COMPLETED.
+	StandingOrderStatusCOMPLETED StandingOrderStatus = "COMPLETED"
+)
+
+var ErrInvalidStandingOrderStatus = errors.New("not a valid StandingOrderStatus")
+
+// String implements the Stringer interface.
+func (x StandingOrderStatus) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x StandingOrderStatus) IsValid() bool {
+	_, err := ParseStandingOrderStatus(string(x))
+	return err == nil
+}
+
+var _StandingOrderStatusValue = map[string]StandingOrderStatus{
+	"ACTIVE":    StandingOrderStatusACTIVE,
+	"CANCELLED": StandingOrderStatusCANCELLED,
+	"COMPLETED": StandingOrderStatusCOMPLETED,
+}
+
+// ParseStandingOrderStatus attempts to convert a string to a StandingOrderStatus.
+func ParseStandingOrderStatus(name string) (StandingOrderStatus, error) {
+	if x, ok := _StandingOrderStatusValue[name]; ok {
+		return x, nil
+	}
+	return StandingOrderStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidStandingOrderStatus)
+}
@@ -1,6 +1,6 @@
 package constants
 
-// TransactionType ENUM(INBOUND, OUTBOUND, OUTGOING_FEE)
+// TransactionType ENUM(INBOUND, OUTBOUND, OUTGOING_FEE, INCOMING_FEE, REVERSAL_OUTBOUND, REVERSAL_INBOUND, FEE_REVERSAL_OUTBOUND, FEE_REVERSAL_INBOUND)
 //
 //go:generate go run github.com/abice/go-enum@v0.5.5
 type TransactionType string
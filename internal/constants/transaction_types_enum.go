@@ -18,6 +18,16 @@ const (
 	TransactionTypeOUTBOUND TransactionType = "OUTBOUND"
 	// TransactionTypeOUTGOINGFEE is a TransactionType of type OUTGOING_FEE.
 	TransactionTypeOUTGOINGFEE TransactionType = "OUTGOING_FEE"
+	// TransactionTypeINCOMINGFEE is a TransactionType of type INCOMING_FEE.
+	TransactionTypeINCOMINGFEE TransactionType = "INCOMING_FEE"
+	// TransactionTypeREVERSALOUTBOUND is a TransactionType of type REVERSAL_OUTBOUND.
+	TransactionTypeREVERSALOUTBOUND TransactionType = "REVERSAL_OUTBOUND"
+	// TransactionTypeREVERSALINBOUND is a TransactionType of type REVERSAL_INBOUND.
+	TransactionTypeREVERSALINBOUND TransactionType = "REVERSAL_INBOUND"
+	// TransactionTypeFEEREVERSALOUTBOUND is a TransactionType of type FEE_REVERSAL_OUTBOUND.
+	TransactionTypeFEEREVERSALOUTBOUND TransactionType = "FEE_REVERSAL_OUTBOUND"
+	// TransactionTypeFEEREVERSALINBOUND is a TransactionType of type FEE_REVERSAL_INBOUND.
+	TransactionTypeFEEREVERSALINBOUND TransactionType = "FEE_REVERSAL_INBOUND"
 )
 
 var ErrInvalidTransactionType = errors.New("not a valid TransactionType")
@@ -34,9 +44,14 @@ func (x TransactionType) IsValid() bool {
 }
 
 var _TransactionTypeValue = map[string]TransactionType{
-	"INBOUND":      TransactionTypeINBOUND,
-	"OUTBOUND":     TransactionTypeOUTBOUND,
-	"OUTGOING_FEE": TransactionTypeOUTGOINGFEE,
+	"INBOUND":               TransactionTypeINBOUND,
+	"OUTBOUND":              TransactionTypeOUTBOUND,
+	"OUTGOING_FEE":          TransactionTypeOUTGOINGFEE,
+	"INCOMING_FEE":          TransactionTypeINCOMINGFEE,
+	"REVERSAL_OUTBOUND":     TransactionTypeREVERSALOUTBOUND,
+	"REVERSAL_INBOUND":      TransactionTypeREVERSALINBOUND,
+	"FEE_REVERSAL_OUTBOUND": TransactionTypeFEEREVERSALOUTBOUND,
+	"FEE_REVERSAL_INBOUND":  TransactionTypeFEEREVERSALINBOUND,
 }
 
 // ParseTransactionType attempts to convert a string to a TransactionType.
@@ -0,0 +1,6 @@
+package constants
+
+// TransferBatchItemStatus ENUM(QUEUED, RUNNING, SUCCEEDED, FAILED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type TransferBatchItemStatus string
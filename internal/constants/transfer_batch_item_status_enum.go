@@ -0,0 +1,51 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TransferBatchItemStatusQUEUED is a TransferBatchItemStatus of type QUEUED.
+	TransferBatchItemStatusQUEUED TransferBatchItemStatus = "QUEUED"
+	// TransferBatchItemStatusRUNNING is a TransferBatchItemStatus of type RUNNING.
+	TransferBatchItemStatusRUNNING TransferBatchItemStatus = "RUNNING"
+	// TransferBatchItemStatusSUCCEEDED is a TransferBatchItemStatus of type SUCCEEDED.
+	TransferBatchItemStatusSUCCEEDED TransferBatchItemStatus = "SUCCEEDED"
+	// TransferBatchItemStatusFAILED is a TransferBatchItemStatus of type FAILED.
+	TransferBatchItemStatusFAILED TransferBatchItemStatus = "FAILED"
+)
+
+var ErrInvalidTransferBatchItemStatus = errors.New("not a valid TransferBatchItemStatus")
+
+// String implements the Stringer interface.
+func (x TransferBatchItemStatus) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x TransferBatchItemStatus) IsValid() bool {
+	_, err := ParseTransferBatchItemStatus(string(x))
+	return err == nil
+}
+
+var _TransferBatchItemStatusValue = map[string]TransferBatchItemStatus{
+	"QUEUED":    TransferBatchItemStatusQUEUED,
+	"RUNNING":   TransferBatchItemStatusRUNNING,
+	"SUCCEEDED": TransferBatchItemStatusSUCCEEDED,
+	"FAILED":    TransferBatchItemStatusFAILED,
+}
+
+// ParseTransferBatchItemStatus attempts to convert a string to a TransferBatchItemStatus.
+func ParseTransferBatchItemStatus(name string) (TransferBatchItemStatus, error) {
+	if x, ok := _TransferBatchItemStatusValue[name]; ok {
+		return x, nil
+	}
+	return TransferBatchItemStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidTransferBatchItemStatus)
+}
@@ -0,0 +1,6 @@
+package constants
+
+// TransferBatchStatus ENUM(PROCESSING, COMPLETED, PARTIALLY_FAILED, FAILED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type TransferBatchStatus string
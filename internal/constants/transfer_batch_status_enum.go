@@ -0,0 +1,51 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TransferBatchStatusPROCESSING is a TransferBatchStatus of type PROCESSING.
+	TransferBatchStatusPROCESSING TransferBatchStatus = "PROCESSING"
+	// TransferBatchStatusCOMPLETED is a TransferBatchStatus of type COMPLETED.
+	TransferBatchStatusCOMPLETED TransferBatchStatus = "COMPLETED"
+	// TransferBatchStatusPARTIALLYFAILED is a TransferBatchStatus of type PARTIALLY_FAILED.
+	TransferBatchStatusPARTIALLYFAILED TransferBatchStatus = "PARTIALLY_FAILED"
+	// TransferBatchStatusFAILED is a TransferBatchStatus of type FAILED.
+	TransferBatchStatusFAILED TransferBatchStatus = "FAILED"
+)
+
+var ErrInvalidTransferBatchStatus = errors.New("not a valid TransferBatchStatus")
+
+// String implements the Stringer interface.
+func (x TransferBatchStatus) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x TransferBatchStatus) IsValid() bool {
+	_, err := ParseTransferBatchStatus(string(x))
+	return err == nil
+}
+
+var _TransferBatchStatusValue = map[string]TransferBatchStatus{
+	"PROCESSING":       TransferBatchStatusPROCESSING,
+	"COMPLETED":        TransferBatchStatusCOMPLETED,
+	"PARTIALLY_FAILED": TransferBatchStatusPARTIALLYFAILED,
+	"FAILED":           TransferBatchStatusFAILED,
+}
+
+// ParseTransferBatchStatus attempts to convert a string to a TransferBatchStatus.
+func ParseTransferBatchStatus(name string) (TransferBatchStatus, error) {
+	if x, ok := _TransferBatchStatusValue[name]; ok {
+		return x, nil
+	}
+	return TransferBatchStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidTransferBatchStatus)
+}
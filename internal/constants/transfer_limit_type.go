@@ -0,0 +1,6 @@
+package constants
+
+// TransferLimitType ENUM(MAX_SINGLE_AMOUNT, DAILY_AMOUNT, MONTHLY_AMOUNT, DAILY_COUNT)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type TransferLimitType string
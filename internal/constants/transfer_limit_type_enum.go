@@ -0,0 +1,51 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TransferLimitTypeMAXSINGLEAMOUNT is a TransferLimitType of type MAX_SINGLE_AMOUNT.
+	TransferLimitTypeMAXSINGLEAMOUNT TransferLimitType = "MAX_SINGLE_AMOUNT"
+	// TransferLimitTypeDAILYAMOUNT is a TransferLimitType of type DAILY_AMOUNT.
+	TransferLimitTypeDAILYAMOUNT TransferLimitType = "DAILY_AMOUNT"
+	// TransferLimitTypeMONTHLYAMOUNT is a TransferLimitType of type MONTHLY_AMOUNT.
+	TransferLimitTypeMONTHLYAMOUNT TransferLimitType = "MONTHLY_AMOUNT"
+	// TransferLimitTypeDAILYCOUNT is a TransferLimitType of type DAILY_COUNT.
+	TransferLimitTypeDAILYCOUNT TransferLimitType = "DAILY_COUNT"
+)
+
+var ErrInvalidTransferLimitType = errors.New("not a valid TransferLimitType")
+
+// String implements the Stringer interface.
+func (x TransferLimitType) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x TransferLimitType) IsValid() bool {
+	_, err := ParseTransferLimitType(string(x))
+	return err == nil
+}
+
+var _TransferLimitTypeValue = map[string]TransferLimitType{
+	"MAX_SINGLE_AMOUNT": TransferLimitTypeMAXSINGLEAMOUNT,
+	"DAILY_AMOUNT":      TransferLimitTypeDAILYAMOUNT,
+	"MONTHLY_AMOUNT":    TransferLimitTypeMONTHLYAMOUNT,
+	"DAILY_COUNT":       TransferLimitTypeDAILYCOUNT,
+}
+
+// ParseTransferLimitType attempts to convert a string to a TransferLimitType.
+func ParseTransferLimitType(name string) (TransferLimitType, error) {
+	if x, ok := _TransferLimitTypeValue[name]; ok {
+		return x, nil
+	}
+	return TransferLimitType(""), fmt.Errorf("%s is %w", name, ErrInvalidTransferLimitType)
+}
@@ -0,0 +1,6 @@
+package constants
+
+// TransferState ENUM(SCHEDULED, AWAITING_APPROVAL, PROCESSING, COMPLETED, FAILED, CANCELLED, REJECTED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type TransferState string
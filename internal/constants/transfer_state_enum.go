@@ -0,0 +1,60 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// TransferStateSCHEDULED is a TransferState of type SCHEDULED.
+	TransferStateSCHEDULED TransferState = "SCHEDULED"
+	// TransferStateAWAITINGAPPROVAL is a TransferState of type AWAITING_APPROVAL.
+	TransferStateAWAITINGAPPROVAL TransferState = "AWAITING_APPROVAL"
+	// TransferStatePROCESSING is a TransferState of type PROCESSING.
+	TransferStatePROCESSING TransferState = "PROCESSING"
+	// TransferStateCOMPLETED is a TransferState of type COMPLETED.
+	TransferStateCOMPLETED TransferState = "COMPLETED"
+	// TransferStateFAILED is a TransferState of type FAILED.
+	TransferStateFAILED TransferState = "FAILED"
+	// TransferStateCANCELLED is a TransferState of type CANCELLED.
+	TransferStateCANCELLED TransferState = "CANCELLED"
+	// TransferStateREJECTED is a TransferState of type REJECTED.
+	TransferStateREJECTED TransferState = "REJECTED"
+)
+
+var ErrInvalidTransferState = errors.New("not a valid TransferState")
+
+// String implements the Stringer interface.
+func (x TransferState) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x TransferState) IsValid() bool {
+	_, err := ParseTransferState(string(x))
+	return err == nil
+}
+
+var _TransferStateValue = map[string]TransferState{
+	"SCHEDULED":         TransferStateSCHEDULED,
+	"AWAITING_APPROVAL": TransferStateAWAITINGAPPROVAL,
+	"PROCESSING":        TransferStatePROCESSING,
+	"COMPLETED":         TransferStateCOMPLETED,
+	"FAILED":            TransferStateFAILED,
+	"CANCELLED":         TransferStateCANCELLED,
+	"REJECTED":          TransferStateREJECTED,
+}
+
+// ParseTransferState attempts to convert a string to a TransferState.
+func ParseTransferState(name string) (TransferState, error) {
+	if x, ok := _TransferStateValue[name]; ok {
+		return x, nil
+	}
+	return TransferState(""), fmt.Errorf("%s is %w", name, ErrInvalidTransferState)
+}
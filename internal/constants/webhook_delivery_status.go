@@ -0,0 +1,6 @@
+package constants
+
+// WebhookDeliveryStatus ENUM(PENDING, SUCCEEDED, FAILED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type WebhookDeliveryStatus string
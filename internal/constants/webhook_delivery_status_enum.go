@@ -0,0 +1,48 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// WebhookDeliveryStatusPENDING is a WebhookDeliveryStatus of type PENDING.
+	WebhookDeliveryStatusPENDING WebhookDeliveryStatus = "PENDING"
+	// WebhookDeliveryStatusSUCCEEDED is a WebhookDeliveryStatus of type SUCCEEDED.
+	WebhookDeliveryStatusSUCCEEDED WebhookDeliveryStatus = "SUCCEEDED"
+	// WebhookDeliveryStatusFAILED is a WebhookDeliveryStatus of type FAILED.
+	WebhookDeliveryStatusFAILED WebhookDeliveryStatus = "FAILED"
+)
+
+var ErrInvalidWebhookDeliveryStatus = errors.New("not a valid WebhookDeliveryStatus")
+
+// String implements the Stringer interface.
+func (x WebhookDeliveryStatus) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x WebhookDeliveryStatus) IsValid() bool {
+	_, err := ParseWebhookDeliveryStatus(string(x))
+	return err == nil
+}
+
+var _WebhookDeliveryStatusValue = map[string]WebhookDeliveryStatus{
+	"PENDING":   WebhookDeliveryStatusPENDING,
+	"SUCCEEDED": WebhookDeliveryStatusSUCCEEDED,
+	"FAILED":    WebhookDeliveryStatusFAILED,
+}
+
+// ParseWebhookDeliveryStatus attempts to convert a string to a WebhookDeliveryStatus.
+func ParseWebhookDeliveryStatus(name string) (WebhookDeliveryStatus, error) {
+	if x, ok := _WebhookDeliveryStatusValue[name]; ok {
+		return x, nil
+	}
+	return WebhookDeliveryStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidWebhookDeliveryStatus)
+}
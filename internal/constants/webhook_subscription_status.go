@@ -0,0 +1,6 @@
+package constants
+
+// WebhookSubscriptionStatus ENUM(ACTIVE, DISABLED)
+//
+//go:generate go run github.com/abice/go-enum@v0.5.5
+type WebhookSubscriptionStatus string
@@ -0,0 +1,45 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package constants
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// WebhookSubscriptionStatusACTIVE is a WebhookSubscriptionStatus of type ACTIVE.
+	WebhookSubscriptionStatusACTIVE WebhookSubscriptionStatus = "ACTIVE"
+	// WebhookSubscriptionStatusDISABLED is a WebhookSubscriptionStatus of type DISABLED.
+	WebhookSubscriptionStatusDISABLED WebhookSubscriptionStatus = "DISABLED"
+)
+
+var ErrInvalidWebhookSubscriptionStatus = errors.New("not a valid WebhookSubscriptionStatus")
+
+// String implements the Stringer interface.
+func (x WebhookSubscriptionStatus) String() string {
+	return string(x)
+}
+
+// String implements the Stringer interface.
+func (x WebhookSubscriptionStatus) IsValid() bool {
+	_, err := ParseWebhookSubscriptionStatus(string(x))
+	return err == nil
+}
+
+var _WebhookSubscriptionStatusValue = map[string]WebhookSubscriptionStatus{
+	"ACTIVE":   WebhookSubscriptionStatusACTIVE,
+	"DISABLED": WebhookSubscriptionStatusDISABLED,
+}
+
+// ParseWebhookSubscriptionStatus attempts to convert a string to a WebhookSubscriptionStatus.
+func ParseWebhookSubscriptionStatus(name string) (WebhookSubscriptionStatus, error) {
+	if x, ok := _WebhookSubscriptionStatusValue[name]; ok {
+		return x, nil
+	}
+	return WebhookSubscriptionStatus(""), fmt.Errorf("%s is %w", name, ErrInvalidWebhookSubscriptionStatus)
+}
@@ -0,0 +1,60 @@
+package fees
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// Rule is one version of a fee rule. Rules sharing a Code are versions of the same rule,
+// the highest version already in effect supersedes the previous ones.
+type Rule struct {
+	ID             uuid.UUID                 `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	Code           string                    `gorm:"type:varchar(100);not null"`
+	Version        int                       `gorm:"not null"`
+	RuleType       constants.FeeRuleType     `gorm:"type:varchar(20);not null"`
+	Currency       *string                   `gorm:"type:varchar(3)"`
+	AccountProduct *string                   `gorm:"type:varchar(50)"`
+	FlatAmount     int                       `gorm:"not null"`
+	PercentageBps  int                       `gorm:"not null"`
+	MinAmount      *int                      `gorm:"type:bigint"`
+	MaxAmount      *int                      `gorm:"type:bigint"`
+	Tiers          datatypes.JSONSlice[Tier] `gorm:"type:jsonb"`
+	EffectiveFrom  time.Time                 `gorm:"type:timestamp with time zone;not null"`
+	EffectiveTo    *time.Time                `gorm:"type:timestamp with time zone"`
+	CreatedAt      time.Time                 `gorm:"type:timestamp with time zone;not null"`
+	UpdatedAt      time.Time                 `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Rule) TableName() string {
+	return "fee_rules"
+}
+
+// Tier applies to the amounts up to and including UpTo, a nil UpTo covers every larger amount.
+type Tier struct {
+	UpTo          *int `json:"up_to,omitempty"`
+	FlatAmount    int  `json:"flat_amount"`
+	PercentageBps int  `json:"percentage_bps"`
+}
+
+// RuleReference identifies the rule version a fee was computed with.
+type RuleReference struct {
+	ID      uuid.UUID
+	Code    string
+	Version int
+}
+
+// Quote is the fee computed for a transfer amount, Rule is nil when no rule applies and the fee is zero.
+type Quote struct {
+	Amount   int
+	Currency string
+	Rule     *RuleReference
+}
+
+// QuoteRequest describes the transfer a fee is computed for, At selects the rule versions in effect.
+type QuoteRequest struct {
+	SourceAccountID uuid.UUID
+	Amount          int
+	At              time.Time
+}
@@ -0,0 +1,92 @@
+package fees
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Repository interface {
+	Create(ctx context.Context, rule *Rule) (*Rule, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+	List(ctx context.Context, code string) ([]*Rule, error)
+	GetCandidates(ctx context.Context, currency, accountProduct string, at time.Time) ([]*Rule, error)
+	GetLatestVersion(ctx context.Context, code string) (int, error)
+	UpdateEffectiveTo(ctx context.Context, id uuid.UUID, effectiveTo time.Time) error
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (r *SQLRepository) Create(ctx context.Context, rule *Rule) (*Rule, error) {
+	if err := r.db.WithContext(ctx).Create(rule).Error; err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+func (r *SQLRepository) GetByID(ctx context.Context, id uuid.UUID) (*Rule, error) {
+	var rule Rule
+	if err := r.db.WithContext(ctx).First(&rule, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rule, nil
+}
+
+// List returns every rule version ordered by code and version, optionally only the versions of one code.
+func (r *SQLRepository) List(ctx context.Context, code string) ([]*Rule, error) {
+	var rules []*Rule
+
+	query := r.db.WithContext(ctx).Order("code, version")
+	if code != "" {
+		query = query.Where("code = ?", code)
+	}
+
+	if err := query.Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// GetCandidates returns the rule versions started at the given time that match the currency and account product,
+// rules without a currency or product match any.
+func (r *SQLRepository) GetCandidates(ctx context.Context, currency, accountProduct string, at time.Time) ([]*Rule, error) {
+	var rules []*Rule
+	if err := r.db.WithContext(ctx).
+		Where("effective_from <= ?", at).
+		Where("currency IS NULL OR currency = ?", currency).
+		Where("account_product IS NULL OR account_product = ?", accountProduct).
+		Find(&rules).Error; err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func (r *SQLRepository) GetLatestVersion(ctx context.Context, code string) (int, error) {
+	var version int
+	if err := r.db.WithContext(ctx).Model(&Rule{}).
+		Where("code = ?", code).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&version).Error; err != nil {
+		return 0, err
+	}
+	return version, nil
+}
+
+func (r *SQLRepository) UpdateEffectiveTo(ctx context.Context, id uuid.UUID, effectiveTo time.Time) error {
+	if err := r.db.WithContext(ctx).Model(&Rule{}).Where("id = ?", id).Update("effective_to", effectiveTo).Error; err != nil {
+		return err
+	}
+	return nil
+}
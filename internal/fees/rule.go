@@ -0,0 +1,204 @@
+package fees
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+const basisPointsDivisor = 10000
+
+var (
+	ErrInvalidRule  = errors.New("invalid fee rule")
+	ErrRuleNotFound = errors.New("fee rule not found")
+)
+
+// Calculate returns the fee of the given amount, percentages are rounded half up to the smallest currency unit.
+func (r *Rule) Calculate(amount int) int {
+	var fee int
+
+	switch r.RuleType {
+	case constants.FeeRuleTypeFLAT:
+		fee = r.FlatAmount
+	case constants.FeeRuleTypePERCENTAGE:
+		fee = r.FlatAmount + percentageOf(amount, r.PercentageBps)
+	case constants.FeeRuleTypeTIERED:
+		tier := r.tierFor(amount)
+		if tier != nil {
+			fee = tier.FlatAmount + percentageOf(amount, tier.PercentageBps)
+		}
+	}
+
+	if r.MinAmount != nil && fee < *r.MinAmount {
+		fee = *r.MinAmount
+	}
+
+	if r.MaxAmount != nil && fee > *r.MaxAmount {
+		fee = *r.MaxAmount
+	}
+
+	return fee
+}
+
+// IsEffectiveAt reports whether the rule version can be applied at the given time.
+func (r *Rule) IsEffectiveAt(at time.Time) bool {
+	if r.EffectiveFrom.After(at) {
+		return false
+	}
+
+	return r.EffectiveTo == nil || r.EffectiveTo.After(at)
+}
+
+func (r *Rule) Reference() *RuleReference {
+	return &RuleReference{ID: r.ID, Code: r.Code, Version: r.Version}
+}
+
+// Validate checks the rule is consistent with its type before it is stored.
+func (r *Rule) Validate() error {
+	if r.Code == "" {
+		return fmt.Errorf("%w: code is required", ErrInvalidRule)
+	}
+
+	if !r.RuleType.IsValid() {
+		return fmt.Errorf("%w: unknown rule type: %s", ErrInvalidRule, r.RuleType)
+	}
+
+	if r.FlatAmount < 0 {
+		return fmt.Errorf("%w: flat amount cannot be negative", ErrInvalidRule)
+	}
+
+	if !isValidBps(r.PercentageBps) {
+		return fmt.Errorf("%w: percentage must be between 0 and %d basis points", ErrInvalidRule, basisPointsDivisor)
+	}
+
+	if r.MinAmount != nil && *r.MinAmount < 0 || r.MaxAmount != nil && *r.MaxAmount < 0 {
+		return fmt.Errorf("%w: min and max amounts cannot be negative", ErrInvalidRule)
+	}
+
+	if r.MinAmount != nil && r.MaxAmount != nil && *r.MinAmount > *r.MaxAmount {
+		return fmt.Errorf("%w: min amount is greater than max amount", ErrInvalidRule)
+	}
+
+	if r.EffectiveTo != nil && !r.EffectiveTo.After(r.EffectiveFrom) {
+		return fmt.Errorf("%w: effective to must be after effective from", ErrInvalidRule)
+	}
+
+	if r.RuleType == constants.FeeRuleTypeTIERED {
+		return r.validateTiers()
+	}
+
+	if len(r.Tiers) > 0 {
+		return fmt.Errorf("%w: tiers are only allowed on %s rules", ErrInvalidRule, constants.FeeRuleTypeTIERED)
+	}
+
+	return nil
+}
+
+func (r *Rule) validateTiers() error {
+	if len(r.Tiers) == 0 {
+		return fmt.Errorf("%w: at least one tier is required", ErrInvalidRule)
+	}
+
+	previousUpTo := 0
+
+	for i, tier := range r.Tiers {
+		if tier.FlatAmount < 0 || !isValidBps(tier.PercentageBps) {
+			return fmt.Errorf("%w: tier %d has an invalid flat amount or percentage", ErrInvalidRule, i)
+		}
+
+		if tier.UpTo == nil {
+			if i != len(r.Tiers)-1 {
+				return fmt.Errorf("%w: only the last tier can be open ended", ErrInvalidRule)
+			}
+
+			continue
+		}
+
+		if *tier.UpTo <= previousUpTo {
+			return fmt.Errorf("%w: tier upper bounds must be ascending", ErrInvalidRule)
+		}
+
+		previousUpTo = *tier.UpTo
+	}
+
+	return nil
+}
+
+func (r *Rule) tierFor(amount int) *Tier {
+	for i := range r.Tiers {
+		if r.Tiers[i].UpTo == nil || amount <= *r.Tiers[i].UpTo {
+			return &r.Tiers[i]
+		}
+	}
+
+	return nil
+}
+
+// specificity ranks account product rules above currency rules and both above catch-all rules.
+func (r *Rule) specificity() int {
+	score := 0
+
+	if r.AccountProduct != nil {
+		score += 2
+	}
+
+	if r.Currency != nil {
+		score++
+	}
+
+	return score
+}
+
+// SelectRule picks the rule to apply among the candidates matching a transfer. Only the latest version of each
+// code in effect at the given time is considered, then the most specific one wins. Remaining ties are broken by
+// the latest effective date and the code, so the same candidates always give the same rule.
+func SelectRule(candidates []*Rule, at time.Time) *Rule {
+	latestVersions := make(map[string]*Rule)
+
+	for _, rule := range candidates {
+		if rule.EffectiveFrom.After(at) {
+			continue
+		}
+
+		latest, ok := latestVersions[rule.Code]
+		if !ok || rule.Version > latest.Version {
+			latestVersions[rule.Code] = rule
+		}
+	}
+
+	effective := make([]*Rule, 0, len(latestVersions))
+
+	for _, rule := range latestVersions {
+		if rule.IsEffectiveAt(at) {
+			effective = append(effective, rule)
+		}
+	}
+
+	if len(effective) == 0 {
+		return nil
+	}
+
+	sort.Slice(effective, func(i, j int) bool {
+		if effective[i].specificity() != effective[j].specificity() {
+			return effective[i].specificity() > effective[j].specificity()
+		}
+
+		if !effective[i].EffectiveFrom.Equal(effective[j].EffectiveFrom) {
+			return effective[i].EffectiveFrom.After(effective[j].EffectiveFrom)
+		}
+
+		return effective[i].Code < effective[j].Code
+	})
+
+	return effective[0]
+}
+
+func percentageOf(amount, bps int) int {
+	return (amount*bps + basisPointsDivisor/2) / basisPointsDivisor
+}
+
+func isValidBps(bps int) bool {
+	return bps >= 0 && bps <= basisPointsDivisor
+}
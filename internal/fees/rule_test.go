@@ -0,0 +1,142 @@
+//go:build tests_unit
+
+package fees_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/fees"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func TestRule_Calculate(t *testing.T) {
+	tests := []struct {
+		name   string
+		rule   fees.Rule
+		amount int
+		want   int
+	}{
+		{
+			name:   "flat",
+			rule:   fees.Rule{RuleType: constants.FeeRuleTypeFLAT, FlatAmount: 250},
+			amount: 10000,
+			want:   250,
+		},
+		{
+			name:   "percentage rounds half up",
+			rule:   fees.Rule{RuleType: constants.FeeRuleTypePERCENTAGE, PercentageBps: 150},
+			amount: 1030,
+			want:   15,
+		},
+		{
+			name:   "percentage below min",
+			rule:   fees.Rule{RuleType: constants.FeeRuleTypePERCENTAGE, PercentageBps: 100, MinAmount: intPtr(50)},
+			amount: 1000,
+			want:   50,
+		},
+		{
+			name:   "percentage above max",
+			rule:   fees.Rule{RuleType: constants.FeeRuleTypePERCENTAGE, PercentageBps: 100, MaxAmount: intPtr(500)},
+			amount: 100000,
+			want:   500,
+		},
+		{
+			name: "tiered picks the first matching tier",
+			rule: fees.Rule{RuleType: constants.FeeRuleTypeTIERED, Tiers: datatypes.NewJSONSlice([]fees.Tier{
+				{UpTo: intPtr(1000), FlatAmount: 10},
+				{UpTo: intPtr(10000), FlatAmount: 5, PercentageBps: 50},
+				{FlatAmount: 0, PercentageBps: 20},
+			})},
+			amount: 5000,
+			want:   30,
+		},
+		{
+			name: "tiered open ended tier",
+			rule: fees.Rule{RuleType: constants.FeeRuleTypeTIERED, Tiers: datatypes.NewJSONSlice([]fees.Tier{
+				{UpTo: intPtr(1000), FlatAmount: 10},
+				{PercentageBps: 20},
+			})},
+			amount: 50000,
+			want:   100,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.rule.Calculate(tt.amount))
+		})
+	}
+}
+
+func TestRule_Validate(t *testing.T) {
+	now := time.Now()
+
+	valid := fees.Rule{Code: "standard", RuleType: constants.FeeRuleTypePERCENTAGE, PercentageBps: 100, EffectiveFrom: now}
+	require.NoError(t, valid.Validate())
+
+	invalidRules := map[string]fees.Rule{
+		"missing code":         {RuleType: constants.FeeRuleTypeFLAT, EffectiveFrom: now},
+		"unknown type":         {Code: "x", RuleType: "UNKNOWN", EffectiveFrom: now},
+		"percentage too high":  {Code: "x", RuleType: constants.FeeRuleTypePERCENTAGE, PercentageBps: 10001, EffectiveFrom: now},
+		"min above max":        {Code: "x", RuleType: constants.FeeRuleTypeFLAT, MinAmount: intPtr(10), MaxAmount: intPtr(5), EffectiveFrom: now},
+		"tiered without tiers": {Code: "x", RuleType: constants.FeeRuleTypeTIERED, EffectiveFrom: now},
+		"tiers not ascending": {Code: "x", RuleType: constants.FeeRuleTypeTIERED, EffectiveFrom: now, Tiers: datatypes.NewJSONSlice([]fees.Tier{
+			{UpTo: intPtr(1000)},
+			{UpTo: intPtr(500)},
+		})},
+		"open ended tier not last": {Code: "x", RuleType: constants.FeeRuleTypeTIERED, EffectiveFrom: now, Tiers: datatypes.NewJSONSlice([]fees.Tier{
+			{},
+			{UpTo: intPtr(500)},
+		})},
+		"effective to before from": {Code: "x", RuleType: constants.FeeRuleTypeFLAT, EffectiveFrom: now, EffectiveTo: &now},
+	}
+
+	for name, rule := range invalidRules {
+		t.Run(name, func(t *testing.T) {
+			require.ErrorIs(t, rule.Validate(), fees.ErrInvalidRule)
+		})
+	}
+}
+
+func TestSelectRule(t *testing.T) {
+	now := time.Date(2024, 9, 5, 12, 0, 0, 0, time.UTC)
+	yesterday := now.Add(-24 * time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+
+	catchAll := &fees.Rule{ID: uuid.New(), Code: "default", Version: 1, EffectiveFrom: yesterday}
+	usd := &fees.Rule{ID: uuid.New(), Code: "usd", Version: 1, Currency: stringPtr("USD"), EffectiveFrom: yesterday}
+	usdV2 := &fees.Rule{ID: uuid.New(), Code: "usd", Version: 2, Currency: stringPtr("USD"), EffectiveFrom: now}
+	usdV3 := &fees.Rule{ID: uuid.New(), Code: "usd", Version: 3, Currency: stringPtr("USD"), EffectiveFrom: tomorrow}
+	premium := &fees.Rule{ID: uuid.New(), Code: "premium", Version: 1, AccountProduct: stringPtr("PREMIUM"), EffectiveFrom: yesterday, EffectiveTo: &now}
+
+	t.Run("most specific rule wins", func(t *testing.T) {
+		assert.Equal(t, usd, fees.SelectRule([]*fees.Rule{catchAll, usd}, now))
+	})
+
+	t.Run("latest started version supersedes older versions", func(t *testing.T) {
+		assert.Equal(t, usdV2, fees.SelectRule([]*fees.Rule{catchAll, usd, usdV2, usdV3}, now))
+		assert.Equal(t, usd, fees.SelectRule([]*fees.Rule{catchAll, usd, usdV2, usdV3}, now.Add(-time.Minute)))
+	})
+
+	t.Run("retired rules are skipped", func(t *testing.T) {
+		assert.Equal(t, premium, fees.SelectRule([]*fees.Rule{catchAll, premium}, now.Add(-time.Minute)))
+		assert.Equal(t, catchAll, fees.SelectRule([]*fees.Rule{catchAll, premium}, now))
+	})
+
+	t.Run("no rule", func(t *testing.T) {
+		assert.Nil(t, fees.SelectRule(nil, now))
+	})
+}
@@ -0,0 +1,113 @@
+package fees
+
+import (
+	"context"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+)
+
+type Service interface {
+	CreateRule(ctx context.Context, rule *Rule) (*Rule, error)
+	GetRuleByID(ctx context.Context, id uuid.UUID) (*Rule, error)
+	ListRules(ctx context.Context, code string) ([]*Rule, error)
+	RetireRule(ctx context.Context, id uuid.UUID, at time.Time) (*Rule, error)
+	CalculateFee(ctx context.Context, request QuoteRequest) (*Quote, error)
+}
+
+type FeeServiceImpl struct {
+	repo            Repository
+	accountsService accounts.Service
+}
+
+func NewFeeService(repo Repository, accountsService accounts.Service) *FeeServiceImpl {
+	return &FeeServiceImpl{repo: repo, accountsService: accountsService}
+}
+
+// CreateRule stores the rule as the next version of its code, rules are never edited in place.
+func (s *FeeServiceImpl) CreateRule(ctx context.Context, rule *Rule) (*Rule, error) {
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+
+	latestVersion, err := s.repo.GetLatestVersion(ctx, rule.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	rule.ID = uuid.New()
+	rule.Version = latestVersion + 1
+
+	return s.repo.Create(ctx, rule)
+}
+
+func (s *FeeServiceImpl) GetRuleByID(ctx context.Context, id uuid.UUID) (*Rule, error) {
+	rule, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule == nil {
+		return nil, ErrRuleNotFound
+	}
+	return rule, nil
+}
+
+func (s *FeeServiceImpl) ListRules(ctx context.Context, code string) ([]*Rule, error) {
+	return s.repo.List(ctx, code)
+}
+
+// RetireRule ends the rule version at the given time, a version already ended earlier is left as is.
+func (s *FeeServiceImpl) RetireRule(ctx context.Context, id uuid.UUID, at time.Time) (*Rule, error) {
+	rule, err := s.GetRuleByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.EffectiveTo != nil && !rule.EffectiveTo.After(at) {
+		return rule, nil
+	}
+
+	if at.Before(rule.EffectiveFrom) {
+		at = rule.EffectiveFrom
+	}
+
+	if err := s.repo.UpdateEffectiveTo(ctx, rule.ID, at); err != nil {
+		return nil, err
+	}
+
+	rule.EffectiveTo = &at
+
+	return rule, nil
+}
+
+// CalculateFee computes the fee of a transfer from the rules in effect at request.At for the currency and
+// product of the source account. The fee is zero when no rule applies.
+func (s *FeeServiceImpl) CalculateFee(ctx context.Context, request QuoteRequest) (*Quote, error) {
+	if request.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive: %d", request.Amount)
+	}
+
+	account, err := s.accountsService.GetAccountByID(ctx, request.SourceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.repo.GetCandidates(ctx, account.Currency, account.Product, request.At)
+	if err != nil {
+		return nil, err
+	}
+
+	quote := &Quote{Currency: account.Currency}
+
+	rule := SelectRule(candidates, request.At)
+	if rule == nil {
+		return quote, nil
+	}
+
+	quote.Amount = rule.Calculate(request.Amount)
+	quote.Rule = rule.Reference()
+
+	return quote, nil
+}
@@ -0,0 +1,41 @@
+package fx
+
+import (
+	"math/big"
+	"strconv"
+	"time"
+)
+
+// Convert quotes the amount at the rate with its spread applied. The rate is taken at its shortest decimal
+// representation and converted amounts are rounded down, so the same rate always yields the same amounts.
+func Convert(rate Rate, amount int, at time.Time) *Quote {
+	midRate := decimalRat(rate.Rate)
+	appliedRate := new(big.Rat).Mul(midRate, big.NewRat(int64(10000-rate.SpreadBps), 10000))
+
+	midAmount := floor(new(big.Rat).Mul(midRate, big.NewRat(int64(amount), 1)))
+	targetAmount := floor(new(big.Rat).Mul(appliedRate, big.NewRat(int64(amount), 1)))
+
+	appliedRateValue, _ := appliedRate.Float64()
+
+	return &Quote{
+		SourceCurrency: rate.SourceCurrency,
+		TargetCurrency: rate.TargetCurrency,
+		Rate:           rate.Rate,
+		AppliedRate:    appliedRateValue,
+		SpreadBps:      rate.SpreadBps,
+		SourceAmount:   amount,
+		TargetAmount:   targetAmount,
+		SpreadAmount:   midAmount - targetAmount,
+		QuotedAt:       at,
+	}
+}
+
+func decimalRat(value float64) *big.Rat {
+	rat, _ := new(big.Rat).SetString(strconv.FormatFloat(value, 'f', -1, 64))
+
+	return rat
+}
+
+func floor(value *big.Rat) int {
+	return int(new(big.Int).Quo(value.Num(), value.Denom()).Int64())
+}
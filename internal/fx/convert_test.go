@@ -0,0 +1,98 @@
+//go:build tests_unit
+
+package fx_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/fx"
+)
+
+func TestConvert(t *testing.T) {
+	quotedAt := time.Date(2024, 9, 12, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		rate             fx.Rate
+		amount           int
+		wantTargetAmount int
+		wantSpreadAmount int
+	}{
+		{
+			name:             "without spread",
+			rate:             fx.Rate{SourceCurrency: "USD", TargetCurrency: "EUR", Rate: 0.92},
+			amount:           10000,
+			wantTargetAmount: 9200,
+		},
+		{
+			name:             "spread is kept back from the target amount",
+			rate:             fx.Rate{SourceCurrency: "USD", TargetCurrency: "EUR", Rate: 0.92, SpreadBps: 50},
+			amount:           10000,
+			wantTargetAmount: 9154,
+			wantSpreadAmount: 46,
+		},
+		{
+			name:             "decimal rate doesn't lose a minor unit",
+			rate:             fx.Rate{SourceCurrency: "TRY", TargetCurrency: "USD", Rate: 0.29},
+			amount:           100,
+			wantTargetAmount: 29,
+		},
+		{
+			name:             "converted amount is rounded down",
+			rate:             fx.Rate{SourceCurrency: "USD", TargetCurrency: "TRY", Rate: 34.1234},
+			amount:           1,
+			wantTargetAmount: 34,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quote := fx.Convert(tt.rate, tt.amount, quotedAt)
+
+			assert.Equal(t, tt.amount, quote.SourceAmount)
+			assert.Equal(t, tt.wantTargetAmount, quote.TargetAmount)
+			assert.Equal(t, tt.wantSpreadAmount, quote.SpreadAmount)
+			assert.Equal(t, tt.rate.Rate, quote.Rate)
+			assert.Equal(t, quotedAt, quote.QuotedAt)
+		})
+	}
+}
+
+func TestStaticRateProvider_GetRate(t *testing.T) {
+	provider, err := fx.NewStaticRateProvider(map[string]float64{"usd/eur": 0.8}, 25)
+	require.NoError(t, err)
+
+	rate, err := provider.GetRate(context.Background(), "USD", "EUR")
+	require.NoError(t, err)
+	assert.Equal(t, 0.8, rate.Rate)
+	assert.Equal(t, 25, rate.SpreadBps)
+
+	inverseRate, err := provider.GetRate(context.Background(), "EUR", "USD")
+	require.NoError(t, err)
+	assert.Equal(t, 1.25, inverseRate.Rate)
+
+	_, err = provider.GetRate(context.Background(), "USD", "TRY")
+	require.ErrorIs(t, err, fx.ErrRateNotFound)
+}
+
+func TestNewFileRateProvider(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"spread_bps": 50, "rates": {"USD/TRY": 34.1}}`), 0o600))
+
+	provider, err := fx.NewFileRateProvider(path)
+	require.NoError(t, err)
+
+	rate, err := provider.GetRate(context.Background(), "USD", "TRY")
+	require.NoError(t, err)
+	assert.Equal(t, 34.1, rate.Rate)
+	assert.Equal(t, 50, rate.SpreadBps)
+
+	_, err = fx.NewStaticRateProvider(map[string]float64{"USDTRY": 34.1}, 0)
+	require.Error(t, err)
+}
@@ -0,0 +1,37 @@
+package fx
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// Rate is the mid-market rate of a currency pair, one unit of SourceCurrency buys Rate units of TargetCurrency.
+// SpreadBps is the margin, in basis points, taken off the mid-market rate when converting.
+type Rate struct {
+	SourceCurrency string
+	TargetCurrency string
+	Rate           float64
+	SpreadBps      int
+}
+
+// Quote is the conversion of a transfer amount at a rate. Amounts are in the minor units of their currency,
+// SpreadAmount is what the spread kept back, in the target currency.
+type Quote struct {
+	SourceCurrency string
+	TargetCurrency string
+	Rate           float64
+	AppliedRate    float64
+	SpreadBps      int
+	SourceAmount   int
+	TargetAmount   int
+	SpreadAmount   int
+	QuotedAt       time.Time
+}
+
+// QuoteRequest describes the transfer a conversion is quoted for.
+type QuoteRequest struct {
+	SourceAccountID      uuid.UUID
+	DestinationAccountID uuid.UUID
+	Amount               int
+	At                   time.Time
+}
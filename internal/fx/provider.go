@@ -0,0 +1,89 @@
+package fx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+var ErrRateNotFound = errors.New("fx rate not found")
+
+type FXRateProvider interface {
+	GetRate(ctx context.Context, sourceCurrency, targetCurrency string) (*Rate, error)
+}
+
+// StaticRateProvider serves a fixed set of rates keyed by "SOURCE/TARGET". A pair that is only configured
+// the other way around is served with the inverse rate.
+type StaticRateProvider struct {
+	rates     map[string]float64
+	spreadBps int
+}
+
+type rateFile struct {
+	SpreadBps int                `json:"spread_bps"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+func NewStaticRateProvider(rates map[string]float64, spreadBps int) (*StaticRateProvider, error) {
+	if spreadBps < 0 || spreadBps >= 10000 {
+		return nil, fmt.Errorf("fx spread must be between 0 and 9999 bps: %d", spreadBps)
+	}
+
+	normalizedRates := make(map[string]float64, len(rates))
+
+	for pair, rate := range rates {
+		sourceCurrency, targetCurrency, ok := strings.Cut(strings.ToUpper(pair), "/")
+		if !ok || len(sourceCurrency) != 3 || len(targetCurrency) != 3 {
+			return nil, fmt.Errorf("invalid fx currency pair: %s", pair)
+		}
+
+		if rate <= 0 {
+			return nil, fmt.Errorf("fx rate must be positive: %s", pair)
+		}
+
+		normalizedRates[pairKey(sourceCurrency, targetCurrency)] = rate
+	}
+
+	return &StaticRateProvider{rates: normalizedRates, spreadBps: spreadBps}, nil
+}
+
+// NewFileRateProvider loads the rates from a JSON file such as {"spread_bps": 50, "rates": {"USD/EUR": 0.92}}.
+func NewFileRateProvider(path string) (*StaticRateProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file rateFile
+
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid fx rates file %s: %w", path, err)
+	}
+
+	return NewStaticRateProvider(file.Rates, file.SpreadBps)
+}
+
+func (p *StaticRateProvider) GetRate(_ context.Context, sourceCurrency, targetCurrency string) (*Rate, error) {
+	rate := &Rate{SourceCurrency: sourceCurrency, TargetCurrency: targetCurrency, SpreadBps: p.spreadBps}
+
+	if value, ok := p.rates[pairKey(sourceCurrency, targetCurrency)]; ok {
+		rate.Rate = value
+
+		return rate, nil
+	}
+
+	if value, ok := p.rates[pairKey(targetCurrency, sourceCurrency)]; ok {
+		rate.Rate = 1 / value
+
+		return rate, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s/%s", ErrRateNotFound, sourceCurrency, targetCurrency)
+}
+
+func pairKey(sourceCurrency, targetCurrency string) string {
+	return sourceCurrency + "/" + targetCurrency
+}
@@ -0,0 +1,49 @@
+package fx
+
+import (
+	"context"
+	"fmt"
+	"ulascansenturk/service/internal/accounts"
+)
+
+type Service interface {
+	QuoteTransfer(ctx context.Context, request QuoteRequest) (*Quote, error)
+}
+
+type FXServiceImpl struct {
+	accountsService accounts.Service
+	rateProvider    FXRateProvider
+}
+
+func NewFXService(accountsService accounts.Service, rateProvider FXRateProvider) *FXServiceImpl {
+	return &FXServiceImpl{accountsService: accountsService, rateProvider: rateProvider}
+}
+
+// QuoteTransfer quotes the conversion of a transfer from the source to the destination account currency.
+// The quote is nil when both accounts hold the same currency.
+func (s *FXServiceImpl) QuoteTransfer(ctx context.Context, request QuoteRequest) (*Quote, error) {
+	if request.Amount <= 0 {
+		return nil, fmt.Errorf("amount must be positive: %d", request.Amount)
+	}
+
+	sourceAccount, err := s.accountsService.GetAccountByID(ctx, request.SourceAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	destinationAccount, err := s.accountsService.GetAccountByID(ctx, request.DestinationAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sourceAccount.Currency == destinationAccount.Currency {
+		return nil, nil
+	}
+
+	rate, err := s.rateProvider.GetRate(ctx, sourceAccount.Currency, destinationAccount.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return Convert(*rate, request.Amount, request.At), nil
+}
@@ -0,0 +1,34 @@
+package holds
+
+import (
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// Hold reserves Amount on AccountID for a later capture to DestinationAccountID. The reserved funds count in the
+// held balance of the account until the hold is captured, voided or expired, whichever comes first.
+type Hold struct {
+	ID                   uuid.UUID            `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	ReferenceID          uuid.UUID            `gorm:"type:uuid;not null"`
+	AccountID            uuid.UUID            `gorm:"type:uuid;not null"`
+	DestinationAccountID uuid.UUID            `gorm:"type:uuid;not null"`
+	Amount               int                  `gorm:"type:bigint;not null"`
+	CapturedAmount       int                  `gorm:"type:bigint;not null;default:0"`
+	Currency             string               `gorm:"type:varchar(3);not null"`
+	Status               constants.HoldStatus `gorm:"type:varchar(20);not null"`
+	Description          *string              `gorm:"type:text"`
+	ReleaseReason        *string              `gorm:"type:text"`
+	ExpiresAt            time.Time            `gorm:"type:timestamp with time zone;not null"`
+	ReleasedAt           *time.Time           `gorm:"type:timestamp with time zone"`
+	CreatedAt            time.Time            `gorm:"type:timestamp with time zone;not null"`
+	UpdatedAt            time.Time            `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Hold) TableName() string {
+	return "holds"
+}
+
+func (h *Hold) IsActive() bool {
+	return h.Status == constants.HoldStatusACTIVE
+}
@@ -0,0 +1,92 @@
+package holds
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ulascansenturk/service/internal/constants"
+)
+
+type Repository interface {
+	CreateWithTx(ctx context.Context, hold *Hold, tx *gorm.DB) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Hold, error)
+	GetByReferenceID(ctx context.Context, referenceID uuid.UUID) (*Hold, error)
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID, tx *gorm.DB) (*Hold, error)
+	GetByReferenceIDForUpdate(ctx context.Context, referenceID uuid.UUID, tx *gorm.DB) (*Hold, error)
+	ListByAccountID(ctx context.Context, accountID uuid.UUID, status *constants.HoldStatus) ([]*Hold, error)
+	UpdateWithTx(ctx context.Context, hold *Hold, tx *gorm.DB) error
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (r *SQLRepository) CreateWithTx(ctx context.Context, hold *Hold, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	return tx.WithContext(ctx).Create(hold).Error
+}
+
+func (r *SQLRepository) GetByID(ctx context.Context, id uuid.UUID) (*Hold, error) {
+	return r.first(r.db.WithContext(ctx), "id = ?", id)
+}
+
+func (r *SQLRepository) GetByReferenceID(ctx context.Context, referenceID uuid.UUID) (*Hold, error) {
+	return r.first(r.db.WithContext(ctx), "reference_id = ?", referenceID)
+}
+
+func (r *SQLRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID, tx *gorm.DB) (*Hold, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	return r.first(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), "id = ?", id)
+}
+
+func (r *SQLRepository) GetByReferenceIDForUpdate(ctx context.Context, referenceID uuid.UUID, tx *gorm.DB) (*Hold, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	return r.first(tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}), "reference_id = ?", referenceID)
+}
+
+// ListByAccountID returns the holds of the account, newest first, optionally only the ones with the status.
+func (r *SQLRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID, status *constants.HoldStatus) ([]*Hold, error) {
+	var holds []*Hold
+
+	query := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("created_at DESC")
+	if status != nil {
+		query = query.Where("status = ?", *status)
+	}
+
+	if err := query.Find(&holds).Error; err != nil {
+		return nil, err
+	}
+	return holds, nil
+}
+
+// UpdateWithTx saves every column of the hold, a zero captured amount included.
+func (r *SQLRepository) UpdateWithTx(ctx context.Context, hold *Hold, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	return tx.WithContext(ctx).Save(hold).Error
+}
+
+func (r *SQLRepository) first(query *gorm.DB, condition string, value interface{}) (*Hold, error) {
+	var hold Hold
+	if err := query.First(&hold, condition, value).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &hold, nil
+}
@@ -0,0 +1,201 @@
+package holds
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+)
+
+var (
+	ErrHoldNotFound       = errors.New("hold not found")
+	ErrHoldNotActive      = errors.New("hold is not active")
+	ErrInvalidHold        = errors.New("invalid hold")
+	ErrInsufficientFunds  = errors.New("insufficient available balance")
+	ErrCaptureExceedsHold = errors.New("capture exceeds the held amount")
+)
+
+type Service interface {
+	PlaceHold(ctx context.Context, hold *Hold) (*Hold, error)
+	ReleaseHold(ctx context.Context, id uuid.UUID, status constants.HoldStatus, reason *string, at time.Time) (*Hold, error)
+	GetHold(ctx context.Context, id uuid.UUID) (*Hold, error)
+	GetHoldByReferenceID(ctx context.Context, referenceID uuid.UUID) (*Hold, error)
+	ListHolds(ctx context.Context, accountID uuid.UUID, status *constants.HoldStatus) ([]*Hold, error)
+}
+
+type HoldServiceImpl struct {
+	repo        Repository
+	accountRepo accounts.Repository
+}
+
+func NewHoldService(repo Repository, accountRepo accounts.Repository) *HoldServiceImpl {
+	return &HoldServiceImpl{repo: repo, accountRepo: accountRepo}
+}
+
+// PlaceHold reserves the amount of the hold on its account, the ledger balance is left as is. Placing a hold
+// again with the same reference ID returns the hold already placed.
+func (s *HoldServiceImpl) PlaceHold(ctx context.Context, hold *Hold) (*Hold, error) {
+	if hold.Amount <= 0 {
+		return nil, fmt.Errorf("%w: amount must be positive", ErrInvalidHold)
+	}
+
+	if hold.AccountID == hold.DestinationAccountID {
+		return nil, fmt.Errorf("%w: destination must be another account", ErrInvalidHold)
+	}
+
+	var placedHold *Hold
+
+	err := s.accountRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		account, err := s.accountRepo.GetByIDForUpdate(ctx, hold.AccountID, tx)
+		if err != nil {
+			return err
+		}
+
+		if account == nil {
+			return accounts.ErrAccountNotFound
+		}
+
+		existing, err := s.repo.GetByReferenceIDForUpdate(ctx, hold.ReferenceID, tx)
+		if err != nil {
+			return err
+		}
+
+		if existing != nil {
+			if existing.AccountID != hold.AccountID || existing.Amount != hold.Amount {
+				return fmt.Errorf("%w: reference ID is already used by hold %s", ErrInvalidHold, existing.ID)
+			}
+
+			placedHold = existing
+
+			return nil
+		}
+
+		destinationAccount, err := s.accountRepo.GetByID(ctx, hold.DestinationAccountID)
+		if err != nil {
+			return err
+		}
+
+		if destinationAccount == nil {
+			return fmt.Errorf("%w: destination account not found: %s", ErrInvalidHold, hold.DestinationAccountID)
+		}
+
+		if account.Status != constants.AccountStatusACTIVE || destinationAccount.Status != constants.AccountStatusACTIVE {
+			return fmt.Errorf("%w: accounts must be active", ErrInvalidHold)
+		}
+
+		if account.Currency != destinationAccount.Currency {
+			return fmt.Errorf("%w: destination currency %s differs from %s", ErrInvalidHold, destinationAccount.Currency, account.Currency)
+		}
+
+		if account.AvailableBalance() < hold.Amount {
+			return fmt.Errorf("%w: hold amount: %d, available balance: %d", ErrInsufficientFunds, hold.Amount, account.AvailableBalance())
+		}
+
+		hold.ID = uuid.New()
+		hold.Currency = account.Currency
+		hold.Status = constants.HoldStatusACTIVE
+
+		if err = s.repo.CreateWithTx(ctx, hold, tx); err != nil {
+			return err
+		}
+
+		if err = s.accountRepo.UpdateHeldBalanceWithTx(ctx, account.ID, account.HeldBalance+hold.Amount, tx); err != nil {
+			return err
+		}
+
+		placedHold = hold
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return placedHold, nil
+}
+
+// ReleaseHold gives the held amount back to the available balance, the hold ends with the given status.
+// Releasing a hold that already ended with the same status returns it as is.
+func (s *HoldServiceImpl) ReleaseHold(
+	ctx context.Context,
+	id uuid.UUID,
+	status constants.HoldStatus,
+	reason *string,
+	at time.Time,
+) (*Hold, error) {
+	if status != constants.HoldStatusVOIDED && status != constants.HoldStatusEXPIRED {
+		return nil, fmt.Errorf("%w: a hold is released as %s or %s", ErrInvalidHold, constants.HoldStatusVOIDED, constants.HoldStatusEXPIRED)
+	}
+
+	hold, err := s.GetHold(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.accountRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		account, lockErr := s.accountRepo.GetByIDForUpdate(ctx, hold.AccountID, tx)
+		if lockErr != nil {
+			return lockErr
+		}
+
+		hold, lockErr = s.repo.GetByIDForUpdate(ctx, id, tx)
+		if lockErr != nil {
+			return lockErr
+		}
+
+		if !hold.IsActive() {
+			if hold.Status == status {
+				return nil
+			}
+
+			return fmt.Errorf("%w: %s", ErrHoldNotActive, hold.Status)
+		}
+
+		hold.Status = status
+		hold.ReleaseReason = reason
+		hold.ReleasedAt = &at
+
+		if updateErr := s.repo.UpdateWithTx(ctx, hold, tx); updateErr != nil {
+			return updateErr
+		}
+
+		return s.accountRepo.UpdateHeldBalanceWithTx(ctx, account.ID, account.HeldBalance-hold.Amount, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (s *HoldServiceImpl) GetHold(ctx context.Context, id uuid.UUID) (*Hold, error) {
+	hold, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold == nil {
+		return nil, ErrHoldNotFound
+	}
+	return hold, nil
+}
+
+func (s *HoldServiceImpl) GetHoldByReferenceID(ctx context.Context, referenceID uuid.UUID) (*Hold, error) {
+	hold, err := s.repo.GetByReferenceID(ctx, referenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if hold == nil {
+		return nil, ErrHoldNotFound
+	}
+	return hold, nil
+}
+
+func (s *HoldServiceImpl) ListHolds(ctx context.Context, accountID uuid.UUID, status *constants.HoldStatus) ([]*Hold, error) {
+	return s.repo.ListByAccountID(ctx, accountID, status)
+}
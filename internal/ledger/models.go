@@ -0,0 +1,122 @@
+package ledger
+
+import (
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// LedgerAccount is an account of the chart of accounts. Customer and fee income accounts share the ID of their
+// accounts row, whose balance caches the balance of their postings. House accounts only exist in the ledger.
+type LedgerAccount struct {
+	ID          uuid.UUID                   `gorm:"type:uuid;primaryKey"`
+	AccountType constants.LedgerAccountType `gorm:"type:varchar(20);not null"`
+	Currency    string                      `gorm:"type:varchar(3);not null"`
+	CreatedAt   time.Time                   `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (LedgerAccount) TableName() string {
+	return "ledger_accounts"
+}
+
+// JournalEntry is a balanced set of postings, its debits add up to its credits in every currency.
+type JournalEntry struct {
+	ID        uuid.UUID                  `gorm:"type:uuid;primaryKey"`
+	EntryType constants.JournalEntryType `gorm:"type:varchar(20);not null"`
+	CreatedAt time.Time                  `gorm:"type:timestamp with time zone;not null"`
+	Postings  []*Posting                 `gorm:"-"`
+}
+
+func (JournalEntry) TableName() string {
+	return "journal_entries"
+}
+
+// Posting debits or credits Amount to a ledger account. TransactionID is the transaction the posting settles,
+// house accounts have no transactions.
+type Posting struct {
+	ID              uuid.UUID                   `gorm:"type:uuid;primaryKey"`
+	JournalEntryID  uuid.UUID                   `gorm:"type:uuid;not null"`
+	LedgerAccountID uuid.UUID                   `gorm:"type:uuid;not null"`
+	TransactionID   *uuid.UUID                  `gorm:"type:uuid"`
+	Direction       constants.PostingDirection  `gorm:"type:varchar(6);not null"`
+	Amount          int                         `gorm:"type:bigint;not null"`
+	Currency        string                      `gorm:"type:varchar(3);not null"`
+	CreatedAt       time.Time                   `gorm:"type:timestamp with time zone;not null"`
+	AccountType     constants.LedgerAccountType `gorm:"-"`
+}
+
+func (Posting) TableName() string {
+	return "postings"
+}
+
+// BalanceDelta is how the posting moves the balance of its account. Customer and fee income accounts are what the
+// bank owes, a credit adds to their balance and a debit takes from it.
+func (p *Posting) BalanceDelta() int {
+	if p.Direction == constants.PostingDirectionCREDIT {
+		return p.Amount
+	}
+
+	return -p.Amount
+}
+
+// Leg is the side of a movement on one account.
+type Leg struct {
+	AccountID     uuid.UUID
+	AccountType   constants.LedgerAccountType
+	Currency      string
+	TransactionID *uuid.UUID
+}
+
+// HouseLeg is the leg of a house account, e.g. the FX clearing account of a currency.
+func HouseLeg(accountType constants.LedgerAccountType, currency string) Leg {
+	return Leg{
+		AccountID:   HouseAccountID(accountType, currency),
+		AccountType: accountType,
+		Currency:    currency,
+	}
+}
+
+// HouseAccountID is the ID of the house account of the type in the currency, the migrations derive the same ID
+// with uuid_generate_v5(uuid_ns_url(), 'ledger:<type>:<currency>').
+func HouseAccountID(accountType constants.LedgerAccountType, currency string) uuid.UUID {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("ledger:"+accountType.String()+":"+currency))
+}
+
+func NewJournalEntry(entryType constants.JournalEntryType) *JournalEntry {
+	return &JournalEntry{
+		ID:        uuid.New(),
+		EntryType: entryType,
+	}
+}
+
+// Move debits debitAmount from the from leg and credits creditAmount to the to leg. The amounts only differ
+// between currencies, the entry then goes through the FX clearing accounts of both currencies so each currency
+// stays balanced.
+func (e *JournalEntry) Move(from, to Leg, debitAmount, creditAmount int) *JournalEntry {
+	if from.Currency == to.Currency {
+		e.post(from, constants.PostingDirectionDEBIT, debitAmount)
+		e.post(to, constants.PostingDirectionCREDIT, creditAmount)
+
+		return e
+	}
+
+	e.post(from, constants.PostingDirectionDEBIT, debitAmount)
+	e.post(HouseLeg(constants.LedgerAccountTypeFXCLEARING, from.Currency), constants.PostingDirectionCREDIT, debitAmount)
+	e.post(HouseLeg(constants.LedgerAccountTypeFXCLEARING, to.Currency), constants.PostingDirectionDEBIT, creditAmount)
+	e.post(to, constants.PostingDirectionCREDIT, creditAmount)
+
+	return e
+}
+
+func (e *JournalEntry) post(leg Leg, direction constants.PostingDirection, amount int) {
+	e.Postings = append(e.Postings, &Posting{
+		ID:              uuid.New(),
+		JournalEntryID:  e.ID,
+		LedgerAccountID: leg.AccountID,
+		TransactionID:   leg.TransactionID,
+		Direction:       direction,
+		Amount:          amount,
+		Currency:        leg.Currency,
+		AccountType:     leg.AccountType,
+	})
+}
@@ -0,0 +1,70 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	CreateEntriesWithTx(ctx context.Context, entries []*JournalEntry, tx *gorm.DB) error
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// CreateEntriesWithTx inserts the entries and their postings, the ledger accounts they post to are added to the
+// chart of accounts the first time they are posted to.
+func (r *SQLRepository) CreateEntriesWithTx(ctx context.Context, entries []*JournalEntry, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var (
+		ledgerAccounts []*LedgerAccount
+		postings       []*Posting
+	)
+
+	seen := make(map[string]bool)
+
+	for _, entry := range entries {
+		for _, posting := range entry.Postings {
+			postings = append(postings, posting)
+
+			if seen[posting.LedgerAccountID.String()] {
+				continue
+			}
+
+			seen[posting.LedgerAccountID.String()] = true
+
+			ledgerAccounts = append(ledgerAccounts, &LedgerAccount{
+				ID:          posting.LedgerAccountID,
+				AccountType: posting.AccountType,
+				Currency:    posting.Currency,
+			})
+		}
+	}
+
+	db := tx.WithContext(ctx)
+
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(ledgerAccounts).Error; err != nil {
+		return err
+	}
+
+	if err := db.Create(entries).Error; err != nil {
+		return err
+	}
+
+	return db.Create(postings).Error
+}
@@ -0,0 +1,73 @@
+package ledger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/gorm"
+	"sort"
+	"ulascansenturk/service/internal/constants"
+)
+
+var ErrUnbalancedEntry = errors.New("journal entry is not balanced")
+
+type Service interface {
+	PostWithTx(ctx context.Context, entries []*JournalEntry, tx *gorm.DB) error
+}
+
+type LedgerServiceImpl struct {
+	repo Repository
+}
+
+func NewLedgerService(repo Repository) *LedgerServiceImpl {
+	return &LedgerServiceImpl{repo: repo}
+}
+
+// PostWithTx records the entries in the transaction of the balance updates they make, an unbalanced entry is
+// rejected before anything is written. The database checks the same invariant again when the transaction commits.
+func (s *LedgerServiceImpl) PostWithTx(ctx context.Context, entries []*JournalEntry, tx *gorm.DB) error {
+	for _, entry := range entries {
+		if err := CheckBalanced(entry); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.CreateEntriesWithTx(ctx, entries, tx)
+}
+
+// CheckBalanced makes sure the entry has postings of positive amounts whose debits add up to their credits in
+// every currency.
+func CheckBalanced(entry *JournalEntry) error {
+	if len(entry.Postings) < 2 {
+		return fmt.Errorf("%w: %s has %d postings", ErrUnbalancedEntry, entry.EntryType, len(entry.Postings))
+	}
+
+	sums := make(map[string]int)
+
+	for _, posting := range entry.Postings {
+		if posting.Amount <= 0 {
+			return fmt.Errorf("%w: %s posts %d to %s", ErrUnbalancedEntry, entry.EntryType, posting.Amount, posting.LedgerAccountID)
+		}
+
+		if posting.Direction == constants.PostingDirectionDEBIT {
+			sums[posting.Currency] += posting.Amount
+		} else {
+			sums[posting.Currency] -= posting.Amount
+		}
+	}
+
+	currencies := make([]string, 0, len(sums))
+	for currency := range sums {
+		currencies = append(currencies, currency)
+	}
+
+	sort.Strings(currencies)
+
+	for _, currency := range currencies {
+		if sums[currency] != 0 {
+			return fmt.Errorf("%w: %s, %s debits exceed credits by %d", ErrUnbalancedEntry, entry.EntryType, currency, sums[currency])
+		}
+	}
+
+	return nil
+}
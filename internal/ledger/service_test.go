@@ -0,0 +1,64 @@
+//go:build tests_unit
+
+package ledger_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/ledger"
+)
+
+func TestCheckBalanced(t *testing.T) {
+	customer := ledger.Leg{AccountID: uuid.New(), AccountType: constants.LedgerAccountTypeCUSTOMER, Currency: "EUR"}
+	otherCustomer := ledger.Leg{AccountID: uuid.New(), AccountType: constants.LedgerAccountTypeCUSTOMER, Currency: "EUR"}
+	usdCustomer := ledger.Leg{AccountID: uuid.New(), AccountType: constants.LedgerAccountTypeCUSTOMER, Currency: "USD"}
+
+	t.Run("Same currency movement is balanced", func(t *testing.T) {
+		entry := ledger.NewJournalEntry(constants.JournalEntryTypeTRANSFER).Move(customer, otherCustomer, 100, 100)
+
+		require.NoError(t, ledger.CheckBalanced(entry))
+		require.Len(t, entry.Postings, 2)
+		assert.Equal(t, -100, entry.Postings[0].BalanceDelta())
+		assert.Equal(t, 100, entry.Postings[1].BalanceDelta())
+	})
+
+	t.Run("Cross-currency movement is balanced in both currencies", func(t *testing.T) {
+		entry := ledger.NewJournalEntry(constants.JournalEntryTypeTRANSFER).Move(customer, usdCustomer, 100, 110)
+
+		require.NoError(t, ledger.CheckBalanced(entry))
+		require.Len(t, entry.Postings, 4)
+		assert.Equal(t, ledger.HouseAccountID(constants.LedgerAccountTypeFXCLEARING, "EUR"), entry.Postings[1].LedgerAccountID)
+		assert.Equal(t, ledger.HouseAccountID(constants.LedgerAccountTypeFXCLEARING, "USD"), entry.Postings[2].LedgerAccountID)
+	})
+
+	t.Run("Different amounts in the same currency are rejected", func(t *testing.T) {
+		entry := ledger.NewJournalEntry(constants.JournalEntryTypeTRANSFER).Move(customer, otherCustomer, 100, 90)
+
+		require.ErrorIs(t, ledger.CheckBalanced(entry), ledger.ErrUnbalancedEntry)
+	})
+
+	t.Run("Non-positive amounts are rejected", func(t *testing.T) {
+		entry := ledger.NewJournalEntry(constants.JournalEntryTypeTRANSFER).Move(customer, otherCustomer, 0, 0)
+
+		require.ErrorIs(t, ledger.CheckBalanced(entry), ledger.ErrUnbalancedEntry)
+	})
+
+	t.Run("Entry without postings is rejected", func(t *testing.T) {
+		require.ErrorIs(t, ledger.CheckBalanced(ledger.NewJournalEntry(constants.JournalEntryTypeFEE)), ledger.ErrUnbalancedEntry)
+	})
+}
+
+func TestHouseAccountID(t *testing.T) {
+	// uuid_generate_v5(uuid_ns_url(), 'ledger:FUNDING:EUR') of the ledger migration
+	assert.Equal(t,
+		uuid.NewSHA1(uuid.MustParse("6ba7b811-9dad-11d1-80b4-00c04fd430c8"), []byte("ledger:FUNDING:EUR")),
+		ledger.HouseAccountID(constants.LedgerAccountTypeFUNDING, "EUR"),
+	)
+	assert.NotEqual(t,
+		ledger.HouseAccountID(constants.LedgerAccountTypeFUNDING, "EUR"),
+		ledger.HouseAccountID(constants.LedgerAccountTypeFXCLEARING, "EUR"),
+	)
+}
@@ -0,0 +1,145 @@
+package limits
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"ulascansenturk/service/internal/constants"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrLimitExceeded = errors.New("transfer limit exceeded")
+	ErrInvalidLimit  = errors.New("invalid transfer limit")
+)
+
+func (e *ExceededError) Error() string {
+	return fmt.Sprintf("%s: %s allows %d, already used %d, requested %d", ErrLimitExceeded, e.LimitType, e.Allowed, e.Used, e.Requested)
+}
+
+func (e *ExceededError) Is(target error) bool {
+	return target == ErrLimitExceeded
+}
+
+// Check returns an *ExceededError for the first cap the transfer of the amount would exceed given the usage.
+// A transfer counts for one against the daily count.
+func (l *Limit) Check(amount int, usage Usage) error {
+	if l.MaxSingleAmount != nil && amount > *l.MaxSingleAmount {
+		return &ExceededError{LimitType: constants.TransferLimitTypeMAXSINGLEAMOUNT, Allowed: *l.MaxSingleAmount, Requested: amount}
+	}
+
+	if l.DailyCount != nil && usage.DailyCount+1 > *l.DailyCount {
+		return &ExceededError{LimitType: constants.TransferLimitTypeDAILYCOUNT, Allowed: *l.DailyCount, Used: usage.DailyCount, Requested: 1}
+	}
+
+	if l.DailyAmount != nil && usage.DailyAmount+amount > *l.DailyAmount {
+		return &ExceededError{LimitType: constants.TransferLimitTypeDAILYAMOUNT, Allowed: *l.DailyAmount, Used: usage.DailyAmount, Requested: amount}
+	}
+
+	if l.MonthlyAmount != nil && usage.MonthlyAmount+amount > *l.MonthlyAmount {
+		return &ExceededError{LimitType: constants.TransferLimitTypeMONTHLYAMOUNT, Allowed: *l.MonthlyAmount, Used: usage.MonthlyAmount, Requested: amount}
+	}
+
+	return nil
+}
+
+// Remaining returns what is left of each cap given the usage, never less than zero.
+func (l *Limit) Remaining(usage Usage) Remaining {
+	return Remaining{
+		DailyAmount:   remainingOf(l.DailyAmount, usage.DailyAmount),
+		DailyCount:    remainingOf(l.DailyCount, usage.DailyCount),
+		MonthlyAmount: remainingOf(l.MonthlyAmount, usage.MonthlyAmount),
+	}
+}
+
+// Validate checks the limit has a single scope kind and non-negative caps before it is stored.
+func (l *Limit) Validate() error {
+	if l.AccountID != nil && (l.AccountProduct != nil || l.Currency != nil) {
+		return fmt.Errorf("%w: an account limit can't also be scoped by product or currency", ErrInvalidLimit)
+	}
+
+	if l.Currency != nil && len(*l.Currency) != 3 {
+		return fmt.Errorf("%w: currency must be a 3 letter code", ErrInvalidLimit)
+	}
+
+	for _, limitCap := range []*int{l.MaxSingleAmount, l.DailyAmount, l.MonthlyAmount, l.DailyCount} {
+		if limitCap != nil && *limitCap < 0 {
+			return fmt.Errorf("%w: caps cannot be negative", ErrInvalidLimit)
+		}
+	}
+
+	return nil
+}
+
+// specificity ranks account limits above product limits, product limits above currency limits and all of them
+// above catch-all limits.
+func (l *Limit) specificity() int {
+	score := 0
+
+	if l.AccountID != nil {
+		score += 4
+	}
+
+	if l.AccountProduct != nil {
+		score += 2
+	}
+
+	if l.Currency != nil {
+		score++
+	}
+
+	return score
+}
+
+func (l *Limit) matches(accountID uuid.UUID, accountProduct, currency string) bool {
+	return (l.AccountID == nil || *l.AccountID == accountID) &&
+		(l.AccountProduct == nil || *l.AccountProduct == accountProduct) &&
+		(l.Currency == nil || *l.Currency == currency)
+}
+
+// SelectLimit picks the most specific limit among the candidates matching the account, nil when none matches.
+// The caps of a limit are not merged with the less specific ones, a nil cap of the selected limit is not enforced.
+func SelectLimit(candidates []*Limit, accountID uuid.UUID, accountProduct, currency string) *Limit {
+	matching := make([]*Limit, 0, len(candidates))
+
+	for _, limit := range candidates {
+		if limit.matches(accountID, accountProduct, currency) {
+			matching = append(matching, limit)
+		}
+	}
+
+	if len(matching) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(matching, func(i, j int) bool {
+		return matching[i].specificity() > matching[j].specificity()
+	})
+
+	return matching[0]
+}
+
+// Periods returns the start of the day and of the month the given time falls in, in UTC.
+func Periods(at time.Time) (dayStart, monthStart time.Time) {
+	at = at.UTC()
+
+	dayStart = time.Date(at.Year(), at.Month(), at.Day(), 0, 0, 0, 0, time.UTC)
+	monthStart = time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+
+	return dayStart, monthStart
+}
+
+func remainingOf(limitCap *int, used int) *int {
+	if limitCap == nil {
+		return nil
+	}
+
+	remaining := *limitCap - used
+	if remaining < 0 {
+		remaining = 0
+	}
+
+	return &remaining
+}
@@ -0,0 +1,180 @@
+//go:build tests_unit
+
+package limits_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/limits"
+)
+
+func intPtr(v int) *int {
+	return &v
+}
+
+func stringPtr(v string) *string {
+	return &v
+}
+
+func TestLimit_Check(t *testing.T) {
+	limit := limits.Limit{
+		MaxSingleAmount: intPtr(1000),
+		DailyAmount:     intPtr(2000),
+		MonthlyAmount:   intPtr(5000),
+		DailyCount:      intPtr(3),
+	}
+
+	tests := []struct {
+		name      string
+		amount    int
+		usage     limits.Usage
+		exceeded  bool
+		limitType constants.TransferLimitType
+	}{
+		{
+			name:   "within every cap",
+			amount: 1000,
+			usage:  limits.Usage{DailyAmount: 1000, DailyCount: 2, MonthlyAmount: 4000},
+		},
+		{
+			name:      "above the single transfer cap",
+			amount:    1001,
+			exceeded:  true,
+			limitType: constants.TransferLimitTypeMAXSINGLEAMOUNT,
+		},
+		{
+			name:      "one transfer too many today",
+			amount:    10,
+			usage:     limits.Usage{DailyAmount: 30, DailyCount: 3, MonthlyAmount: 30},
+			exceeded:  true,
+			limitType: constants.TransferLimitTypeDAILYCOUNT,
+		},
+		{
+			name:      "daily total reached",
+			amount:    500,
+			usage:     limits.Usage{DailyAmount: 1600, DailyCount: 1, MonthlyAmount: 1600},
+			exceeded:  true,
+			limitType: constants.TransferLimitTypeDAILYAMOUNT,
+		},
+		{
+			name:      "monthly total reached",
+			amount:    500,
+			usage:     limits.Usage{DailyAmount: 0, DailyCount: 0, MonthlyAmount: 4600},
+			exceeded:  true,
+			limitType: constants.TransferLimitTypeMONTHLYAMOUNT,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := limit.Check(tt.amount, tt.usage)
+			if !tt.exceeded {
+				assert.NoError(t, err)
+				return
+			}
+
+			require.ErrorIs(t, err, limits.ErrLimitExceeded)
+
+			var exceededErr *limits.ExceededError
+			require.ErrorAs(t, err, &exceededErr)
+			assert.Equal(t, tt.limitType, exceededErr.LimitType)
+		})
+	}
+}
+
+func TestLimit_CheckWithoutCaps(t *testing.T) {
+	limit := limits.Limit{DailyCount: intPtr(1)}
+
+	assert.NoError(t, limit.Check(1_000_000_000, limits.Usage{MonthlyAmount: 1_000_000_000}))
+}
+
+func TestLimit_Remaining(t *testing.T) {
+	limit := limits.Limit{DailyAmount: intPtr(2000), DailyCount: intPtr(3)}
+
+	remaining := limit.Remaining(limits.Usage{DailyAmount: 2500, DailyCount: 1, MonthlyAmount: 2500})
+
+	assert.Equal(t, 0, *remaining.DailyAmount)
+	assert.Equal(t, 2, *remaining.DailyCount)
+	assert.Nil(t, remaining.MonthlyAmount)
+}
+
+func TestLimit_Validate(t *testing.T) {
+	accountID := uuid.New()
+
+	assert.NoError(t, (&limits.Limit{Currency: stringPtr("USD"), DailyAmount: intPtr(0)}).Validate())
+	assert.ErrorIs(t, (&limits.Limit{AccountID: &accountID, Currency: stringPtr("USD")}).Validate(), limits.ErrInvalidLimit)
+	assert.ErrorIs(t, (&limits.Limit{Currency: stringPtr("US")}).Validate(), limits.ErrInvalidLimit)
+	assert.ErrorIs(t, (&limits.Limit{DailyCount: intPtr(-1)}).Validate(), limits.ErrInvalidLimit)
+}
+
+func TestSelectLimit(t *testing.T) {
+	accountID := uuid.New()
+
+	catchAll := &limits.Limit{ID: uuid.New()}
+	currency := &limits.Limit{ID: uuid.New(), Currency: stringPtr("USD")}
+	product := &limits.Limit{ID: uuid.New(), AccountProduct: stringPtr("PREMIUM")}
+	productCurrency := &limits.Limit{ID: uuid.New(), AccountProduct: stringPtr("PREMIUM"), Currency: stringPtr("USD")}
+	account := &limits.Limit{ID: uuid.New(), AccountID: &accountID}
+	otherAccount := &limits.Limit{ID: uuid.New(), AccountID: uuidPtr(uuid.New())}
+
+	tests := []struct {
+		name       string
+		candidates []*limits.Limit
+		product    string
+		want       *limits.Limit
+	}{
+		{
+			name:       "account limit wins",
+			candidates: []*limits.Limit{catchAll, currency, product, productCurrency, account},
+			product:    "PREMIUM",
+			want:       account,
+		},
+		{
+			name:       "product and currency above product alone",
+			candidates: []*limits.Limit{catchAll, product, productCurrency, otherAccount},
+			product:    "PREMIUM",
+			want:       productCurrency,
+		},
+		{
+			name:       "product limit of another product is skipped",
+			candidates: []*limits.Limit{catchAll, currency, product},
+			product:    "STANDARD",
+			want:       currency,
+		},
+		{
+			name:       "catch-all when nothing else matches",
+			candidates: []*limits.Limit{catchAll, otherAccount},
+			product:    "STANDARD",
+			want:       catchAll,
+		},
+		{
+			name:       "no limit",
+			candidates: []*limits.Limit{otherAccount},
+			product:    "STANDARD",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, limits.SelectLimit(tt.candidates, accountID, tt.product, "USD"))
+		})
+	}
+}
+
+func TestPeriods(t *testing.T) {
+	at := time.Date(2024, time.March, 31, 23, 30, 0, 0, time.FixedZone("UTC-2", -2*60*60))
+
+	dayStart, monthStart := limits.Periods(at)
+
+	assert.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), dayStart)
+	assert.Equal(t, time.Date(2024, time.April, 1, 0, 0, 0, 0, time.UTC), monthStart)
+}
+
+func uuidPtr(v uuid.UUID) *uuid.UUID {
+	return &v
+}
@@ -0,0 +1,62 @@
+package limits
+
+import (
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// Limit caps the outgoing transfers of the accounts in its scope. A limit set on an account applies to it alone,
+// otherwise it applies to the accounts of its product and currency, a nil scope field matches any.
+// A nil cap is not enforced.
+type Limit struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID       *uuid.UUID `gorm:"type:uuid"`
+	AccountProduct  *string    `gorm:"type:varchar(50)"`
+	Currency        *string    `gorm:"type:varchar(3)"`
+	MaxSingleAmount *int       `gorm:"type:bigint"`
+	DailyAmount     *int       `gorm:"type:bigint"`
+	MonthlyAmount   *int       `gorm:"type:bigint"`
+	DailyCount      *int       `gorm:"type:int"`
+	CreatedAt       time.Time  `gorm:"type:timestamp with time zone;not null"`
+	UpdatedAt       time.Time  `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Limit) TableName() string {
+	return "transfer_limits"
+}
+
+// Usage is what an account sent out in the current day and month, in the currency of the account.
+type Usage struct {
+	DailyAmount   int
+	DailyCount    int
+	MonthlyAmount int
+}
+
+// Remaining is what an account can still send before a cap is reached, a nil field has no cap.
+type Remaining struct {
+	DailyAmount   *int
+	DailyCount    *int
+	MonthlyAmount *int
+}
+
+// AccountLimits is the limit applied to an account with its usage in the current periods.
+// Limit is nil when no limit applies to the account.
+type AccountLimits struct {
+	AccountID     uuid.UUID
+	Currency      string
+	Limit         *Limit
+	Usage         Usage
+	Remaining     Remaining
+	DayResetsAt   time.Time
+	MonthResetsAt time.Time
+}
+
+// ExceededError tells which cap a transfer would exceed. Allowed is the cap, Used what the account already sent
+// in the period and Requested what the transfer adds to it.
+type ExceededError struct {
+	LimitType constants.TransferLimitType
+	Allowed   int
+	Used      int
+	Requested int
+}
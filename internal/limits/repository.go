@@ -0,0 +1,93 @@
+package limits
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+type Repository interface {
+	Save(ctx context.Context, limit *Limit) (*Limit, error)
+	GetCandidates(ctx context.Context, accountID uuid.UUID, accountProduct, currency string) ([]*Limit, error)
+	GetUsage(ctx context.Context, accountID uuid.UUID, dayStart, monthStart time.Time) (*Usage, error)
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+// Save stores the limit, replacing the caps of the limit already stored for the same scope.
+func (r *SQLRepository) Save(ctx context.Context, limit *Limit) (*Limit, error) {
+	var existing Limit
+
+	query := r.db.WithContext(ctx).Limit(1)
+	query = whereNullable(query, "account_id", limit.AccountID)
+	query = whereNullable(query, "account_product", limit.AccountProduct)
+	query = whereNullable(query, "currency", limit.Currency)
+
+	result := query.Find(&existing)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		limit.ID = existing.ID
+		limit.CreatedAt = existing.CreatedAt
+	}
+
+	if err := r.db.WithContext(ctx).Save(limit).Error; err != nil {
+		return nil, err
+	}
+	return limit, nil
+}
+
+// GetCandidates returns the limits of the account and the limits matching its product and currency,
+// limits without a product or currency match any.
+func (r *SQLRepository) GetCandidates(ctx context.Context, accountID uuid.UUID, accountProduct, currency string) ([]*Limit, error) {
+	var limits []*Limit
+	if err := r.db.WithContext(ctx).
+		Where("account_id IS NULL OR account_id = ?", accountID).
+		Where("account_product IS NULL OR account_product = ?", accountProduct).
+		Where("currency IS NULL OR currency = ?", currency).
+		Find(&limits).Error; err != nil {
+		return nil, err
+	}
+	return limits, nil
+}
+
+// GetUsage sums the outbound transfers of the account posted since the start of the month, and of the day.
+// A transfer counts from when its balances moved, not from when it was requested. Captured holds count with the
+// outbound transaction of their capture, fees and reversals don't count against the limits.
+func (r *SQLRepository) GetUsage(ctx context.Context, accountID uuid.UUID, dayStart, monthStart time.Time) (*Usage, error) {
+	var usage Usage
+	if err := r.db.WithContext(ctx).Table("transactions").
+		Select(
+			"COALESCE(SUM(amount) FILTER (WHERE posted_at >= ?), 0) AS daily_amount, "+
+				"COUNT(*) FILTER (WHERE posted_at >= ?) AS daily_count, "+
+				"COALESCE(SUM(amount), 0) AS monthly_amount",
+			dayStart, dayStart,
+		).
+		Where("account_id = ?", accountID).
+		Where("transaction_type = ?", constants.TransactionTypeOUTBOUND).
+		Where("status = ?", constants.TransactionStatusSUCCESS).
+		Where("posted_at >= ?", monthStart).
+		Scan(&usage).Error; err != nil {
+		return nil, err
+	}
+	return &usage, nil
+}
+
+func whereNullable[T any](query *gorm.DB, column string, value *T) *gorm.DB {
+	if value == nil {
+		return query.Where(column + " IS NULL")
+	}
+
+	return query.Where(column+" = ?", *value)
+}
@@ -0,0 +1,85 @@
+package limits
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+)
+
+type Service interface {
+	SaveLimit(ctx context.Context, limit *Limit) (*Limit, error)
+	CheckTransfer(ctx context.Context, accountID uuid.UUID, amount int, at time.Time) error
+	GetAccountLimits(ctx context.Context, accountID uuid.UUID, at time.Time) (*AccountLimits, error)
+}
+
+type LimitServiceImpl struct {
+	repo            Repository
+	accountsService accounts.Service
+}
+
+func NewLimitService(repo Repository, accountsService accounts.Service) *LimitServiceImpl {
+	return &LimitServiceImpl{repo: repo, accountsService: accountsService}
+}
+
+// SaveLimit creates the limit of its scope or replaces the caps of the existing one.
+func (s *LimitServiceImpl) SaveLimit(ctx context.Context, limit *Limit) (*Limit, error) {
+	if err := limit.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Save(ctx, limit)
+}
+
+// CheckTransfer returns an *ExceededError when a transfer of the amount out of the account at the given time
+// would exceed its limit. The usage is read from the posted transactions, the caller must hold the account lock
+// for the check to stay true until the transfer is posted.
+func (s *LimitServiceImpl) CheckTransfer(ctx context.Context, accountID uuid.UUID, amount int, at time.Time) error {
+	accountLimits, err := s.GetAccountLimits(ctx, accountID, at)
+	if err != nil {
+		return err
+	}
+
+	if accountLimits.Limit == nil {
+		return nil
+	}
+
+	return accountLimits.Limit.Check(amount, accountLimits.Usage)
+}
+
+// GetAccountLimits returns the limit applied to the account with what is left of it at the given time.
+func (s *LimitServiceImpl) GetAccountLimits(ctx context.Context, accountID uuid.UUID, at time.Time) (*AccountLimits, error) {
+	account, err := s.accountsService.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates, err := s.repo.GetCandidates(ctx, account.ID, account.Product, account.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	dayStart, monthStart := Periods(at)
+
+	accountLimits := &AccountLimits{
+		AccountID:     account.ID,
+		Currency:      account.Currency,
+		Limit:         SelectLimit(candidates, account.ID, account.Product, account.Currency),
+		DayResetsAt:   dayStart.AddDate(0, 0, 1),
+		MonthResetsAt: monthStart.AddDate(0, 1, 0),
+	}
+
+	if accountLimits.Limit == nil {
+		return accountLimits, nil
+	}
+
+	usage, err := s.repo.GetUsage(ctx, account.ID, dayStart, monthStart)
+	if err != nil {
+		return nil, err
+	}
+
+	accountLimits.Usage = *usage
+	accountLimits.Remaining = accountLimits.Limit.Remaining(*usage)
+
+	return accountLimits, nil
+}
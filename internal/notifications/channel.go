@@ -0,0 +1,86 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// Channel sends the rendered messages of one channel. A message that couldn't be sent returns an error, it is sent
+// again when the activity sending it is retried.
+type Channel interface {
+	Send(ctx context.Context, message *Message) error
+}
+
+// SMTPChannel sends the email notifications through an SMTP server. Without a username it sends without
+// authentication, which is what a local mail catcher like Mailpit expects.
+type SMTPChannel struct {
+	addr string
+	host string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPChannel(host string, port int, username, password, from string) *SMTPChannel {
+	channel := &SMTPChannel{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		host: host,
+		from: from,
+	}
+
+	if username != "" {
+		channel.auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return channel
+}
+
+func (c *SMTPChannel) Send(_ context.Context, message *Message) error {
+	if err := smtp.SendMail(c.addr, c.auth, c.from, []string{message.To}, c.compose(message)); err != nil {
+		return fmt.Errorf("sending %s email to %s: %w", message.Kind, message.To, err)
+	}
+
+	return nil
+}
+
+func (c *SMTPChannel) compose(message *Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", c.from)
+	fmt.Fprintf(&buf, "To: %s\r\n", message.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(message.Body)
+	buf.WriteString("\r\n")
+
+	return buf.Bytes()
+}
+
+// LogSMSChannel only logs the SMS notifications, there is no SMS provider to send them through yet.
+type LogSMSChannel struct {
+	logger *zerolog.Logger
+}
+
+func NewLogSMSChannel(logger *zerolog.Logger) *LogSMSChannel {
+	return &LogSMSChannel{logger: logger}
+}
+
+func (c *LogSMSChannel) Send(_ context.Context, message *Message) error {
+	c.logger.Info().
+		Str("kind", message.Kind.String()).
+		Str("to", message.To).
+		Str("body", message.Body).
+		Msg("sms notification sent")
+
+	return nil
+}
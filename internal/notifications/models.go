@@ -0,0 +1,94 @@
+package notifications
+
+import (
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// Preferences are the channels a user is notified on and the kinds of notifications they get. LowBalanceThreshold
+// is in the minor units of the account, a transfer that leaves less on its source account sends a LowBalance
+// notification.
+type Preferences struct {
+	UserID              uuid.UUID `gorm:"type:uuid;primaryKey"`
+	EmailEnabled        bool      `gorm:"not null"`
+	SMSEnabled          bool      `gorm:"column:sms_enabled;not null"`
+	PhoneNumber         *string   `gorm:"type:varchar(20)"`
+	TransferSent        bool      `gorm:"not null"`
+	TransferReceived    bool      `gorm:"not null"`
+	TransferFailed      bool      `gorm:"not null"`
+	LowBalance          bool      `gorm:"not null"`
+	LowBalanceThreshold int       `gorm:"type:bigint;not null"`
+	CreatedAt           time.Time `gorm:"type:timestamp with time zone;not null"`
+	UpdatedAt           time.Time `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Preferences) TableName() string {
+	return "notification_preferences"
+}
+
+// DefaultPreferences are the preferences of a user who never set theirs: every kind of notification by email.
+func DefaultPreferences(userID uuid.UUID, lowBalanceThreshold int) *Preferences {
+	return &Preferences{
+		UserID:              userID,
+		EmailEnabled:        true,
+		TransferSent:        true,
+		TransferReceived:    true,
+		TransferFailed:      true,
+		LowBalance:          true,
+		LowBalanceThreshold: lowBalanceThreshold,
+	}
+}
+
+func (p *Preferences) Wants(kind constants.NotificationKind) bool {
+	switch kind {
+	case constants.NotificationKindTransferSent:
+		return p.TransferSent
+	case constants.NotificationKindTransferReceived:
+		return p.TransferReceived
+	case constants.NotificationKindTransferFailed:
+		return p.TransferFailed
+	case constants.NotificationKindLowBalance:
+		return p.LowBalance
+	default:
+		return false
+	}
+}
+
+// Channels returns the enabled channels, SMS only with a phone number to send to.
+func (p *Preferences) Channels() []constants.NotificationChannel {
+	var channels []constants.NotificationChannel
+
+	if p.EmailEnabled {
+		channels = append(channels, constants.NotificationChannelEMAIL)
+	}
+
+	if p.SMSEnabled && p.PhoneNumber != nil {
+		channels = append(channels, constants.NotificationChannelSMS)
+	}
+
+	return channels
+}
+
+// TransferNotice describes a transfer that completed or failed, FailureReason is set for a failed one. Amount and
+// FeeAmount are in the currency of the source account, DestinationAmount in the currency of the destination.
+type TransferNotice struct {
+	ReferenceID          uuid.UUID
+	SourceAccountID      uuid.UUID
+	DestinationAccountID uuid.UUID
+	Amount               int
+	DestinationAmount    int
+	FeeAmount            int
+	FailureReason        *string
+	At                   time.Time
+}
+
+// Message is a rendered notification, To is an email address or a phone number depending on the channel.
+// Subject is empty for an SMS.
+type Message struct {
+	Kind    constants.NotificationKind
+	Channel constants.NotificationChannel
+	To      string
+	Subject string
+	Body    string
+}
@@ -0,0 +1,45 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type Repository interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*Preferences, error)
+	UpsertPreferences(ctx context.Context, preferences *Preferences) error
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (r *SQLRepository) GetPreferences(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	var preferences Preferences
+	if err := r.db.WithContext(ctx).First(&preferences, "user_id = ?", userID).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &preferences, nil
+}
+
+// UpsertPreferences creates the preferences of the user or replaces the ones they had.
+func (r *SQLRepository) UpsertPreferences(ctx context.Context, preferences *Preferences) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"email_enabled", "sms_enabled", "phone_number", "transfer_sent", "transfer_received", "transfer_failed",
+			"low_balance", "low_balance_threshold", "updated_at",
+		}),
+	}).Create(preferences).Error
+}
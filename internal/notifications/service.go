@@ -0,0 +1,257 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"regexp"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/users"
+)
+
+var (
+	ErrInvalidPreferences = errors.New("invalid notification preferences")
+	ErrUserNotFound       = errors.New("user not found")
+)
+
+// phoneNumberPattern matches a phone number in E.164 format.
+var phoneNumberPattern = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
+type Service interface {
+	GetPreferences(ctx context.Context, userID uuid.UUID) (*Preferences, error)
+	UpdatePreferences(ctx context.Context, preferences *Preferences) (*Preferences, error)
+	NotifyTransferSent(ctx context.Context, notice TransferNotice) error
+	NotifyTransferReceived(ctx context.Context, notice TransferNotice) error
+	NotifyTransferFailed(ctx context.Context, notice TransferNotice) error
+	NotifyLowBalance(ctx context.Context, accountID uuid.UUID, at time.Time) error
+}
+
+type NotificationServiceImpl struct {
+	repo                       Repository
+	accountRepo                accounts.Repository
+	userRepo                   users.Repository
+	templates                  *Templates
+	channels                   map[constants.NotificationChannel]Channel
+	defaultLowBalanceThreshold int
+}
+
+func NewNotificationService(
+	repo Repository,
+	accountRepo accounts.Repository,
+	userRepo users.Repository,
+	templates *Templates,
+	channels map[constants.NotificationChannel]Channel,
+	defaultLowBalanceThreshold int,
+) *NotificationServiceImpl {
+	return &NotificationServiceImpl{
+		repo:                       repo,
+		accountRepo:                accountRepo,
+		userRepo:                   userRepo,
+		templates:                  templates,
+		channels:                   channels,
+		defaultLowBalanceThreshold: defaultLowBalanceThreshold,
+	}
+}
+
+// GetPreferences returns the preferences of the user, the default ones when they never set theirs.
+func (s *NotificationServiceImpl) GetPreferences(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user == nil {
+		return nil, ErrUserNotFound
+	}
+
+	return s.preferencesOf(ctx, userID)
+}
+
+// UpdatePreferences replaces the preferences of the user, SMS can only be enabled with a phone number.
+func (s *NotificationServiceImpl) UpdatePreferences(ctx context.Context, preferences *Preferences) (*Preferences, error) {
+	if err := validatePreferences(preferences); err != nil {
+		return nil, err
+	}
+
+	current, err := s.GetPreferences(ctx, preferences.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+
+	preferences.CreatedAt = current.CreatedAt
+	if preferences.CreatedAt.IsZero() {
+		preferences.CreatedAt = now
+	}
+	preferences.UpdatedAt = now
+
+	if err = s.repo.UpsertPreferences(ctx, preferences); err != nil {
+		return nil, err
+	}
+
+	return preferences, nil
+}
+
+// NotifyTransferSent notifies the owner of the source account of a completed transfer.
+func (s *NotificationServiceImpl) NotifyTransferSent(ctx context.Context, notice TransferNotice) error {
+	return s.notify(ctx, constants.NotificationKindTransferSent, notice.SourceAccountID, func(account *accounts.Account) TemplateData {
+		return TemplateData{
+			CounterpartyAccountID: notice.DestinationAccountID,
+			ReferenceID:           notice.ReferenceID,
+			Amount:                notice.Amount,
+			FeeAmount:             notice.FeeAmount,
+			At:                    notice.At,
+		}
+	})
+}
+
+// NotifyTransferReceived notifies the owner of the destination account of a completed transfer, in the currency of
+// the destination account.
+func (s *NotificationServiceImpl) NotifyTransferReceived(ctx context.Context, notice TransferNotice) error {
+	amount := notice.DestinationAmount
+	if amount == 0 {
+		amount = notice.Amount
+	}
+
+	return s.notify(ctx, constants.NotificationKindTransferReceived, notice.DestinationAccountID, func(account *accounts.Account) TemplateData {
+		return TemplateData{
+			CounterpartyAccountID: notice.SourceAccountID,
+			ReferenceID:           notice.ReferenceID,
+			Amount:                amount,
+			At:                    notice.At,
+		}
+	})
+}
+
+// NotifyTransferFailed notifies the owner of the source account of a transfer that failed.
+func (s *NotificationServiceImpl) NotifyTransferFailed(ctx context.Context, notice TransferNotice) error {
+	reason := ""
+	if notice.FailureReason != nil {
+		reason = *notice.FailureReason
+	}
+
+	return s.notify(ctx, constants.NotificationKindTransferFailed, notice.SourceAccountID, func(account *accounts.Account) TemplateData {
+		return TemplateData{
+			CounterpartyAccountID: notice.DestinationAccountID,
+			ReferenceID:           notice.ReferenceID,
+			Amount:                notice.Amount,
+			Reason:                reason,
+			At:                    notice.At,
+		}
+	})
+}
+
+// NotifyLowBalance notifies the owner of the account when its balance is below the threshold of their preferences,
+// it does nothing otherwise.
+func (s *NotificationServiceImpl) NotifyLowBalance(ctx context.Context, accountID uuid.UUID, at time.Time) error {
+	return s.notify(ctx, constants.NotificationKindLowBalance, accountID, func(account *accounts.Account) TemplateData {
+		return TemplateData{At: at}
+	})
+}
+
+// notify sends the notification of the kind to the owner of the account on every channel they enabled. The channels
+// are all tried, the errors of the ones that failed are joined.
+func (s *NotificationServiceImpl) notify(
+	ctx context.Context,
+	kind constants.NotificationKind,
+	accountID uuid.UUID,
+	dataOf func(account *accounts.Account) TemplateData,
+) error {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if account == nil {
+		return accounts.ErrAccountNotFound
+	}
+
+	user, err := s.userRepo.GetByID(ctx, account.UserID)
+	if err != nil {
+		return err
+	}
+
+	if user == nil {
+		return ErrUserNotFound
+	}
+
+	preferences, err := s.preferencesOf(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	if !preferences.Wants(kind) {
+		return nil
+	}
+
+	if kind == constants.NotificationKindLowBalance && account.Balance >= preferences.LowBalanceThreshold {
+		return nil
+	}
+
+	data := dataOf(account)
+	data.FirstName = user.FirstName
+	data.AccountID = account.ID
+	data.Balance = account.Balance
+	data.Threshold = preferences.LowBalanceThreshold
+	data.Currency = account.Currency
+
+	var errs []error
+
+	for _, channelName := range preferences.Channels() {
+		channel, ok := s.channels[channelName]
+		if !ok {
+			continue
+		}
+
+		to := user.Email
+		if channelName == constants.NotificationChannelSMS {
+			to = *preferences.PhoneNumber
+		}
+
+		message, err := s.templates.Render(kind, channelName, to, data)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if err = channel.Send(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (s *NotificationServiceImpl) preferencesOf(ctx context.Context, userID uuid.UUID) (*Preferences, error) {
+	preferences, err := s.repo.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if preferences == nil {
+		return DefaultPreferences(userID, s.defaultLowBalanceThreshold), nil
+	}
+
+	return preferences, nil
+}
+
+func validatePreferences(preferences *Preferences) error {
+	if preferences.PhoneNumber != nil && !phoneNumberPattern.MatchString(*preferences.PhoneNumber) {
+		return fmt.Errorf("%w: phone_number must be in E.164 format", ErrInvalidPreferences)
+	}
+
+	if preferences.SMSEnabled && preferences.PhoneNumber == nil {
+		return fmt.Errorf("%w: phone_number is required to enable SMS", ErrInvalidPreferences)
+	}
+
+	if preferences.LowBalanceThreshold < 0 {
+		return fmt.Errorf("%w: low_balance_threshold can't be negative", ErrInvalidPreferences)
+	}
+
+	return nil
+}
@@ -0,0 +1,196 @@
+//go:build tests_unit
+
+package notifications_test
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/notifications"
+	"ulascansenturk/service/internal/users"
+)
+
+type fakeChannel struct {
+	messages []*notifications.Message
+	err      error
+}
+
+func (c *fakeChannel) Send(_ context.Context, message *notifications.Message) error {
+	c.messages = append(c.messages, message)
+
+	return c.err
+}
+
+func TestNotificationService_Notify(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	userID := uuid.New()
+	createdAt := time.Date(2024, 10, 5, 9, 0, 0, 0, time.UTC)
+	phoneNumber := "+905551112233"
+
+	notice := notifications.TransferNotice{
+		ReferenceID:          uuid.New(),
+		SourceAccountID:      accountID,
+		DestinationAccountID: uuid.New(),
+		Amount:               1000,
+		At:                   createdAt,
+	}
+
+	newService := func(t *testing.T) (*notifications.NotificationServiceImpl, sqlmock.Sqlmock, *fakeChannel, *fakeChannel) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		gormDB, err := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		require.NoError(t, err)
+
+		templates, err := notifications.LoadTemplates()
+		require.NoError(t, err)
+
+		email, sms := &fakeChannel{}, &fakeChannel{}
+
+		service := notifications.NewNotificationService(
+			notifications.NewSQLRepository(gormDB),
+			accounts.NewSQLRepository(gormDB),
+			users.NewSQLRepository(gormDB),
+			templates,
+			map[constants.NotificationChannel]notifications.Channel{
+				constants.NotificationChannelEMAIL: email,
+				constants.NotificationChannelSMS:   sms,
+			},
+			10000,
+		)
+
+		return service, mock, email, sms
+	}
+
+	expectAccount := func(mock sqlmock.Sqlmock, balance int) {
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1`).
+			WithArgs(accountID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "balance", "currency", "status", "created_at"}).
+				AddRow(accountID, userID, balance, "USD", "ACTIVE", createdAt))
+	}
+
+	expectUser := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT \* FROM "users" WHERE id = \$1`).
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "email", "first_name", "last_name"}).
+				AddRow(userID, "ada@example.com", "Ada", "Lovelace"))
+	}
+
+	expectPreferences := func(mock sqlmock.Sqlmock, transferSent, smsEnabled bool) {
+		mock.ExpectQuery(`SELECT \* FROM "notification_preferences" WHERE user_id = \$1`).
+			WithArgs(userID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id", "email_enabled", "sms_enabled", "phone_number", "transfer_sent",
+				"transfer_received", "transfer_failed", "low_balance", "low_balance_threshold"}).
+				AddRow(userID, true, smsEnabled, phoneNumber, transferSent, true, true, true, 5000))
+	}
+
+	t.Run("User without preferences is notified by email", func(t *testing.T) {
+		service, mock, email, sms := newService(t)
+
+		expectAccount(mock, 9000)
+		expectUser(mock)
+		mock.ExpectQuery(`SELECT \* FROM "notification_preferences"`).
+			WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+
+		require.NoError(t, service.NotifyTransferSent(ctx, notice))
+
+		require.Len(t, email.messages, 1)
+		assert.Equal(t, "ada@example.com", email.messages[0].To)
+		assert.Equal(t, "You sent 10.00 USD", email.messages[0].Subject)
+		assert.Empty(t, sms.messages)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Enabled SMS is sent to the phone number", func(t *testing.T) {
+		service, mock, email, sms := newService(t)
+
+		expectAccount(mock, 9000)
+		expectUser(mock)
+		expectPreferences(mock, true, true)
+
+		require.NoError(t, service.NotifyTransferSent(ctx, notice))
+
+		assert.Len(t, email.messages, 1)
+		require.Len(t, sms.messages, 1)
+		assert.Equal(t, phoneNumber, sms.messages[0].To)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Kind turned off isn't sent", func(t *testing.T) {
+		service, mock, email, sms := newService(t)
+
+		expectAccount(mock, 9000)
+		expectUser(mock)
+		expectPreferences(mock, false, true)
+
+		require.NoError(t, service.NotifyTransferSent(ctx, notice))
+
+		assert.Empty(t, email.messages)
+		assert.Empty(t, sms.messages)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Low balance is only sent below the threshold", func(t *testing.T) {
+		service, mock, email, _ := newService(t)
+
+		expectAccount(mock, 5000)
+		expectUser(mock)
+		expectPreferences(mock, true, false)
+
+		require.NoError(t, service.NotifyLowBalance(ctx, accountID, createdAt))
+		assert.Empty(t, email.messages)
+
+		expectAccount(mock, 4999)
+		expectUser(mock)
+		expectPreferences(mock, true, false)
+
+		require.NoError(t, service.NotifyLowBalance(ctx, accountID, createdAt))
+		require.Len(t, email.messages, 1)
+		assert.Equal(t, "Your balance is below 50.00 USD", email.messages[0].Subject)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Channel that fails doesn't stop the others", func(t *testing.T) {
+		service, mock, email, sms := newService(t)
+		email.err = errors.New("smtp server unreachable")
+
+		expectAccount(mock, 9000)
+		expectUser(mock)
+		expectPreferences(mock, true, true)
+
+		err := service.NotifyTransferSent(ctx, notice)
+		assert.ErrorContains(t, err, "smtp server unreachable")
+		assert.Len(t, sms.messages, 1)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestNotificationService_UpdatePreferences(t *testing.T) {
+	service := notifications.NewNotificationService(nil, nil, nil, nil, nil, 10000)
+
+	t.Run("SMS requires a phone number", func(t *testing.T) {
+		_, err := service.UpdatePreferences(context.Background(), &notifications.Preferences{UserID: uuid.New(), SMSEnabled: true})
+		assert.ErrorIs(t, err, notifications.ErrInvalidPreferences)
+	})
+
+	t.Run("Phone number must be in E.164 format", func(t *testing.T) {
+		phoneNumber := "0555 111 22 33"
+
+		_, err := service.UpdatePreferences(context.Background(), &notifications.Preferences{UserID: uuid.New(), PhoneNumber: &phoneNumber})
+		assert.ErrorIs(t, err, notifications.ErrInvalidPreferences)
+	})
+}
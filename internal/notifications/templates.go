@@ -0,0 +1,131 @@
+package notifications
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/google/uuid"
+	"ulascansenturk/service/internal/constants"
+)
+
+// templateFiles has a template per kind of notification and channel, templates/<kind>.<channel>.tmpl. An email
+// template defines a subject and a body, an SMS template only a body.
+//
+//go:embed templates/*.tmpl
+var templateFiles embed.FS
+
+var templateFuncs = template.FuncMap{
+	"money":     formatMoney,
+	"timestamp": formatTimestamp,
+}
+
+// TemplateData is what the templates are rendered with. AccountID is the account of the notified user,
+// CounterpartyAccountID the other account of the transfer. The amounts are in the minor units of Currency.
+type TemplateData struct {
+	FirstName             string
+	AccountID             uuid.UUID
+	CounterpartyAccountID uuid.UUID
+	ReferenceID           uuid.UUID
+	Amount                int
+	FeeAmount             int
+	Balance               int
+	Threshold             int
+	Currency              string
+	Reason                string
+	At                    time.Time
+}
+
+// Templates renders the notifications, every template is parsed once when it is loaded.
+type Templates struct {
+	templates map[string]*template.Template
+}
+
+func LoadTemplates() (*Templates, error) {
+	templates := make(map[string]*template.Template)
+
+	for _, kind := range []constants.NotificationKind{
+		constants.NotificationKindTransferSent,
+		constants.NotificationKindTransferReceived,
+		constants.NotificationKindTransferFailed,
+		constants.NotificationKindLowBalance,
+	} {
+		for _, channel := range []constants.NotificationChannel{constants.NotificationChannelEMAIL, constants.NotificationChannelSMS} {
+			name := templateName(kind, channel)
+
+			parsed, err := template.New(name).Funcs(templateFuncs).ParseFS(templateFiles, "templates/"+name)
+			if err != nil {
+				return nil, err
+			}
+
+			templates[name] = parsed
+		}
+	}
+
+	return &Templates{templates: templates}, nil
+}
+
+// Render renders the notification of the kind for the channel, the message is addressed to to.
+func (t *Templates) Render(
+	kind constants.NotificationKind,
+	channel constants.NotificationChannel,
+	to string,
+	data TemplateData,
+) (*Message, error) {
+	tmpl, ok := t.templates[templateName(kind, channel)]
+	if !ok {
+		return nil, fmt.Errorf("no %s template for %s notifications", channel, kind)
+	}
+
+	message := &Message{Kind: kind, Channel: channel, To: to}
+
+	if channel == constants.NotificationChannelEMAIL {
+		subject, err := execute(tmpl, "subject", data)
+		if err != nil {
+			return nil, err
+		}
+
+		message.Subject = subject
+	}
+
+	body, err := execute(tmpl, "body", data)
+	if err != nil {
+		return nil, err
+	}
+
+	message.Body = body
+
+	return message, nil
+}
+
+func execute(tmpl *template.Template, name string, data TemplateData) (string, error) {
+	var buf bytes.Buffer
+
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(buf.String()), nil
+}
+
+func templateName(kind constants.NotificationKind, channel constants.NotificationChannel) string {
+	return fmt.Sprintf("%s.%s.tmpl", kind, strings.ToLower(channel.String()))
+}
+
+// formatMoney formats an amount in minor units, every supported currency has two decimals.
+func formatMoney(amount int, currency string) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d %s", sign, amount/100, amount%100, currency)
+}
+
+func formatTimestamp(at time.Time) string {
+	return at.UTC().Format("2006-01-02 15:04 MST")
+}
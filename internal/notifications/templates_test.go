@@ -0,0 +1,71 @@
+//go:build tests_unit
+
+package notifications_test
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/notifications"
+)
+
+func TestTemplates_Render(t *testing.T) {
+	templates, err := notifications.LoadTemplates()
+	require.NoError(t, err)
+
+	data := notifications.TemplateData{
+		FirstName:             "Ada",
+		AccountID:             uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000"),
+		CounterpartyAccountID: uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000"),
+		ReferenceID:           uuid.MustParse("cccccccc-0000-0000-0000-000000000000"),
+		Amount:                125050,
+		FeeAmount:             250,
+		Balance:               4905,
+		Threshold:             10000,
+		Currency:              "USD",
+		Reason:                "insufficient funds",
+		At:                    time.Date(2024, 10, 5, 9, 30, 0, 0, time.UTC),
+	}
+
+	t.Run("Transfer sent email", func(t *testing.T) {
+		message, err := templates.Render(constants.NotificationKindTransferSent, constants.NotificationChannelEMAIL, "ada@example.com", data)
+		require.NoError(t, err)
+
+		assert.Equal(t, "ada@example.com", message.To)
+		assert.Equal(t, "You sent 1250.50 USD", message.Subject)
+		assert.Contains(t, message.Body, "Hi Ada,")
+		assert.Contains(t, message.Body, "1250.50 USD left your account aaaaaaaa-0000-0000-0000-000000000000 for account bbbbbbbb-0000-0000-0000-000000000000 on 2024-10-05 09:30 UTC.")
+		assert.Contains(t, message.Body, "A fee of 2.50 USD was charged for the transfer.")
+		assert.Contains(t, message.Body, "Your balance is now 49.05 USD.")
+	})
+
+	t.Run("Transfer failed SMS", func(t *testing.T) {
+		message, err := templates.Render(constants.NotificationKindTransferFailed, constants.NotificationChannelSMS, "+905551112233", data)
+		require.NoError(t, err)
+
+		assert.Empty(t, message.Subject)
+		assert.Contains(t, message.Body, "1250.50 USD")
+		assert.Contains(t, message.Body, "failed, no money left your account")
+	})
+
+	t.Run("Every kind has an email and an SMS template", func(t *testing.T) {
+		kinds := []constants.NotificationKind{
+			constants.NotificationKindTransferSent,
+			constants.NotificationKindTransferReceived,
+			constants.NotificationKindTransferFailed,
+			constants.NotificationKindLowBalance,
+		}
+
+		for _, kind := range kinds {
+			for _, channel := range []constants.NotificationChannel{constants.NotificationChannelEMAIL, constants.NotificationChannelSMS} {
+				message, err := templates.Render(kind, channel, "to", data)
+				require.NoError(t, err, "%s %s", kind, channel)
+				assert.NotEmpty(t, message.Body, "%s %s", kind, channel)
+			}
+		}
+	})
+}
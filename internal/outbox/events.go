@@ -0,0 +1,129 @@
+package outbox
+
+import (
+	"github.com/google/uuid"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// TransferCompleted is written when the balances of a transfer, or of a captured hold, are moved. Its aggregate is
+// the source account.
+type TransferCompleted struct {
+	ReferenceID          uuid.UUID   `json:"reference_id"`
+	SourceAccountID      uuid.UUID   `json:"source_account_id"`
+	DestinationAccountID uuid.UUID   `json:"destination_account_id"`
+	Amount               int         `json:"amount"`
+	Currency             string      `json:"currency"`
+	DestinationAmount    int         `json:"destination_amount"`
+	DestinationCurrency  string      `json:"destination_currency"`
+	FeeAmount            int         `json:"fee_amount"`
+	TransactionIDs       []uuid.UUID `json:"transaction_ids"`
+	CompletedAt          time.Time   `json:"completed_at"`
+}
+
+func (TransferCompleted) EventType() constants.OutboxEventType {
+	return constants.OutboxEventTypeTransferCompleted
+}
+
+func (TransferCompleted) SchemaVersion() int { return 1 }
+
+func (TransferCompleted) AggregateType() string { return AggregateTypeAccount }
+
+func (e TransferCompleted) AggregateID() uuid.UUID { return e.SourceAccountID }
+
+// TransferFailed is written when the outgoing transaction of a transfer fails or is cancelled before it was
+// posted. Its aggregate is the source account.
+type TransferFailed struct {
+	ReferenceID     uuid.UUID `json:"reference_id"`
+	SourceAccountID uuid.UUID `json:"source_account_id"`
+	TransactionID   uuid.UUID `json:"transaction_id"`
+	Amount          int       `json:"amount"`
+	Currency        string    `json:"currency"`
+	Reason          *string   `json:"reason,omitempty"`
+	FailedAt        time.Time `json:"failed_at"`
+}
+
+func (TransferFailed) EventType() constants.OutboxEventType {
+	return constants.OutboxEventTypeTransferFailed
+}
+
+func (TransferFailed) SchemaVersion() int { return 1 }
+
+func (TransferFailed) AggregateType() string { return AggregateTypeAccount }
+
+func (e TransferFailed) AggregateID() uuid.UUID { return e.SourceAccountID }
+
+// UserCreated carries no personal data, consumers that need it look the user up.
+type UserCreated struct {
+	UserID    uuid.UUID `json:"user_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (UserCreated) EventType() constants.OutboxEventType {
+	return constants.OutboxEventTypeUserCreated
+}
+
+func (UserCreated) SchemaVersion() int { return 1 }
+
+func (UserCreated) AggregateType() string { return AggregateTypeUser }
+
+func (e UserCreated) AggregateID() uuid.UUID { return e.UserID }
+
+type AccountCreated struct {
+	AccountID uuid.UUID `json:"account_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	Currency  string    `json:"currency"`
+	Product   string    `json:"product"`
+	Status    string    `json:"status"`
+	Balance   int       `json:"balance"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (AccountCreated) EventType() constants.OutboxEventType {
+	return constants.OutboxEventTypeAccountCreated
+}
+
+func (AccountCreated) SchemaVersion() int { return 1 }
+
+func (AccountCreated) AggregateType() string { return AggregateTypeAccount }
+
+func (e AccountCreated) AggregateID() uuid.UUID { return e.AccountID }
+
+type AccountStatusChanged struct {
+	AccountID      uuid.UUID `json:"account_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	ChangedAt      time.Time `json:"changed_at"`
+}
+
+func (AccountStatusChanged) EventType() constants.OutboxEventType {
+	return constants.OutboxEventTypeAccountStatusChanged
+}
+
+func (AccountStatusChanged) SchemaVersion() int { return 1 }
+
+func (AccountStatusChanged) AggregateType() string { return AggregateTypeAccount }
+
+func (e AccountStatusChanged) AggregateID() uuid.UUID { return e.AccountID }
+
+// BalanceChanged is written for every account whose balance a posting or an adjustment moved. TransactionIDs are
+// the transactions of the account settled by the posting, an adjustment has none.
+type BalanceChanged struct {
+	AccountID       uuid.UUID   `json:"account_id"`
+	Currency        string      `json:"currency"`
+	PreviousBalance int         `json:"previous_balance"`
+	Balance         int         `json:"balance"`
+	HeldBalance     int         `json:"held_balance"`
+	TransactionIDs  []uuid.UUID `json:"transaction_ids"`
+	ChangedAt       time.Time   `json:"changed_at"`
+}
+
+func (BalanceChanged) EventType() constants.OutboxEventType {
+	return constants.OutboxEventTypeBalanceChanged
+}
+
+func (BalanceChanged) SchemaVersion() int { return 1 }
+
+func (BalanceChanged) AggregateType() string { return AggregateTypeAccount }
+
+func (e BalanceChanged) AggregateID() uuid.UUID { return e.AccountID }
@@ -0,0 +1,87 @@
+package outbox
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+const (
+	AggregateTypeAccount = "account"
+	AggregateTypeUser    = "user"
+)
+
+// Event is a domain event written in the database transaction of the change it describes. Sequence is the order
+// the events were written in, the events of an aggregate are published in it. PublishedAt is set once a publisher
+// took the event, Attempts and LastError record the publications that failed before.
+type Event struct {
+	ID            uuid.UUID                 `gorm:"type:uuid;primaryKey"`
+	Sequence      int64                     `gorm:"type:bigserial;autoIncrement;<-:false"`
+	EventType     constants.OutboxEventType `gorm:"type:varchar(50);not null"`
+	SchemaVersion int                       `gorm:"not null"`
+	AggregateType string                    `gorm:"type:varchar(20);not null"`
+	AggregateID   uuid.UUID                 `gorm:"type:uuid;not null"`
+	Payload       datatypes.JSON            `gorm:"type:jsonb;not null"`
+	OccurredAt    time.Time                 `gorm:"type:timestamp with time zone;not null"`
+	PublishedAt   *time.Time                `gorm:"type:timestamp with time zone"`
+	Attempts      int                       `gorm:"not null;default:0"`
+	LastError     *string                   `gorm:"type:text"`
+}
+
+func (Event) TableName() string {
+	return "outbox_events"
+}
+
+// Payload is the data of an event. Every event type and schema version has a JSON schema in schemas, a change that
+// isn't backward compatible gets a new version.
+type Payload interface {
+	EventType() constants.OutboxEventType
+	SchemaVersion() int
+	AggregateType() string
+	AggregateID() uuid.UUID
+}
+
+func NewEvent(payload Payload, occurredAt time.Time) (*Event, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:            uuid.New(),
+		EventType:     payload.EventType(),
+		SchemaVersion: payload.SchemaVersion(),
+		AggregateType: payload.AggregateType(),
+		AggregateID:   payload.AggregateID(),
+		Payload:       data,
+		OccurredAt:    occurredAt.UTC(),
+	}, nil
+}
+
+// Envelope is what publishers send for an event, see schemas/envelope.v1.json. Consumers deduplicate by ID, an
+// event can be published more than once.
+type Envelope struct {
+	ID            uuid.UUID                 `json:"id"`
+	Type          constants.OutboxEventType `json:"type"`
+	SchemaVersion int                       `json:"schema_version"`
+	Sequence      int64                     `json:"sequence"`
+	AggregateType string                    `json:"aggregate_type"`
+	AggregateID   uuid.UUID                 `json:"aggregate_id"`
+	OccurredAt    time.Time                 `json:"occurred_at"`
+	Data          json.RawMessage           `json:"data"`
+}
+
+func (e *Event) Envelope() Envelope {
+	return Envelope{
+		ID:            e.ID,
+		Type:          e.EventType,
+		SchemaVersion: e.SchemaVersion,
+		Sequence:      e.Sequence,
+		AggregateType: e.AggregateType,
+		AggregateID:   e.AggregateID,
+		OccurredAt:    e.OccurredAt,
+		Data:          json.RawMessage(e.Payload),
+	}
+}
@@ -0,0 +1,145 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	PublisherLog  = "log"
+	PublisherFile = "file"
+	PublisherHTTP = "http"
+)
+
+// Publisher hands an event over to its consumers. An event whose publication failed is published again, so a
+// publisher can deliver an event more than once but never loses one it returned nil for.
+type Publisher interface {
+	Publish(ctx context.Context, envelope Envelope) error
+}
+
+// LogPublisher only logs the events.
+type LogPublisher struct {
+	logger *zerolog.Logger
+}
+
+func NewLogPublisher(logger *zerolog.Logger) *LogPublisher {
+	return &LogPublisher{logger: logger}
+}
+
+func (p *LogPublisher) Publish(_ context.Context, envelope Envelope) error {
+	p.logger.Info().
+		Str("event_id", envelope.ID.String()).
+		Str("event_type", envelope.Type.String()).
+		Int("schema_version", envelope.SchemaVersion).
+		Str("aggregate_id", envelope.AggregateID.String()).
+		RawJSON("data", envelope.Data).
+		Msg("outbox event published")
+
+	return nil
+}
+
+// FilePublisher appends the envelopes to a file, one JSON document per line.
+type FilePublisher struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+func NewFilePublisher(path string) (*FilePublisher, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FilePublisher{file: file}, nil
+}
+
+func (p *FilePublisher) Publish(_ context.Context, envelope Envelope) error {
+	line, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err = p.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+
+	return p.file.Sync()
+}
+
+func (p *FilePublisher) Close() error {
+	return p.file.Close()
+}
+
+// HTTPPublisher posts the envelopes to an endpoint, a response other than 2xx fails the publication.
+type HTTPPublisher struct {
+	client *http.Client
+	url    string
+}
+
+func NewHTTPPublisher(client *http.Client, url string) *HTTPPublisher {
+	return &HTTPPublisher{client: client, url: url}
+}
+
+func (p *HTTPPublisher) Publish(ctx context.Context, envelope Envelope) error {
+	body, err := json.Marshal(envelope)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Event-ID", envelope.ID.String())
+	req.Header.Set("X-Event-Type", envelope.Type.String())
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("event endpoint responded %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// FanOutPublisher hands the events to every one of its publishers. An event is published again when one of them
+// fails, the others then get it a second time.
+type FanOutPublisher struct {
+	publishers []Publisher
+}
+
+func NewFanOutPublisher(publishers ...Publisher) *FanOutPublisher {
+	return &FanOutPublisher{publishers: publishers}
+}
+
+func (p *FanOutPublisher) Publish(ctx context.Context, envelope Envelope) error {
+	var errs []error
+
+	for _, publisher := range p.publishers {
+		if err := publisher.Publish(ctx, envelope); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
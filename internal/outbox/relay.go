@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"context"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Relay publishes the events of the outbox in the order they were written. An event is marked published only after
+// its publisher took it, so every event is delivered at least once. An event that fails to publish holds back the
+// later events of its aggregate until it goes through, the events of an account are never delivered out of order.
+type Relay struct {
+	repo         Repository
+	publisher    Publisher
+	batchSize    int
+	pollInterval time.Duration
+}
+
+func NewRelay(repo Repository, publisher Publisher, batchSize int, pollInterval time.Duration) *Relay {
+	return &Relay{
+		repo:         repo,
+		publisher:    publisher,
+		batchSize:    batchSize,
+		pollInterval: pollInterval,
+	}
+}
+
+// Run publishes batches until ctx is done, it waits for the poll interval only once the outbox is drained.
+func (r *Relay) Run(ctx context.Context) {
+	for {
+		published, err := r.PublishBatch(ctx)
+		if err != nil {
+			log.Err(err).Msg("outbox relay failed")
+		}
+
+		if err == nil && published == r.batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.pollInterval):
+		}
+	}
+}
+
+// PublishBatch publishes the next batch of events under the relay lock and returns how many went through. Nothing
+// is published while another relay holds the lock.
+func (r *Relay) PublishBatch(ctx context.Context) (int, error) {
+	var published int
+
+	err := r.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		locked, err := r.repo.TryLockRelayWithTx(ctx, tx)
+		if err != nil || !locked {
+			return err
+		}
+
+		events, err := r.repo.ListUnpublishedWithTx(ctx, r.batchSize, tx)
+		if err != nil {
+			return err
+		}
+
+		blocked := make(map[uuid.UUID]bool)
+
+		for _, event := range events {
+			if blocked[event.AggregateID] {
+				continue
+			}
+
+			publishErr := r.publisher.Publish(ctx, event.Envelope())
+			if publishErr != nil {
+				log.Warn().Err(publishErr).
+					Str("event_id", event.ID.String()).
+					Str("event_type", event.EventType.String()).
+					Msg("outbox event publication failed")
+
+				blocked[event.AggregateID] = true
+
+				if err = r.repo.MarkFailedWithTx(ctx, event.ID, publishErr, tx); err != nil {
+					return err
+				}
+
+				continue
+			}
+
+			if err = r.repo.MarkPublishedWithTx(ctx, event.ID, time.Now().UTC(), tx); err != nil {
+				return err
+			}
+
+			published++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return published, nil
+}
@@ -0,0 +1,136 @@
+//go:build tests_unit
+
+package outbox_test
+
+import (
+	"context"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/outbox"
+)
+
+type recordingPublisher struct {
+	failing   map[uuid.UUID]bool
+	published []uuid.UUID
+}
+
+func (p *recordingPublisher) Publish(_ context.Context, envelope outbox.Envelope) error {
+	if p.failing[envelope.ID] {
+		return errors.New("endpoint unavailable")
+	}
+
+	p.published = append(p.published, envelope.ID)
+
+	return nil
+}
+
+func newRelay(t *testing.T, publisher outbox.Publisher) (*outbox.Relay, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return outbox.NewRelay(outbox.NewSQLRepository(gormDB), publisher, 10, time.Second), mock
+}
+
+func expectRelayLock(mock sqlmock.Sqlmock, locked bool) {
+	mock.ExpectQuery(`SELECT pg_try_advisory_xact_lock\(\$1\)`).
+		WillReturnRows(sqlmock.NewRows([]string{"pg_try_advisory_xact_lock"}).AddRow(locked))
+}
+
+func TestRelay_PublishBatch(t *testing.T) {
+	ctx := context.Background()
+	accountID := uuid.New()
+	otherAccountID := uuid.New()
+	eventIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New()}
+
+	unpublished := func() *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{"id", "sequence", "event_type", "schema_version", "aggregate_type", "aggregate_id", "payload", "occurred_at"})
+
+		for i, aggregateID := range []uuid.UUID{accountID, accountID, otherAccountID} {
+			rows.AddRow(eventIDs[i], i+1, constants.OutboxEventTypeBalanceChanged, 1, outbox.AggregateTypeAccount, aggregateID, `{}`, time.Now())
+		}
+
+		return rows
+	}
+
+	t.Run("Events are published in sequence order and marked published", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		relay, mock := newRelay(t, publisher)
+
+		mock.ExpectBegin()
+		expectRelayLock(mock, true)
+		mock.ExpectQuery(`SELECT \* FROM outbox_events e WHERE e.published_at IS NULL .* ORDER BY e.sequence LIMIT \$1`).
+			WithArgs(10).
+			WillReturnRows(unpublished())
+
+		for _, eventID := range eventIDs {
+			mock.ExpectExec(`UPDATE "outbox_events" SET "attempts"=attempts \+ 1,"last_error"=\$1,"published_at"=\$2 WHERE id = \$3`).
+				WithArgs(nil, sqlmock.AnyArg(), eventID).
+				WillReturnResult(sqlmock.NewResult(0, 1))
+		}
+
+		mock.ExpectCommit()
+
+		published, err := relay.PublishBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 3, published)
+		assert.Equal(t, eventIDs, publisher.published)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Failed event holds back the later events of its account only", func(t *testing.T) {
+		publisher := &recordingPublisher{failing: map[uuid.UUID]bool{eventIDs[0]: true}}
+		relay, mock := newRelay(t, publisher)
+
+		mock.ExpectBegin()
+		expectRelayLock(mock, true)
+		mock.ExpectQuery(`SELECT \* FROM outbox_events e`).
+			WithArgs(10).
+			WillReturnRows(unpublished())
+		mock.ExpectExec(`UPDATE "outbox_events" SET "attempts"=attempts \+ 1,"last_error"=\$1 WHERE id = \$2`).
+			WithArgs("endpoint unavailable", eventIDs[0]).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`UPDATE "outbox_events" SET "attempts"=attempts \+ 1,"last_error"=\$1,"published_at"=\$2 WHERE id = \$3`).
+			WithArgs(nil, sqlmock.AnyArg(), eventIDs[2]).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		published, err := relay.PublishBatch(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, published)
+		assert.Equal(t, []uuid.UUID{eventIDs[2]}, publisher.published)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Nothing is published while another relay holds the lock", func(t *testing.T) {
+		publisher := &recordingPublisher{}
+		relay, mock := newRelay(t, publisher)
+
+		mock.ExpectBegin()
+		expectRelayLock(mock, false)
+		mock.ExpectCommit()
+
+		published, err := relay.PublishBatch(ctx)
+		require.NoError(t, err)
+		assert.Zero(t, published)
+		assert.Empty(t, publisher.published)
+
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
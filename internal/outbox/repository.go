@@ -0,0 +1,114 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+)
+
+// relayLockKey is the key of the advisory lock held by the relay publishing a batch, one relay publishes at a
+// time so the events of an aggregate are never published out of order.
+const relayLockKey = 7_243_001
+
+type Repository interface {
+	CreateWithTx(ctx context.Context, events []*Event, tx *gorm.DB) error
+	TryLockRelayWithTx(ctx context.Context, tx *gorm.DB) (bool, error)
+	ListUnpublishedWithTx(ctx context.Context, limit int, tx *gorm.DB) ([]*Event, error)
+	MarkPublishedWithTx(ctx context.Context, id uuid.UUID, publishedAt time.Time, tx *gorm.DB) error
+	MarkFailedWithTx(ctx context.Context, id uuid.UUID, publishErr error, tx *gorm.DB) error
+	Transaction(ctx context.Context, fn func(*gorm.DB) error) error
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (r *SQLRepository) CreateWithTx(ctx context.Context, events []*Event, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	return tx.WithContext(ctx).Create(events).Error
+}
+
+// TryLockRelayWithTx takes the relay lock until the transaction ends, false when another relay holds it.
+func (r *SQLRepository) TryLockRelayWithTx(ctx context.Context, tx *gorm.DB) (bool, error) {
+	if tx == nil {
+		return false, errors.New("transaction is required")
+	}
+
+	var locked bool
+
+	err := tx.WithContext(ctx).Raw("SELECT pg_try_advisory_xact_lock(?)", relayLockKey).Scan(&locked).Error
+	if err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// listUnpublishedSQL skips the events queued behind an event of their aggregate that failed to publish, only the
+// failed event is retried until it goes through, so a stuck aggregate doesn't fill the batch.
+const listUnpublishedSQL = `
+SELECT * FROM outbox_events e
+WHERE e.published_at IS NULL AND NOT EXISTS (
+    SELECT 1 FROM outbox_events f
+    WHERE f.published_at IS NULL AND f.attempts > 0
+      AND f.aggregate_type = e.aggregate_type AND f.aggregate_id = e.aggregate_id AND f.sequence < e.sequence
+)
+ORDER BY e.sequence
+LIMIT ?`
+
+// ListUnpublishedWithTx returns the next events to publish in the order they were written.
+func (r *SQLRepository) ListUnpublishedWithTx(ctx context.Context, limit int, tx *gorm.DB) ([]*Event, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+
+	var events []*Event
+
+	err := tx.WithContext(ctx).Raw(listUnpublishedSQL, limit).Scan(&events).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (r *SQLRepository) MarkPublishedWithTx(ctx context.Context, id uuid.UUID, publishedAt time.Time, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+
+	return tx.WithContext(ctx).Model(&Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"published_at": publishedAt,
+		"attempts":     gorm.Expr("attempts + 1"),
+		"last_error":   nil,
+	}).Error
+}
+
+func (r *SQLRepository) MarkFailedWithTx(ctx context.Context, id uuid.UUID, publishErr error, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+
+	return tx.WithContext(ctx).Model(&Event{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": publishErr.Error(),
+	}).Error
+}
+
+func (r *SQLRepository) Transaction(ctx context.Context, fn func(*gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
@@ -0,0 +1,17 @@
+package outbox
+
+import (
+	"embed"
+	"fmt"
+	"ulascansenturk/service/internal/constants"
+)
+
+// Schemas holds the JSON schema of every event type and schema version, and the one of the envelope.
+//
+//go:embed schemas/*.json
+var Schemas embed.FS
+
+// SchemaPath is the path in Schemas of the schema of the event type in the version.
+func SchemaPath(eventType constants.OutboxEventType, version int) string {
+	return fmt.Sprintf("schemas/%s.v%d.json", eventType, version)
+}
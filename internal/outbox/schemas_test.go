@@ -0,0 +1,90 @@
+//go:build tests_unit
+
+package outbox_test
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sort"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/outbox"
+)
+
+type jsonSchema struct {
+	Properties map[string]interface{} `json:"properties"`
+	Required   []string               `json:"required"`
+}
+
+func loadSchema(t *testing.T, path string) jsonSchema {
+	content, err := outbox.Schemas.ReadFile(path)
+	require.NoError(t, err, "missing schema %s", path)
+
+	var schema jsonSchema
+	require.NoError(t, json.Unmarshal(content, &schema))
+
+	return schema
+}
+
+func keysOf(fields map[string]interface{}) []string {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// TestSchemas makes sure every payload matches the schema of its event type and version: it has every required
+// property and nothing the schema doesn't describe.
+func TestSchemas(t *testing.T) {
+	reason := "duplicate payment"
+	now := time.Now()
+
+	payloads := []outbox.Payload{
+		&outbox.TransferCompleted{TransactionIDs: []uuid.UUID{}, CompletedAt: now},
+		&outbox.TransferFailed{Reason: &reason, FailedAt: now},
+		&outbox.UserCreated{CreatedAt: now},
+		&outbox.AccountCreated{CreatedAt: now},
+		&outbox.AccountStatusChanged{ChangedAt: now},
+		&outbox.BalanceChanged{TransactionIDs: []uuid.UUID{}, ChangedAt: now},
+	}
+
+	for _, payload := range payloads {
+		t.Run(payload.EventType().String(), func(t *testing.T) {
+			schema := loadSchema(t, outbox.SchemaPath(payload.EventType(), payload.SchemaVersion()))
+
+			event, err := outbox.NewEvent(payload, now)
+			require.NoError(t, err)
+
+			var fields map[string]interface{}
+			require.NoError(t, json.Unmarshal(event.Payload, &fields))
+
+			assert.Equal(t, keysOf(schema.Properties), keysOf(fields))
+
+			for _, required := range schema.Required {
+				assert.Contains(t, fields, required)
+			}
+		})
+	}
+
+	t.Run("Envelope", func(t *testing.T) {
+		schema := loadSchema(t, "schemas/envelope.v1.json")
+
+		event, err := outbox.NewEvent(payloads[0], now)
+		require.NoError(t, err)
+
+		content, err := json.Marshal(event.Envelope())
+		require.NoError(t, err)
+
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(content, &fields))
+
+		assert.Equal(t, keysOf(schema.Properties), keysOf(fields))
+	})
+}
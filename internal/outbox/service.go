@@ -0,0 +1,37 @@
+package outbox
+
+import (
+	"context"
+	"gorm.io/gorm"
+	"time"
+)
+
+type Service interface {
+	RecordWithTx(ctx context.Context, payloads []Payload, tx *gorm.DB) error
+}
+
+type OutboxServiceImpl struct {
+	repo Repository
+}
+
+func NewOutboxService(repo Repository) *OutboxServiceImpl {
+	return &OutboxServiceImpl{repo: repo}
+}
+
+// RecordWithTx writes the events in the transaction of the change they describe, they are only published once
+// it commits and are lost with it when it rolls back.
+func (s *OutboxServiceImpl) RecordWithTx(ctx context.Context, payloads []Payload, tx *gorm.DB) error {
+	occurredAt := time.Now().UTC()
+	events := make([]*Event, 0, len(payloads))
+
+	for _, payload := range payloads {
+		event, err := NewEvent(payload, occurredAt)
+		if err != nil {
+			return err
+		}
+
+		events = append(events, event)
+	}
+
+	return s.repo.CreateWithTx(ctx, events, tx)
+}
@@ -0,0 +1,90 @@
+package standingorders
+
+import (
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// StandingOrder is a recurring transfer, every occurrence runs the Transfer workflow from a Temporal schedule.
+type StandingOrder struct {
+	ID                   uuid.UUID                        `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SourceAccountID      uuid.UUID                        `gorm:"type:uuid;not null"`
+	DestinationAccountID uuid.UUID                        `gorm:"type:uuid;not null"`
+	Amount               int                              `gorm:"not null"`
+	Metadata             datatypes.JSONMap                `gorm:"type:jsonb"`
+	Frequency            constants.StandingOrderFrequency `gorm:"type:varchar(20);not null"`
+	CronExpression       *string                          `gorm:"type:varchar(100)"`
+	DayOfWeek            *int                             `gorm:"type:int"`
+	DayOfMonth           *int                             `gorm:"type:int"`
+	Hour                 int                              `gorm:"not null"`
+	Minute               int                              `gorm:"not null"`
+	StartAt              *time.Time                       `gorm:"type:timestamp with time zone"`
+	EndAt                *time.Time                       `gorm:"type:timestamp with time zone"`
+	MaxCount             *int                             `gorm:"type:int"`
+	ExecutedCount        int                              `gorm:"not null"`
+	FailedCount          int                              `gorm:"not null"`
+	Status               constants.StandingOrderStatus    `gorm:"type:varchar(20);not null"`
+	ScheduleID           string                           `gorm:"type:varchar(100);not null"`
+	CreatedAt            time.Time                        `gorm:"type:timestamp with time zone;not null"`
+	UpdatedAt            time.Time                        `gorm:"type:timestamp with time zone;not null"`
+}
+
+// Occurrence is the outcome of one scheduled run of a standing order.
+type Occurrence struct {
+	ID              uuid.UUID               `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	StandingOrderID uuid.UUID               `gorm:"type:uuid;not null"`
+	ReferenceID     uuid.UUID               `gorm:"type:uuid;not null"`
+	WorkflowID      string                  `gorm:"type:varchar(255);not null"`
+	Status          constants.TransferState `gorm:"type:varchar(20);not null"`
+	FailureReason   *string                 `gorm:"type:text"`
+	CreatedAt       time.Time               `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Occurrence) TableName() string {
+	return "standing_order_occurrences"
+}
+
+// Validate checks the standing order has a schedule rule matching its frequency.
+func (o *StandingOrder) Validate() error {
+	if o.Amount <= 0 {
+		return fmt.Errorf("%w: amount must be positive", ErrInvalidStandingOrder)
+	}
+
+	if o.SourceAccountID == o.DestinationAccountID {
+		return fmt.Errorf("%w: source and destination accounts must differ", ErrInvalidStandingOrder)
+	}
+
+	if o.Hour < 0 || o.Hour > 23 || o.Minute < 0 || o.Minute > 59 {
+		return fmt.Errorf("%w: invalid time of day %02d:%02d", ErrInvalidStandingOrder, o.Hour, o.Minute)
+	}
+
+	switch o.Frequency {
+	case constants.StandingOrderFrequencyCRON:
+		if o.CronExpression == nil || *o.CronExpression == "" {
+			return fmt.Errorf("%w: cron expression is required", ErrInvalidStandingOrder)
+		}
+	case constants.StandingOrderFrequencyWEEKLY:
+		if o.DayOfWeek == nil || *o.DayOfWeek < 0 || *o.DayOfWeek > 6 {
+			return fmt.Errorf("%w: day of week must be between 0 (Sunday) and 6", ErrInvalidStandingOrder)
+		}
+	case constants.StandingOrderFrequencyMONTHLY:
+		if o.DayOfMonth == nil || *o.DayOfMonth < 1 || *o.DayOfMonth > 31 {
+			return fmt.Errorf("%w: day of month must be between 1 and 31", ErrInvalidStandingOrder)
+		}
+	default:
+		return fmt.Errorf("%w: unknown frequency: %s", ErrInvalidStandingOrder, o.Frequency)
+	}
+
+	if o.StartAt != nil && o.EndAt != nil && !o.EndAt.After(*o.StartAt) {
+		return fmt.Errorf("%w: end date must be after start date", ErrInvalidStandingOrder)
+	}
+
+	if o.MaxCount != nil && *o.MaxCount <= 0 {
+		return fmt.Errorf("%w: max count must be positive", ErrInvalidStandingOrder)
+	}
+
+	return nil
+}
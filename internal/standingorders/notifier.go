@@ -0,0 +1,36 @@
+package standingorders
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+)
+
+// Notifier is called when an occurrence of a standing order fails.
+type Notifier interface {
+	NotifyOccurrenceFailed(ctx context.Context, standingOrder *StandingOrder, occurrence *Occurrence) error
+}
+
+// LogNotifier only logs the failed occurrences.
+type LogNotifier struct {
+	logger *zerolog.Logger
+}
+
+func NewLogNotifier(logger *zerolog.Logger) *LogNotifier {
+	return &LogNotifier{logger: logger}
+}
+
+func (n *LogNotifier) NotifyOccurrenceFailed(_ context.Context, standingOrder *StandingOrder, occurrence *Occurrence) error {
+	event := n.logger.Warn().
+		Str("standing_order_id", standingOrder.ID.String()).
+		Str("source_account_id", standingOrder.SourceAccountID.String()).
+		Str("reference_id", occurrence.ReferenceID.String())
+
+	if occurrence.FailureReason != nil {
+		event = event.Str("failure_reason", *occurrence.FailureReason)
+	}
+
+	event.Msg("standing order occurrence failed")
+
+	return nil
+}
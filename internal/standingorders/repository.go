@@ -0,0 +1,111 @@
+package standingorders
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ulascansenturk/service/internal/constants"
+)
+
+type Repository interface {
+	Create(ctx context.Context, standingOrder *StandingOrder) (*StandingOrder, error)
+	GetByID(ctx context.Context, id uuid.UUID) (*StandingOrder, error)
+	List(ctx context.Context, sourceAccountID *uuid.UUID) ([]*StandingOrder, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status constants.StandingOrderStatus) error
+	RecordOccurrence(ctx context.Context, occurrence *Occurrence) error
+	GetOccurrences(ctx context.Context, standingOrderID uuid.UUID) ([]*Occurrence, error)
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (r *SQLRepository) Create(ctx context.Context, standingOrder *StandingOrder) (*StandingOrder, error) {
+	if err := r.db.WithContext(ctx).Create(standingOrder).Error; err != nil {
+		return nil, err
+	}
+	return standingOrder, nil
+}
+
+func (r *SQLRepository) GetByID(ctx context.Context, id uuid.UUID) (*StandingOrder, error) {
+	var standingOrder StandingOrder
+	if err := r.db.WithContext(ctx).First(&standingOrder, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &standingOrder, nil
+}
+
+func (r *SQLRepository) List(ctx context.Context, sourceAccountID *uuid.UUID) ([]*StandingOrder, error) {
+	var standingOrders []*StandingOrder
+
+	query := r.db.WithContext(ctx).Order("created_at DESC")
+	if sourceAccountID != nil {
+		query = query.Where("source_account_id = ?", *sourceAccountID)
+	}
+
+	if err := query.Find(&standingOrders).Error; err != nil {
+		return nil, err
+	}
+	return standingOrders, nil
+}
+
+func (r *SQLRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status constants.StandingOrderStatus) error {
+	if err := r.db.WithContext(ctx).Model(&StandingOrder{}).Where("id = ?", id).Update("status", status).Error; err != nil {
+		return err
+	}
+	return nil
+}
+
+// RecordOccurrence stores the occurrence and updates the counters of its standing order in one transaction.
+// An occurrence already recorded for the same reference ID is ignored, so retried recordings count once.
+// The standing order is completed once it has run MaxCount times.
+func (r *SQLRepository) RecordOccurrence(ctx context.Context, occurrence *Occurrence) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(occurrence)
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return nil
+		}
+
+		counter := "executed_count"
+		if occurrence.Status != constants.TransferStateCOMPLETED {
+			counter = "failed_count"
+		}
+
+		if err := tx.Model(&StandingOrder{}).
+			Where("id = ?", occurrence.StandingOrderID).
+			Update(counter, gorm.Expr(counter+" + 1")).Error; err != nil {
+			return err
+		}
+
+		return tx.Model(&StandingOrder{}).
+			Where("id = ?", occurrence.StandingOrderID).
+			Where("status = ?", constants.StandingOrderStatusACTIVE).
+			Where("max_count IS NOT NULL AND executed_count + failed_count >= max_count").
+			Update("status", constants.StandingOrderStatusCOMPLETED).Error
+	})
+}
+
+func (r *SQLRepository) GetOccurrences(ctx context.Context, standingOrderID uuid.UUID) ([]*Occurrence, error) {
+	var occurrences []*Occurrence
+	if err := r.db.WithContext(ctx).
+		Where("standing_order_id = ?", standingOrderID).
+		Order("created_at DESC").
+		Find(&occurrences).Error; err != nil {
+		return nil, err
+	}
+	return occurrences, nil
+}
@@ -0,0 +1,66 @@
+package standingorders
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"ulascansenturk/service/internal/constants"
+)
+
+var (
+	ErrInvalidStandingOrder  = errors.New("invalid standing order")
+	ErrStandingOrderNotFound = errors.New("standing order not found")
+)
+
+type Service interface {
+	CreateStandingOrder(ctx context.Context, standingOrder *StandingOrder) (*StandingOrder, error)
+	GetStandingOrder(ctx context.Context, id uuid.UUID) (*StandingOrder, error)
+	ListStandingOrders(ctx context.Context, sourceAccountID *uuid.UUID) ([]*StandingOrder, error)
+	UpdateStatus(ctx context.Context, id uuid.UUID, status constants.StandingOrderStatus) error
+	RecordOccurrence(ctx context.Context, occurrence *Occurrence) error
+	ListOccurrences(ctx context.Context, standingOrderID uuid.UUID) ([]*Occurrence, error)
+}
+
+type StandingOrderServiceImpl struct {
+	repo Repository
+}
+
+func NewStandingOrderService(repo Repository) *StandingOrderServiceImpl {
+	return &StandingOrderServiceImpl{repo: repo}
+}
+
+func (s *StandingOrderServiceImpl) CreateStandingOrder(ctx context.Context, standingOrder *StandingOrder) (*StandingOrder, error) {
+	if err := standingOrder.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, standingOrder)
+}
+
+func (s *StandingOrderServiceImpl) GetStandingOrder(ctx context.Context, id uuid.UUID) (*StandingOrder, error) {
+	standingOrder, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if standingOrder == nil {
+		return nil, ErrStandingOrderNotFound
+	}
+	return standingOrder, nil
+}
+
+func (s *StandingOrderServiceImpl) ListStandingOrders(ctx context.Context, sourceAccountID *uuid.UUID) ([]*StandingOrder, error) {
+	return s.repo.List(ctx, sourceAccountID)
+}
+
+func (s *StandingOrderServiceImpl) UpdateStatus(ctx context.Context, id uuid.UUID, status constants.StandingOrderStatus) error {
+	return s.repo.UpdateStatus(ctx, id, status)
+}
+
+func (s *StandingOrderServiceImpl) RecordOccurrence(ctx context.Context, occurrence *Occurrence) error {
+	return s.repo.RecordOccurrence(ctx, occurrence)
+}
+
+func (s *StandingOrderServiceImpl) ListOccurrences(ctx context.Context, standingOrderID uuid.UUID) ([]*Occurrence, error) {
+	return s.repo.GetOccurrences(ctx, standingOrderID)
+}
@@ -0,0 +1,418 @@
+package temporalworkflows
+
+import (
+	"time"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"github.com/google/uuid"
+	"github.com/ilyakaznacheev/cleanenv"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// AccountTurnSignal is sent to the owner of a turn request once it holds the turn of the account.
+	AccountTurnSignal = "account-turn"
+	// AccountTurnReleaseSignal ends the turn of its owner, or withdraws its request while it is still queued.
+	AccountTurnReleaseSignal = "account-turn-release"
+	// AccountEntityStateQuery returns the AccountEntityState of a running AccountEntity workflow.
+	AccountEntityStateQuery = "account-entity-state"
+)
+
+const (
+	// accountEntityIdleTimeout is how long an AccountEntity workflow waits for a request before it ends, the
+	// next request starts it again.
+	accountEntityIdleTimeout = 10 * time.Minute
+	// accountEntityMaxTurns bounds the history of a run, the turns are carried over to a new run after it.
+	accountEntityMaxTurns = 500
+)
+
+// staleAccountTurnVersion marks the workflows that release the turns granted to a wait they abandoned.
+const staleAccountTurnVersion = "stale-account-turn"
+
+type AccountTurn struct {
+	AccountID uuid.UUID
+}
+
+type AccountTurnRelease struct {
+	OwnerWorkflowID string
+}
+
+// AccountEntityState is the workflow holding the turn of the account and the workflows queued after it.
+type AccountEntityState struct {
+	AccountID    uuid.UUID
+	Holder       *string
+	Queue        []string
+	TurnsGranted int
+}
+
+// accountEntityActivityOptions bounds the calls to the Temporal service made for the turns.
+var accountEntityActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: 30 * time.Second,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    10 * time.Second,
+	},
+}
+
+// AccountEntity hands out the turn of an account to the workflows writing to it, one at a time and in the order
+// their requests arrived. Requests are signal-with-started by RequestAccountTurn, so the workflow runs only while
+// the account has requests. A turn is held until its owner releases it. A holder that hasn't released it within
+// TRANSFER_MUTEX_TTL_SECONDS is checked, the turn is taken back once the holder is no longer running.
+func AccountEntity(ctx workflow.Context, params activities.AccountEntityParams) error {
+	var cfg TransferEnvConfig
+
+	readCfgErr := cleanenv.ReadEnv(&cfg)
+	if readCfgErr != nil {
+		return readCfgErr
+	}
+
+	entity := &accountEntity{
+		accountID: params.AccountID,
+		holder:    params.Holder,
+		queue:     params.Queue,
+	}
+
+	err := workflow.SetQueryHandler(ctx, AccountEntityStateQuery, func() (AccountEntityState, error) {
+		return entity.state(), nil
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, accountEntityActivityOptions)
+
+	requests := workflow.GetSignalChannel(ctx, activities.AccountTurnRequestSignal)
+	releases := workflow.GetSignalChannel(ctx, AccountTurnReleaseSignal)
+
+	holdTimeout := time.Duration(cfg.TransferMutexTTLSeconds) * time.Second
+	if holdTimeout <= 0 {
+		holdTimeout = accountEntityIdleTimeout
+	}
+
+	for {
+		entity.grantNext(ctx)
+
+		if entity.turnsGranted >= accountEntityMaxTurns || workflow.GetInfo(ctx).GetContinueAsNewSuggested() {
+			entity.drain(ctx, requests, releases)
+
+			return workflow.NewContinueAsNewError(ctx, AccountEntity, entity.params())
+		}
+
+		timeout := accountEntityIdleTimeout
+		if entity.holder != nil {
+			timeout = holdTimeout
+		}
+
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		timedOut := false
+
+		selector := workflow.NewSelector(ctx)
+		selector.AddReceive(requests, func(c workflow.ReceiveChannel, _ bool) {
+			var request activities.AccountTurnRequest
+			c.Receive(ctx, &request)
+
+			entity.enqueue(request)
+		})
+		selector.AddReceive(releases, func(c workflow.ReceiveChannel, _ bool) {
+			var release AccountTurnRelease
+			c.Receive(ctx, &release)
+
+			entity.release(release.OwnerWorkflowID)
+		})
+		selector.AddFuture(workflow.NewTimer(timerCtx, timeout), func(f workflow.Future) {
+			timedOut = f.Get(ctx, nil) == nil
+		})
+
+		selector.Select(ctx)
+		cancelTimer()
+
+		if !timedOut {
+			continue
+		}
+
+		if entity.holder == nil {
+			entity.drain(ctx, requests, releases)
+
+			if len(entity.queue) == 0 {
+				return nil
+			}
+
+			continue
+		}
+
+		entity.checkHolder(ctx)
+	}
+}
+
+type accountEntity struct {
+	accountID    uuid.UUID
+	holder       *activities.AccountTurnRequest
+	queue        []activities.AccountTurnRequest
+	turnsGranted int
+}
+
+// enqueue adds the request to the end of the queue, a request sent again by a retried activity is ignored.
+func (e *accountEntity) enqueue(request activities.AccountTurnRequest) {
+	if e.holder != nil && e.holder.OwnerWorkflowID == request.OwnerWorkflowID {
+		return
+	}
+
+	for _, queued := range e.queue {
+		if queued.OwnerWorkflowID == request.OwnerWorkflowID {
+			return
+		}
+	}
+
+	e.queue = append(e.queue, request)
+}
+
+func (e *accountEntity) release(ownerWorkflowID string) {
+	if e.holder != nil && e.holder.OwnerWorkflowID == ownerWorkflowID {
+		e.holder = nil
+
+		return
+	}
+
+	for i, queued := range e.queue {
+		if queued.OwnerWorkflowID == ownerWorkflowID {
+			e.queue = append(e.queue[:i], e.queue[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// grantNext gives the free turn to the first request of the queue. An owner that can't be signalled has ended
+// already, the turn goes to the next one.
+func (e *accountEntity) grantNext(ctx workflow.Context) {
+	for e.holder == nil && len(e.queue) > 0 {
+		next := e.queue[0]
+		e.queue = e.queue[1:]
+
+		err := workflow.SignalExternalWorkflow(ctx, next.OwnerWorkflowID, "", AccountTurnSignal, AccountTurn{
+			AccountID: e.accountID,
+		}).Get(ctx, nil)
+		if err != nil {
+			workflow.GetLogger(ctx).Warn("Account turn owner can't be signalled", "Owner", next.OwnerWorkflowID, "Error", err)
+
+			continue
+		}
+
+		e.holder = &next
+		e.turnsGranted++
+	}
+}
+
+// checkHolder takes the turn back from a holder that ended without releasing it.
+func (e *accountEntity) checkHolder(ctx workflow.Context) {
+	var (
+		accountEntityOperations *activities.AccountEntityOperations
+		running                 bool
+	)
+
+	err := workflow.ExecuteActivity(ctx, accountEntityOperations.IsWorkflowRunning, e.holder.OwnerWorkflowID).Get(ctx, &running)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("Account turn holder check failed", "Owner", e.holder.OwnerWorkflowID, "Error", err)
+
+		return
+	}
+
+	if !running {
+		workflow.GetLogger(ctx).Warn("Account turn taken back from an ended holder", "Owner", e.holder.OwnerWorkflowID)
+
+		e.holder = nil
+	}
+}
+
+// drain handles the signals already received, so none is lost when the run ends.
+func (e *accountEntity) drain(ctx workflow.Context, requests, releases workflow.ReceiveChannel) {
+	for {
+		var request activities.AccountTurnRequest
+		if requests.ReceiveAsync(&request) {
+			e.enqueue(request)
+
+			continue
+		}
+
+		var release AccountTurnRelease
+		if releases.ReceiveAsync(&release) {
+			e.release(release.OwnerWorkflowID)
+
+			continue
+		}
+
+		return
+	}
+}
+
+func (e *accountEntity) params() activities.AccountEntityParams {
+	return activities.AccountEntityParams{
+		AccountID: e.accountID,
+		Holder:    e.holder,
+		Queue:     e.queue,
+	}
+}
+
+func (e *accountEntity) state() AccountEntityState {
+	state := AccountEntityState{
+		AccountID:    e.accountID,
+		Queue:        make([]string, 0, len(e.queue)),
+		TurnsGranted: e.turnsGranted,
+	}
+
+	if e.holder != nil {
+		state.Holder = &e.holder.OwnerWorkflowID
+	}
+
+	for _, queued := range e.queue {
+		state.Queue = append(state.Queue, queued.OwnerWorkflowID)
+	}
+
+	return state
+}
+
+// entityAccountLocks takes the turns of the accounts from their AccountEntity workflows, the turns are handed
+// out in the order they were requested and are never taken over while their holder runs, so they come without
+// a fencing token.
+type entityAccountLocks struct {
+	ownerWorkflowID string
+	waits           map[uuid.UUID]*accountTurnWait
+}
+
+type accountTurnWait struct {
+	cancel   workflow.CancelFunc
+	enqueued workflow.Future
+}
+
+func newEntityAccountLocks(ctx workflow.Context) *entityAccountLocks {
+	return &entityAccountLocks{
+		ownerWorkflowID: workflow.GetInfo(ctx).WorkflowExecution.ID,
+		waits:           make(map[uuid.UUID]*accountTurnWait),
+	}
+}
+
+func (l *entityAccountLocks) acquire(ctx workflow.Context, accountID uuid.UUID) workflow.Future {
+	var accountEntityOperations *activities.AccountEntityOperations
+
+	turn, setTurn := workflow.NewFuture(ctx)
+
+	waitCtx, cancel := workflow.WithCancel(ctx)
+
+	// The request isn't cancelled with the wait, a withdrawal must reach the entity after it.
+	requestCtx, _ := workflow.NewDisconnectedContext(ctx)
+	requestCtx = workflow.WithActivityOptions(requestCtx, accountEntityActivityOptions)
+
+	enqueued := workflow.ExecuteActivity(requestCtx, accountEntityOperations.RequestAccountTurn, activities.AccountTurnRequest{
+		AccountID:       accountID,
+		OwnerWorkflowID: l.ownerWorkflowID,
+	})
+
+	l.waits[accountID] = &accountTurnWait{cancel: cancel, enqueued: enqueued}
+
+	workflow.Go(waitCtx, func(ctx workflow.Context) {
+		err := enqueued.Get(ctx, nil)
+		if err != nil {
+			setTurn.Set(nil, err)
+
+			return
+		}
+
+		turns := workflow.GetSignalChannel(ctx, AccountTurnSignal)
+
+		for ctx.Err() == nil {
+			var granted AccountTurn
+
+			selector := workflow.NewSelector(ctx)
+			selector.AddReceive(turns, func(c workflow.ReceiveChannel, _ bool) {
+				c.Receive(ctx, &granted)
+			})
+			selector.AddReceive(ctx.Done(), func(workflow.ReceiveChannel, bool) {})
+			selector.Select(ctx)
+
+			if granted.AccountID == accountID {
+				setTurn.Set(int64(0), nil)
+
+				return
+			}
+
+			if granted.AccountID != uuid.Nil {
+				l.releaseStale(ctx, granted.AccountID)
+			}
+		}
+	})
+
+	return turn
+}
+
+func (l *entityAccountLocks) abandon(ctx workflow.Context, accountID uuid.UUID) {
+	wait, ok := l.waits[accountID]
+	if !ok {
+		return
+	}
+
+	wait.cancel()
+	delete(l.waits, accountID)
+
+	releaseCtx, _ := workflow.NewDisconnectedContext(ctx)
+
+	// A request that failed may still have reached the entity, it is withdrawn as well. Workflows started before
+	// the stale turns were released only withdraw the requests that succeeded.
+	if wait.enqueued.Get(releaseCtx, nil) != nil &&
+		workflow.GetVersion(ctx, staleAccountTurnVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return
+	}
+
+	err := l.release(ctx, []uuid.UUID{accountID})
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("Account turn withdrawal failed", "Error", err)
+	}
+}
+
+// releaseStale ends a turn granted for an account that is neither waited for nor held, e.g. the one of an
+// abandoned wait, so the entity doesn't keep it until it checks on its holder. Workflows started before the
+// stale turns were released drop them.
+func (l *entityAccountLocks) releaseStale(ctx workflow.Context, accountID uuid.UUID) {
+	if _, ok := l.waits[accountID]; ok {
+		return
+	}
+
+	if workflow.GetVersion(ctx, staleAccountTurnVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return
+	}
+
+	workflow.GetLogger(ctx).Warn("Stale account turn released", "AccountID", accountID)
+
+	err := l.release(ctx, []uuid.UUID{accountID})
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("Stale account turn release failed", "Error", err)
+	}
+}
+
+func (l *entityAccountLocks) hold(ctx workflow.Context, accountIDs []uuid.UUID) MutexReleaseFunc {
+	return func() error {
+		return l.release(ctx, accountIDs)
+	}
+}
+
+// release ends the turns in a disconnected context, so they are released even when the workflow failed or is
+// cancelled.
+func (l *entityAccountLocks) release(ctx workflow.Context, accountIDs []uuid.UUID) error {
+	releaseCtx, _ := workflow.NewDisconnectedContext(ctx)
+
+	futures := make([]workflow.Future, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		delete(l.waits, accountID)
+
+		futures = append(futures, workflow.SignalExternalWorkflow(
+			releaseCtx,
+			activities.AccountEntityWorkflowID(accountID),
+			"",
+			AccountTurnReleaseSignal,
+			AccountTurnRelease{OwnerWorkflowID: l.ownerWorkflowID},
+		))
+	}
+
+	return joinFutureErrors(releaseCtx, futures)
+}
@@ -0,0 +1,405 @@
+//go:build tests_unit
+
+package temporalworkflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/fees"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+	"ulascansenturk/service/internal/transactions"
+
+	"go.temporal.io/sdk/converter"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type accountEntityTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (s *accountEntityTestSuite) SetupSubTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	s.env.RegisterWorkflow(AccountEntity)
+}
+
+func (s *accountEntityTestSuite) TearDownSubTest() {
+	s.env.AssertExpectations(s.T())
+}
+
+func TestAccountEntity(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(accountEntityTestSuite))
+}
+
+func (s *accountEntityTestSuite) TestAccountEntityWorkflow() {
+	accountID := uuid.New()
+
+	requestTurn := func(ownerWorkflowID string) {
+		s.env.SignalWorkflow(activities.AccountTurnRequestSignal, activities.AccountTurnRequest{
+			AccountID:       accountID,
+			OwnerWorkflowID: ownerWorkflowID,
+		})
+	}
+
+	releaseTurn := func(ownerWorkflowID string) {
+		s.env.SignalWorkflow(AccountTurnReleaseSignal, AccountTurnRelease{OwnerWorkflowID: ownerWorkflowID})
+	}
+
+	s.Run("Turns are granted one at a time in the order they were requested", func() {
+		var granted []string
+		grantedAt := map[string]time.Time{}
+
+		s.env.OnSignalExternalWorkflow(mock.Anything, mock.Anything, "", AccountTurnSignal, AccountTurn{AccountID: accountID}).
+			Return(func(_, workflowID, _, _ string, _ interface{}) error {
+				granted = append(granted, workflowID)
+				grantedAt[workflowID] = s.env.Now()
+
+				return nil
+			}).Times(3)
+
+		s.env.RegisterDelayedCallback(func() {
+			requestTurn("first")
+			requestTurn("second")
+			requestTurn("second")
+			requestTurn("third")
+		}, time.Second)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("first") }, time.Minute)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("second") }, 2*time.Minute)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("third") }, 3*time.Minute)
+
+		s.env.ExecuteWorkflow(AccountEntity, activities.AccountEntityParams{AccountID: accountID})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.Equal([]string{"first", "second", "third"}, granted)
+		s.False(grantedAt["second"].Before(grantedAt["first"].Add(time.Minute - time.Second)))
+		s.False(grantedAt["third"].Before(grantedAt["first"].Add(2*time.Minute - time.Second)))
+	})
+
+	s.Run("Withdrawn request is never granted", func() {
+		var granted []string
+
+		s.env.OnSignalExternalWorkflow(mock.Anything, mock.Anything, "", AccountTurnSignal, mock.Anything).
+			Return(func(_, workflowID, _, _ string, _ interface{}) error {
+				granted = append(granted, workflowID)
+
+				return nil
+			}).Twice()
+
+		s.env.RegisterDelayedCallback(func() {
+			requestTurn("first")
+			requestTurn("cancelled")
+			requestTurn("third")
+		}, time.Second)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("cancelled") }, 10*time.Second)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("first") }, time.Minute)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("third") }, 2*time.Minute)
+
+		s.env.ExecuteWorkflow(AccountEntity, activities.AccountEntityParams{AccountID: accountID})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.Equal([]string{"first", "third"}, granted)
+	})
+
+	s.Run("Turn of a holder that ended without releasing it goes to the next request", func() {
+		var accountEntityOperations *activities.AccountEntityOperations
+
+		s.env.OnSignalExternalWorkflow(mock.Anything, "ended", "", AccountTurnSignal, mock.Anything).Return(nil).Once()
+		s.env.OnSignalExternalWorkflow(mock.Anything, "next", "", AccountTurnSignal, mock.Anything).Return(nil).Once()
+		s.env.OnActivity(accountEntityOperations.IsWorkflowRunning, mock.Anything, "ended").Return(false, nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			requestTurn("ended")
+			requestTurn("next")
+		}, time.Second)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("next") }, time.Hour)
+
+		s.env.ExecuteWorkflow(AccountEntity, activities.AccountEntityParams{AccountID: accountID})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Owner that can't be signalled is skipped", func() {
+		s.env.OnSignalExternalWorkflow(mock.Anything, "ended", "", AccountTurnSignal, mock.Anything).
+			Return(errors.New("unknown external workflow")).Once()
+		s.env.OnSignalExternalWorkflow(mock.Anything, "next", "", AccountTurnSignal, mock.Anything).Return(nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			requestTurn("ended")
+			requestTurn("next")
+		}, time.Second)
+		s.env.RegisterDelayedCallback(func() { releaseTurn("next") }, time.Minute)
+
+		s.env.ExecuteWorkflow(AccountEntity, activities.AccountEntityParams{AccountID: accountID})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Entity continues as new with its turns after the max turns of a run", func() {
+		s.env.OnSignalExternalWorkflow(mock.Anything, mock.Anything, "", AccountTurnSignal, mock.Anything).
+			Return(nil).Times(accountEntityMaxTurns)
+
+		s.env.RegisterDelayedCallback(func() {
+			for i := 0; i <= accountEntityMaxTurns; i++ {
+				requestTurn(fmt.Sprintf("owner-%d", i))
+			}
+		}, time.Second)
+
+		for i := 0; i < accountEntityMaxTurns-1; i++ {
+			ownerWorkflowID := fmt.Sprintf("owner-%d", i)
+
+			s.env.RegisterDelayedCallback(func() { releaseTurn(ownerWorkflowID) }, time.Duration(i+2)*time.Second)
+		}
+
+		s.env.ExecuteWorkflow(AccountEntity, activities.AccountEntityParams{AccountID: accountID})
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var continueAsNewErr *workflow.ContinueAsNewError
+		s.Require().ErrorAs(s.env.GetWorkflowError(), &continueAsNewErr)
+
+		var params activities.AccountEntityParams
+		s.NoError(converter.GetDefaultDataConverter().FromPayloads(continueAsNewErr.Input, &params))
+		s.Equal(accountID, params.AccountID)
+		s.Equal(fmt.Sprintf("owner-%d", accountEntityMaxTurns-1), params.Holder.OwnerWorkflowID)
+		s.Equal([]activities.AccountTurnRequest{{
+			AccountID:       accountID,
+			OwnerWorkflowID: fmt.Sprintf("owner-%d", accountEntityMaxTurns),
+		}}, params.Queue)
+	})
+}
+
+// TestTransferWithAccountEntities isn't parallel, it runs the transfer with TRANSFER_ACCOUNT_ENTITY_WORKFLOWS set.
+func TestTransferWithAccountEntities(t *testing.T) {
+	t.Setenv("TRANSFER_ACCOUNT_ENTITY_WORKFLOWS", "true")
+
+	var (
+		testSuite               testsuite.WorkflowTestSuite
+		transactionOperations   *activities.TransactionOperations
+		feeOperations           *activities.FeeOperations
+		fxOperations            *activities.FXOperations
+		approvalOperations      *activities.ApprovalOperations
+		limitOperations         *activities.LimitOperations
+		accountEntityOperations *activities.AccountEntityOperations
+	)
+
+	env := testSuite.NewTestWorkflowEnvironment()
+	env.RegisterWorkflow(Transfer)
+	env.RegisterWorkflow(TransferNotifications)
+	env.OnWorkflow(TransferNotifications, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+	sourceAccountID := uuid.MustParse("cccccccc-0000-0000-0000-000000000000")
+	destinationAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	feeAccountID := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+	accountIDs := []uuid.UUID{destinationAccountID, feeAccountID, sourceAccountID}
+
+	var requested []uuid.UUID
+
+	env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{Amount: 10}, nil)
+	env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+	env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+	env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{
+		IncomingFeeTrx: &transactions.Transaction{AccountID: feeAccountID},
+	}, nil)
+	env.OnActivity(accountEntityOperations.RequestAccountTurn, mock.Anything, mock.Anything).
+		Return(func(_ context.Context, request activities.AccountTurnRequest) error {
+			requested = append(requested, request.AccountID)
+
+			return nil
+		}).Times(3)
+	env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil).Once()
+	env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+		return len(params.FencingTokens) == 0
+	}), mock.Anything).Return(&activities.TransferResult{}, nil).Once()
+
+	for _, accountID := range accountIDs {
+		env.OnSignalExternalWorkflow(
+			mock.Anything,
+			activities.AccountEntityWorkflowID(accountID),
+			"",
+			AccountTurnReleaseSignal,
+			mock.Anything,
+		).Return(nil).Once()
+	}
+
+	// Every turn is granted a minute after the previous one, the transfer asks for the next turn only once it
+	// holds the previous one.
+	for i, accountID := range accountIDs {
+		turn := AccountTurn{AccountID: accountID}
+
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(AccountTurnSignal, turn)
+		}, time.Duration(i+1)*time.Minute)
+	}
+
+	env.ExecuteWorkflow(Transfer, &TransferParams{
+		Amount:               1000,
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+	})
+
+	if !env.IsWorkflowCompleted() {
+		t.Fatal("transfer did not complete")
+	}
+
+	if err := env.GetWorkflowError(); err != nil {
+		t.Fatal(err)
+	}
+
+	if fmt.Sprint(requested) != fmt.Sprint(accountIDs) {
+		t.Fatalf("turns requested in %v, expected %v", requested, accountIDs)
+	}
+
+	env.AssertExpectations(t)
+}
+
+// TestTransferReleasesStaleAccountTurns isn't parallel, it runs the transfer with TRANSFER_ACCOUNT_ENTITY_WORKFLOWS
+// set.
+func TestTransferReleasesStaleAccountTurns(t *testing.T) {
+	t.Setenv("TRANSFER_ACCOUNT_ENTITY_WORKFLOWS", "true")
+
+	var (
+		testSuite               testsuite.WorkflowTestSuite
+		transactionOperations   *activities.TransactionOperations
+		feeOperations           *activities.FeeOperations
+		fxOperations            *activities.FXOperations
+		approvalOperations      *activities.ApprovalOperations
+		limitOperations         *activities.LimitOperations
+		accountEntityOperations *activities.AccountEntityOperations
+	)
+
+	sourceAccountID := uuid.MustParse("cccccccc-0000-0000-0000-000000000000")
+	destinationAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+
+	newEnv := func() *testsuite.TestWorkflowEnvironment {
+		env := testSuite.NewTestWorkflowEnvironment()
+		env.RegisterWorkflow(Transfer)
+		env.RegisterWorkflow(TransferNotifications)
+		env.OnWorkflow(TransferNotifications, mock.Anything, mock.Anything).Return(nil).Maybe()
+
+		env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).
+			Return(&activities.PendingTransactions{}, nil)
+
+		return env
+	}
+
+	// releasedAt records when the turn of the account is released.
+	releasedAt := func(env *testsuite.TestWorkflowEnvironment, accountID uuid.UUID) *time.Time {
+		var at time.Time
+
+		env.OnSignalExternalWorkflow(
+			mock.Anything,
+			activities.AccountEntityWorkflowID(accountID),
+			"",
+			AccountTurnReleaseSignal,
+			mock.Anything,
+		).Return(func(string, string, string, string, interface{}) error {
+			at = env.Now()
+
+			return nil
+		}).Once()
+
+		return &at
+	}
+
+	t.Run("Turn of a cancelled wait is withdrawn when its request failed", func(t *testing.T) {
+		env := newEnv()
+
+		// The request reached the entity but the activity reports a failure, after the transfer was cancelled.
+		env.OnActivity(accountEntityOperations.RequestAccountTurn, mock.Anything, mock.Anything).
+			Return(func(context.Context, activities.AccountTurnRequest) error {
+				env.SignalWorkflow(CancelTransferSignal, CancelTransferRequest{Reason: "no longer needed"})
+
+				return temporal.NewNonRetryableApplicationError("entity unavailable", "", nil)
+			}).Once()
+		env.OnActivity(transactionOperations.CancelTransactions, mock.Anything, mock.Anything, "no longer needed").
+			Return(nil).Once()
+
+		releasedAt(env, destinationAccountID)
+
+		env.ExecuteWorkflow(Transfer, &TransferParams{
+			Amount:               1000,
+			SourceAccountID:      sourceAccountID,
+			DestinationAccountID: destinationAccountID,
+		})
+
+		if !env.IsWorkflowCompleted() {
+			t.Fatal("transfer did not complete")
+		}
+
+		var applicationErr *temporal.ApplicationError
+		if !errors.As(env.GetWorkflowError(), &applicationErr) || applicationErr.Type() != TransferCancelledErrorType {
+			t.Fatalf("transfer failed with %v, expected its cancellation", env.GetWorkflowError())
+		}
+
+		env.AssertExpectations(t)
+	})
+
+	t.Run("Turn granted for an account that isn't waited for is released right away", func(t *testing.T) {
+		env := newEnv()
+		staleAccountID := uuid.New()
+
+		env.OnActivity(accountEntityOperations.RequestAccountTurn, mock.Anything, mock.Anything).Return(nil).Times(2)
+		env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil).Once()
+		env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil).Once()
+
+		staleReleasedAt := releasedAt(env, staleAccountID)
+		releasedAt(env, destinationAccountID)
+		releasedAt(env, sourceAccountID)
+
+		var staleGrantedAt time.Time
+
+		env.RegisterDelayedCallback(func() {
+			staleGrantedAt = env.Now()
+
+			env.SignalWorkflow(AccountTurnSignal, AccountTurn{AccountID: staleAccountID})
+		}, time.Minute)
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(AccountTurnSignal, AccountTurn{AccountID: destinationAccountID})
+		}, 2*time.Minute)
+		env.RegisterDelayedCallback(func() {
+			env.SignalWorkflow(AccountTurnSignal, AccountTurn{AccountID: sourceAccountID})
+		}, 3*time.Minute)
+
+		env.ExecuteWorkflow(Transfer, &TransferParams{
+			Amount:               1000,
+			SourceAccountID:      sourceAccountID,
+			DestinationAccountID: destinationAccountID,
+		})
+
+		if !env.IsWorkflowCompleted() {
+			t.Fatal("transfer did not complete")
+		}
+
+		if err := env.GetWorkflowError(); err != nil {
+			t.Fatal(err)
+		}
+
+		if !staleReleasedAt.Equal(staleGrantedAt) {
+			t.Fatalf("stale turn released at %v, expected when it was granted at %v", *staleReleasedAt, staleGrantedAt)
+		}
+
+		env.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,89 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+)
+
+const (
+	// AccountEntityWorkflowType is the name the AccountEntity workflow is registered with.
+	AccountEntityWorkflowType = "AccountEntity"
+	// AccountTurnRequestSignal queues a workflow for the turn of an account, see AccountTurnRequest.
+	AccountTurnRequestSignal = "account-turn-request"
+)
+
+// AccountTurnRequest asks the AccountEntity workflow of the account for a turn, the turn is signalled to the
+// owner workflow once the requests queued before it are done.
+type AccountTurnRequest struct {
+	AccountID       uuid.UUID
+	OwnerWorkflowID string
+}
+
+// AccountEntityParams starts the AccountEntity workflow of an account. Holder and Queue carry the turns over
+// a continue-as-new.
+type AccountEntityParams struct {
+	AccountID uuid.UUID
+	Holder    *AccountTurnRequest
+	Queue     []AccountTurnRequest
+}
+
+// AccountEntityWorkflowID is the ID of the AccountEntity workflow of an account, it is prefixed so it never
+// collides with the ID of a Transfer workflow.
+func AccountEntityWorkflowID(accountID uuid.UUID) string {
+	return "account-entity-" + accountID.String()
+}
+
+type AccountEntityOperations struct {
+	temporalClient         client.Client
+	transfersTaskQueueName string
+}
+
+func NewAccountEntityOperations(temporalClient client.Client, transfersTaskQueueName string) *AccountEntityOperations {
+	return &AccountEntityOperations{
+		temporalClient:         temporalClient,
+		transfersTaskQueueName: transfersTaskQueueName,
+	}
+}
+
+// RequestAccountTurn queues the request at the AccountEntity workflow of the account, the workflow is started
+// when the account has none running.
+func (a *AccountEntityOperations) RequestAccountTurn(ctx context.Context, request AccountTurnRequest) error {
+	_, err := a.temporalClient.SignalWithStartWorkflow(
+		ctx,
+		AccountEntityWorkflowID(request.AccountID),
+		AccountTurnRequestSignal,
+		request,
+		client.StartWorkflowOptions{
+			ID:        AccountEntityWorkflowID(request.AccountID),
+			TaskQueue: a.transfersTaskQueueName,
+		},
+		AccountEntityWorkflowType,
+		AccountEntityParams{AccountID: request.AccountID},
+	)
+	if err != nil {
+		return fmt.Errorf("account turn request failed: %w", err)
+	}
+
+	return nil
+}
+
+// IsWorkflowRunning tells whether the workflow holding a turn can still use it.
+func (a *AccountEntityOperations) IsWorkflowRunning(ctx context.Context, workflowID string) (bool, error) {
+	description, err := a.temporalClient.DescribeWorkflowExecution(ctx, workflowID, "")
+	if err != nil {
+		var notFoundErr *serviceerror.NotFound
+		if errors.As(err, &notFoundErr) {
+			return false, nil
+		}
+
+		return false, err
+	}
+
+	return description.GetWorkflowExecutionInfo().GetStatus() == enums.WORKFLOW_EXECUTION_STATUS_RUNNING, nil
+}
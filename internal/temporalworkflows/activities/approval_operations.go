@@ -0,0 +1,29 @@
+package activities
+
+import (
+	"context"
+	"ulascansenturk/service/internal/approvals"
+)
+
+type ApprovalOperations struct {
+	approvalService approvals.Service
+}
+
+func NewApprovalOperations(approvalService approvals.Service) *ApprovalOperations {
+	return &ApprovalOperations{approvalService: approvalService}
+}
+
+type ApprovalCheck struct {
+	Currency string
+	Amount   int
+}
+
+// CheckApproval tells whether the transfer needs an approval before it is posted.
+func (a *ApprovalOperations) CheckApproval(ctx context.Context, check ApprovalCheck) (*approvals.Requirement, error) {
+	return a.approvalService.CheckApproval(ctx, check.Currency, check.Amount)
+}
+
+// RecordDecision stores the approval decision of the transfer, a retried recording keeps the first one.
+func (a *ApprovalOperations) RecordDecision(ctx context.Context, decision approvals.Decision) error {
+	return a.approvalService.RecordDecision(ctx, &decision)
+}
@@ -0,0 +1,32 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"ulascansenturk/service/internal/fees"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+type FeeOperations struct {
+	feesService fees.Service
+}
+
+func NewFeeOperations(feesService fees.Service) *FeeOperations {
+	return &FeeOperations{feesService: feesService}
+}
+
+// CalculateFee computes the transfer fee from the fee schedule. The workflow passes its own time in the request
+// so a retried or replayed calculation selects the same rule versions.
+func (f *FeeOperations) CalculateFee(ctx context.Context, request fees.QuoteRequest) (*fees.Quote, error) {
+	quote, err := f.feesService.CalculateFee(ctx, request)
+	if err != nil {
+		if errors.Is(err, fees.ErrInvalidRule) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "calculate-fee-err", err)
+		}
+
+		return nil, err
+	}
+
+	return quote, nil
+}
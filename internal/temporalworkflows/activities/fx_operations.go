@@ -0,0 +1,32 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"ulascansenturk/service/internal/fx"
+
+	"go.temporal.io/sdk/temporal"
+)
+
+type FXOperations struct {
+	fxService fx.Service
+}
+
+func NewFXOperations(fxService fx.Service) *FXOperations {
+	return &FXOperations{fxService: fxService}
+}
+
+// QuoteConversion quotes the currency conversion of a transfer, the quote is nil for a same currency transfer.
+// The workflow keeps the quote in its history, so the rate is locked for the rest of the transfer.
+func (f *FXOperations) QuoteConversion(ctx context.Context, request fx.QuoteRequest) (*fx.Quote, error) {
+	quote, err := f.fxService.QuoteTransfer(ctx, request)
+	if err != nil {
+		if errors.Is(err, fx.ErrRateNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), "fx-rate-err", err)
+		}
+
+		return nil, err
+	}
+
+	return quote, nil
+}
@@ -0,0 +1,208 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/datatypes"
+	"time"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/helpers"
+	"ulascansenturk/service/internal/holds"
+	"ulascansenturk/service/internal/transactions"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+)
+
+const holdErrType = "hold-err"
+
+type HoldOperations struct {
+	holdService            holds.Service
+	finderOrCreatorService transactions.FinderOrCreator
+	transactionService     transactions.Service
+	poster                 transactions.Poster
+	timeProvider           helpers.TimeProvider
+}
+
+func NewHoldOperations(
+	holdService holds.Service,
+	finderOrCreatorService transactions.FinderOrCreator,
+	transactionService transactions.Service,
+	poster transactions.Poster,
+	timeProvider helpers.TimeProvider,
+) *HoldOperations {
+	return &HoldOperations{
+		holdService:            holdService,
+		finderOrCreatorService: finderOrCreatorService,
+		transactionService:     transactionService,
+		poster:                 poster,
+		timeProvider:           timeProvider,
+	}
+}
+
+// CaptureParams captures Amount of the hold, the whole held amount when it is nil.
+type CaptureParams struct {
+	HoldID                         uuid.UUID
+	Amount                         *int
+	OutboundTransactionReferenceID uuid.UUID
+	InboundTransactionReferenceID  uuid.UUID
+}
+
+// PendingCapture holds the PENDING transactions of a capture, OutboundTrx debits the account of the hold and
+// InboundTrx credits its destination. FencingTokens are set once the capture holds the account lock.
+type PendingCapture struct {
+	HoldID        uuid.UUID
+	OutboundTrx   *transactions.Transaction
+	InboundTrx    *transactions.Transaction
+	FencingTokens map[uuid.UUID]int64
+}
+
+type HoldRelease struct {
+	HoldID uuid.UUID
+	Status constants.HoldStatus
+	Reason *string
+}
+
+// CreatePendingCapture checks the capture fits in the active hold and creates its PENDING transactions.
+func (h *HoldOperations) CreatePendingCapture(ctx context.Context, params CaptureParams) (*PendingCapture, error) {
+	hold, err := h.holdService.GetHold(ctx, params.HoldID)
+	if err != nil {
+		if errors.Is(err, holds.ErrHoldNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), holdErrType, err)
+		}
+
+		return nil, err
+	}
+
+	if !hold.IsActive() {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("hold %s can't be captured, status: %s", hold.ID, hold.Status),
+			holdErrType,
+			holds.ErrHoldNotActive,
+		)
+	}
+
+	amount := hold.Amount
+	if params.Amount != nil {
+		amount = *params.Amount
+	}
+
+	if amount <= 0 || amount > hold.Amount {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("capture amount %d is not within the held amount: %d", amount, hold.Amount),
+			holdErrType,
+			holds.ErrCaptureExceedsHold,
+		)
+	}
+
+	pending := &PendingCapture{HoldID: hold.ID}
+
+	pending.OutboundTrx, err = h.createCaptureTransaction(ctx, hold, hold.AccountID, hold.DestinationAccountID, amount,
+		constants.TransactionTypeOUTBOUND, params.OutboundTransactionReferenceID, params.InboundTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending.InboundTrx, err = h.createCaptureTransaction(ctx, hold, hold.DestinationAccountID, hold.AccountID, amount,
+		constants.TransactionTypeINBOUND, params.InboundTransactionReferenceID, params.OutboundTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// PostCapture moves the captured amount and releases the rest of the hold in a single database transaction.
+func (h *HoldOperations) PostCapture(ctx context.Context, pending PendingCapture) (*holds.Hold, error) {
+	_, postErr := h.poster.PostCapture(ctx, &transactions.CapturePosting{
+		HoldID:               pending.HoldID,
+		SourceAccountID:      pending.OutboundTrx.AccountID,
+		DestinationAccountID: pending.InboundTrx.AccountID,
+		Amount:               pending.OutboundTrx.Amount,
+		TransactionIDs:       []uuid.UUID{pending.OutboundTrx.ID, pending.InboundTrx.ID},
+		CapturedAt:           h.timeProvider.Now(),
+		FencingTokens:        pending.FencingTokens,
+	})
+	if postErr != nil {
+		if errors.Is(postErr, holds.ErrHoldNotActive) ||
+			errors.Is(postErr, holds.ErrCaptureExceedsHold) ||
+			errors.Is(postErr, transactions.ErrTransactionNotPostable) ||
+			errors.Is(postErr, transactions.ErrStaleFencingToken) {
+			return nil, temporal.NewNonRetryableApplicationError(postErr.Error(), holdErrType, postErr)
+		}
+
+		return nil, postErr
+	}
+
+	return h.holdService.GetHold(ctx, pending.HoldID)
+}
+
+// FailCapture compensates CreatePendingCapture by marking the pending capture transactions as FAILURE.
+func (h *HoldOperations) FailCapture(ctx context.Context, pending PendingCapture) error {
+	for _, trx := range []*transactions.Transaction{pending.OutboundTrx, pending.InboundTrx} {
+		if trx == nil {
+			continue
+		}
+
+		_, updateErr := h.transactionService.UpdateTransactionStatus(ctx, trx.ID, constants.TransactionStatusFAILURE)
+		if updateErr != nil {
+			return updateErr
+		}
+	}
+
+	return nil
+}
+
+// ReleaseHold voids or expires the hold, the held amount is available again.
+func (h *HoldOperations) ReleaseHold(ctx context.Context, release HoldRelease) (*holds.Hold, error) {
+	hold, err := h.holdService.ReleaseHold(ctx, release.HoldID, release.Status, release.Reason, h.timeProvider.Now())
+	if err != nil {
+		if errors.Is(err, holds.ErrHoldNotActive) || errors.Is(err, holds.ErrHoldNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), holdErrType, err)
+		}
+
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+func (h *HoldOperations) createCaptureTransaction(
+	ctx context.Context,
+	hold *holds.Hold,
+	accountID uuid.UUID,
+	linkedAccountID uuid.UUID,
+	amount int,
+	transactionType constants.TransactionType,
+	referenceID uuid.UUID,
+	linkedReferenceID uuid.UUID,
+) (*transactions.Transaction, error) {
+	metadata := datatypes.JSONMap(map[string]interface{}{
+		"OperationType":       "Hold Capture",
+		"HoldID":              hold.ID.String(),
+		"HoldReferenceID":     hold.ReferenceID.String(),
+		"LinkedTransactionID": linkedReferenceID.String(),
+		"LinkedAccountID":     linkedAccountID.String(),
+		"timestamp":           h.timeProvider.Now().Format(time.RFC3339),
+	})
+
+	if hold.Description != nil {
+		metadata["HoldDescription"] = *hold.Description
+	}
+
+	transaction, err := h.finderOrCreatorService.Call(ctx, &transactions.Transaction{
+		Amount:          amount,
+		AccountID:       accountID,
+		CurrencyCode:    constants.CurrencyCode(hold.Currency),
+		ReferenceID:     referenceID,
+		Metadata:        metadata,
+		Status:          constants.TransactionStatusPENDING,
+		TransactionType: transactionType,
+	})
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "error while creating pending capture trx", nil)
+	}
+
+	return transaction, nil
+}
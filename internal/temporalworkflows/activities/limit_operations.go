@@ -0,0 +1,44 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+	"time"
+	"ulascansenturk/service/internal/limits"
+)
+
+// TransferLimitExceededErrorType is the type of the error failing a transfer that exceeds a limit of its
+// source account, its details hold the limits.ExceededError.
+const TransferLimitExceededErrorType = "transfer-limit-exceeded"
+
+type LimitOperations struct {
+	limitService limits.Service
+}
+
+func NewLimitOperations(limitService limits.Service) *LimitOperations {
+	return &LimitOperations{limitService: limitService}
+}
+
+type LimitCheck struct {
+	AccountID uuid.UUID
+	Amount    int
+	At        time.Time
+}
+
+// CheckLimits fails for good when the transfer exceeds a limit of the account, retrying won't change the outcome
+// while the account is locked.
+func (l *LimitOperations) CheckLimits(ctx context.Context, check LimitCheck) error {
+	err := l.limitService.CheckTransfer(ctx, check.AccountID, check.Amount, check.At)
+	if err != nil {
+		var exceededErr *limits.ExceededError
+		if errors.As(err, &exceededErr) {
+			return temporal.NewNonRetryableApplicationError(exceededErr.Error(), TransferLimitExceededErrorType, exceededErr, *exceededErr)
+		}
+
+		return err
+	}
+
+	return nil
+}
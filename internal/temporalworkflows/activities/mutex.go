@@ -3,77 +3,74 @@ package activities
 import (
 	"context"
 	"errors"
-
-	"github.com/go-redsync/redsync/v4"
-	"github.com/gookit/goutil/errorx"
-	"github.com/rs/zerolog/log"
 	"time"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
 )
 
-type Mutex struct {
-	locker *redsync.Redsync
-}
+// MutexLockLostErrorType is the type of the error ExtendLock fails with once the lock expired or is held by
+// another owner, extending it again can't succeed.
+const MutexLockLostErrorType = "mutex-lock-lost"
+
+var (
+	ErrLockNotAcquired = errors.New("lock is held by another owner")
+	ErrLockLost        = errors.New("lock is no longer held by its owner")
+)
 
-func NewMutex(locker *redsync.Redsync) *Mutex {
-	return &Mutex{locker: locker}
+// Mutex is a lock shared by the workers, held by the owner of its ownership token until it is released or its
+// TTL passes.
+type Mutex interface {
+	// AcquireLock tries to acquire the lock once, it fails with ErrLockNotAcquired when the lock is held.
+	// Otherwise, it returns the fencing token of the lock: every acquisition of a key gets a higher token than
+	// the ones before it, so writes can tell a current holder from one whose lock expired.
+	AcquireLock(ctx context.Context, params MutexParams) (int64, error)
+	// ExtendLock resets the TTL of a lock still held by its owner, it fails with ErrLockLost when the lock
+	// expired or was taken by another owner.
+	ExtendLock(ctx context.Context, params MutexParams) error
+	// ReleaseLock unlocks the lock if it is still held by its owner. A lock that already expired, or was taken
+	// by another owner since, has nothing left to release.
+	ReleaseLock(ctx context.Context, params MutexParams) error
 }
 
 type MutexParams struct {
-	// Key — Redis key used for the lock
+	// Key — key used for the lock
 	Key string
-	// OwnershipToken — Redis value used for transferring lock ownership.
+	// OwnershipToken — value identifying the owner of the lock, used for transferring lock ownership.
 	// This is needed to release the lock from a difference context or process.
 	OwnershipToken string
 	// TTL — Definitive expiration period for the lock after which the lock is release automatically
 	TTL time.Duration
+	// AccountID — account the lock guards, its stored fencing token is the floor of the tokens of the lock
+	AccountID uuid.UUID
 }
 
-// AcquireLock tries to acquire the lock with provided parameters once.
-// Returns an error if the lock is not available.
-func (m *Mutex) AcquireLock(ctx context.Context, params MutexParams) error {
-	mutex := m.locker.NewMutex(
-		params.Key,
-		redsync.WithExpiry(params.TTL),
-		redsync.WithGenValueFunc(func() (string, error) {
-			return params.OwnershipToken, nil
-		}),
-	)
-
-	lockErr := mutex.TryLockContext(ctx)
-	if lockErr != nil {
-		log.Ctx(ctx).Err(lockErr).Msg("Mutex#AcquireLock: TryLock error")
-
-		return lockErr
-	}
-
-	return nil
+// MutexOperations are the activities of the Mutex the workers are configured with.
+type MutexOperations struct {
+	mutex Mutex
 }
 
-// ReleaseLock acquires the lock first and then unlocks it immediately.
-func (m *Mutex) ReleaseLock(ctx context.Context, params MutexParams) error {
-	mutex := m.locker.NewMutex(
-		params.Key,
-		redsync.WithGenValueFunc(func() (string, error) {
-			return params.OwnershipToken, nil
-		}),
-	)
-
-	// Need to acquire lock first otherwise unlocking won't work
-	lockErr := mutex.LockContext(ctx)
-	if lockErr != nil {
-		log.Ctx(ctx).Err(lockErr).Msg("Mutex#ReleaseLock: Lock error")
+func NewMutexOperations(mutex Mutex) *MutexOperations {
+	return &MutexOperations{mutex: mutex}
+}
 
-		return errorx.Wrap(lockErr, "TryLockContext")
-	}
+// AcquireLock tries to acquire the lock with provided parameters once.
+// Returns an error if the lock is not available, otherwise the fencing token of the lock.
+func (m *MutexOperations) AcquireLock(ctx context.Context, params MutexParams) (int64, error) {
+	return m.mutex.AcquireLock(ctx, params)
+}
 
-	ok, unlockErr := mutex.UnlockContext(ctx)
-	if unlockErr != nil {
-		return unlockErr
+// ExtendLock resets the TTL of the lock. It fails with a non-retryable MutexLockLostErrorType error when the
+// lock expired or was taken by another owner.
+func (m *MutexOperations) ExtendLock(ctx context.Context, params MutexParams) error {
+	err := m.mutex.ExtendLock(ctx, params)
+	if errors.Is(err, ErrLockLost) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), MutexLockLostErrorType, err)
 	}
 
-	if !ok {
-		return errors.New("release lock failed")
-	}
+	return err
+}
 
-	return nil
+func (m *MutexOperations) ReleaseLock(ctx context.Context, params MutexParams) error {
+	return m.mutex.ReleaseLock(ctx, params)
 }
@@ -0,0 +1,38 @@
+package activities
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+// nextFencingTokenSQL counts the acquisitions of the key. The token is never below the one last stored on the
+// account the lock guards, so a counter that was lost or never existed continues above the tokens already used.
+const nextFencingTokenSQL = `
+INSERT INTO fencing_tokens (key, token)
+SELECT @key, COALESCE(MAX(fencing_token), 0) + 1 FROM accounts WHERE id = @account_id
+ON CONFLICT (key) DO UPDATE
+SET token = GREATEST(fencing_tokens.token + 1, EXCLUDED.token)
+RETURNING token`
+
+// FencingTokens hands out the fencing tokens of the account locks from the fencing_tokens table, so they are as
+// durable as the balances they protect. Every Mutex backend shares them, a switch of backend keeps the counters.
+type FencingTokens struct {
+	db *gorm.DB
+}
+
+func NewFencingTokens(db *gorm.DB) *FencingTokens {
+	return &FencingTokens{db: db}
+}
+
+// Next returns the fencing token of a new acquisition of the lock.
+func (f *FencingTokens) Next(ctx context.Context, params MutexParams) (int64, error) {
+	var token int64
+
+	err := f.db.WithContext(ctx).Raw(nextFencingTokenSQL, map[string]interface{}{
+		"key":        params.Key,
+		"account_id": params.AccountID,
+	}).Row().Scan(&token)
+
+	return token, err
+}
@@ -0,0 +1,107 @@
+package activities
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// acquireLeaseSQL takes the lease of the key unless it is held by another owner, the database clock decides
+// when a lease has expired so the workers' clocks don't need to agree.
+const acquireLeaseSQL = `
+INSERT INTO mutex_leases (key, owner, expires_at)
+VALUES (@key, @owner, now() + make_interval(secs => @ttl))
+ON CONFLICT (key) DO UPDATE
+SET owner = EXCLUDED.owner, expires_at = EXCLUDED.expires_at
+WHERE mutex_leases.expires_at <= now()
+RETURNING key`
+
+const extendLeaseSQL = `
+UPDATE mutex_leases SET expires_at = now() + make_interval(secs => @ttl)
+WHERE key = @key AND owner = @owner AND expires_at > now()`
+
+// releaseLeaseSQL expires the lease rather than deleting it.
+const releaseLeaseSQL = `
+UPDATE mutex_leases SET expires_at = now()
+WHERE key = @key AND owner = @owner AND expires_at > now()`
+
+// PostgresMutex is the Mutex backed by the mutex_leases table, for deployments locking without Redis. The fencing
+// tokens are counted in fencing_tokens like for the other backends.
+type PostgresMutex struct {
+	db *gorm.DB
+}
+
+func NewPostgresMutex(db *gorm.DB) *PostgresMutex {
+	return &PostgresMutex{db: db}
+}
+
+func (m *PostgresMutex) AcquireLock(ctx context.Context, params MutexParams) (int64, error) {
+	var fencingToken int64
+
+	// The lease and its token are taken together, a lease without a token is never left behind.
+	err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var keys []string
+
+		leaseErr := tx.Raw(acquireLeaseSQL, leaseArgs(params)).Scan(&keys).Error
+		if leaseErr != nil {
+			return leaseErr
+		}
+
+		if len(keys) == 0 {
+			return ErrLockNotAcquired
+		}
+
+		var tokenErr error
+
+		fencingToken, tokenErr = NewFencingTokens(tx).Next(ctx, params)
+
+		return tokenErr
+	})
+	if err != nil {
+		if !errors.Is(err, ErrLockNotAcquired) {
+			log.Ctx(ctx).Err(err).Msg("PostgresMutex#AcquireLock: lease error")
+		}
+
+		return 0, err
+	}
+
+	return fencingToken, nil
+}
+
+func (m *PostgresMutex) ExtendLock(ctx context.Context, params MutexParams) error {
+	result := m.db.WithContext(ctx).Exec(extendLeaseSQL, leaseArgs(params))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		log.Ctx(ctx).Warn().Str("key", params.Key).Msg("PostgresMutex#ExtendLock: lock lost")
+
+		return ErrLockLost
+	}
+
+	return nil
+}
+
+func (m *PostgresMutex) ReleaseLock(ctx context.Context, params MutexParams) error {
+	result := m.db.WithContext(ctx).Exec(releaseLeaseSQL, leaseArgs(params))
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		log.Ctx(ctx).Warn().Str("key", params.Key).Msg("PostgresMutex#ReleaseLock: lock no longer held")
+	}
+
+	return nil
+}
+
+func leaseArgs(params MutexParams) map[string]interface{} {
+	return map[string]interface{}{
+		"key":   params.Key,
+		"owner": params.OwnershipToken,
+		"ttl":   params.TTL.Seconds(),
+	}
+}
@@ -0,0 +1,99 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-redsync/redsync/v4"
+	"github.com/rs/zerolog/log"
+)
+
+// RedisMutex is the Mutex backed by redsync, the fencing tokens are counted in the database so a Redis flush doesn't
+// restart them.
+type RedisMutex struct {
+	locker        *redsync.Redsync
+	fencingTokens *FencingTokens
+}
+
+func NewRedisMutex(locker *redsync.Redsync, fencingTokens *FencingTokens) *RedisMutex {
+	return &RedisMutex{locker: locker, fencingTokens: fencingTokens}
+}
+
+func (m *RedisMutex) AcquireLock(ctx context.Context, params MutexParams) (int64, error) {
+	mutex := m.locker.NewMutex(
+		params.Key,
+		redsync.WithExpiry(params.TTL),
+		redsync.WithValue(params.OwnershipToken),
+	)
+
+	lockErr := mutex.TryLockContext(ctx)
+	if lockErr != nil {
+		log.Ctx(ctx).Err(lockErr).Msg("RedisMutex#AcquireLock: TryLock error")
+
+		var redisErr *redsync.RedisError
+		if errors.As(lockErr, &redisErr) {
+			return 0, lockErr
+		}
+
+		return 0, fmt.Errorf("%w: %w", ErrLockNotAcquired, lockErr)
+	}
+
+	token, tokenErr := m.fencingTokens.Next(ctx, params)
+	if tokenErr != nil {
+		log.Ctx(ctx).Err(tokenErr).Msg("RedisMutex#AcquireLock: fencing token error")
+
+		// A lock without a token can't be used, it is released so the retry can take it again.
+		_, unlockErr := mutex.UnlockContext(ctx)
+		if unlockErr != nil {
+			log.Ctx(ctx).Err(unlockErr).Msg("RedisMutex#AcquireLock: Unlock error")
+		}
+
+		return 0, tokenErr
+	}
+
+	return token, nil
+}
+
+func (m *RedisMutex) ExtendLock(ctx context.Context, params MutexParams) error {
+	mutex := m.locker.NewMutex(
+		params.Key,
+		redsync.WithExpiry(params.TTL),
+		redsync.WithValue(params.OwnershipToken),
+	)
+
+	ok, extendErr := mutex.ExtendContext(ctx)
+	if ok {
+		return nil
+	}
+
+	var redisErr *redsync.RedisError
+	if errors.As(extendErr, &redisErr) {
+		return extendErr
+	}
+
+	log.Ctx(ctx).Warn().Err(extendErr).Str("key", params.Key).Msg("RedisMutex#ExtendLock: lock lost")
+
+	return ErrLockLost
+}
+
+func (m *RedisMutex) ReleaseLock(ctx context.Context, params MutexParams) error {
+	mutex := m.locker.NewMutex(
+		params.Key,
+		redsync.WithValue(params.OwnershipToken),
+	)
+
+	ok, unlockErr := mutex.UnlockContext(ctx)
+	if ok {
+		return nil
+	}
+
+	var redisErr *redsync.RedisError
+	if errors.As(unlockErr, &redisErr) {
+		return unlockErr
+	}
+
+	log.Ctx(ctx).Warn().Err(unlockErr).Str("key", params.Key).Msg("RedisMutex#ReleaseLock: lock no longer held")
+
+	return nil
+}
@@ -4,6 +4,7 @@ package activities
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 	"ulascansenturk/service/internal/api/testutils/support"
@@ -13,53 +14,180 @@ import (
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
-	redisContainer "github.com/testcontainers/testcontainers-go/modules/redis"
-	"github.com/ulascansenturk/service/internal/api/testutils/support"
+	"go.temporal.io/sdk/temporal"
+	"gorm.io/gorm"
 )
 
-const maxContainerCreationAttempts = 10
+// mutexLeasesMigrations create the mutex_leases table, its fencing tokens moved to fencing_tokens.
+var mutexLeasesMigrations = []string{
+	"../../../db/migrations/20240930090000_create_mutex_leases.up.sql",
+	"../../../db/migrations/20241008090100_move_mutex_leases_fencing_tokens.up.sql",
+}
+
+// fencingTokensMigrations create the fencing_tokens table and the accounts whose stored token it continues from.
+var fencingTokensMigrations = []string{
+	"../../../db/migrations/20240814195957_enable_uuid.up.sql",
+	"../../../db/migrations/20240814200035_create_accounts.up.sql",
+	"../../../db/migrations/20240929090000_add_accounts_fencing_token.up.sql",
+	"../../../db/migrations/20241008090000_create_fencing_tokens.up.sql",
+}
 
+// testSuiteMutex runs the same scenarios against every Mutex backend.
 type testSuiteMutex struct {
 	suite.Suite
 
-	mutex          *Mutex
-	redisContainer *redisContainer.RedisContainer
-	redisClient    *redis.Client
-	rd             *support.Redis
+	backend string
+	mutex   Mutex
+	// db holds the fencing tokens and the accounts
+	db *gorm.DB
+	// holder returns the ownership token of the lock held on the key, empty when it isn't held
+	holder func(ctx context.Context, key string) (string, error)
+	// ttl returns the time left before the lock held on the key expires
+	ttl      func(ctx context.Context, key string) (time.Duration, error)
+	tearDown func()
 }
 
 func (s *testSuiteMutex) SetupTest() {
-	s.createRedisContainer()
+	switch s.backend {
+	case "redis":
+		s.setUpRedisMutex()
+	case "postgres":
+		s.setUpPostgresMutex()
+	default:
+		s.FailNow("unknown mutex backend", s.backend)
+	}
+}
+
+func (s *testSuiteMutex) TearDownTest() {
+	s.tearDown()
+}
+
+func (s *testSuiteMutex) setUpRedisMutex() {
+	rd := support.NewRedis()
+	rd.SetUp()
 
-	endpoint := lo.Must(s.redisContainer.Endpoint(context.Background(), ""))
+	pg := support.NewPostgres()
+	pg.SetUp(fencingTokensMigrations...)
 
-	s.redisClient = redis.NewClient(&redis.Options{
+	endpoint := lo.Must(rd.Container.Endpoint(context.Background(), ""))
+
+	redisClient := redis.NewClient(&redis.Options{
 		Network: "tcp",
 		Addr:    endpoint,
 	})
 
-	pool := goredis.NewPool(s.redisClient)
+	pool := goredis.NewPool(redisClient)
 	locker := redsync.New(pool)
 
-	s.mutex = NewMutex(locker)
+	s.db = pg.DB
+	s.mutex = NewRedisMutex(locker, NewFencingTokens(pg.DB))
+	s.holder = func(ctx context.Context, key string) (string, error) {
+		val, err := redisClient.Get(ctx, key).Result()
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+
+		return val, err
+	}
+	s.ttl = func(ctx context.Context, key string) (time.Duration, error) {
+		return redisClient.PTTL(ctx, key).Result()
+	}
+	s.tearDown = func() {
+		rd.TearDown()
+		pg.TearDown()
+	}
 }
 
-func (s *testSuiteMutex) TearDownTest() {
-	s.rd.TearDown()
+func (s *testSuiteMutex) setUpPostgresMutex() {
+	pg := support.NewPostgres()
+	pg.SetUp(append(fencingTokensMigrations, mutexLeasesMigrations...)...)
+
+	s.db = pg.DB
+	s.mutex = NewPostgresMutex(pg.DB)
+	s.holder = func(ctx context.Context, key string) (string, error) {
+		var owners []string
+
+		err := pg.DB.WithContext(ctx).
+			Raw("SELECT owner FROM mutex_leases WHERE key = ? AND expires_at > now()", key).
+			Scan(&owners).Error
+		if err != nil || len(owners) == 0 {
+			return "", err
+		}
+
+		return owners[0], nil
+	}
+	s.ttl = func(ctx context.Context, key string) (time.Duration, error) {
+		var seconds float64
+
+		err := pg.DB.WithContext(ctx).
+			Raw("SELECT EXTRACT(EPOCH FROM expires_at - now()) FROM mutex_leases WHERE key = ?", key).
+			Row().Scan(&seconds)
+
+		return time.Duration(seconds * float64(time.Second)), err
+	}
+	s.tearDown = pg.TearDown
 }
 
-func (s *testSuiteMutex) createRedisContainer() {
-	s.rd = support.NewRedis()
-	s.rd.SetUp()
+func TestRedisMutex(t *testing.T) {
+	t.Parallel()
 
-	s.redisContainer = s.rd.Container
+	suite.Run(t, &testSuiteMutex{backend: "redis"})
 }
 
-func TestMutex(t *testing.T) {
+func TestPostgresMutex(t *testing.T) {
 	t.Parallel()
 
-	suite.Run(t, new(testSuiteMutex))
+	suite.Run(t, &testSuiteMutex{backend: "postgres"})
+}
+
+func TestMutexBackendSwitch(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	rd := support.NewRedis()
+	rd.SetUp()
+	defer rd.TearDown()
+
+	pg := support.NewPostgres()
+	pg.SetUp(append(fencingTokensMigrations, mutexLeasesMigrations...)...)
+	defer pg.TearDown()
+
+	redisClient := redis.NewClient(&redis.Options{
+		Network: "tcp",
+		Addr:    lo.Must(rd.Container.Endpoint(ctx, "")),
+	})
+
+	redisMutex := NewRedisMutex(redsync.New(goredis.NewPool(redisClient)), NewFencingTokens(pg.DB))
+	postgresMutex := NewPostgresMutex(pg.DB)
+
+	params := MutexParams{
+		Key:            uuid.New().String(),
+		OwnershipToken: "owner-1",
+		TTL:            1 * time.Minute,
+	}
+
+	redisToken, err := redisMutex.AcquireLock(ctx, params)
+	require.NoError(t, err)
+	require.NoError(t, redisMutex.ReleaseLock(ctx, params))
+
+	params.OwnershipToken = "owner-2"
+
+	postgresToken, err := postgresMutex.AcquireLock(ctx, params)
+	require.NoError(t, err)
+	require.NoError(t, postgresMutex.ReleaseLock(ctx, params))
+
+	assert.Greater(t, postgresToken, redisToken)
+
+	params.OwnershipToken = "owner-3"
+
+	switchedBackToken, err := redisMutex.AcquireLock(ctx, params)
+	require.NoError(t, err)
+
+	assert.Greater(t, switchedBackToken, postgresToken)
 }
 
 func (s *testSuiteMutex) TestMutex_AcquireLock() {
@@ -72,11 +200,12 @@ func (s *testSuiteMutex) TestMutex_AcquireLock() {
 			TTL:            1 * time.Minute,
 		}
 
-		err := s.mutex.AcquireLock(context.Background(), params)
+		fencingToken, err := s.mutex.AcquireLock(context.Background(), params)
 
 		s.NoError(err)
+		s.Equal(int64(1), fencingToken)
 
-		val, getKeyErr := s.redisClient.Get(ctx, params.Key).Result()
+		val, getKeyErr := s.holder(ctx, params.Key)
 
 		s.Require().NoError(getKeyErr)
 
@@ -84,6 +213,25 @@ func (s *testSuiteMutex) TestMutex_AcquireLock() {
 
 	})
 
+	s.Run("when the lock is acquired again after its release", func() {
+		params := MutexParams{
+			Key:            uuid.New().String(),
+			OwnershipToken: "owner-1",
+			TTL:            1 * time.Minute,
+		}
+
+		firstToken, err := s.mutex.AcquireLock(ctx, params)
+		s.Require().NoError(err)
+		s.Require().NoError(s.mutex.ReleaseLock(ctx, params))
+
+		params.OwnershipToken = "owner-2"
+
+		secondToken, err := s.mutex.AcquireLock(ctx, params)
+
+		s.NoError(err)
+		s.Greater(secondToken, firstToken)
+	})
+
 	s.Run("when the lock cannot be acquired", func() {
 		params := MutexParams{
 			Key:            uuid.New().String(),
@@ -91,19 +239,55 @@ func (s *testSuiteMutex) TestMutex_AcquireLock() {
 			TTL:            1 * time.Minute,
 		}
 
-		err := s.mutex.AcquireLock(context.Background(), params)
+		_, err := s.mutex.AcquireLock(context.Background(), params)
 
 		s.NoError(err)
 
-		val, getKeyErr := s.redisClient.Get(ctx, params.Key).Result()
+		val, getKeyErr := s.holder(ctx, params.Key)
 
 		s.Require().NoError(getKeyErr)
 
 		s.Equal(params.OwnershipToken, val)
 
-		secondErr := s.mutex.AcquireLock(context.Background(), params)
+		_, secondErr := s.mutex.AcquireLock(context.Background(), params)
+
+		s.ErrorIs(secondErr, ErrLockNotAcquired)
+	})
+}
+
+func (s *testSuiteMutex) TestMutex_FencingTokens() {
+	ctx := context.Background()
+
+	s.Run("when the fencing token counter of the key was lost", func() {
+		var accountID uuid.UUID
+
+		err := s.db.WithContext(ctx).
+			Raw("INSERT INTO accounts (user_id, balance, currency, fencing_token) VALUES (?, 0, 'USD', 7) RETURNING id", uuid.New()).
+			Row().Scan(&accountID)
+		s.Require().NoError(err)
 
-		s.EqualError(secondErr, "redsync: failed to acquire lock")
+		params := MutexParams{
+			Key:            uuid.New().String(),
+			OwnershipToken: "owner-1",
+			TTL:            1 * time.Minute,
+			AccountID:      accountID,
+		}
+
+		firstToken, err := s.mutex.AcquireLock(ctx, params)
+		s.Require().NoError(err)
+		s.Greater(firstToken, int64(7))
+		s.Require().NoError(s.mutex.ReleaseLock(ctx, params))
+
+		// the posting stored the token, then the counter was lost
+		s.Require().NoError(s.db.Exec("UPDATE accounts SET fencing_token = ? WHERE id = ?", firstToken, accountID).Error)
+		s.Require().NoError(s.db.Exec("DELETE FROM fencing_tokens WHERE key = ?", params.Key).Error)
+
+		params.OwnershipToken = "owner-2"
+
+		secondToken, err := s.mutex.AcquireLock(ctx, params)
+
+		s.NoError(err)
+		s.Greater(secondToken, firstToken)
 	})
 }
 
@@ -117,17 +301,17 @@ func (s *testSuiteMutex) TestMutex_ReleaseLock() {
 			TTL:            1 * time.Minute,
 		}
 
-		acquireLockErr := s.mutex.AcquireLock(ctx, params)
+		_, acquireLockErr := s.mutex.AcquireLock(ctx, params)
 		s.Require().NoError(acquireLockErr)
 
 		err := s.mutex.ReleaseLock(ctx, params)
 
 		s.NoError(err)
 
-		val, getKeyErr := s.redisClient.Get(ctx, params.Key).Result()
+		val, getKeyErr := s.holder(ctx, params.Key)
 
 		s.Equal("", val)
-		s.EqualError(getKeyErr, redis.Nil.Error())
+		s.NoError(getKeyErr)
 	})
 
 	s.Run("when releasing returns an error during the initial lock", func() {
@@ -138,18 +322,84 @@ func (s *testSuiteMutex) TestMutex_ReleaseLock() {
 			TTL:            1 * time.Minute,
 		}
 
-		acquireLockErr := s.mutex.AcquireLock(ctx, params)
+		_, acquireLockErr := s.mutex.AcquireLock(ctx, params)
 		s.Require().NoError(acquireLockErr)
 
 		cancelShortCtx()
 
 		err := s.mutex.ReleaseLock(shortCtx, params)
 
-		s.ErrorIs(err, redsync.ErrFailed)
+		s.ErrorIs(err, context.Canceled)
 
-		val, getKeyErr := s.redisClient.Get(ctx, params.Key).Result()
+		val, getKeyErr := s.holder(ctx, params.Key)
 
 		s.Equal("owner-1", val)
 		s.NoError(getKeyErr)
 	})
+
+	s.Run("when the lock was taken by another owner", func() {
+		params := MutexParams{
+			Key:            uuid.New().String(),
+			OwnershipToken: "owner-1",
+			TTL:            1 * time.Minute,
+		}
+
+		_, acquireLockErr := s.mutex.AcquireLock(ctx, params)
+		s.Require().NoError(acquireLockErr)
+
+		err := s.mutex.ReleaseLock(ctx, MutexParams{Key: params.Key, OwnershipToken: "owner-2"})
+
+		s.NoError(err)
+
+		val, getKeyErr := s.holder(ctx, params.Key)
+
+		s.Equal("owner-1", val)
+		s.NoError(getKeyErr)
+	})
+}
+
+func (s *testSuiteMutex) TestMutex_ExtendLock() {
+	ctx := context.Background()
+
+	s.Run("when the lock is still held by its owner", func() {
+		params := MutexParams{
+			Key:            uuid.New().String(),
+			OwnershipToken: "owner-1",
+			TTL:            1 * time.Minute,
+		}
+
+		_, acquireLockErr := s.mutex.AcquireLock(ctx, params)
+		s.Require().NoError(acquireLockErr)
+
+		params.TTL = 10 * time.Minute
+
+		err := s.mutex.ExtendLock(ctx, params)
+
+		s.NoError(err)
+
+		ttl, ttlErr := s.ttl(ctx, params.Key)
+
+		s.Require().NoError(ttlErr)
+		s.Greater(ttl, time.Minute)
+	})
+
+	s.Run("when the lock expired", func() {
+		params := MutexParams{
+			Key:            uuid.New().String(),
+			OwnershipToken: "owner-1",
+			TTL:            1 * time.Minute,
+		}
+
+		err := s.mutex.ExtendLock(ctx, params)
+
+		s.ErrorIs(err, ErrLockLost)
+
+		// the activity doesn't retry an extension that can't succeed
+		err = NewMutexOperations(s.mutex).ExtendLock(ctx, params)
+
+		var applicationErr *temporal.ApplicationError
+		s.Require().ErrorAs(err, &applicationErr)
+		s.Equal(MutexLockLostErrorType, applicationErr.Type())
+		s.True(applicationErr.NonRetryable())
+	})
 }
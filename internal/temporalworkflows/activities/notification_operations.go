@@ -0,0 +1,47 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/notifications"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+)
+
+const notificationErrType = "notification-err"
+
+type NotificationOperations struct {
+	notificationService notifications.Service
+}
+
+func NewNotificationOperations(notificationService notifications.Service) *NotificationOperations {
+	return &NotificationOperations{notificationService: notificationService}
+}
+
+func (n *NotificationOperations) NotifyTransferSent(ctx context.Context, notice notifications.TransferNotice) error {
+	return notificationError(n.notificationService.NotifyTransferSent(ctx, notice))
+}
+
+func (n *NotificationOperations) NotifyTransferReceived(ctx context.Context, notice notifications.TransferNotice) error {
+	return notificationError(n.notificationService.NotifyTransferReceived(ctx, notice))
+}
+
+func (n *NotificationOperations) NotifyTransferFailed(ctx context.Context, notice notifications.TransferNotice) error {
+	return notificationError(n.notificationService.NotifyTransferFailed(ctx, notice))
+}
+
+func (n *NotificationOperations) NotifyLowBalance(ctx context.Context, accountID uuid.UUID, at time.Time) error {
+	return notificationError(n.notificationService.NotifyLowBalance(ctx, accountID, at))
+}
+
+// notificationError doesn't retry a notification whose account or user is gone, every other error is retried.
+func notificationError(err error) error {
+	if errors.Is(err, accounts.ErrAccountNotFound) || errors.Is(err, notifications.ErrUserNotFound) {
+		return temporal.NewNonRetryableApplicationError(err.Error(), notificationErrType, err)
+	}
+
+	return err
+}
@@ -0,0 +1,319 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"gorm.io/datatypes"
+	"math/big"
+	"time"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/helpers"
+	"ulascansenturk/service/internal/transactions"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+)
+
+const reversalErrType = "reversal-err"
+
+type ReversalOperations struct {
+	finderOrCreatorService transactions.FinderOrCreator
+	transactionService     transactions.Service
+	poster                 transactions.Poster
+	timeProvider           helpers.TimeProvider
+}
+
+func NewReversalOperations(finderOrCreatorService transactions.FinderOrCreator, transactionService transactions.Service, poster transactions.Poster, timeProvider helpers.TimeProvider) *ReversalOperations {
+	return &ReversalOperations{
+		finderOrCreatorService: finderOrCreatorService,
+		transactionService:     transactionService,
+		poster:                 poster,
+		timeProvider:           timeProvider,
+	}
+}
+
+// ReversalParams identifies the transactions of the reversed transfer and of the reversal itself.
+// Amount is in the currency of the source account, the remaining amount of the transfer is reversed when it is nil.
+type ReversalParams struct {
+	ReferenceID                               uuid.UUID
+	TransferReferenceID                       uuid.UUID
+	Amount                                    *int
+	IncludeFee                                bool
+	Reason                                    string
+	OriginalSourceTransactionReferenceID      uuid.UUID
+	OriginalDestinationTransactionReferenceID uuid.UUID
+	OriginalFeeTransactionReferenceID         uuid.UUID
+	OriginalIncomingFeeTransactionReferenceID uuid.UUID
+	OutboundTransactionReferenceID            uuid.UUID
+	InboundTransactionReferenceID             uuid.UUID
+	FeeOutboundTransactionReferenceID         uuid.UUID
+	FeeInboundTransactionReferenceID          uuid.UUID
+	// FencingTokens are the fencing tokens of the account locks the reversal is posted under.
+	FencingTokens map[uuid.UUID]int64
+}
+
+// PendingReversal holds the PENDING transactions of a reversal. OutboundTrx debits the destination of the
+// reversed transfer and InboundTrx credits its source, the fee transactions are only set when the fee is refunded.
+type PendingReversal struct {
+	OutboundTrx    *transactions.Transaction
+	InboundTrx     *transactions.Transaction
+	FeeOutboundTrx *transactions.Transaction
+	FeeInboundTrx  *transactions.Transaction
+}
+
+type ReversalResult struct {
+	ReferenceID         uuid.UUID
+	TransferReferenceID uuid.UUID
+	OutboundTransaction *transactions.Transaction
+	InboundTransaction  *transactions.Transaction
+	FeeOutbound         *transactions.Transaction
+	FeeInbound          *transactions.Transaction
+}
+
+// CreatePendingReversal checks what is left to reverse of the transfer and creates the PENDING reversal
+// transactions, linked to the transactions they reverse. A partial reversal of a cross-currency transfer debits
+// the destination in proportion to the converted amount, the last reversal debits whatever is left of it.
+func (r *ReversalOperations) CreatePendingReversal(ctx context.Context, params ReversalParams) (*PendingReversal, error) {
+	originalOutgoing, err := r.findOriginal(ctx, params.OriginalSourceTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	originalIncoming, err := r.findOriginal(ctx, params.OriginalDestinationTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	reversedAmount, err := r.transactionService.GetReversedAmount(ctx, originalOutgoing.ID, constants.TransactionTypeREVERSALINBOUND)
+	if err != nil {
+		return nil, err
+	}
+
+	remainingAmount := originalOutgoing.Amount - reversedAmount
+
+	amount := remainingAmount
+	if params.Amount != nil {
+		amount = *params.Amount
+	}
+
+	if amount <= 0 || amount > remainingAmount {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("reversal amount %d is not within the remaining amount: %d", amount, remainingAmount),
+			reversalErrType,
+			transactions.ErrReversalExceedsAmount,
+		)
+	}
+
+	debitAmount := originalIncoming.Amount
+	if amount == remainingAmount {
+		reversedDebit, reversedDebitErr := r.transactionService.GetReversedAmount(ctx, originalIncoming.ID, constants.TransactionTypeREVERSALOUTBOUND)
+		if reversedDebitErr != nil {
+			return nil, reversedDebitErr
+		}
+
+		debitAmount -= reversedDebit
+	} else if originalIncoming.Amount != originalOutgoing.Amount {
+		debitAmount = proportionalAmount(originalIncoming.Amount, amount, originalOutgoing.Amount)
+	} else {
+		debitAmount = amount
+	}
+
+	pending := &PendingReversal{}
+
+	pending.OutboundTrx, err = r.createReversalTransaction(ctx, params, originalIncoming, debitAmount, constants.TransactionTypeREVERSALOUTBOUND, params.OutboundTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending.InboundTrx, err = r.createReversalTransaction(ctx, params, originalOutgoing, amount, constants.TransactionTypeREVERSALINBOUND, params.InboundTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !params.IncludeFee {
+		return pending, nil
+	}
+
+	originalFee, err := r.transactionService.GetTransactionByReferenceID(ctx, params.OriginalFeeTransactionReferenceID)
+	if err != nil {
+		if errors.Is(err, transactions.ErrTransactionNotFound) {
+			// The transfer was free, there is no fee to refund.
+			return pending, nil
+		}
+
+		return nil, err
+	}
+
+	originalIncomingFee, err := r.findOriginal(ctx, params.OriginalIncomingFeeTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	refundedFee, err := r.transactionService.GetReversedAmount(ctx, originalFee.ID, constants.TransactionTypeFEEREVERSALINBOUND)
+	if err != nil {
+		return nil, err
+	}
+
+	feeAmount := originalFee.Amount - refundedFee
+	if feeAmount <= 0 {
+		return nil, temporal.NewNonRetryableApplicationError("fee is already refunded", reversalErrType, transactions.ErrReversalExceedsAmount)
+	}
+
+	pending.FeeOutboundTrx, err = r.createReversalTransaction(ctx, params, originalIncomingFee, feeAmount, constants.TransactionTypeFEEREVERSALOUTBOUND, params.FeeOutboundTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending.FeeInboundTrx, err = r.createReversalTransaction(ctx, params, originalFee, feeAmount, constants.TransactionTypeFEEREVERSALINBOUND, params.FeeInboundTransactionReferenceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return pending, nil
+}
+
+// PostReversal moves the balances of the reversal back and flips its pending transactions to SUCCESS
+// in a single database transaction.
+func (r *ReversalOperations) PostReversal(ctx context.Context, params ReversalParams, pending PendingReversal) (*ReversalResult, error) {
+	posting := &transactions.ReversalPosting{
+		OriginalTransactionID: *pending.InboundTrx.OriginalTransactionID,
+		DebitAccountID:        pending.OutboundTrx.AccountID,
+		CreditAccountID:       pending.InboundTrx.AccountID,
+		DebitAmount:           pending.OutboundTrx.Amount,
+		CreditAmount:          pending.InboundTrx.Amount,
+		TransactionIDs:        []uuid.UUID{pending.OutboundTrx.ID, pending.InboundTrx.ID},
+		FencingTokens:         params.FencingTokens,
+	}
+
+	if pending.FeeInboundTrx != nil {
+		posting.OriginalFeeTransactionID = *pending.FeeInboundTrx.OriginalTransactionID
+		posting.FeeAccountID = pending.FeeOutboundTrx.AccountID
+		posting.FeeAmount = pending.FeeInboundTrx.Amount
+		posting.TransactionIDs = append(posting.TransactionIDs, pending.FeeOutboundTrx.ID, pending.FeeInboundTrx.ID)
+	}
+
+	postedTransactions, postErr := r.poster.PostReversal(ctx, posting)
+	if postErr != nil {
+		if errors.Is(postErr, transactions.ErrInsufficientFunds) ||
+			errors.Is(postErr, transactions.ErrTransactionNotPostable) ||
+			errors.Is(postErr, transactions.ErrReversalExceedsAmount) ||
+			errors.Is(postErr, transactions.ErrStaleFencingToken) {
+			return nil, temporal.NewNonRetryableApplicationError(postErr.Error(), reversalErrType, postErr)
+		}
+
+		return nil, postErr
+	}
+
+	result := &ReversalResult{
+		ReferenceID:         params.ReferenceID,
+		TransferReferenceID: params.TransferReferenceID,
+		OutboundTransaction: postedTransactions[0],
+		InboundTransaction:  postedTransactions[1],
+	}
+
+	if pending.FeeInboundTrx != nil {
+		result.FeeOutbound = postedTransactions[2]
+		result.FeeInbound = postedTransactions[3]
+	}
+
+	return result, nil
+}
+
+// FailReversal compensates CreatePendingReversal by marking the pending reversal transactions as FAILURE.
+func (r *ReversalOperations) FailReversal(ctx context.Context, pending PendingReversal) error {
+	metadata := map[string]interface{}{
+		"FailedAt": r.timeProvider.Now().Format(time.RFC3339),
+	}
+
+	for _, trx := range []*transactions.Transaction{pending.OutboundTrx, pending.InboundTrx, pending.FeeOutboundTrx, pending.FeeInboundTrx} {
+		if trx == nil {
+			continue
+		}
+
+		_, failErr := r.transactionService.FailTransaction(ctx, trx.ID, metadata)
+		if failErr != nil {
+			return failErr
+		}
+	}
+
+	return nil
+}
+
+// findOriginal returns a posted transaction of the reversed transfer, only SUCCESS transactions can be reversed.
+func (r *ReversalOperations) findOriginal(ctx context.Context, referenceID uuid.UUID) (*transactions.Transaction, error) {
+	original, err := r.transactionService.GetTransactionByReferenceID(ctx, referenceID)
+	if err != nil {
+		if errors.Is(err, transactions.ErrTransactionNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError("transfer to reverse not found", reversalErrType, err)
+		}
+
+		return nil, err
+	}
+
+	if original.Status != constants.TransactionStatusSUCCESS {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("transaction %s is not reversible, status: %s", original.ID, original.Status),
+			reversalErrType,
+			nil,
+		)
+	}
+
+	return original, nil
+}
+
+// createReversalTransaction creates a PENDING reversal transaction on the account of the original transaction,
+// in its currency.
+func (r *ReversalOperations) createReversalTransaction(
+	ctx context.Context,
+	params ReversalParams,
+	original *transactions.Transaction,
+	amount int,
+	transactionType constants.TransactionType,
+	referenceID uuid.UUID,
+) (*transactions.Transaction, error) {
+	operationType := "Reversal"
+	if transactionType == constants.TransactionTypeFEEREVERSALOUTBOUND || transactionType == constants.TransactionTypeFEEREVERSALINBOUND {
+		operationType = "Fee Reversal"
+	}
+
+	metadata := datatypes.JSONMap(map[string]interface{}{
+		"OperationType":               operationType,
+		"LinkedTransactionID":         referenceID.String(),
+		"LinkedAccountID":             original.AccountID.String(),
+		"ReversalReferenceID":         params.ReferenceID.String(),
+		"ReversedTransferReferenceID": params.TransferReferenceID.String(),
+		"timestamp":                   r.timeProvider.Now().Format(time.RFC3339),
+	})
+
+	if params.Reason != "" {
+		metadata["ReversalReason"] = params.Reason
+	}
+
+	originalID := original.ID
+
+	transaction, err := r.finderOrCreatorService.Call(ctx, &transactions.Transaction{
+		UserID:                original.UserID,
+		Amount:                amount,
+		AccountID:             original.AccountID,
+		CurrencyCode:          original.CurrencyCode,
+		ReferenceID:           referenceID,
+		Metadata:              metadata,
+		Status:                constants.TransactionStatusPENDING,
+		TransactionType:       transactionType,
+		OriginalTransactionID: &originalID,
+	})
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "error while creating pending reversal trx", nil)
+	}
+
+	return transaction, nil
+}
+
+// proportionalAmount returns total * part / whole, rounded down.
+func proportionalAmount(total, part, whole int) int {
+	amount := new(big.Int).Mul(big.NewInt(int64(total)), big.NewInt(int64(part)))
+	amount.Quo(amount, big.NewInt(int64(whole)))
+
+	return int(amount.Int64())
+}
@@ -0,0 +1,182 @@
+//go:build tests_unit
+
+package activities
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+	"ulascansenturk/service/internal/constants"
+	mockTime "ulascansenturk/service/internal/helpers/mocks"
+	"ulascansenturk/service/internal/transactions"
+	"ulascansenturk/service/internal/transactions/mocks"
+)
+
+type reversalOperationsSuite struct {
+	suite.Suite
+
+	ctx                    context.Context
+	finderOrCreatorService *mocks.MockFinderOrCreator
+	transactionsService    *mocks.MockService
+	poster                 *mocks.MockPoster
+	timeProvider           *mockTime.MockTimeProvider
+
+	reversalOperations *ReversalOperations
+
+	params           ReversalParams
+	originalOutgoing *transactions.Transaction
+	originalIncoming *transactions.Transaction
+}
+
+func (s *reversalOperationsSuite) SetupSubTest() {
+	s.ctx = context.Background()
+
+	s.finderOrCreatorService = mocks.NewMockFinderOrCreator(s.T())
+	s.transactionsService = mocks.NewMockService(s.T())
+	s.poster = mocks.NewMockPoster(s.T())
+	s.timeProvider = new(mockTime.MockTimeProvider)
+	s.timeProvider.On("Now").Return(time.Now())
+
+	s.reversalOperations = NewReversalOperations(s.finderOrCreatorService, s.transactionsService, s.poster, s.timeProvider)
+
+	s.params = ReversalParams{
+		ReferenceID:                               uuid.New(),
+		TransferReferenceID:                       uuid.New(),
+		OriginalSourceTransactionReferenceID:      uuid.New(),
+		OriginalDestinationTransactionReferenceID: uuid.New(),
+		OriginalFeeTransactionReferenceID:         uuid.New(),
+		OriginalIncomingFeeTransactionReferenceID: uuid.New(),
+		OutboundTransactionReferenceID:            uuid.New(),
+		InboundTransactionReferenceID:             uuid.New(),
+		FeeOutboundTransactionReferenceID:         uuid.New(),
+		FeeInboundTransactionReferenceID:          uuid.New(),
+	}
+
+	s.originalOutgoing = &transactions.Transaction{
+		ID:              uuid.New(),
+		Amount:          1000,
+		AccountID:       uuid.New(),
+		CurrencyCode:    "USD",
+		Status:          constants.TransactionStatusSUCCESS,
+		TransactionType: constants.TransactionTypeOUTBOUND,
+	}
+
+	s.originalIncoming = &transactions.Transaction{
+		ID:              uuid.New(),
+		Amount:          920,
+		AccountID:       uuid.New(),
+		CurrencyCode:    "EUR",
+		Status:          constants.TransactionStatusSUCCESS,
+		TransactionType: constants.TransactionTypeINBOUND,
+	}
+
+	s.transactionsService.On("GetTransactionByReferenceID", mock.Anything, s.params.OriginalSourceTransactionReferenceID).Return(s.originalOutgoing, nil)
+	s.transactionsService.On("GetTransactionByReferenceID", mock.Anything, s.params.OriginalDestinationTransactionReferenceID).Return(s.originalIncoming, nil).Maybe()
+}
+
+func TestReversalOperationsSuite(t *testing.T) {
+	suite.Run(t, new(reversalOperationsSuite))
+}
+
+func (s *reversalOperationsSuite) expectReversalTransaction(original *transactions.Transaction, transactionType constants.TransactionType, amount int) {
+	s.finderOrCreatorService.On("Call", mock.Anything, mock.MatchedBy(func(trx *transactions.Transaction) bool {
+		return trx.TransactionType == transactionType &&
+			trx.Amount == amount &&
+			trx.AccountID == original.AccountID &&
+			trx.CurrencyCode == original.CurrencyCode &&
+			trx.Status == constants.TransactionStatusPENDING &&
+			*trx.OriginalTransactionID == original.ID
+	})).Return(func(_ context.Context, trx *transactions.Transaction) (*transactions.Transaction, error) {
+		created := *trx
+		created.ID = uuid.New()
+
+		return &created, nil
+	}).Once()
+}
+
+func (s *reversalOperationsSuite) TestCreatePendingReversal() {
+	s.Run("Partial reversal of a cross-currency transfer debits the destination in proportion", func() {
+		amount := 500
+		s.params.Amount = &amount
+
+		s.transactionsService.On("GetReversedAmount", mock.Anything, s.originalOutgoing.ID, constants.TransactionTypeREVERSALINBOUND).Return(0, nil)
+
+		s.expectReversalTransaction(s.originalIncoming, constants.TransactionTypeREVERSALOUTBOUND, 460)
+		s.expectReversalTransaction(s.originalOutgoing, constants.TransactionTypeREVERSALINBOUND, 500)
+
+		pending, err := s.reversalOperations.CreatePendingReversal(s.ctx, s.params)
+		s.NoError(err)
+		s.Equal(460, pending.OutboundTrx.Amount)
+		s.Equal(500, pending.InboundTrx.Amount)
+		s.Nil(pending.FeeInboundTrx)
+	})
+
+	s.Run("Full reversal debits what is left of the destination and refunds the fee", func() {
+		s.params.IncludeFee = true
+
+		originalFee := &transactions.Transaction{
+			ID:              uuid.New(),
+			Amount:          10,
+			AccountID:       s.originalOutgoing.AccountID,
+			CurrencyCode:    "USD",
+			Status:          constants.TransactionStatusSUCCESS,
+			TransactionType: constants.TransactionTypeOUTGOINGFEE,
+		}
+		originalIncomingFee := &transactions.Transaction{
+			ID:              uuid.New(),
+			Amount:          10,
+			AccountID:       uuid.New(),
+			CurrencyCode:    "USD",
+			Status:          constants.TransactionStatusSUCCESS,
+			TransactionType: constants.TransactionTypeINCOMINGFEE,
+		}
+
+		s.transactionsService.On("GetTransactionByReferenceID", mock.Anything, s.params.OriginalFeeTransactionReferenceID).Return(originalFee, nil)
+		s.transactionsService.On("GetTransactionByReferenceID", mock.Anything, s.params.OriginalIncomingFeeTransactionReferenceID).Return(originalIncomingFee, nil)
+
+		s.transactionsService.On("GetReversedAmount", mock.Anything, s.originalOutgoing.ID, constants.TransactionTypeREVERSALINBOUND).Return(333, nil)
+		s.transactionsService.On("GetReversedAmount", mock.Anything, s.originalIncoming.ID, constants.TransactionTypeREVERSALOUTBOUND).Return(306, nil)
+		s.transactionsService.On("GetReversedAmount", mock.Anything, originalFee.ID, constants.TransactionTypeFEEREVERSALINBOUND).Return(0, nil)
+
+		s.expectReversalTransaction(s.originalIncoming, constants.TransactionTypeREVERSALOUTBOUND, 614)
+		s.expectReversalTransaction(s.originalOutgoing, constants.TransactionTypeREVERSALINBOUND, 667)
+		s.expectReversalTransaction(originalIncomingFee, constants.TransactionTypeFEEREVERSALOUTBOUND, 10)
+		s.expectReversalTransaction(originalFee, constants.TransactionTypeFEEREVERSALINBOUND, 10)
+
+		pending, err := s.reversalOperations.CreatePendingReversal(s.ctx, s.params)
+		s.NoError(err)
+		s.NotNil(pending.FeeOutboundTrx)
+		s.NotNil(pending.FeeInboundTrx)
+	})
+
+	s.Run("Reversal of more than the remaining amount is refused", func() {
+		amount := 700
+		s.params.Amount = &amount
+
+		s.transactionsService.On("GetReversedAmount", mock.Anything, s.originalOutgoing.ID, constants.TransactionTypeREVERSALINBOUND).Return(400, nil)
+
+		pending, err := s.reversalOperations.CreatePendingReversal(s.ctx, s.params)
+		s.Nil(pending)
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(err, &applicationErr)
+		s.True(applicationErr.NonRetryable())
+		s.ErrorIs(err, transactions.ErrReversalExceedsAmount)
+	})
+
+	s.Run("Transfer that was not posted can't be reversed", func() {
+		s.originalOutgoing.Status = constants.TransactionStatusFAILURE
+
+		pending, err := s.reversalOperations.CreatePendingReversal(s.ctx, s.params)
+		s.Nil(pending)
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(err, &applicationErr)
+		s.Equal(reversalErrType, applicationErr.Type())
+	})
+}
@@ -0,0 +1,33 @@
+package activities
+
+import (
+	"context"
+	"ulascansenturk/service/internal/standingorders"
+)
+
+type StandingOrderOperations struct {
+	standingOrdersService standingorders.Service
+	notifier              standingorders.Notifier
+}
+
+func NewStandingOrderOperations(standingOrdersService standingorders.Service, notifier standingorders.Notifier) *StandingOrderOperations {
+	return &StandingOrderOperations{
+		standingOrdersService: standingOrdersService,
+		notifier:              notifier,
+	}
+}
+
+// RecordOccurrence stores the outcome of a standing order occurrence, recording it again is a no-op.
+func (o *StandingOrderOperations) RecordOccurrence(ctx context.Context, occurrence standingorders.Occurrence) error {
+	return o.standingOrdersService.RecordOccurrence(ctx, &occurrence)
+}
+
+// NotifyOccurrenceFailed calls the notification hook of a failed occurrence.
+func (o *StandingOrderOperations) NotifyOccurrenceFailed(ctx context.Context, occurrence standingorders.Occurrence) error {
+	standingOrder, err := o.standingOrdersService.GetStandingOrder(ctx, occurrence.StandingOrderID)
+	if err != nil {
+		return err
+	}
+
+	return o.notifier.NotifyOccurrenceFailed(ctx, standingOrder, &occurrence)
+}
@@ -2,11 +2,15 @@ package activities
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"gorm.io/datatypes"
 	"time"
 	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/approvals"
 	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/fees"
+	"ulascansenturk/service/internal/fx"
 	"ulascansenturk/service/internal/helpers"
 	"ulascansenturk/service/internal/transactions"
 
@@ -18,14 +22,18 @@ type TransactionOperations struct {
 	finderOrCreatorService transactions.FinderOrCreator
 	transactionService     transactions.Service
 	accountsService        accounts.Service
+	poster                 transactions.Poster
+	feeCollectionAccounts  accounts.FeeCollectionAccounts
 	timeProvider           helpers.TimeProvider
 }
 
-func NewTransactionOperations(finderOrCreatorService transactions.FinderOrCreator, transactionsService transactions.Service, accountsService accounts.Service, timeProvider helpers.TimeProvider) *TransactionOperations {
+func NewTransactionOperations(finderOrCreatorService transactions.FinderOrCreator, transactionsService transactions.Service, accountsService accounts.Service, poster transactions.Poster, feeCollectionAccounts accounts.FeeCollectionAccounts, timeProvider helpers.TimeProvider) *TransactionOperations {
 	return &TransactionOperations{
 		finderOrCreatorService: finderOrCreatorService,
 		transactionService:     transactionsService,
 		accountsService:        accountsService,
+		poster:                 poster,
+		feeCollectionAccounts:  feeCollectionAccounts,
 		timeProvider:           timeProvider,
 	}
 }
@@ -33,25 +41,36 @@ func NewTransactionOperations(finderOrCreatorService transactions.FinderOrCreato
 type TransferParams struct {
 	Amount                            int
 	FeeAmount                         *int
+	FeeRule                           *fees.RuleReference
+	FXQuote                           *fx.Quote
 	Metadata                          *map[string]interface{}
 	DestinationAccountID              uuid.UUID
 	SourceTransactionReferenceID      uuid.UUID
 	DestinationTransactionReferenceID uuid.UUID
 	FeeTransactionReferenceID         uuid.UUID
+	IncomingFeeTransactionReferenceID uuid.UUID
 	SourceAccountID                   uuid.UUID
+	// FencingTokens are the fencing tokens of the account locks the transfer is posted under.
+	FencingTokens map[uuid.UUID]int64
 }
 
 type TransferResult struct {
 	SourceTransactionReferenceID      uuid.UUID
 	DestinationTransactionReferenceID uuid.UUID
 	FeeTransactionReferenceID         uuid.UUID
+	IncomingFeeTransactionReferenceID uuid.UUID
 	FeeTransaction                    *transactions.Transaction
+	IncomingFeeTransaction            *transactions.Transaction
 	SourceTransaction                 *transactions.Transaction
 	DestinationTransaction            *transactions.Transaction
+	FXQuote                           *fx.Quote
 }
 
-func (t *TransactionOperations) Transfer(ctx context.Context, params TransferParams) (*TransferResult, error) {
-	validAccounts, accountsErr := t.validateAccount(ctx, params.Amount, params.FeeAmount, params.SourceAccountID, params.DestinationAccountID)
+// CreatePendingTransactions validates both accounts and creates the PENDING outgoing, incoming and fee transactions.
+// A fee is recorded twice, as an outgoing fee on the source account and as an incoming fee on the fee collection account.
+// A cross-currency transfer credits the destination with the target amount of params.FXQuote.
+func (t *TransactionOperations) CreatePendingTransactions(ctx context.Context, params TransferParams) (*PendingTransactions, error) {
+	validAccounts, accountsErr := t.validateAccount(ctx, params.Amount, params.FeeAmount, params.FXQuote, params.SourceAccountID, params.DestinationAccountID)
 	if accountsErr != nil {
 		return nil, temporal.NewNonRetryableApplicationError("Error on validating accounts", "validate-accounts-err", accountsErr)
 
@@ -67,21 +86,197 @@ func (t *TransactionOperations) Transfer(ctx context.Context, params TransferPar
 		return nil, pendingFeeTrxErr
 	}
 
+	var pendingIncomingFeeTrx *transactions.Transaction
+
+	if pendingFeeTrx != nil {
+		var pendingIncomingFeeTrxErr error
+
+		pendingIncomingFeeTrx, pendingIncomingFeeTrxErr = t.createPendingIncomingFeeTransaction(ctx, params, *validAccounts.SourceAccount)
+		if pendingIncomingFeeTrxErr != nil {
+			return nil, pendingIncomingFeeTrxErr
+		}
+	}
+
 	pendingIncomingTransaction, pendingIncomingTransactionErr := t.createPendingIncomingTransaction(ctx, params, *validAccounts.DestinationAccount)
 	if pendingIncomingTransactionErr != nil {
 		return nil, pendingIncomingTransactionErr
 	}
 
-	if updateAccountBalanceErr := t.updateAccountBalances(ctx, *validAccounts.SourceAccount, *validAccounts.DestinationAccount, params); updateAccountBalanceErr != nil {
-		return nil, updateAccountBalanceErr
+	return &PendingTransactions{
+		OutgoingTrx:    pendingOutGoingTransaction,
+		IncomingTrx:    pendingIncomingTransaction,
+		FeeTrx:         pendingFeeTrx,
+		IncomingFeeTrx: pendingIncomingFeeTrx,
+	}, nil
+}
+
+// PostTransfer applies the debit, credit and fee of the transfer and flips its pending transactions to SUCCESS
+// in a single database transaction, so retries can never leave a transfer half applied.
+func (t *TransactionOperations) PostTransfer(ctx context.Context, params TransferParams, pending PendingTransactions) (*TransferResult, error) {
+	transactionIDs := []uuid.UUID{pending.OutgoingTrx.ID, pending.IncomingTrx.ID}
+
+	feeAmount := 0
+	feeAccountID := uuid.Nil
+
+	if pending.FeeTrx != nil {
+		transactionIDs = append(transactionIDs, pending.FeeTrx.ID, pending.IncomingFeeTrx.ID)
+		feeAmount = pending.FeeTrx.Amount
+		feeAccountID = pending.IncomingFeeTrx.AccountID
+	}
+
+	postedTransactions, postErr := t.poster.PostTransfer(ctx, &transactions.TransferPosting{
+		SourceAccountID:      params.SourceAccountID,
+		DestinationAccountID: params.DestinationAccountID,
+		FeeAccountID:         feeAccountID,
+		Amount:               params.Amount,
+		DestinationAmount:    pending.IncomingTrx.Amount,
+		FeeAmount:            feeAmount,
+		TransactionIDs:       transactionIDs,
+		FencingTokens:        params.FencingTokens,
+	})
+	if postErr != nil {
+		if errors.Is(postErr, transactions.ErrInsufficientFunds) ||
+			errors.Is(postErr, transactions.ErrTransactionNotPostable) ||
+			errors.Is(postErr, transactions.ErrStaleFencingToken) {
+			return nil, temporal.NewNonRetryableApplicationError(postErr.Error(), "post-transfer-err", postErr)
+		}
+
+		return nil, postErr
+	}
+
+	var feeTrx, incomingFeeTrx *transactions.Transaction
+	if pending.FeeTrx != nil {
+		feeTrx = postedTransactions[2]
+		incomingFeeTrx = postedTransactions[3]
 	}
 
-	updatedTransactions, finalizeTranscationErr := t.finalizeTransactions(ctx, pendingOutGoingTransaction, pendingIncomingTransaction, pendingFeeTrx)
-	if finalizeTranscationErr != nil {
-		return nil, finalizeTranscationErr
+	return t.createTransferResult(params, postedTransactions[0], postedTransactions[1], feeTrx, incomingFeeTrx), nil
+}
+
+// Transfer creates the pending transactions of the transfer and posts them in one go. Only the Transfer workflows
+// started before the transfer was run in steps schedule it.
+func (t *TransactionOperations) Transfer(ctx context.Context, params TransferParams) (*TransferResult, error) {
+	pending, err := t.CreatePendingTransactions(ctx, params)
+	if err != nil {
+		return nil, err
 	}
 
-	return t.createTransferResult(params, &updatedTransactions.OutgoingTrx, &updatedTransactions.IncomingTrx, updatedTransactions.FeeTrx), nil
+	return t.PostTransfer(ctx, params, *pending)
+}
+
+// DebitSourceAccount decreases the source account balance by the transfer amount. Only the Transfer workflows
+// started before the transfer was posted in a single database transaction schedule it, and its compensations.
+func (t *TransactionOperations) DebitSourceAccount(ctx context.Context, params TransferParams) error {
+	return t.accountsService.UpdateBalance(ctx, params.SourceAccountID, params.Amount, constants.BalanceOperationDECREASE.String())
+}
+
+// CreditDestinationAccount increases the destination account balance by the transfer amount.
+func (t *TransactionOperations) CreditDestinationAccount(ctx context.Context, params TransferParams) error {
+	return t.accountsService.UpdateBalance(ctx, params.DestinationAccountID, params.Amount, constants.BalanceOperationINCREASE.String())
+}
+
+// FinalizeTransactions marks the pending transactions of the transfer as SUCCESS.
+func (t *TransactionOperations) FinalizeTransactions(ctx context.Context, params TransferParams, pending PendingTransactions) (*TransferResult, error) {
+	finalized := make([]*transactions.Transaction, 4)
+
+	for i, trx := range []*transactions.Transaction{pending.OutgoingTrx, pending.IncomingTrx, pending.FeeTrx, pending.IncomingFeeTrx} {
+		if trx == nil {
+			continue
+		}
+
+		updatedTrx, updateErr := t.transactionService.UpdateTransactionStatus(ctx, trx.ID, constants.TransactionStatusSUCCESS)
+		if updateErr != nil {
+			return nil, updateErr
+		}
+
+		finalized[i] = updatedTrx
+	}
+
+	return t.createTransferResult(params, finalized[0], finalized[1], finalized[2], finalized[3]), nil
+}
+
+// RefundSourceAccount compensates DebitSourceAccount by crediting the transfer amount back to the source account.
+func (t *TransactionOperations) RefundSourceAccount(ctx context.Context, params TransferParams) error {
+	return t.accountsService.UpdateBalance(ctx, params.SourceAccountID, params.Amount, constants.BalanceOperationINCREASE.String())
+}
+
+// ReverseDestinationCredit compensates CreditDestinationAccount by taking the transfer amount back from the destination account.
+func (t *TransactionOperations) ReverseDestinationCredit(ctx context.Context, params TransferParams) error {
+	return t.accountsService.UpdateBalance(ctx, params.DestinationAccountID, params.Amount, constants.BalanceOperationDECREASE.String())
+}
+
+// FailTransactions compensates CreatePendingTransactions by marking the pending transactions of the transfer as
+// FAILURE. Transactions that are no longer PENDING are left as they are, PostTransfer may have committed even
+// though its activity failed, and the balances of posted transactions already moved.
+func (t *TransactionOperations) FailTransactions(ctx context.Context, pending PendingTransactions) error {
+	for _, trx := range []*transactions.Transaction{pending.OutgoingTrx, pending.IncomingTrx, pending.FeeTrx, pending.IncomingFeeTrx} {
+		if trx == nil {
+			continue
+		}
+
+		_, failErr := t.transactionService.FailTransaction(ctx, trx.ID, nil)
+		if failErr != nil {
+			return failErr
+		}
+	}
+
+	return nil
+}
+
+// CancelTransactions marks the pending transactions of a cancelled transfer as FAILURE and records the
+// cancellation reason in their metadata. Transactions that are no longer PENDING are left as they are.
+func (t *TransactionOperations) CancelTransactions(ctx context.Context, pending PendingTransactions, reason string) error {
+	metadata := map[string]interface{}{
+		"CancellationReason": reason,
+		"CancelledAt":        t.timeProvider.Now().Format(time.RFC3339),
+	}
+
+	for _, trx := range []*transactions.Transaction{pending.OutgoingTrx, pending.IncomingTrx, pending.FeeTrx, pending.IncomingFeeTrx} {
+		if trx == nil {
+			continue
+		}
+
+		_, failErr := t.transactionService.FailTransaction(ctx, trx.ID, metadata)
+		if failErr != nil {
+			return failErr
+		}
+	}
+
+	return nil
+}
+
+// ApplyApprovalDecision records the approval decision in the metadata of the pending transactions,
+// the transactions of a rejected transfer are marked as FAILURE.
+func (t *TransactionOperations) ApplyApprovalDecision(ctx context.Context, pending PendingTransactions, decision approvals.Decision) error {
+	metadata := map[string]interface{}{
+		"ApprovalDecision":  decision.Decision.String(),
+		"Approver":          decision.Approver,
+		"ApprovalDecidedAt": decision.DecidedAt.Format(time.RFC3339),
+	}
+
+	if decision.Reason != nil {
+		metadata["ApprovalReason"] = *decision.Reason
+	}
+
+	for _, trx := range []*transactions.Transaction{pending.OutgoingTrx, pending.IncomingTrx, pending.FeeTrx, pending.IncomingFeeTrx} {
+		if trx == nil {
+			continue
+		}
+
+		var updateErr error
+
+		if decision.Decision == constants.ApprovalDecisionREJECTED {
+			_, updateErr = t.transactionService.FailTransaction(ctx, trx.ID, metadata)
+		} else {
+			_, updateErr = t.transactionService.UpdateTransactionMetadata(ctx, trx.ID, metadata)
+		}
+
+		if updateErr != nil {
+			return updateErr
+		}
+	}
+
+	return nil
 }
 
 func (t *TransactionOperations) createPendingOutgoingTransaction(ctx context.Context, params TransferParams, sourceAccount accounts.Account) (*transactions.Transaction, error) {
@@ -101,6 +296,7 @@ func (t *TransactionOperations) createPendingOutgoingTransaction(ctx context.Con
 		Status:          constants.TransactionStatusPENDING,
 		TransactionType: constants.TransactionTypeOUTBOUND,
 	}
+	addFXMetadata(pendingOutgoingTransactionParams.Metadata, params.FXQuote)
 	pendingOutGoingTransaction, err := t.findOrCreateTransaction(ctx, pendingOutgoingTransactionParams)
 	if err != nil {
 		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "error while creating pending outgoing trx", nil)
@@ -127,6 +323,11 @@ func (t *TransactionOperations) createPendingFeeTransaction(ctx context.Context,
 		Status:          constants.TransactionStatusPENDING,
 		TransactionType: constants.TransactionTypeOUTGOINGFEE,
 	}
+	if params.FeeRule != nil {
+		pendingOutgoingFeeTransactionParams.Metadata["FeeRuleID"] = params.FeeRule.ID.String()
+		pendingOutgoingFeeTransactionParams.Metadata["FeeRuleCode"] = params.FeeRule.Code
+		pendingOutgoingFeeTransactionParams.Metadata["FeeRuleVersion"] = params.FeeRule.Version
+	}
 	pendingOutGoingFeeTransaction, err := t.findOrCreateTransaction(ctx, pendingOutgoingFeeTransactionParams)
 	if err != nil {
 		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "error while creating pending outgoing fee trx", nil)
@@ -134,10 +335,58 @@ func (t *TransactionOperations) createPendingFeeTransaction(ctx context.Context,
 	return pendingOutGoingFeeTransaction, nil
 }
 
+func (t *TransactionOperations) createPendingIncomingFeeTransaction(ctx context.Context, params TransferParams, sourceAccount accounts.Account) (*transactions.Transaction, error) {
+	feeAccountID, feeAccountIDErr := t.feeCollectionAccounts.AccountIDFor(sourceAccount.Currency)
+	if feeAccountIDErr != nil {
+		return nil, temporal.NewNonRetryableApplicationError(feeAccountIDErr.Error(), "fee-collection-account-err", nil)
+	}
+
+	feeAccount, feeAccountErr := t.accountsService.GetAccountByID(ctx, feeAccountID)
+	if feeAccountErr != nil {
+		return nil, feeAccountErr
+	}
+
+	if feeAccount.Currency != sourceAccount.Currency {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("fee collection account currency mismatch: %s, expected: %s", feeAccount.Currency, sourceAccount.Currency),
+			"fee-collection-account-err",
+			nil,
+		)
+	}
+
+	pendingIncomingFeeTransactionParams := &transactions.Transaction{
+		UserID:       &feeAccount.UserID,
+		Amount:       *params.FeeAmount,
+		AccountID:    feeAccount.ID,
+		CurrencyCode: constants.CurrencyCode(feeAccount.Currency),
+		ReferenceID:  params.IncomingFeeTransactionReferenceID,
+		Metadata: datatypes.JSONMap(map[string]interface{}{
+			"OperationType":          "Fee Transfer",
+			"LinkedTransactionID":    params.IncomingFeeTransactionReferenceID.String(),
+			"LinkedAccountID":        feeAccount.ID.String(),
+			"SourceAccountID":        params.SourceAccountID.String(),
+			"OutgoingFeeReferenceID": params.FeeTransactionReferenceID.String(),
+			"timestamp":              t.timeProvider.Now().Format(time.RFC3339),
+		}),
+		Status:          constants.TransactionStatusPENDING,
+		TransactionType: constants.TransactionTypeINCOMINGFEE,
+	}
+	pendingIncomingFeeTransaction, err := t.findOrCreateTransaction(ctx, pendingIncomingFeeTransactionParams)
+	if err != nil {
+		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "error while creating pending incoming fee trx", nil)
+	}
+	return pendingIncomingFeeTransaction, nil
+}
+
 func (t *TransactionOperations) createPendingIncomingTransaction(ctx context.Context, params TransferParams, destinationAccount accounts.Account) (*transactions.Transaction, error) {
+	amount := params.Amount
+	if params.FXQuote != nil {
+		amount = params.FXQuote.TargetAmount
+	}
+
 	pendingIncomingTransactionParams := &transactions.Transaction{
 		UserID:       &destinationAccount.UserID,
-		Amount:       params.Amount,
+		Amount:       amount,
 		AccountID:    destinationAccount.ID,
 		CurrencyCode: constants.CurrencyCode(destinationAccount.Currency),
 		Metadata: datatypes.JSONMap(map[string]interface{}{
@@ -153,6 +402,7 @@ func (t *TransactionOperations) createPendingIncomingTransaction(ctx context.Con
 		Status:          constants.TransactionStatusPENDING,
 		TransactionType: constants.TransactionTypeINBOUND,
 	}
+	addFXMetadata(pendingIncomingTransactionParams.Metadata, params.FXQuote)
 	pendingIncomingTransaction, err := t.findOrCreateTransaction(ctx, pendingIncomingTransactionParams)
 	if err != nil {
 		return nil, temporal.NewNonRetryableApplicationError(err.Error(), "error while creating pending incoming trx", nil)
@@ -160,57 +410,17 @@ func (t *TransactionOperations) createPendingIncomingTransaction(ctx context.Con
 	return pendingIncomingTransaction, nil
 }
 
-func (t *TransactionOperations) updateAccountBalances(ctx context.Context, sourceAcc, destinationAcc accounts.Account, params TransferParams) error {
-	sourceAccBalanceUpdateErr := t.accountsService.UpdateBalance(ctx, sourceAcc.ID, params.Amount, constants.BalanceOperationDECREASE.String())
-	if sourceAccBalanceUpdateErr != nil {
-		return sourceAccBalanceUpdateErr
-	}
-
-	destinationAccBalanceUpdateErr := t.accountsService.UpdateBalance(ctx, destinationAcc.ID, params.Amount, constants.BalanceOperationINCREASE.String())
-	if destinationAccBalanceUpdateErr != nil {
-		return destinationAccBalanceUpdateErr
-	}
-
-	return nil
-}
-
-func (t *TransactionOperations) finalizeTransactions(ctx context.Context, outgoing, incoming, fee *transactions.Transaction) (*UpdatedTransactions, error) {
-	updatedOutgoingTrx, updatedOutgoingTrxErr := t.transactionService.UpdateTransactionStatus(ctx, outgoing.ID, constants.TransactionStatusSUCCESS)
-	if updatedOutgoingTrxErr != nil {
-		return nil, updatedOutgoingTrxErr
-	}
-
-	updatedIncomingTrx, updatedIncomingTrxErr := t.transactionService.UpdateTransactionStatus(ctx, incoming.ID, constants.TransactionStatusSUCCESS)
-	if updatedIncomingTrxErr != nil {
-		return nil, updatedIncomingTrxErr
-	}
-	var feeTrx *transactions.Transaction
-
-	if fee != nil {
-		fee.Status = constants.TransactionStatusSUCCESS
-		updatedFeeTrx, updatedFeeTrxErr := t.transactionService.UpdateTransactionStatus(ctx, fee.ID, constants.TransactionStatusSUCCESS)
-		if updatedFeeTrxErr != nil {
-			return nil, updatedFeeTrxErr
-		}
-		feeTrx = updatedFeeTrx
-	}
-
-	return &UpdatedTransactions{
-		IncomingTrx: *updatedIncomingTrx,
-		OutgoingTrx: *updatedOutgoingTrx,
-		FeeTrx:      feeTrx,
-	}, nil
-
-}
-
-func (t *TransactionOperations) createTransferResult(params TransferParams, outgoing, incoming, fee *transactions.Transaction) *TransferResult {
+func (t *TransactionOperations) createTransferResult(params TransferParams, outgoing, incoming, fee, incomingFee *transactions.Transaction) *TransferResult {
 	return &TransferResult{
 		SourceTransactionReferenceID:      params.SourceTransactionReferenceID,
 		DestinationTransactionReferenceID: params.DestinationTransactionReferenceID,
 		FeeTransactionReferenceID:         params.FeeTransactionReferenceID,
+		IncomingFeeTransactionReferenceID: params.IncomingFeeTransactionReferenceID,
 		FeeTransaction:                    fee,
+		IncomingFeeTransaction:            incomingFee,
 		SourceTransaction:                 outgoing,
 		DestinationTransaction:            incoming,
+		FXQuote:                           params.FXQuote,
 	}
 }
 
@@ -223,7 +433,7 @@ func (t *TransactionOperations) findOrCreateTransaction(ctx context.Context, par
 	return transaction, nil
 }
 
-func (t *TransactionOperations) validateAccount(ctx context.Context, transferAmount int, feeAmount *int, sourceAccountID uuid.UUID, destinationAccountID uuid.UUID) (*ValidAccounts, error) {
+func (t *TransactionOperations) validateAccount(ctx context.Context, transferAmount int, feeAmount *int, fxQuote *fx.Quote, sourceAccountID uuid.UUID, destinationAccountID uuid.UUID) (*ValidAccounts, error) {
 	sourceAccount, accountErr := t.accountsService.GetAccountByID(ctx, sourceAccountID)
 	if accountErr != nil {
 		return nil, accountErr
@@ -250,13 +460,17 @@ func (t *TransactionOperations) validateAccount(ctx context.Context, transferAmo
 		return nil, fmt.Errorf("account is not active: %s", destinationAccount.ID)
 	}
 
+	if err := validateConversion(fxQuote, transferAmount, sourceAccount, destinationAccount); err != nil {
+		return nil, err
+	}
+
 	totalAmount := transferAmount
 	if feeAmount != nil {
 		totalAmount += *feeAmount
 	}
 
-	if totalAmount > sourceAccount.Balance {
-		return nil, fmt.Errorf("insufficient balance! transfer amount: %d,  account balance: %d", totalAmount, sourceAccount.Balance)
+	if totalAmount > sourceAccount.AvailableBalance() {
+		return nil, fmt.Errorf("insufficient balance! transfer amount: %d,  available balance: %d", totalAmount, sourceAccount.AvailableBalance())
 	}
 
 	return &ValidAccounts{
@@ -265,13 +479,53 @@ func (t *TransactionOperations) validateAccount(ctx context.Context, transferAmo
 	}, nil
 }
 
+// validateConversion makes sure a cross-currency transfer comes with a quote for exactly its currencies and amount.
+func validateConversion(fxQuote *fx.Quote, transferAmount int, sourceAccount, destinationAccount *accounts.Account) error {
+	if fxQuote == nil {
+		if sourceAccount.Currency != destinationAccount.Currency {
+			return fmt.Errorf("currency mismatch without an fx quote: %s, %s", sourceAccount.Currency, destinationAccount.Currency)
+		}
+
+		return nil
+	}
+
+	if fxQuote.SourceCurrency != sourceAccount.Currency || fxQuote.TargetCurrency != destinationAccount.Currency {
+		return fmt.Errorf("fx quote %s/%s does not match the accounts: %s, %s",
+			fxQuote.SourceCurrency, fxQuote.TargetCurrency, sourceAccount.Currency, destinationAccount.Currency)
+	}
+
+	if fxQuote.SourceAmount != transferAmount {
+		return fmt.Errorf("fx quote amount %d does not match the transfer amount: %d", fxQuote.SourceAmount, transferAmount)
+	}
+
+	return nil
+}
+
+// addFXMetadata records the conversion a cross-currency transaction was created with.
+func addFXMetadata(metadata datatypes.JSONMap, fxQuote *fx.Quote) {
+	if fxQuote == nil {
+		return
+	}
+
+	metadata["FXSourceCurrency"] = fxQuote.SourceCurrency
+	metadata["FXTargetCurrency"] = fxQuote.TargetCurrency
+	metadata["FXRate"] = fxQuote.Rate
+	metadata["FXAppliedRate"] = fxQuote.AppliedRate
+	metadata["FXSpreadBps"] = fxQuote.SpreadBps
+	metadata["FXSourceAmount"] = fxQuote.SourceAmount
+	metadata["FXTargetAmount"] = fxQuote.TargetAmount
+	metadata["FXSpreadAmount"] = fxQuote.SpreadAmount
+	metadata["FXQuotedAt"] = fxQuote.QuotedAt.Format(time.RFC3339)
+}
+
 type ValidAccounts struct {
 	SourceAccount      *accounts.Account
 	DestinationAccount *accounts.Account
 }
 
-type UpdatedTransactions struct {
-	IncomingTrx transactions.Transaction
-	OutgoingTrx transactions.Transaction
-	FeeTrx      *transactions.Transaction
+type PendingTransactions struct {
+	OutgoingTrx    *transactions.Transaction
+	IncomingTrx    *transactions.Transaction
+	FeeTrx         *transactions.Transaction
+	IncomingFeeTrx *transactions.Transaction
 }
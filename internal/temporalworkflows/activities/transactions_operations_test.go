@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -16,7 +17,9 @@ import (
 	"ulascansenturk/service/internal/accounts"
 	accountMocks "ulascansenturk/service/internal/accounts/mocks"
 	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/fx"
 	mockTime "ulascansenturk/service/internal/helpers/mocks"
+	"ulascansenturk/service/internal/outbox"
 	"ulascansenturk/service/internal/transactions"
 	"ulascansenturk/service/internal/transactions/mocks"
 	"ulascansenturk/service/internal/users"
@@ -32,6 +35,8 @@ type transactionOperationsSuite struct {
 	finderOrCreatorService *mocks.MockFinderOrCreator
 	transactionsService    *mocks.MockService
 	accountsService        *accountMocks.MockService
+	poster                 *mocks.MockPoster
+	feeCollectionAccountID uuid.UUID
 	timeProvider           *mockTime.MockTimeProvider
 
 	transactionOperations *TransactionOperations
@@ -53,8 +58,12 @@ func (s *transactionOperationsSuite) SetupTest() {
 	s.finderOrCreatorService = mocks.NewMockFinderOrCreator(s.T())
 	s.transactionsService = mocks.NewMockService(s.T())
 	s.accountsService = accountMocks.NewMockService(s.T())
+	s.poster = mocks.NewMockPoster(s.T())
+	s.feeCollectionAccountID = uuid.New()
 
-	s.transactionOperations = NewTransactionOperations(s.finderOrCreatorService, s.transactionsService, s.accountsService, s.timeProvider)
+	feeCollectionAccounts := accounts.FeeCollectionAccounts{"USD": s.feeCollectionAccountID}
+
+	s.transactionOperations = NewTransactionOperations(s.finderOrCreatorService, s.transactionsService, s.accountsService, s.poster, feeCollectionAccounts, s.timeProvider)
 }
 
 func TestTransactionsOperationsSuite(t *testing.T) {
@@ -109,17 +118,26 @@ func (s *transactionOperationsSuite) TestTransactionOperations() {
 			SourceTransactionReferenceID:      uuid.New(),
 			DestinationTransactionReferenceID: uuid.New(),
 			FeeTransactionReferenceID:         uuid.New(),
+			IncomingFeeTransactionReferenceID: uuid.New(),
 			SourceAccountID:                   sourceAccID,
 		}
 
+		feeCollectionAccount := accounts.Account{
+			ID:        s.feeCollectionAccountID,
+			UserID:    uuid.New(),
+			Balance:   0,
+			Currency:  "USD",
+			Status:    constants.AccountStatusACTIVE,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		}
+
 		timestamp := time.Now()
 		s.timeProvider.On("Now").Return(timestamp)
 
 		s.accountsService.On("GetAccountByID", mock.Anything, sourceAccID).Return(&sourceAccount, nil)
 		s.accountsService.On("GetAccountByID", mock.Anything, destinationAccID).Return(&destinationAccount, nil)
-
-		s.accountsService.On("UpdateBalance", mock.Anything, sourceAccID, amount, constants.BalanceOperationDECREASE.String()).Return(nil)
-		s.accountsService.On("UpdateBalance", mock.Anything, destinationAccID, amount, constants.BalanceOperationINCREASE.String()).Return(nil)
+		s.accountsService.On("GetAccountByID", mock.Anything, s.feeCollectionAccountID).Return(&feeCollectionAccount, nil)
 
 		sourceTransaction := &transactions.Transaction{
 			ID:              uuid.New(),
@@ -165,15 +183,41 @@ func (s *transactionOperationsSuite) TestTransactionOperations() {
 			}),
 		}
 
+		incomingFeeTransaction := &transactions.Transaction{
+			ID:              uuid.New(),
+			AccountID:       s.feeCollectionAccountID,
+			Amount:          feeAmount,
+			Status:          constants.TransactionStatusPENDING,
+			TransactionType: constants.TransactionTypeINCOMINGFEE,
+			Metadata: datatypes.JSONMap(map[string]interface{}{
+				"OperationType":          "Fee Transfer",
+				"LinkedTransactionID":    params.IncomingFeeTransactionReferenceID.String(),
+				"LinkedAccountID":        s.feeCollectionAccountID.String(),
+				"SourceAccountID":        sourceAccID.String(),
+				"OutgoingFeeReferenceID": params.FeeTransactionReferenceID.String(),
+				"timestamp":              timestamp.Format(time.RFC3339),
+			}),
+		}
+
 		s.finderOrCreatorService.On("Call", mock.Anything, mock.Anything).Return(sourceTransaction, nil).Once()
-		s.finderOrCreatorService.On("Call", mock.Anything, mock.Anything).Return(destinationTransaction, nil).Once()
 		s.finderOrCreatorService.On("Call", mock.Anything, mock.Anything).Return(feeTransaction, nil).Once()
+		s.finderOrCreatorService.On("Call", mock.Anything, mock.Anything).Return(incomingFeeTransaction, nil).Once()
+		s.finderOrCreatorService.On("Call", mock.Anything, mock.Anything).Return(destinationTransaction, nil).Once()
 
-		s.transactionsService.On("UpdateTransactionStatus", mock.Anything, sourceTransaction.ID, constants.TransactionStatusSUCCESS).Return(sourceTransaction, nil)
-		s.transactionsService.On("UpdateTransactionStatus", mock.Anything, destinationTransaction.ID, constants.TransactionStatusSUCCESS).Return(destinationTransaction, nil)
-		s.transactionsService.On("UpdateTransactionStatus", mock.Anything, feeTransaction.ID, constants.TransactionStatusSUCCESS).Return(feeTransaction, nil)
+		s.poster.On("PostTransfer", mock.Anything, &transactions.TransferPosting{
+			SourceAccountID:      sourceAccID,
+			DestinationAccountID: destinationAccID,
+			FeeAccountID:         s.feeCollectionAccountID,
+			Amount:               amount,
+			DestinationAmount:    amount,
+			FeeAmount:            feeAmount,
+			TransactionIDs:       []uuid.UUID{sourceTransaction.ID, destinationTransaction.ID, feeTransaction.ID, incomingFeeTransaction.ID},
+		}).Return([]*transactions.Transaction{sourceTransaction, destinationTransaction, feeTransaction, incomingFeeTransaction}, nil)
 
-		result, err := s.transactionOperations.Transfer(s.ctx, params)
+		pending, err := s.transactionOperations.CreatePendingTransactions(s.ctx, params)
+		require.NoError(s.T(), err)
+
+		result, err := s.transactionOperations.PostTransfer(s.ctx, params, *pending)
 		require.NoError(s.T(), err)
 
 		require.Equal(s.T(), params.SourceTransactionReferenceID, result.SourceTransactionReferenceID)
@@ -182,5 +226,100 @@ func (s *transactionOperationsSuite) TestTransactionOperations() {
 		require.NotNil(s.T(), result.SourceTransaction)
 		require.NotNil(s.T(), result.DestinationTransaction)
 		require.NotNil(s.T(), result.FeeTransaction)
+		require.NotNil(s.T(), result.IncomingFeeTransaction)
+		require.Equal(s.T(), s.feeCollectionAccountID, result.IncomingFeeTransaction.AccountID)
+	})
+
+	s.Run("Cross-currency transfer credits the quoted target amount", func() {
+		sourceAccount := accounts.Account{ID: uuid.New(), UserID: uuid.New(), Balance: 1000, Currency: "USD", Status: constants.AccountStatusACTIVE}
+		destinationAccount := accounts.Account{ID: uuid.New(), UserID: uuid.New(), Currency: "EUR", Status: constants.AccountStatusACTIVE}
+
+		params := TransferParams{
+			Amount:                            100,
+			DestinationAccountID:              destinationAccount.ID,
+			SourceTransactionReferenceID:      uuid.New(),
+			DestinationTransactionReferenceID: uuid.New(),
+			SourceAccountID:                   sourceAccount.ID,
+		}
+
+		s.timeProvider.On("Now").Return(time.Now())
+		s.accountsService.On("GetAccountByID", mock.Anything, sourceAccount.ID).Return(&sourceAccount, nil)
+		s.accountsService.On("GetAccountByID", mock.Anything, destinationAccount.ID).Return(&destinationAccount, nil)
+
+		_, err := s.transactionOperations.CreatePendingTransactions(s.ctx, params)
+		require.ErrorContains(s.T(), err, "Error on validating accounts")
+
+		params.FXQuote = &fx.Quote{SourceCurrency: "USD", TargetCurrency: "EUR", Rate: 0.92, SpreadBps: 50, SourceAmount: 100, TargetAmount: 91, SpreadAmount: 1}
+
+		s.finderOrCreatorService.On("Call", mock.Anything, mock.MatchedBy(func(trx *transactions.Transaction) bool {
+			return trx.TransactionType == constants.TransactionTypeOUTBOUND && trx.Amount == 100 && trx.CurrencyCode == "USD" && trx.Metadata["FXRate"] == 0.92
+		})).Return(&transactions.Transaction{ID: uuid.New(), Amount: 100}, nil).Once()
+		s.finderOrCreatorService.On("Call", mock.Anything, mock.MatchedBy(func(trx *transactions.Transaction) bool {
+			return trx.TransactionType == constants.TransactionTypeINBOUND && trx.Amount == 91 && trx.CurrencyCode == "EUR" && trx.Metadata["FXSpreadAmount"] == 1
+		})).Return(&transactions.Transaction{ID: uuid.New(), Amount: 91}, nil).Once()
+
+		pending, err := s.transactionOperations.CreatePendingTransactions(s.ctx, params)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), 91, pending.IncomingTrx.Amount)
+	})
+
+	s.Run("Fail Transactions Compensation", func() {
+		pending := PendingTransactions{
+			OutgoingTrx: &transactions.Transaction{ID: uuid.New()},
+			IncomingTrx: &transactions.Transaction{ID: uuid.New()},
+		}
+
+		s.transactionsService.On("FailTransaction", mock.Anything, pending.OutgoingTrx.ID, map[string]interface{}(nil)).Return(pending.OutgoingTrx, nil).Once()
+		s.transactionsService.On("FailTransaction", mock.Anything, pending.IncomingTrx.ID, map[string]interface{}(nil)).Return(pending.IncomingTrx, nil).Once()
+
+		err := s.transactionOperations.FailTransactions(s.ctx, pending)
+		require.NoError(s.T(), err)
+	})
+	s.Run("Fail Transactions Compensation leaves posted transactions as they are", func() {
+		transactionService := transactions.NewTransactionService(
+			transactions.NewSQLRepository(s.mockGormDB),
+			outbox.NewOutboxService(outbox.NewSQLRepository(s.mockGormDB)),
+			validator.New(),
+		)
+		transactionOperations := NewTransactionOperations(s.finderOrCreatorService, transactionService, s.accountsService, s.poster, accounts.FeeCollectionAccounts{}, s.timeProvider)
+
+		pending := PendingTransactions{
+			OutgoingTrx: &transactions.Transaction{ID: uuid.New()},
+			IncomingTrx: &transactions.Transaction{ID: uuid.New()},
+		}
+
+		columns := []string{"id", "account_id", "reference_id", "amount", "currency_code", "transaction_type", "metadata", "status"}
+
+		for _, trx := range []*transactions.Transaction{pending.OutgoingTrx, pending.IncomingTrx} {
+			s.mockDB.ExpectBegin()
+			s.mockDB.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+				WithArgs(trx.ID, 1).
+				WillReturnRows(sqlmock.NewRows(columns).AddRow(
+					trx.ID, uuid.New(), uuid.New(), 100, "USD", constants.TransactionTypeOUTBOUND, `{}`, constants.TransactionStatusSUCCESS,
+				))
+			s.mockDB.ExpectCommit()
+		}
+
+		err := transactionOperations.FailTransactions(s.ctx, pending)
+		require.NoError(s.T(), err)
+		require.NoError(s.T(), s.mockDB.ExpectationsWereMet())
+	})
+	s.Run("Cancel Transactions records the cancellation reason", func() {
+		pending := PendingTransactions{
+			OutgoingTrx: &transactions.Transaction{ID: uuid.New()},
+			IncomingTrx: &transactions.Transaction{ID: uuid.New()},
+		}
+
+		s.timeProvider.On("Now").Return(time.Now())
+
+		hasReason := mock.MatchedBy(func(metadata map[string]interface{}) bool {
+			return metadata["CancellationReason"] == "duplicate payment"
+		})
+
+		s.transactionsService.On("FailTransaction", mock.Anything, pending.OutgoingTrx.ID, hasReason).Return(pending.OutgoingTrx, nil).Once()
+		s.transactionsService.On("FailTransaction", mock.Anything, pending.IncomingTrx.ID, hasReason).Return(pending.IncomingTrx, nil).Once()
+
+		err := s.transactionOperations.CancelTransactions(s.ctx, pending, "duplicate payment")
+		require.NoError(s.T(), err)
 	})
 }
@@ -0,0 +1,94 @@
+package activities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"ulascansenturk/service/internal/helpers"
+	"ulascansenturk/service/internal/webhooks"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+)
+
+const (
+	webhookErrType = "webhook-err"
+	// WebhookGoneErrType is the error type of a delivery or subscription that was deleted, there is nothing to
+	// deliver anymore.
+	WebhookGoneErrType = "webhook-gone-err"
+)
+
+type WebhookOperations struct {
+	webhookService webhooks.Service
+	sender         *webhooks.Sender
+	timeProvider   helpers.TimeProvider
+}
+
+func NewWebhookOperations(webhookService webhooks.Service, sender *webhooks.Sender, timeProvider helpers.TimeProvider) *WebhookOperations {
+	return &WebhookOperations{
+		webhookService: webhookService,
+		sender:         sender,
+		timeProvider:   timeProvider,
+	}
+}
+
+// DeliverWebhook sends the delivery once and records the attempt, a failed attempt is returned as an error so the
+// activity is retried with backoff. A delivery that isn't pending anymore is returned as is.
+func (w *WebhookOperations) DeliverWebhook(ctx context.Context, deliveryID uuid.UUID) (*webhooks.Delivery, error) {
+	delivery, err := w.webhookService.GetDelivery(ctx, deliveryID)
+	if err != nil {
+		if errors.Is(err, webhooks.ErrDeliveryNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), WebhookGoneErrType, err)
+		}
+
+		return nil, err
+	}
+
+	if !delivery.IsPending() {
+		return delivery, nil
+	}
+
+	subscription, err := w.webhookService.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		if errors.Is(err, webhooks.ErrSubscriptionNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), WebhookGoneErrType, err)
+		}
+
+		return nil, err
+	}
+
+	if !subscription.IsActive() {
+		return nil, temporal.NewNonRetryableApplicationError(
+			fmt.Sprintf("subscription %s is disabled", subscription.ID),
+			webhookErrType,
+			webhooks.ErrSubscriptionDisabled,
+		)
+	}
+
+	attempt := w.sender.Send(ctx, subscription, delivery, w.timeProvider.Now())
+
+	delivery, err = w.webhookService.RecordAttempt(ctx, deliveryID, attempt)
+	if err != nil {
+		return nil, err
+	}
+
+	if attempt.Err != nil {
+		return nil, attempt.Err
+	}
+
+	return delivery, nil
+}
+
+// FailWebhookDelivery gives up on the delivery, it counts against the subscription.
+func (w *WebhookOperations) FailWebhookDelivery(ctx context.Context, deliveryID uuid.UUID, reason string) (*webhooks.Delivery, error) {
+	delivery, err := w.webhookService.FailDelivery(ctx, deliveryID, reason)
+	if err != nil {
+		if errors.Is(err, webhooks.ErrDeliveryNotFound) {
+			return nil, temporal.NewNonRetryableApplicationError(err.Error(), WebhookGoneErrType, err)
+		}
+
+		return nil, err
+	}
+
+	return delivery, nil
+}
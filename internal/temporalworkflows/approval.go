@@ -0,0 +1,101 @@
+package temporalworkflows
+
+import (
+	"time"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/constants"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// ApproveTransferSignal approves a transfer awaiting approval, see TransferApprovalRequest.
+	ApproveTransferSignal = "approve-transfer"
+	// RejectTransferSignal rejects a transfer awaiting approval, see TransferApprovalRequest.
+	RejectTransferSignal = "reject-transfer"
+
+	TransferRejectedErrorType = "transfer-rejected"
+)
+
+type TransferApprovalRequest struct {
+	Approver string
+	Reason   string
+}
+
+// awaitApproval pauses the transfer in AWAITING_APPROVAL until it is approved or rejected. The transfer is
+// rejected on behalf of the system once the timeout passes, a cancel signal ends the wait with a cancellation error.
+func awaitApproval(ctx workflow.Context, state *TransferState, requirement approvals.Requirement, timeout time.Duration) (*approvals.Decision, error) {
+	state.Status = constants.TransferStateAWAITINGAPPROVAL
+	state.ApprovalThreshold = &requirement.Threshold
+
+	decision := &approvals.Decision{
+		ID:          getActivityReferenceID(state.ReferenceID, "transfer-approval"),
+		ReferenceID: state.ReferenceID,
+		Amount:      state.Amount,
+		Currency:    requirement.Currency,
+		Threshold:   requirement.Threshold,
+	}
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+	defer cancelTimer()
+
+	var cancelErr error
+
+	receiveDecision := func(decisionType constants.ApprovalDecision) func(c workflow.ReceiveChannel, _ bool) {
+		return func(c workflow.ReceiveChannel, _ bool) {
+			var request TransferApprovalRequest
+			c.Receive(ctx, &request)
+
+			decision.Decision = decisionType
+			decision.Approver = request.Approver
+
+			if request.Reason != "" {
+				decision.Reason = &request.Reason
+			}
+		}
+	}
+
+	selector := workflow.NewSelector(ctx)
+	selector.AddFuture(workflow.NewTimer(timerCtx, timeout), func(f workflow.Future) {
+		reason := "approval timed out"
+
+		decision.Decision = constants.ApprovalDecisionREJECTED
+		decision.Approver = approvals.SystemApprover
+		decision.Reason = &reason
+	})
+	selector.AddReceive(workflow.GetSignalChannel(ctx, ApproveTransferSignal), receiveDecision(constants.ApprovalDecisionAPPROVED))
+	selector.AddReceive(workflow.GetSignalChannel(ctx, RejectTransferSignal), receiveDecision(constants.ApprovalDecisionREJECTED))
+	selector.AddReceive(workflow.GetSignalChannel(ctx, CancelTransferSignal), func(c workflow.ReceiveChannel, _ bool) {
+		var request CancelTransferRequest
+		c.Receive(ctx, &request)
+
+		state.CancellationReason = request.Reason
+		cancelErr = transferCancelledError(state)
+	})
+
+	selector.Select(ctx)
+
+	if cancelErr != nil {
+		return nil, cancelErr
+	}
+
+	decision.DecidedAt = workflow.Now(ctx)
+	state.Approval = decision
+
+	workflow.GetLogger(ctx).Info("Transfer approval decided", "Decision", decision.Decision, "Approver", decision.Approver)
+
+	return decision, nil
+}
+
+// transferRejectedError moves the transfer to REJECTED, the rejection reason is kept in the error details.
+func transferRejectedError(state *TransferState) error {
+	state.Status = constants.TransferStateREJECTED
+
+	var reason string
+	if state.Approval != nil && state.Approval.Reason != nil {
+		reason = *state.Approval.Reason
+	}
+
+	return temporal.NewNonRetryableApplicationError("transfer rejected", TransferRejectedErrorType, nil, reason)
+}
@@ -0,0 +1,211 @@
+package temporalworkflows
+
+import (
+	"time"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/holds"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"github.com/google/uuid"
+	"github.com/ilyakaznacheev/cleanenv"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// CaptureHoldSignal captures an active hold, the payload is a CaptureHoldRequest.
+	CaptureHoldSignal = "capture-hold"
+	// VoidHoldSignal releases an active hold without moving money, the payload is a VoidHoldRequest.
+	VoidHoldSignal = "void-hold"
+
+	holdExpiredReason = "hold expired"
+)
+
+// holdCaptureAccountLockingVersion marks the holds whose capture locks its destination too, not only the held account.
+const holdCaptureAccountLockingVersion = "hold-capture-account-locking"
+
+// holdCaptureLimitsVersion marks the holds whose capture is checked against the transfer limits of the account.
+const holdCaptureLimitsVersion = "hold-capture-limits"
+
+// CaptureHoldRequest captures Amount of the hold, the whole held amount when it is nil.
+type CaptureHoldRequest struct {
+	Amount *int
+}
+
+type VoidHoldRequest struct {
+	Reason string
+}
+
+// HoldParams identifies a hold already placed, the Hold workflow only decides how it ends.
+type HoldParams struct {
+	HoldID      uuid.UUID
+	ReferenceID uuid.UUID
+	AccountID   uuid.UUID
+	ExpiresAt   time.Time
+}
+
+// HoldWorkflowID is the ID of the Hold workflow of a hold, it is prefixed so it never collides with the ID of
+// a Transfer workflow.
+func HoldWorkflowID(referenceID uuid.UUID) string {
+	return "hold-" + referenceID.String()
+}
+
+// Hold waits for the first of a capture signal, a void signal or the expiry of the hold, and ends the hold
+// accordingly. A capture that can't be posted voids the hold so the funds never stay held for nothing.
+func Hold(ctx workflow.Context, params *HoldParams) (*holds.Hold, error) {
+	var cfg TransferEnvConfig
+
+	err := cleanenv.ReadEnv(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, transferActivityOptions)
+
+	var (
+		holdOperations *activities.HoldOperations
+		hold           *holds.Hold
+		captureRequest *CaptureHoldRequest
+		voidRequest    *VoidHoldRequest
+	)
+
+	timerCtx, cancelTimer := workflow.WithCancel(ctx)
+
+	expiresIn := params.ExpiresAt.Sub(workflow.Now(ctx))
+	if expiresIn < 0 {
+		expiresIn = 0
+	}
+
+	selector := workflow.NewSelector(ctx)
+	selector.AddFuture(workflow.NewTimer(timerCtx, expiresIn), func(f workflow.Future) {})
+	selector.AddReceive(workflow.GetSignalChannel(ctx, CaptureHoldSignal), func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, &captureRequest)
+	})
+	selector.AddReceive(workflow.GetSignalChannel(ctx, VoidHoldSignal), func(c workflow.ReceiveChannel, _ bool) {
+		c.Receive(ctx, &voidRequest)
+	})
+
+	selector.Select(ctx)
+	cancelTimer()
+
+	switch {
+	case captureRequest != nil:
+		return captureHold(ctx, cfg, params, *captureRequest)
+	case voidRequest != nil:
+		var reason *string
+		if voidRequest.Reason != "" {
+			reason = &voidRequest.Reason
+		}
+
+		err = workflow.ExecuteActivity(ctx, holdOperations.ReleaseHold, activities.HoldRelease{
+			HoldID: params.HoldID,
+			Status: constants.HoldStatusVOIDED,
+			Reason: reason,
+		}).Get(ctx, &hold)
+	default:
+		reason := holdExpiredReason
+
+		err = workflow.ExecuteActivity(ctx, holdOperations.ReleaseHold, activities.HoldRelease{
+			HoldID: params.HoldID,
+			Status: constants.HoldStatusEXPIRED,
+			Reason: &reason,
+		}).Get(ctx, &hold)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// captureHold posts the capture under the locks of the held account and of its destination, like a transfer
+// between them. The captured amount counts against the transfer limits of the account, a capture over a limit
+// voids the hold.
+func captureHold(ctx workflow.Context, cfg TransferEnvConfig, params *HoldParams, request CaptureHoldRequest) (hold *holds.Hold, err error) {
+	var (
+		holdOperations  *activities.HoldOperations
+		limitOperations *activities.LimitOperations
+		pendingCapture  *activities.PendingCapture
+		compensations   saga
+	)
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		compensateErr := compensations.compensate(ctx)
+		if compensateErr != nil {
+			workflow.GetLogger(ctx).Error("Hold capture compensation failed", "Error", compensateErr)
+		}
+	}()
+
+	voidReason := "capture failed"
+	compensations.addCompensation(holdOperations.ReleaseHold, activities.HoldRelease{
+		HoldID: params.HoldID,
+		Status: constants.HoldStatusVOIDED,
+		Reason: &voidReason,
+	})
+
+	err = workflow.ExecuteActivity(ctx, holdOperations.CreatePendingCapture, activities.CaptureParams{
+		HoldID:                         params.HoldID,
+		Amount:                         request.Amount,
+		OutboundTransactionReferenceID: getActivityReferenceID(params.ReferenceID, "hold-capture-outbound"),
+		InboundTransactionReferenceID:  getActivityReferenceID(params.ReferenceID, "hold-capture-inbound"),
+	}).Get(ctx, &pendingCapture)
+	if err != nil {
+		return nil, err
+	}
+
+	compensations.addCompensation(holdOperations.FailCapture, *pendingCapture)
+
+	releaseFunc, fencingTokens, err := lockCaptureAccounts(ctx, cfg, params, *pendingCapture)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		releaseErr := releaseFunc()
+		if releaseErr != nil {
+			workflow.GetLogger(ctx).Error("Hold capture mutex release failed", "Error", releaseErr)
+		}
+	}()
+
+	// Limits are checked under the lock so no transfer out of the account is posted in between.
+	if workflow.GetVersion(ctx, holdCaptureLimitsVersion, workflow.DefaultVersion, 1) == 1 {
+		err = workflow.ExecuteActivity(ctx, limitOperations.CheckLimits, activities.LimitCheck{
+			AccountID: params.AccountID,
+			Amount:    pendingCapture.OutboundTrx.Amount,
+			At:        workflow.Now(ctx),
+		}).Get(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pendingCapture.FencingTokens = fencingTokens
+
+	err = workflow.ExecuteActivity(ctx, holdOperations.PostCapture, *pendingCapture).Get(ctx, &hold)
+	if err != nil {
+		return nil, err
+	}
+
+	return hold, nil
+}
+
+// lockCaptureAccounts locks the held account and the destination of the hold, in the same order as a transfer
+// between them.
+func lockCaptureAccounts(
+	ctx workflow.Context,
+	cfg TransferEnvConfig,
+	params *HoldParams,
+	pendingCapture activities.PendingCapture,
+) (MutexReleaseFunc, map[uuid.UUID]int64, error) {
+	if workflow.GetVersion(ctx, holdCaptureAccountLockingVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return lockAccount(ctx, cfg, params.AccountID, params.ReferenceID)
+	}
+
+	accountIDs := []uuid.UUID{params.AccountID, pendingCapture.InboundTrx.AccountID}
+
+	return lockAccounts(ctx, cfg, params.ReferenceID, accountIDs, nil)
+}
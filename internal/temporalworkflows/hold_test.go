@@ -0,0 +1,207 @@
+//go:build tests_unit
+
+package temporalworkflows
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/holds"
+	"ulascansenturk/service/internal/limits"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+	"ulascansenturk/service/internal/transactions"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type holdTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (s *holdTestSuite) SetupSubTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	s.env.RegisterWorkflow(Hold)
+}
+
+func (s *holdTestSuite) TearDownSubTest() {
+	s.env.AssertExpectations(s.T())
+}
+
+func TestHold(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(holdTestSuite))
+}
+
+func (s *holdTestSuite) TestHoldWorkflow() {
+	startTime := time.Date(2024, 9, 27, 9, 0, 0, 0, time.UTC)
+
+	destinationAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+
+	params := &HoldParams{
+		HoldID:      uuid.New(),
+		ReferenceID: uuid.New(),
+		AccountID:   uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000"),
+		ExpiresAt:   startTime.Add(24 * time.Hour),
+	}
+
+	pending := &activities.PendingCapture{
+		HoldID:      params.HoldID,
+		OutboundTrx: &transactions.Transaction{ID: uuid.New(), AccountID: params.AccountID, Amount: 700},
+		InboundTrx:  &transactions.Transaction{ID: uuid.New(), AccountID: destinationAccountID, Amount: 700},
+	}
+
+	fenced := *pending
+	fenced.FencingTokens = map[uuid.UUID]int64{params.AccountID: 7, destinationAccountID: 7}
+
+	isReleasedAs := func(status constants.HoldStatus) interface{} {
+		return mock.MatchedBy(func(release activities.HoldRelease) bool {
+			return release.HoldID == params.HoldID && release.Status == status
+		})
+	}
+
+	s.Run("Hold is captured under the locks of the held account and its destination, taken in key order", func() {
+		var holdOperations *activities.HoldOperations
+		var mutex *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		amount := 700
+
+		var acquiredKeys []string
+
+		captured := *pending
+		captured.FencingTokens = map[uuid.UUID]int64{destinationAccountID: 1, params.AccountID: 2}
+
+		s.env.SetStartTime(startTime)
+		s.env.OnActivity(holdOperations.CreatePendingCapture, mock.Anything, mock.MatchedBy(func(captureParams activities.CaptureParams) bool {
+			return captureParams.HoldID == params.HoldID && *captureParams.Amount == amount
+		})).Return(pending, nil).Once()
+		s.env.OnActivity(mutex.AcquireLock, mock.Anything, mock.Anything).Return(func(_ context.Context, mutexParams activities.MutexParams) (int64, error) {
+			acquiredKeys = append(acquiredKeys, mutexParams.Key)
+
+			return int64(len(acquiredKeys)), nil
+		}).Times(2)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.MatchedBy(func(check activities.LimitCheck) bool {
+			return check.AccountID == params.AccountID && check.Amount == amount && !check.At.IsZero()
+		})).Return(nil).Once()
+		s.env.OnActivity(holdOperations.PostCapture, mock.Anything, captured).
+			Return(&holds.Hold{ID: params.HoldID, Status: constants.HoldStatusCAPTURED, CapturedAmount: amount}, nil).Once()
+		s.env.OnActivity(mutex.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Times(2)
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CaptureHoldSignal, CaptureHoldRequest{Amount: &amount})
+		}, time.Hour)
+
+		s.env.ExecuteWorkflow(Hold, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.Equal([]string{
+			"transfers_mutex_" + destinationAccountID.String(),
+			"transfers_mutex_" + params.AccountID.String(),
+		}, acquiredKeys)
+
+		var hold holds.Hold
+		s.NoError(s.env.GetWorkflowResult(&hold))
+		s.Equal(constants.HoldStatusCAPTURED, hold.Status)
+		s.Equal(amount, hold.CapturedAmount)
+	})
+
+	s.Run("Hold is voided by signal", func() {
+		var holdOperations *activities.HoldOperations
+
+		s.env.SetStartTime(startTime)
+		s.env.OnActivity(holdOperations.ReleaseHold, mock.Anything, mock.MatchedBy(func(release activities.HoldRelease) bool {
+			return release.Status == constants.HoldStatusVOIDED && *release.Reason == "order cancelled"
+		})).Return(&holds.Hold{ID: params.HoldID, Status: constants.HoldStatusVOIDED}, nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(VoidHoldSignal, VoidHoldRequest{Reason: "order cancelled"})
+		}, time.Hour)
+
+		s.env.ExecuteWorkflow(Hold, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Hold expires when nothing happens before its expiry", func() {
+		var holdOperations *activities.HoldOperations
+
+		s.env.SetStartTime(startTime)
+		s.env.OnActivity(holdOperations.ReleaseHold, mock.Anything, isReleasedAs(constants.HoldStatusEXPIRED)).
+			Return(&holds.Hold{ID: params.HoldID, Status: constants.HoldStatusEXPIRED}, nil).Once()
+
+		s.env.ExecuteWorkflow(Hold, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.False(s.env.Now().Before(params.ExpiresAt))
+	})
+
+	s.Run("Capture over a limit of the account voids the hold without posting", func() {
+		var holdOperations *activities.HoldOperations
+		var mutex *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		exceeded := limits.ExceededError{LimitType: constants.TransferLimitTypeDAILYAMOUNT, Allowed: 1000, Used: 500, Requested: 700}
+
+		s.env.SetStartTime(startTime)
+		s.env.OnActivity(holdOperations.CreatePendingCapture, mock.Anything, mock.Anything).Return(pending, nil).Once()
+		s.env.OnActivity(mutex.AcquireLock, mock.Anything, mock.Anything).Return(int64(7), nil).Times(2)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).
+			Return(temporal.NewNonRetryableApplicationError(exceeded.Error(), activities.TransferLimitExceededErrorType, nil, exceeded)).Once()
+		s.env.OnActivity(mutex.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Times(2)
+		s.env.OnActivity(holdOperations.FailCapture, mock.Anything, *pending).Return(nil).Once()
+		s.env.OnActivity(holdOperations.ReleaseHold, mock.Anything, isReleasedAs(constants.HoldStatusVOIDED)).
+			Return(&holds.Hold{ID: params.HoldID, Status: constants.HoldStatusVOIDED}, nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CaptureHoldSignal, CaptureHoldRequest{})
+		}, time.Minute)
+
+		s.env.ExecuteWorkflow(Hold, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(s.env.GetWorkflowError(), &applicationErr)
+		s.Equal(activities.TransferLimitExceededErrorType, applicationErr.Type())
+	})
+
+	s.Run("Capture that can't be posted voids the hold", func() {
+		var holdOperations *activities.HoldOperations
+		var mutex *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		s.env.SetStartTime(startTime)
+		s.env.OnActivity(holdOperations.CreatePendingCapture, mock.Anything, mock.Anything).Return(pending, nil).Once()
+		s.env.OnActivity(mutex.AcquireLock, mock.Anything, mock.Anything).Return(int64(7), nil).Times(2)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil).Once()
+		s.env.OnActivity(holdOperations.PostCapture, mock.Anything, fenced).
+			Return(nil, temporal.NewNonRetryableApplicationError("not postable", "hold-err", errors.New("not postable"))).Once()
+		s.env.OnActivity(mutex.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Times(2)
+		s.env.OnActivity(holdOperations.FailCapture, mock.Anything, *pending).Return(nil).Once()
+		s.env.OnActivity(holdOperations.ReleaseHold, mock.Anything, isReleasedAs(constants.HoldStatusVOIDED)).
+			Return(&holds.Hold{ID: params.HoldID, Status: constants.HoldStatusVOIDED}, nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CaptureHoldSignal, CaptureHoldRequest{})
+		}, time.Minute)
+
+		s.env.ExecuteWorkflow(Hold, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.ErrorContains(s.env.GetWorkflowError(), "not postable")
+	})
+}
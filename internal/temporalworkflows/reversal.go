@@ -0,0 +1,124 @@
+package temporalworkflows
+
+import (
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"github.com/google/uuid"
+	"github.com/ilyakaznacheev/cleanenv"
+	"go.temporal.io/sdk/workflow"
+)
+
+// ReversalParams reverses the transfer started with TransferReferenceID. Amount is in the currency of the
+// source account, the remaining amount of the transfer is reversed when it is nil.
+type ReversalParams struct {
+	ReferenceID         uuid.UUID
+	TransferReferenceID uuid.UUID
+	Amount              *int
+	IncludeFee          bool
+	Reason              string
+}
+
+// ReversalWorkflowID is the ID of the Reversal workflow of a reversal, it is prefixed so it never collides
+// with the ID of a Transfer workflow.
+func ReversalWorkflowID(referenceID uuid.UUID) string {
+	return "reversal-" + referenceID.String()
+}
+
+// reversalAccountLockingVersion marks the reversals that lock every account they post to, not only the debited one.
+const reversalAccountLockingVersion = "reversal-account-locking"
+
+// Reversal moves the money of a posted transfer back, fully or partially and optionally with its fee, through
+// linked reversal transactions. Every account the reversal posts to is locked like the accounts of a transfer.
+func Reversal(ctx workflow.Context, params *ReversalParams) (result *activities.ReversalResult, err error) {
+	var cfg TransferEnvConfig
+
+	err = cleanenv.ReadEnv(&cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, transferActivityOptions)
+
+	var (
+		reversalOperations *activities.ReversalOperations
+		pendingReversal    *activities.PendingReversal
+		compensations      saga
+	)
+
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		compensateErr := compensations.compensate(ctx)
+		if compensateErr != nil {
+			workflow.GetLogger(ctx).Error("Reversal compensation failed", "Error", compensateErr)
+		}
+	}()
+
+	transferParams := TransferParams{ReferenceId: params.TransferReferenceID}
+
+	reversalParams := activities.ReversalParams{
+		ReferenceID:                          params.ReferenceID,
+		TransferReferenceID:                  params.TransferReferenceID,
+		Amount:                               params.Amount,
+		IncludeFee:                           params.IncludeFee,
+		Reason:                               params.Reason,
+		OriginalSourceTransactionReferenceID: transferParams.SourceTransactionReferenceID(),
+		OriginalDestinationTransactionReferenceID: transferParams.DestinationTransactionReferenceID(),
+		OriginalFeeTransactionReferenceID:         transferParams.FeeTransactionReferenceID(),
+		OriginalIncomingFeeTransactionReferenceID: transferParams.IncomingFeeTransactionReferenceID(),
+		OutboundTransactionReferenceID:            getActivityReferenceID(params.ReferenceID, "reversal-outbound"),
+		InboundTransactionReferenceID:             getActivityReferenceID(params.ReferenceID, "reversal-inbound"),
+		FeeOutboundTransactionReferenceID:         getActivityReferenceID(params.ReferenceID, "fee-reversal-outbound"),
+		FeeInboundTransactionReferenceID:          getActivityReferenceID(params.ReferenceID, "fee-reversal-inbound"),
+	}
+
+	err = workflow.ExecuteActivity(ctx, reversalOperations.CreatePendingReversal, reversalParams).Get(ctx, &pendingReversal)
+	if err != nil {
+		return nil, err
+	}
+
+	compensations.addCompensation(reversalOperations.FailReversal, *pendingReversal)
+
+	releaseFunc, fencingTokens, err := lockReversalAccounts(ctx, cfg, params, *pendingReversal)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		releaseErr := releaseFunc()
+		if releaseErr != nil {
+			workflow.GetLogger(ctx).Error("Reversal mutex release failed", "Error", releaseErr)
+		}
+	}()
+
+	reversalParams.FencingTokens = fencingTokens
+
+	err = workflow.ExecuteActivity(ctx, reversalOperations.PostReversal, reversalParams, *pendingReversal).Get(ctx, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// lockReversalAccounts locks the destination of the transfer, its source and, when the fee is refunded, the fee
+// collection account, in the same order as a transfer between them.
+func lockReversalAccounts(
+	ctx workflow.Context,
+	cfg TransferEnvConfig,
+	params *ReversalParams,
+	pendingReversal activities.PendingReversal,
+) (MutexReleaseFunc, map[uuid.UUID]int64, error) {
+	if workflow.GetVersion(ctx, reversalAccountLockingVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return lockAccount(ctx, cfg, pendingReversal.OutboundTrx.AccountID, params.ReferenceID)
+	}
+
+	accountIDs := []uuid.UUID{pendingReversal.OutboundTrx.AccountID, pendingReversal.InboundTrx.AccountID}
+	if pendingReversal.FeeOutboundTrx != nil {
+		accountIDs = append(accountIDs, pendingReversal.FeeOutboundTrx.AccountID)
+	}
+
+	return lockAccounts(ctx, cfg, params.ReferenceID, accountIDs, nil)
+}
@@ -0,0 +1,125 @@
+//go:build tests_unit
+
+package temporalworkflows
+
+import (
+	"context"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"reflect"
+	"testing"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+	"ulascansenturk/service/internal/transactions"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type reversalTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (s *reversalTestSuite) SetupSubTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	s.env.RegisterWorkflow(Reversal)
+}
+
+func (s *reversalTestSuite) TearDownSubTest() {
+	s.env.AssertExpectations(s.T())
+}
+
+func TestReversal(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(reversalTestSuite))
+}
+
+func (s *reversalTestSuite) TestReversalWorkflow() {
+	transferReferenceID := uuid.New()
+	destinationAccountID := uuid.MustParse("cccccccc-0000-0000-0000-000000000000")
+	sourceAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	feeAccountID := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+
+	params := &ReversalParams{
+		ReferenceID:         uuid.New(),
+		TransferReferenceID: transferReferenceID,
+		IncludeFee:          true,
+		Reason:              "sent to the wrong account",
+	}
+
+	pending := &activities.PendingReversal{
+		OutboundTrx:    &transactions.Transaction{ID: uuid.New(), AccountID: destinationAccountID},
+		InboundTrx:     &transactions.Transaction{ID: uuid.New(), AccountID: sourceAccountID},
+		FeeOutboundTrx: &transactions.Transaction{ID: uuid.New(), AccountID: feeAccountID},
+		FeeInboundTrx:  &transactions.Transaction{ID: uuid.New(), AccountID: sourceAccountID},
+	}
+
+	transferParams := TransferParams{ReferenceId: transferReferenceID}
+
+	isReversalOfTransfer := mock.MatchedBy(func(reversalParams activities.ReversalParams) bool {
+		return reversalParams.OriginalSourceTransactionReferenceID == transferParams.SourceTransactionReferenceID() &&
+			reversalParams.OriginalFeeTransactionReferenceID == transferParams.FeeTransactionReferenceID() &&
+			reversalParams.IncludeFee && reversalParams.Reason == params.Reason
+	})
+
+	s.Run("Reversal locks every account it posts to in key order and posts with their fencing tokens", func() {
+		var reversalOperations *activities.ReversalOperations
+		var mutex *activities.MutexOperations
+
+		var acquiredKeys []string
+
+		s.env.OnActivity(reversalOperations.CreatePendingReversal, mock.Anything, isReversalOfTransfer).Return(pending, nil).Once()
+		s.env.OnActivity(mutex.AcquireLock, mock.Anything, mock.MatchedBy(func(mutexParams activities.MutexParams) bool {
+			return mutexParams.OwnershipToken == params.ReferenceID.String()
+		})).Return(func(_ context.Context, mutexParams activities.MutexParams) (int64, error) {
+			acquiredKeys = append(acquiredKeys, mutexParams.Key)
+
+			return int64(len(acquiredKeys)), nil
+		}).Times(3)
+		s.env.OnActivity(reversalOperations.PostReversal, mock.Anything, mock.MatchedBy(func(reversalParams activities.ReversalParams) bool {
+			return reflect.DeepEqual(reversalParams.FencingTokens, map[uuid.UUID]int64{
+				sourceAccountID:      1,
+				feeAccountID:         2,
+				destinationAccountID: 3,
+			})
+		}), *pending).Return(&activities.ReversalResult{ReferenceID: params.ReferenceID}, nil).Once()
+		s.env.OnActivity(mutex.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Times(3)
+
+		s.env.ExecuteWorkflow(Reversal, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.Equal([]string{
+			"transfers_mutex_" + sourceAccountID.String(),
+			"transfers_mutex_" + feeAccountID.String(),
+			"transfers_mutex_" + destinationAccountID.String(),
+		}, acquiredKeys)
+
+		var result activities.ReversalResult
+		s.NoError(s.env.GetWorkflowResult(&result))
+		s.Equal(params.ReferenceID, result.ReferenceID)
+	})
+
+	s.Run("Reversal that can't be posted fails its pending transactions", func() {
+		var reversalOperations *activities.ReversalOperations
+		var mutex *activities.MutexOperations
+
+		s.env.OnActivity(reversalOperations.CreatePendingReversal, mock.Anything, mock.Anything).Return(pending, nil).Once()
+		s.env.OnActivity(mutex.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil).Times(3)
+		s.env.OnActivity(reversalOperations.PostReversal, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, temporal.NewNonRetryableApplicationError("insufficient funds", "reversal-err", errors.New("insufficient funds"))).Once()
+		s.env.OnActivity(mutex.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Times(3)
+		s.env.OnActivity(reversalOperations.FailReversal, mock.Anything, *pending).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(Reversal, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.Error(s.env.GetWorkflowError())
+	})
+}
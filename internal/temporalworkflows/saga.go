@@ -0,0 +1,56 @@
+package temporalworkflows
+
+import (
+	"errors"
+	"time"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// compensationActivityOptions retries the undo actions more patiently than the transfer steps,
+// a compensation that gives up leaves the accounts in the state the saga is trying to fix.
+var compensationActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: time.Minute,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    time.Minute,
+		MaximumAttempts:    20,
+	},
+}
+
+type compensation struct {
+	activity interface{}
+	args     []interface{}
+}
+
+// saga keeps the undo actions of the workflow steps that already completed.
+type saga struct {
+	compensations []compensation
+}
+
+// addCompensation registers the activity that undoes the step that has just completed.
+func (s *saga) addCompensation(activity interface{}, args ...interface{}) {
+	s.compensations = append(s.compensations, compensation{activity: activity, args: args})
+}
+
+// compensate runs the registered undo actions one by one in reverse order.
+// A disconnected context is used so the compensations still run when the workflow is cancelled.
+func (s *saga) compensate(ctx workflow.Context) error {
+	disconnectedCtx, _ := workflow.NewDisconnectedContext(ctx)
+	disconnectedCtx = workflow.WithActivityOptions(disconnectedCtx, compensationActivityOptions)
+
+	var compensateErr error
+
+	for i := len(s.compensations) - 1; i >= 0; i-- {
+		c := s.compensations[i]
+
+		err := workflow.ExecuteActivity(disconnectedCtx, c.activity, c.args...).Get(disconnectedCtx, nil)
+		if err != nil {
+			compensateErr = errors.Join(compensateErr, err)
+		}
+	}
+
+	return compensateErr
+}
@@ -0,0 +1,123 @@
+package temporalworkflows
+
+import (
+	"time"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/constants"
+
+	"github.com/google/uuid"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const (
+	// TransferStateQuery returns the TransferState of a Transfer workflow.
+	TransferStateQuery = "transfer-state"
+	// RescheduleTransferSignal moves the execution time of a scheduled transfer, see RescheduleTransferRequest.
+	RescheduleTransferSignal = "reschedule-transfer"
+	// CancelTransferSignal cancels a transfer that has not started moving money, see CancelTransferRequest.
+	// It is honoured while the transfer waits for its execution time or the account lock, and up to the posting.
+	CancelTransferSignal = "cancel-transfer"
+
+	TransferCancelledErrorType = "transfer-cancelled"
+)
+
+// TransferState is what the Transfer workflow reports through TransferStateQuery.
+type TransferState struct {
+	ReferenceID          uuid.UUID
+	Status               constants.TransferState
+	ExecuteAt            *time.Time
+	Amount               int
+	SourceAccountID      uuid.UUID
+	DestinationAccountID uuid.UUID
+	CancellationReason   string
+	ApprovalThreshold    *int
+	Approval             *approvals.Decision
+	// LockWait is how long the transfer waited for the locks of its accounts.
+	LockWait time.Duration
+}
+
+type RescheduleTransferRequest struct {
+	ExecuteAt time.Time
+}
+
+type CancelTransferRequest struct {
+	Reason string
+}
+
+// checkCancelled returns the cancellation error when a cancel signal is pending, without blocking.
+func checkCancelled(ctx workflow.Context, state *TransferState) error {
+	var request CancelTransferRequest
+
+	if !workflow.GetSignalChannel(ctx, CancelTransferSignal).ReceiveAsync(&request) {
+		return nil
+	}
+
+	state.CancellationReason = request.Reason
+
+	return transferCancelledError(state)
+}
+
+// transferCancelledError moves the transfer to CANCELLED, the cancellation reason is kept in the error details.
+func transferCancelledError(state *TransferState) error {
+	state.Status = constants.TransferStateCANCELLED
+
+	return temporal.NewNonRetryableApplicationError("transfer cancelled", TransferCancelledErrorType, nil, state.CancellationReason)
+}
+
+// waitForExecuteAt blocks on a durable timer until the scheduled execution time of the transfer.
+// A reschedule signal restarts the timer with the new time, a cancel signal ends the wait with a cancellation error.
+func waitForExecuteAt(ctx workflow.Context, state *TransferState) error {
+	rescheduleCh := workflow.GetSignalChannel(ctx, RescheduleTransferSignal)
+	cancelCh := workflow.GetSignalChannel(ctx, CancelTransferSignal)
+
+	for state.ExecuteAt != nil {
+		delay := state.ExecuteAt.Sub(workflow.Now(ctx))
+		if delay <= 0 {
+			return nil
+		}
+
+		state.Status = constants.TransferStateSCHEDULED
+
+		timerCtx, cancelTimer := workflow.WithCancel(ctx)
+		timer := workflow.NewTimer(timerCtx, delay)
+
+		var (
+			timerFired bool
+			cancelled  bool
+		)
+
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(timer, func(f workflow.Future) {
+			timerFired = f.Get(ctx, nil) == nil
+		})
+		selector.AddReceive(rescheduleCh, func(c workflow.ReceiveChannel, _ bool) {
+			var request RescheduleTransferRequest
+			c.Receive(ctx, &request)
+
+			workflow.GetLogger(ctx).Info("Transfer rescheduled", "ExecuteAt", request.ExecuteAt)
+
+			state.ExecuteAt = &request.ExecuteAt
+		})
+		selector.AddReceive(cancelCh, func(c workflow.ReceiveChannel, _ bool) {
+			var request CancelTransferRequest
+			c.Receive(ctx, &request)
+
+			cancelled = true
+			state.CancellationReason = request.Reason
+		})
+
+		selector.Select(ctx)
+		cancelTimer()
+
+		if cancelled {
+			return transferCancelledError(state)
+		}
+
+		if timerFired {
+			return nil
+		}
+	}
+
+	return nil
+}
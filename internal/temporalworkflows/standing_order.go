@@ -0,0 +1,98 @@
+package temporalworkflows
+
+import (
+	"time"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/standingorders"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"github.com/google/uuid"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+type StandingOrderOccurrenceParams struct {
+	StandingOrderID      uuid.UUID
+	Amount               int
+	Metadata             map[string]interface{}
+	SourceAccountID      uuid.UUID
+	DestinationAccountID uuid.UUID
+}
+
+var standingOrderActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: time.Minute,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    time.Minute,
+		MaximumAttempts:    10,
+	},
+}
+
+// StandingOrderReferenceID derives the reference ID of a standing order occurrence from the ID of the workflow
+// started by the schedule. Temporal suffixes that ID with the nominal time of the occurrence, so a retried or
+// re-delivered occurrence always gets the same reference ID and the Transfer workflow runs at most once.
+func StandingOrderReferenceID(standingOrderID uuid.UUID, occurrenceWorkflowID string) uuid.UUID {
+	return getActivityReferenceID(standingOrderID, occurrenceWorkflowID)
+}
+
+// StandingOrderOccurrence runs one occurrence of a standing order as a child Transfer workflow and records
+// its outcome. A failed transfer does not fail the occurrence, it is recorded and the notification hook is called.
+func StandingOrderOccurrence(ctx workflow.Context, params StandingOrderOccurrenceParams) error {
+	var standingOrderOperations *activities.StandingOrderOperations
+
+	workflowID := workflow.GetInfo(ctx).WorkflowExecution.ID
+	referenceID := StandingOrderReferenceID(params.StandingOrderID, workflowID)
+
+	metadata := map[string]interface{}{}
+	for key, value := range params.Metadata {
+		metadata[key] = value
+	}
+	metadata["StandingOrderID"] = params.StandingOrderID.String()
+
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:            referenceID.String(),
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+	})
+
+	transferErr := workflow.ExecuteChildWorkflow(childCtx, Transfer, &TransferParams{
+		ReferenceId:          referenceID,
+		Amount:               params.Amount,
+		Metadata:             &metadata,
+		SourceAccountID:      params.SourceAccountID,
+		DestinationAccountID: params.DestinationAccountID,
+	}).Get(ctx, nil)
+
+	occurrence := standingorders.Occurrence{
+		ID:              getActivityReferenceID(referenceID, "standing-order-occurrence"),
+		StandingOrderID: params.StandingOrderID,
+		ReferenceID:     referenceID,
+		WorkflowID:      workflowID,
+		Status:          constants.TransferStateCOMPLETED,
+		CreatedAt:       workflow.Now(ctx),
+	}
+
+	if transferErr != nil {
+		failureReason := transferErr.Error()
+
+		occurrence.Status = constants.TransferStateFAILED
+		occurrence.FailureReason = &failureReason
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, standingOrderActivityOptions)
+
+	err := workflow.ExecuteActivity(ctx, standingOrderOperations.RecordOccurrence, occurrence).Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if transferErr != nil {
+		notifyErr := workflow.ExecuteActivity(ctx, standingOrderOperations.NotifyOccurrenceFailed, occurrence).Get(ctx, nil)
+		if notifyErr != nil {
+			workflow.GetLogger(ctx).Error("Standing order failure notification failed", "Error", notifyErr)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+//go:build tests_unit
+
+package temporalworkflows
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/standingorders"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"go.temporal.io/sdk/testsuite"
+)
+
+type standingOrdersTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (s *standingOrdersTestSuite) SetupSubTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	s.env.RegisterWorkflow(Transfer)
+	s.env.RegisterWorkflow(StandingOrderOccurrence)
+	s.env.RegisterWorkflow(TransferNotifications)
+
+	s.env.OnWorkflow(TransferNotifications, mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+func (s *standingOrdersTestSuite) TearDownSubTest() {
+	s.env.AssertExpectations(s.T())
+}
+
+func TestStandingOrderOccurrence(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(standingOrdersTestSuite))
+}
+
+func (s *standingOrdersTestSuite) TestStandingOrderOccurrenceWorkflow() {
+	standingOrderID := uuid.New()
+	params := StandingOrderOccurrenceParams{
+		StandingOrderID:      standingOrderID,
+		Amount:               1000,
+		SourceAccountID:      uuid.New(),
+		DestinationAccountID: uuid.New(),
+	}
+
+	s.Run("Occurrence runs the transfer with a reference ID derived from the workflow ID", func() {
+		var standingOrderOperations *activities.StandingOrderOperations
+
+		s.env.OnWorkflow(Transfer, mock.Anything, mock.MatchedBy(func(transferParams *TransferParams) bool {
+			return transferParams.ReferenceId == StandingOrderReferenceID(standingOrderID, "default-test-workflow-id") &&
+				(*transferParams.Metadata)["StandingOrderID"] == standingOrderID.String()
+		})).Return(&activities.TransferResult{}, nil)
+
+		s.env.OnActivity(standingOrderOperations.RecordOccurrence, mock.Anything, mock.MatchedBy(func(occurrence standingorders.Occurrence) bool {
+			return occurrence.Status == constants.TransferStateCOMPLETED && occurrence.FailureReason == nil
+		})).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(StandingOrderOccurrence, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Failed occurrence is recorded and notified", func() {
+		var standingOrderOperations *activities.StandingOrderOperations
+
+		s.env.OnWorkflow(Transfer, mock.Anything, mock.Anything).Return(nil, errors.New("insufficient funds"))
+
+		s.env.OnActivity(standingOrderOperations.RecordOccurrence, mock.Anything, mock.MatchedBy(func(occurrence standingorders.Occurrence) bool {
+			return occurrence.Status == constants.TransferStateFAILED && occurrence.FailureReason != nil
+		})).Return(nil).Once()
+		s.env.OnActivity(standingOrderOperations.NotifyOccurrenceFailed, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(StandingOrderOccurrence, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+}
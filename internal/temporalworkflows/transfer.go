@@ -1,35 +1,88 @@
 package temporalworkflows
 
 import (
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
 	"github.com/ilyakaznacheev/cleanenv"
 	"go.temporal.io/sdk/temporal"
 	"go.temporal.io/sdk/workflow"
+	"sort"
 	"time"
 	"ulascansenturk/service/internal/api/server"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/fees"
+	"ulascansenturk/service/internal/fx"
+	"ulascansenturk/service/internal/notifications"
 	"ulascansenturk/service/internal/temporalworkflows/activities"
 )
 
 type TransferParams server.TransferWorkflowParams
 
 type TransferEnvConfig struct {
-	TransferMutexTTLSeconds int `env:"TRANSFER_MUTEX_TTL_SECONDS" env-default:"300"`
+	TransferMutexTTLSeconds        int `env:"TRANSFER_MUTEX_TTL_SECONDS" env-default:"300"`
+	TransferApprovalTimeoutSeconds int `env:"TRANSFER_APPROVAL_TIMEOUT_SECONDS" env-default:"86400"`
+	// TransferAccountEntityWorkflows serializes the writes to an account with its AccountEntity workflow
+	// instead of the Mutex
+	TransferAccountEntityWorkflows bool `env:"TRANSFER_ACCOUNT_ENTITY_WORKFLOWS" env-default:"false"`
 }
 
-func (p *TransferParams) sourceTransactionReferenceID() uuid.UUID {
+func (p *TransferParams) SourceTransactionReferenceID() uuid.UUID {
 	return getActivityReferenceID(p.ReferenceId, "transfer-source")
 }
 
-func (p *TransferParams) destinationTransactionReferenceID() uuid.UUID {
+func (p *TransferParams) DestinationTransactionReferenceID() uuid.UUID {
 	return getActivityReferenceID(p.ReferenceId, "transfer-destination")
 }
 
-func (p *TransferParams) feeTransactionReferenceID() uuid.UUID {
+func (p *TransferParams) FeeTransactionReferenceID() uuid.UUID {
 	return getActivityReferenceID(p.ReferenceId, "transfer-fee")
 }
 
-func Transfer(ctx workflow.Context, params *TransferParams) (*activities.TransferResult, error) {
+func (p *TransferParams) IncomingFeeTransactionReferenceID() uuid.UUID {
+	return getActivityReferenceID(p.ReferenceId, "transfer-fee-collection")
+}
+
+// transferActivityOptions bounds the retries of every transfer step, once a step fails for good
+// the completed steps are compensated.
+var transferActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: time.Minute,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    30 * time.Second,
+		MaximumAttempts:    5,
+	},
+}
+
+// transferStepsVersion marks the workflows that run the transfer in steps and compensate the completed ones.
+const transferStepsVersion = "transfer-steps"
+
+// transferPostingVersion marks the workflows that post the transfer in a single database transaction.
+const transferPostingVersion = "transfer-posting"
+
+// transferFeeScheduleVersion marks the workflows that take the fee of the transfer from the fee schedule.
+const transferFeeScheduleVersion = "transfer-fee-schedule"
+
+// transferScheduleVersion marks the workflows that wait for the execute_at of a scheduled transfer.
+const transferScheduleVersion = "transfer-schedule"
+
+// transferFXVersion marks the workflows that quote the conversion of a cross-currency transfer.
+const transferFXVersion = "transfer-fx"
+
+// transferCancellationVersion marks the workflows that can be cancelled until the balances move, not only while
+// they are scheduled.
+const transferCancellationVersion = "transfer-cancellation"
+
+// transferApprovalVersion marks the workflows that check whether the transfer needs approval and only lock the
+// accounts once it is ready to post.
+const transferApprovalVersion = "transfer-approval"
+
+// transferLimitsVersion marks the workflows that check the transfer against the limits of the source account.
+const transferLimitsVersion = "transfer-limits"
+
+func Transfer(ctx workflow.Context, params *TransferParams) (result *activities.TransferResult, err error) {
 	var cfg TransferEnvConfig
 
 	readCfgErr := cleanenv.ReadEnv(&cfg)
@@ -37,34 +90,280 @@ func Transfer(ctx workflow.Context, params *TransferParams) (*activities.Transfe
 		return nil, readCfgErr
 	}
 
-	releaseFunc, mutexErr := mutexLock(ctx, cfg, params)
-	if mutexErr != nil {
-		return nil, mutexErr
+	state := &TransferState{
+		ReferenceID:          params.ReferenceId,
+		Status:               constants.TransferStatePROCESSING,
+		ExecuteAt:            params.ExecuteAt,
+		Amount:               params.Amount,
+		SourceAccountID:      params.SourceAccountID,
+		DestinationAccountID: params.DestinationAccountID,
 	}
 
-	defer releaseFunc()
+	err = workflow.SetQueryHandler(ctx, TransferStateQuery, func() (TransferState, error) {
+		return *state, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	options := workflow.ActivityOptions{
-		StartToCloseTimeout: time.Minute,
+	notice := notifications.TransferNotice{
+		ReferenceID:          params.ReferenceId,
+		SourceAccountID:      params.SourceAccountID,
+		DestinationAccountID: params.DestinationAccountID,
+		Amount:               params.Amount,
+		DestinationAmount:    params.Amount,
 	}
-	ctx = workflow.WithActivityOptions(ctx, options)
+
+	// Deferred before the status is settled so it runs after it, a cancelled or rejected transfer isn't notified.
+	defer func() {
+		switch state.Status {
+		case constants.TransferStateCOMPLETED:
+			notice.At = workflow.Now(ctx)
+			notifyTransfer(ctx, notice)
+		case constants.TransferStateFAILED:
+			reason := transferFailureReason(err)
+			notice.At = workflow.Now(ctx)
+			notice.FailureReason = &reason
+			notifyTransfer(ctx, notice)
+		}
+	}()
+
+	defer func() {
+		switch {
+		case err == nil:
+			state.Status = constants.TransferStateCOMPLETED
+		case state.Status != constants.TransferStateCANCELLED && state.Status != constants.TransferStateREJECTED:
+			state.Status = constants.TransferStateFAILED
+		}
+	}()
+
+	// Workflows started before transfers could be scheduled run right away.
+	if workflow.GetVersion(ctx, transferScheduleVersion, workflow.DefaultVersion, 1) != workflow.DefaultVersion {
+		err = waitForExecuteAt(ctx, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	state.Status = constants.TransferStatePROCESSING
+
+	// Workflows started before transfers could be cancelled until the balances move ignore a cancel signal from here
+	// on, they wait for the account locks without a state to cancel.
+	lockState := state
+	cancellable := workflow.GetVersion(ctx, transferCancellationVersion, workflow.DefaultVersion, 1) != workflow.DefaultVersion
+	if !cancellable {
+		lockState = nil
+	}
+
+	var (
+		releaseFunc   MutexReleaseFunc
+		fencingTokens map[uuid.UUID]int64
+	)
+
+	// Workflows started before transfers could await approval lock the accounts before anything else and are never
+	// held for approval.
+	approvalVersion := workflow.GetVersion(ctx, transferApprovalVersion, workflow.DefaultVersion, 1)
+	if approvalVersion == workflow.DefaultVersion {
+		releaseFunc, fencingTokens, err = mutexLock(ctx, cfg, params, activities.PendingTransactions{}, lockState)
+		if err != nil {
+			return nil, err
+		}
+
+		defer releaseTransferLocks(ctx, releaseFunc)
+	}
+
+	ctx = workflow.WithActivityOptions(ctx, transferActivityOptions)
 	ctx = workflow.WithWorkflowID(ctx, getWorkflowReferenceID(params.ReferenceId).String())
 
 	var (
+		feeOperations         *activities.FeeOperations
+		feeQuote              *fees.Quote
+		fxOperations          *activities.FXOperations
+		fxQuote               *fx.Quote
+		approvalOperations    *activities.ApprovalOperations
+		approvalRequirement   *approvals.Requirement
+		limitOperations       *activities.LimitOperations
 		transactionOperations *activities.TransactionOperations
+		pendingTransactions   *activities.PendingTransactions
 		transactionsResult    *activities.TransferResult
+		compensations         saga
 	)
 
-	err := workflow.ExecuteActivity(ctx, transactionOperations.Transfer, activities.TransferParams{
+	defer func() {
+		if err == nil {
+			return
+		}
+
+		compensateErr := compensations.compensate(ctx)
+		if compensateErr != nil {
+			workflow.GetLogger(ctx).Error("Transfer compensation failed", "Error", compensateErr)
+		}
+	}()
+
+	// The fee always comes from the fee schedule, a fee_amount sent by the client is ignored. Workflows started
+	// before the fee schedule keep the fee_amount they were started with.
+	if workflow.GetVersion(ctx, transferFeeScheduleVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		feeQuote = &fees.Quote{}
+		if params.FeeAmount != nil {
+			feeQuote.Amount = *params.FeeAmount
+		}
+	} else {
+		err = workflow.ExecuteActivity(ctx, feeOperations.CalculateFee, fees.QuoteRequest{
+			SourceAccountID: params.SourceAccountID,
+			Amount:          params.Amount,
+			At:              workflow.Now(ctx),
+		}).Get(ctx, &feeQuote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var feeAmount *int
+	if feeQuote.Amount > 0 {
+		feeAmount = &feeQuote.Amount
+	}
+
+	notice.FeeAmount = feeQuote.Amount
+
+	// The conversion is quoted once, retried steps reuse the quote so the rate stays locked for the whole transfer.
+	// Workflows started before cross-currency transfers go without a quote.
+	if workflow.GetVersion(ctx, transferFXVersion, workflow.DefaultVersion, 1) != workflow.DefaultVersion {
+		err = workflow.ExecuteActivity(ctx, fxOperations.QuoteConversion, fx.QuoteRequest{
+			SourceAccountID:      params.SourceAccountID,
+			DestinationAccountID: params.DestinationAccountID,
+			Amount:               params.Amount,
+			At:                   workflow.Now(ctx),
+		}).Get(ctx, &fxQuote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if fxQuote != nil {
+		notice.DestinationAmount = fxQuote.TargetAmount
+	}
+
+	if cancellable {
+		err = checkCancelled(ctx, state)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transferParams := activities.TransferParams{
 		Amount:                            params.Amount,
-		FeeAmount:                         params.FeeAmount,
+		FeeAmount:                         feeAmount,
+		FeeRule:                           feeQuote.Rule,
+		FXQuote:                           fxQuote,
 		Metadata:                          params.Metadata,
 		DestinationAccountID:              params.DestinationAccountID,
-		SourceTransactionReferenceID:      params.sourceTransactionReferenceID(),
-		DestinationTransactionReferenceID: params.destinationTransactionReferenceID(),
-		FeeTransactionReferenceID:         params.feeTransactionReferenceID(),
+		SourceTransactionReferenceID:      params.SourceTransactionReferenceID(),
+		DestinationTransactionReferenceID: params.DestinationTransactionReferenceID(),
+		FeeTransactionReferenceID:         params.FeeTransactionReferenceID(),
+		IncomingFeeTransactionReferenceID: params.IncomingFeeTransactionReferenceID(),
 		SourceAccountID:                   params.SourceAccountID,
-	}).Get(ctx, &transactionsResult)
+	}
+
+	// Workflows started before the transfer was run in steps create and post its transactions in one activity.
+	if workflow.GetVersion(ctx, transferStepsVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		err = workflow.ExecuteActivity(ctx, transactionOperations.Transfer, transferParams).Get(ctx, &transactionsResult)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactionsResult, nil
+	}
+
+	err = workflow.ExecuteActivity(ctx, transactionOperations.CreatePendingTransactions, transferParams).Get(ctx, &pendingTransactions)
+	if err != nil {
+		return nil, err
+	}
+
+	compensations.addCompensation(transactionOperations.FailTransactions, *pendingTransactions)
+
+	if approvalVersion != workflow.DefaultVersion {
+		err = workflow.ExecuteActivity(ctx, approvalOperations.CheckApproval, activities.ApprovalCheck{
+			Currency: feeQuote.Currency,
+			Amount:   params.Amount,
+		}).Get(ctx, &approvalRequirement)
+		if err != nil {
+			return nil, err
+		}
+
+		if approvalRequirement.Required {
+			err = approveTransfer(ctx, cfg, state, *approvalRequirement, *pendingTransactions)
+			if err != nil {
+				if state.Status == constants.TransferStateCANCELLED {
+					compensations.addCompensation(transactionOperations.CancelTransactions, *pendingTransactions, state.CancellationReason)
+				}
+
+				return nil, err
+			}
+		}
+
+		// The account locks are only taken once the transfer is ready to post, a transfer awaiting approval doesn't hold them.
+		releaseFunc, fencingTokens, err = mutexLock(ctx, cfg, params, *pendingTransactions, lockState)
+		if err != nil {
+			if state.Status == constants.TransferStateCANCELLED {
+				compensations.addCompensation(transactionOperations.CancelTransactions, *pendingTransactions, state.CancellationReason)
+			}
+
+			return nil, err
+		}
+
+		defer releaseTransferLocks(ctx, releaseFunc)
+	}
+
+	// Last chance to cancel, the pending transactions are failed with the cancellation reason by the compensations.
+	if cancellable {
+		err = checkCancelled(ctx, state)
+		if err != nil {
+			compensations.addCompensation(transactionOperations.CancelTransactions, *pendingTransactions, state.CancellationReason)
+
+			return nil, err
+		}
+	}
+
+	// Limits are checked under the lock so no other transfer out of the account is posted in between. Workflows
+	// started before the limits post without the check.
+	if workflow.GetVersion(ctx, transferLimitsVersion, workflow.DefaultVersion, 1) != workflow.DefaultVersion {
+		err = workflow.ExecuteActivity(ctx, limitOperations.CheckLimits, activities.LimitCheck{
+			AccountID: params.SourceAccountID,
+			Amount:    params.Amount,
+			At:        workflow.Now(ctx),
+		}).Get(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transferParams.FencingTokens = fencingTokens
+
+	// Workflows started before the transfer was posted in a single database transaction move the balances in steps.
+	if workflow.GetVersion(ctx, transferPostingVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		err = workflow.ExecuteActivity(ctx, transactionOperations.DebitSourceAccount, transferParams).Get(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		compensations.addCompensation(transactionOperations.RefundSourceAccount, transferParams)
+
+		err = workflow.ExecuteActivity(ctx, transactionOperations.CreditDestinationAccount, transferParams).Get(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		compensations.addCompensation(transactionOperations.ReverseDestinationCredit, transferParams)
+
+		err = workflow.ExecuteActivity(ctx, transactionOperations.FinalizeTransactions, transferParams, *pendingTransactions).Get(ctx, &transactionsResult)
+		if err != nil {
+			return nil, err
+		}
+
+		return transactionsResult, nil
+	}
+
+	err = workflow.ExecuteActivity(ctx, transactionOperations.PostTransfer, transferParams, *pendingTransactions).Get(ctx, &transactionsResult)
 	if err != nil {
 		return nil, err
 	}
@@ -73,54 +372,444 @@ func Transfer(ctx workflow.Context, params *TransferParams) (*activities.Transfe
 		SourceTransactionReferenceID:      transactionsResult.SourceTransactionReferenceID,
 		DestinationTransactionReferenceID: transactionsResult.DestinationTransactionReferenceID,
 		FeeTransactionReferenceID:         transactionsResult.FeeTransactionReferenceID,
+		IncomingFeeTransactionReferenceID: transactionsResult.IncomingFeeTransactionReferenceID,
 		FeeTransaction:                    transactionsResult.FeeTransaction,
+		IncomingFeeTransaction:            transactionsResult.IncomingFeeTransaction,
 		SourceTransaction:                 transactionsResult.SourceTransaction,
 		DestinationTransaction:            transactionsResult.DestinationTransaction,
+		FXQuote:                           transactionsResult.FXQuote,
 	}, nil
 
 }
 
-type MutexReleaseFunc func() error
+// releaseTransferLocks releases the account locks of the transfer, a failed release is only logged as the locks
+// expire with their TTL anyway.
+func releaseTransferLocks(ctx workflow.Context, releaseFunc MutexReleaseFunc) {
+	releaseErr := releaseFunc()
+	if releaseErr != nil {
+		workflow.GetLogger(ctx).Error("Transfer mutex release failed", "Error", releaseErr)
+	}
+}
 
-func mutexLock(ctx workflow.Context, cfg TransferEnvConfig, params *TransferParams) (MutexReleaseFunc, error) {
+// approveTransfer waits for the approval decision, records it and reflects it in the pending transactions.
+// A rejected transfer returns a rejection error, its transactions are already failed with the decision.
+func approveTransfer(
+	ctx workflow.Context,
+	cfg TransferEnvConfig,
+	state *TransferState,
+	requirement approvals.Requirement,
+	pendingTransactions activities.PendingTransactions,
+) error {
 	var (
-		mutex *activities.Mutex
+		approvalOperations    *activities.ApprovalOperations
+		transactionOperations *activities.TransactionOperations
 	)
 
-	mutexCtx := workflow.WithActivityOptions(
-		ctx, workflow.ActivityOptions{
-			StartToCloseTimeout: 2 * time.Minute,
-			RetryPolicy: &temporal.RetryPolicy{
-				InitialInterval:    5 * time.Second,
-				BackoffCoefficient: 1.0,
-				MaximumInterval:    10 * time.Second,
-			},
-		},
-	)
-	mutexParams := activities.MutexParams{
-		Key:            fmt.Sprintf("transfers_mutex_%s", params.SourceAccountID.String()),
-		OwnershipToken: params.ReferenceId.String(),
-		TTL:            time.Duration(cfg.TransferMutexTTLSeconds) * time.Second,
+	decision, err := awaitApproval(ctx, state, requirement, time.Duration(cfg.TransferApprovalTimeoutSeconds)*time.Second)
+	if err != nil {
+		return err
 	}
 
-	acquireLockErr := workflow.ExecuteActivity(
-		mutexCtx,
-		mutex.AcquireLock,
-		mutexParams,
-	).Get(ctx, nil)
-	if acquireLockErr != nil {
-		return nil, acquireLockErr
+	err = workflow.ExecuteActivity(ctx, approvalOperations.RecordDecision, *decision).Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	err = workflow.ExecuteActivity(ctx, transactionOperations.ApplyApprovalDecision, pendingTransactions, *decision).Get(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if decision.Decision == constants.ApprovalDecisionREJECTED {
+		return transferRejectedError(state)
+	}
+
+	state.Status = constants.TransferStatePROCESSING
+
+	return nil
+}
+
+type MutexReleaseFunc func() error
+
+// transferAccountLockingVersion marks the workflows that lock every account the transfer moves money between.
+const transferAccountLockingVersion = "transfer-account-locking"
+
+// transferLockWaitMetric is the time a transfer waited for the locks of its accounts.
+const transferLockWaitMetric = "transfer_lock_wait"
+
+// accountLockingVersion marks the workflows that choose how they lock accounts with accountLocksFor.
+const accountLockingVersion = "account-locking"
+
+// accountLocks serializes the workflows writing to the same accounts.
+type accountLocks interface {
+	// acquire starts acquiring the lock of the account, the future is ready with its fencing token once the
+	// lock is held. A lock that can't be taken over comes without a token.
+	acquire(ctx workflow.Context, accountID uuid.UUID) workflow.Future
+	// abandon gives up an acquisition that didn't complete.
+	abandon(ctx workflow.Context, accountID uuid.UUID)
+	// hold keeps the acquired locks until the returned function releases them.
+	hold(ctx workflow.Context, accountIDs []uuid.UUID) MutexReleaseFunc
+	// release releases the acquired locks.
+	release(ctx workflow.Context, accountIDs []uuid.UUID) error
+}
+
+// accountLocksFor returns the turns of the AccountEntity workflows when TRANSFER_ACCOUNT_ENTITY_WORKFLOWS is set,
+// the Mutex locks otherwise. The choice is recorded, so a workflow keeps it when the setting changes.
+func accountLocksFor(ctx workflow.Context, cfg TransferEnvConfig, ownerReferenceID uuid.UUID) accountLocks {
+	useAccountEntities := false
+
+	if workflow.GetVersion(ctx, accountLockingVersion, workflow.DefaultVersion, 1) == 1 {
+		_ = workflow.SideEffect(ctx, func(workflow.Context) interface{} {
+			return cfg.TransferAccountEntityWorkflows
+		}).Get(&useAccountEntities)
 	}
 
+	if useAccountEntities {
+		return newEntityAccountLocks(ctx)
+	}
+
+	return newMutexAccountLocks(ctx, cfg, ownerReferenceID)
+}
+
+// mutexLock acquires the locks of every account the transfer moves money between: the source, the destination
+// and the fee collection account. When the state of the transfer is given, a cancel signal received while waiting
+// stops the wait. It returns the fencing token of the lock of every account that has one.
+func mutexLock(
+	ctx workflow.Context,
+	cfg TransferEnvConfig,
+	params *TransferParams,
+	pendingTransactions activities.PendingTransactions,
+	state *TransferState,
+) (MutexReleaseFunc, map[uuid.UUID]int64, error) {
+	accountIDs := []uuid.UUID{params.SourceAccountID, params.DestinationAccountID}
+	if pendingTransactions.IncomingFeeTrx != nil {
+		accountIDs = append(accountIDs, pendingTransactions.IncomingFeeTrx.AccountID)
+	}
+
+	// Workflows started before every account of the transfer was locked only lock its source account.
+	if workflow.GetVersion(ctx, transferAccountLockingVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		accountIDs = []uuid.UUID{params.SourceAccountID}
+	}
+
+	return lockAccounts(ctx, cfg, params.ReferenceId, accountIDs, state)
+}
+
+// lockAccounts acquires the locks of the accounts one at a time in the order of their keys, so workflows locking
+// the same accounts, e.g. a transfer from A to B and one from B to A, always wait on each other in the same order
+// and never deadlock. When the state of a transfer is given, a cancel signal received while waiting stops the
+// wait, the locks already taken and the one being acquired are then released. It returns the fencing token of
+// the lock of every account that has one.
+func lockAccounts(
+	ctx workflow.Context,
+	cfg TransferEnvConfig,
+	ownerReferenceID uuid.UUID,
+	accountIDs []uuid.UUID,
+	state *TransferState,
+) (MutexReleaseFunc, map[uuid.UUID]int64, error) {
+	accountIDs = lockOrder(accountIDs...)
+
+	locks := accountLocksFor(ctx, cfg, ownerReferenceID)
+	acquired := make([]uuid.UUID, 0, len(accountIDs))
+	fencingTokens := make(map[uuid.UUID]int64, len(accountIDs))
+
+	waitStartedAt := workflow.Now(ctx)
+
+	for _, accountID := range accountIDs {
+		var (
+			fencingToken   int64
+			acquireLockErr error
+			cancelErr      error
+		)
+
+		selector := workflow.NewSelector(ctx)
+		selector.AddFuture(locks.acquire(ctx, accountID), func(f workflow.Future) {
+			acquireLockErr = f.Get(ctx, &fencingToken)
+		})
+
+		if state != nil {
+			selector.AddReceive(workflow.GetSignalChannel(ctx, CancelTransferSignal), func(c workflow.ReceiveChannel, _ bool) {
+				var request CancelTransferRequest
+				c.Receive(ctx, &request)
+
+				state.CancellationReason = request.Reason
+				cancelErr = transferCancelledError(state)
+			})
+		}
+
+		selector.Select(ctx)
+
+		if cancelErr != nil {
+			locks.abandon(ctx, accountID)
+
+			releaseLockErr := locks.release(ctx, acquired)
+			if releaseLockErr != nil {
+				workflow.GetLogger(ctx).Warn("Mutex release after cancellation failed", "Error", releaseLockErr)
+			}
+
+			return nil, nil, cancelErr
+		}
+
+		if acquireLockErr != nil {
+			releaseLockErr := locks.release(ctx, acquired)
+			if releaseLockErr != nil {
+				workflow.GetLogger(ctx).Warn("Mutex release after a failed acquisition failed", "Error", releaseLockErr)
+			}
+
+			return nil, nil, acquireLockErr
+		}
+
+		acquired = append(acquired, accountID)
+
+		if fencingToken > 0 {
+			fencingTokens[accountID] = fencingToken
+		}
+	}
+
+	lockWait := workflow.Now(ctx).Sub(waitStartedAt)
+
+	if state != nil {
+		state.LockWait = lockWait
+
+		workflow.GetMetricsHandler(ctx).Timer(transferLockWaitMetric).Record(lockWait)
+	}
+
+	workflow.GetLogger(ctx).Info("Accounts locked", "Locks", len(acquired), "Wait", lockWait)
+
+	return locks.hold(ctx, acquired), fencingTokens, nil
+}
+
+// lockAccount acquires the lock of a single account and keeps it until the returned function releases it, it
+// returns the fencing token of the lock when it has one. Only reversals and captures started before they locked
+// every account they post to still use it.
+func lockAccount(
+	ctx workflow.Context,
+	cfg TransferEnvConfig,
+	accountID uuid.UUID,
+	ownerReferenceID uuid.UUID,
+) (MutexReleaseFunc, map[uuid.UUID]int64, error) {
+	locks := accountLocksFor(ctx, cfg, ownerReferenceID)
+
+	var fencingToken int64
+
+	err := locks.acquire(ctx, accountID).Get(ctx, &fencingToken)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fencingTokens := make(map[uuid.UUID]int64, 1)
+	if fencingToken > 0 {
+		fencingTokens[accountID] = fencingToken
+	}
+
+	return locks.hold(ctx, []uuid.UUID{accountID}), fencingTokens, nil
+}
+
+// mutexAccountLocks takes the Mutex locks of the accounts, AcquireLock is retried until the lock is free.
+type mutexAccountLocks struct {
+	cfg              TransferEnvConfig
+	ownerReferenceID uuid.UUID
+	mutexCtx         workflow.Context
+	cancels          map[uuid.UUID]workflow.CancelFunc
+}
+
+func newMutexAccountLocks(ctx workflow.Context, cfg TransferEnvConfig, ownerReferenceID uuid.UUID) *mutexAccountLocks {
+	return &mutexAccountLocks{
+		cfg:              cfg,
+		ownerReferenceID: ownerReferenceID,
+		mutexCtx:         workflow.WithActivityOptions(ctx, mutexActivityOptions),
+		cancels:          make(map[uuid.UUID]workflow.CancelFunc),
+	}
+}
+
+func (l *mutexAccountLocks) acquire(_ workflow.Context, accountID uuid.UUID) workflow.Future {
+	var mutex *activities.MutexOperations
+
+	acquireCtx, cancelAcquire := workflow.WithCancel(l.mutexCtx)
+	l.cancels[accountID] = cancelAcquire
+
+	return workflow.ExecuteActivity(acquireCtx, mutex.AcquireLock, accountMutexParams(l.cfg, accountID, l.ownerReferenceID))
+}
+
+func (l *mutexAccountLocks) abandon(ctx workflow.Context, accountID uuid.UUID) {
+	var mutex *activities.MutexOperations
+
+	if cancelAcquire, ok := l.cancels[accountID]; ok {
+		cancelAcquire()
+	}
+
+	// A single attempt is enough for the lock in flight, it is most likely held by another transfer and
+	// expires with its TTL anyway.
+	releaseCtx, _ := workflow.NewDisconnectedContext(l.mutexCtx)
+	releaseCtx = workflow.WithRetryPolicy(releaseCtx, temporal.RetryPolicy{MaximumAttempts: 1})
+
+	releaseLockErr := workflow.ExecuteActivity(releaseCtx, mutex.ReleaseLock, accountMutexParams(l.cfg, accountID, l.ownerReferenceID)).
+		Get(releaseCtx, nil)
+	if releaseLockErr != nil {
+		workflow.GetLogger(ctx).Warn("Mutex release after cancellation failed", "Error", releaseLockErr)
+	}
+}
+
+func (l *mutexAccountLocks) hold(ctx workflow.Context, accountIDs []uuid.UUID) MutexReleaseFunc {
+	locks := l.mutexParams(accountIDs)
+
+	// Workflows started before the locks were renewed hold them for their TTL only.
+	if workflow.GetVersion(ctx, lockRenewalVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return func() error {
+			return releaseLocks(l.mutexCtx, locks)
+		}
+	}
+
+	stopRenewal := renewLocks(ctx, locks)
+
 	return func() error {
-		releaseLockErr := workflow.ExecuteActivity(
-			mutexCtx,
-			mutex.ReleaseLock,
-			mutexParams,
-		).Get(ctx, nil)
+		stopRenewal()
 
-		return releaseLockErr
-	}, nil
+		return releaseLocks(l.mutexCtx, locks)
+	}
+}
+
+func (l *mutexAccountLocks) release(_ workflow.Context, accountIDs []uuid.UUID) error {
+	return releaseLocks(l.mutexCtx, l.mutexParams(accountIDs))
+}
+
+func (l *mutexAccountLocks) mutexParams(accountIDs []uuid.UUID) []activities.MutexParams {
+	locks := make([]activities.MutexParams, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		locks = append(locks, accountMutexParams(l.cfg, accountID, l.ownerReferenceID))
+	}
+
+	return locks
+}
+
+// lockRenewalVersion marks the workflows that renew the Mutex locks they hold.
+const lockRenewalVersion = "lock-renewal"
+
+// renewLocks extends the locks every third of their TTL until the returned function is called, so a workflow
+// holding them longer than the TTL doesn't lose them. A lock that is lost anyway is no longer extended, the
+// fencing token it was acquired with keeps its holder from posting.
+func renewLocks(ctx workflow.Context, locks []activities.MutexParams) workflow.CancelFunc {
+	var mutex *activities.MutexOperations
+
+	renewCtx, stop := workflow.WithCancel(ctx)
+	renewCtx = workflow.WithActivityOptions(renewCtx, lockRenewalActivityOptions)
+
+	workflow.Go(renewCtx, func(ctx workflow.Context) {
+		held := locks
+
+		for len(held) > 0 && held[0].TTL > 0 {
+			if workflow.Sleep(ctx, held[0].TTL/3) != nil {
+				return
+			}
+
+			futures := make([]workflow.Future, 0, len(held))
+			for _, mutexParams := range held {
+				futures = append(futures, workflow.ExecuteActivity(ctx, mutex.ExtendLock, mutexParams))
+			}
+
+			stillHeld := make([]activities.MutexParams, 0, len(held))
+
+			for i, future := range futures {
+				err := future.Get(ctx, nil)
+				if ctx.Err() != nil {
+					return
+				}
+
+				if err != nil {
+					workflow.GetLogger(ctx).Warn("Mutex lease extension failed", "Key", held[i].Key, "Error", err)
+
+					continue
+				}
+
+				stillHeld = append(stillHeld, held[i])
+			}
+
+			held = stillHeld
+		}
+	})
+
+	return stop
+}
+
+// releaseLocks releases the locks in a disconnected context, so they are released even when the workflow failed
+// or is cancelled. Every lock is released even when releasing another one fails.
+func releaseLocks(ctx workflow.Context, locks []activities.MutexParams) error {
+	var mutex *activities.MutexOperations
+
+	releaseCtx, _ := workflow.NewDisconnectedContext(ctx)
+
+	futures := make([]workflow.Future, 0, len(locks))
+	for _, mutexParams := range locks {
+		futures = append(futures, workflow.ExecuteActivity(releaseCtx, mutex.ReleaseLock, mutexParams))
+	}
+
+	return joinFutureErrors(releaseCtx, futures)
+}
+
+// joinFutureErrors waits for every future and joins their errors.
+func joinFutureErrors(ctx workflow.Context, futures []workflow.Future) error {
+	var errs []error
+
+	for _, future := range futures {
+		err := future.Get(ctx, nil)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// mutexActivityOptions retries AcquireLock until the lock is free, the activities are bounded by the workflow.
+var mutexActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: 2 * time.Minute,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    5 * time.Second,
+		BackoffCoefficient: 1.0,
+		MaximumInterval:    10 * time.Second,
+	},
+}
+
+// lockRenewalActivityOptions gives up on an extension quickly, the next one is due a third of the TTL later.
+var lockRenewalActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: 10 * time.Second,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval: time.Second,
+		MaximumAttempts: 3,
+	},
+}
+
+// accountMutexParams is the lock of an account. Transfers, reversals and hold captures take it for every account
+// they move money between.
+func accountMutexParams(cfg TransferEnvConfig, accountID uuid.UUID, ownerReferenceID uuid.UUID) activities.MutexParams {
+	return activities.MutexParams{
+		Key:            fmt.Sprintf("transfers_mutex_%s", accountID.String()),
+		OwnershipToken: ownerReferenceID.String(),
+		TTL:            time.Duration(cfg.TransferMutexTTLSeconds) * time.Second,
+		AccountID:      accountID,
+	}
+}
+
+// lockOrder returns the accounts in the order their locks are acquired, sorted like their lock keys. An account
+// listed twice is locked once.
+func lockOrder(accountIDs ...uuid.UUID) []uuid.UUID {
+	sorted := make([]uuid.UUID, 0, len(accountIDs))
+	seen := make(map[uuid.UUID]bool, len(accountIDs))
+
+	for _, accountID := range accountIDs {
+		if seen[accountID] {
+			continue
+		}
+
+		seen[accountID] = true
+
+		sorted = append(sorted, accountID)
+	}
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].String() < sorted[j].String()
+	})
+
+	return sorted
 }
 
 func getActivityReferenceID(workflowReference uuid.UUID, prefix string) uuid.UUID {
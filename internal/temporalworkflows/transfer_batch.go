@@ -0,0 +1,208 @@
+package temporalworkflows
+
+import (
+	"errors"
+	"ulascansenturk/service/internal/constants"
+
+	"github.com/google/uuid"
+	"github.com/ilyakaznacheev/cleanenv"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/workflow"
+)
+
+// TransferBatchStateQuery returns the TransferBatchState of a running or finished TransferBatch workflow.
+const TransferBatchStateQuery = "transfer-batch-state"
+
+// transferBatchConcurrencyVersion marks the batches that record the TRANSFER_BATCH_MAX_CONCURRENCY they run with.
+const transferBatchConcurrencyVersion = "transfer-batch-concurrency"
+
+type TransferBatchEnvConfig struct {
+	TransferBatchMaxConcurrency int `env:"TRANSFER_BATCH_MAX_CONCURRENCY" env-default:"10"`
+}
+
+type TransferBatchParams struct {
+	BatchID uuid.UUID
+	Items   []TransferParams
+	// MaxConcurrency caps the transfers running at the same time, TRANSFER_BATCH_MAX_CONCURRENCY is used when unset.
+	MaxConcurrency int
+}
+
+type TransferBatchItemResult struct {
+	ReferenceID   uuid.UUID
+	Status        constants.TransferBatchItemStatus
+	FailureReason *string
+}
+
+// TransferBatchState is the progress of a batch, the counters always add up to Total.
+type TransferBatchState struct {
+	BatchID   uuid.UUID
+	Status    constants.TransferBatchStatus
+	Total     int
+	Queued    int
+	Running   int
+	Succeeded int
+	Failed    int
+	Items     []TransferBatchItemResult
+}
+
+// TransferBatchWorkflowID is the ID of the TransferBatch workflow of a batch, it is prefixed so it never collides
+// with the ID of a Transfer workflow.
+func TransferBatchWorkflowID(batchID uuid.UUID) string {
+	return "transfer-batch-" + batchID.String()
+}
+
+// TransferBatch runs every item of the batch as a child Transfer workflow, at most MaxConcurrency at a time.
+// A failed item doesn't stop the batch, the returned state reports the outcome of each item. The whole batch runs
+// in one run, the API caps its items so the history stays bounded.
+func TransferBatch(ctx workflow.Context, params TransferBatchParams) (*TransferBatchState, error) {
+	maxConcurrency, err := batchMaxConcurrency(ctx, params.MaxConcurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &TransferBatchState{
+		BatchID: params.BatchID,
+		Status:  constants.TransferBatchStatusPROCESSING,
+		Total:   len(params.Items),
+		Queued:  len(params.Items),
+		Items:   make([]TransferBatchItemResult, len(params.Items)),
+	}
+
+	for i, item := range params.Items {
+		state.Items[i] = TransferBatchItemResult{
+			ReferenceID: item.ReferenceId,
+			Status:      constants.TransferBatchItemStatusQUEUED,
+		}
+	}
+
+	err = workflow.SetQueryHandler(ctx, TransferBatchStateQuery, func() (TransferBatchState, error) {
+		return *state, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	selector := workflow.NewSelector(ctx)
+	next, running := 0, 0
+
+	for next < len(params.Items) || running > 0 {
+		for running < maxConcurrency && next < len(params.Items) {
+			index := next
+			next++
+			running++
+
+			state.setItemStatus(index, constants.TransferBatchItemStatusRUNNING, nil)
+
+			future := startBatchItem(ctx, params.BatchID, params.Items[index])
+
+			selector.AddFuture(future, func(f workflow.Future) {
+				running--
+
+				itemErr := f.Get(ctx, nil)
+				if itemErr != nil {
+					failureReason := itemErr.Error()
+					state.setItemStatus(index, constants.TransferBatchItemStatusFAILED, &failureReason)
+
+					return
+				}
+
+				state.setItemStatus(index, constants.TransferBatchItemStatusSUCCEEDED, nil)
+			})
+		}
+
+		selector.Select(ctx)
+	}
+
+	switch {
+	case state.Failed == 0:
+		state.Status = constants.TransferBatchStatusCOMPLETED
+	case state.Succeeded == 0:
+		state.Status = constants.TransferBatchStatusFAILED
+	default:
+		state.Status = constants.TransferBatchStatusPARTIALLYFAILED
+	}
+
+	return state, nil
+}
+
+// batchMaxConcurrency returns how many items of the batch run at the same time, the requested count capped by
+// TRANSFER_BATCH_MAX_CONCURRENCY. The cap is read once and recorded, so a running batch keeps it when the setting
+// changes.
+func batchMaxConcurrency(ctx workflow.Context, requested int) (int, error) {
+	var cfg *TransferBatchEnvConfig
+
+	if workflow.GetVersion(ctx, transferBatchConcurrencyVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		// Batches started before the cap was recorded keep reading it from the environment.
+		cfg = new(TransferBatchEnvConfig)
+
+		err := cleanenv.ReadEnv(cfg)
+		if err != nil {
+			return 0, err
+		}
+	} else {
+		err := workflow.SideEffect(ctx, func(workflow.Context) interface{} {
+			var envCfg TransferBatchEnvConfig
+			if cleanenv.ReadEnv(&envCfg) != nil {
+				return nil
+			}
+
+			return &envCfg
+		}).Get(&cfg)
+		if err != nil {
+			return 0, err
+		}
+
+		if cfg == nil {
+			return 0, errors.New("TRANSFER_BATCH_MAX_CONCURRENCY is not a number")
+		}
+	}
+
+	if requested <= 0 || requested > cfg.TransferBatchMaxConcurrency {
+		return cfg.TransferBatchMaxConcurrency, nil
+	}
+
+	return requested, nil
+}
+
+// startBatchItem starts the child Transfer workflow of an item. The child is abandoned when the batch is
+// terminated, so a transfer that is posting is never interrupted.
+func startBatchItem(ctx workflow.Context, batchID uuid.UUID, item TransferParams) workflow.ChildWorkflowFuture {
+	metadata := map[string]interface{}{}
+	if item.Metadata != nil {
+		for key, value := range *item.Metadata {
+			metadata[key] = value
+		}
+	}
+	metadata["TransferBatchID"] = batchID.String()
+
+	item.Metadata = &metadata
+
+	childCtx := workflow.WithChildOptions(ctx, workflow.ChildWorkflowOptions{
+		WorkflowID:            item.ReferenceId.String(),
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+		ParentClosePolicy:     enumspb.PARENT_CLOSE_POLICY_ABANDON,
+	})
+
+	return workflow.ExecuteChildWorkflow(childCtx, Transfer, &item)
+}
+
+func (s *TransferBatchState) setItemStatus(index int, status constants.TransferBatchItemStatus, failureReason *string) {
+	s.countItem(s.Items[index].Status, -1)
+	s.countItem(status, 1)
+
+	s.Items[index].Status = status
+	s.Items[index].FailureReason = failureReason
+}
+
+func (s *TransferBatchState) countItem(status constants.TransferBatchItemStatus, delta int) {
+	switch status {
+	case constants.TransferBatchItemStatusQUEUED:
+		s.Queued += delta
+	case constants.TransferBatchItemStatusRUNNING:
+		s.Running += delta
+	case constants.TransferBatchItemStatusSUCCEEDED:
+		s.Succeeded += delta
+	case constants.TransferBatchItemStatusFAILED:
+		s.Failed += delta
+	}
+}
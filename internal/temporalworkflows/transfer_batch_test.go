@@ -0,0 +1,146 @@
+//go:build tests_unit
+
+package temporalworkflows
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"go.temporal.io/sdk/testsuite"
+	"go.temporal.io/sdk/workflow"
+)
+
+type transferBatchTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (s *transferBatchTestSuite) SetupSubTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	s.env.RegisterWorkflow(Transfer)
+	s.env.RegisterWorkflow(TransferBatch)
+	s.env.RegisterWorkflow(TransferNotifications)
+
+	s.env.OnWorkflow(TransferNotifications, mock.Anything, mock.Anything).Return(nil).Maybe()
+}
+
+func (s *transferBatchTestSuite) TearDownSubTest() {
+	s.env.AssertExpectations(s.T())
+}
+
+func TestTransferBatch(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(transferBatchTestSuite))
+}
+
+func (s *transferBatchTestSuite) TestTransferBatchWorkflow() {
+	batchID := uuid.New()
+
+	newItems := func(count int) []TransferParams {
+		items := make([]TransferParams, count)
+		for i := range items {
+			items[i] = TransferParams{
+				ReferenceId:          uuid.New(),
+				Amount:               1000,
+				SourceAccountID:      uuid.New(),
+				DestinationAccountID: uuid.New(),
+			}
+		}
+
+		return items
+	}
+
+	s.Run("Batch runs every item as a child transfer tagged with the batch ID", func() {
+		items := newItems(3)
+
+		s.env.OnWorkflow(Transfer, mock.Anything, mock.MatchedBy(func(params *TransferParams) bool {
+			return (*params.Metadata)["TransferBatchID"] == batchID.String()
+		})).Return(&activities.TransferResult{}, nil).Times(3)
+
+		s.env.ExecuteWorkflow(TransferBatch, TransferBatchParams{BatchID: batchID, Items: items})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+
+		var state TransferBatchState
+		s.NoError(s.env.GetWorkflowResult(&state))
+		s.Equal(constants.TransferBatchStatusCOMPLETED, state.Status)
+		s.Equal(3, state.Succeeded)
+		s.Equal(0, state.Queued+state.Running+state.Failed)
+
+		for i, item := range state.Items {
+			s.Equal(items[i].ReferenceId, item.ReferenceID)
+			s.Equal(constants.TransferBatchItemStatusSUCCEEDED, item.Status)
+		}
+	})
+
+	s.Run("Failed items are reported without failing the batch", func() {
+		items := newItems(3)
+
+		s.env.OnWorkflow(Transfer, mock.Anything, mock.MatchedBy(func(params *TransferParams) bool {
+			return params.ReferenceId == items[1].ReferenceId
+		})).Return(nil, errors.New("insufficient funds"))
+		s.env.OnWorkflow(Transfer, mock.Anything, mock.Anything).Return(&activities.TransferResult{}, nil)
+
+		s.env.ExecuteWorkflow(TransferBatch, TransferBatchParams{BatchID: batchID, Items: items})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+
+		var state TransferBatchState
+		s.NoError(s.env.GetWorkflowResult(&state))
+		s.Equal(constants.TransferBatchStatusPARTIALLYFAILED, state.Status)
+		s.Equal(2, state.Succeeded)
+		s.Equal(1, state.Failed)
+		s.Equal(constants.TransferBatchItemStatusFAILED, state.Items[1].Status)
+		s.Require().NotNil(state.Items[1].FailureReason)
+		s.Contains(*state.Items[1].FailureReason, "insufficient funds")
+	})
+
+	s.Run("Batch never runs more items than its concurrency and reports its progress", func() {
+		items := newItems(5)
+		running, maxRunning := 0, 0
+
+		s.env.OnWorkflow(Transfer, mock.Anything, mock.Anything).Return(func(ctx workflow.Context, _ *TransferParams) (*activities.TransferResult, error) {
+			running++
+			if running > maxRunning {
+				maxRunning = running
+			}
+
+			err := workflow.Sleep(ctx, time.Minute)
+
+			running--
+
+			return &activities.TransferResult{}, err
+		})
+
+		s.env.RegisterDelayedCallback(func() {
+			encodedState, err := s.env.QueryWorkflow(TransferBatchStateQuery)
+			s.NoError(err)
+
+			var state TransferBatchState
+			s.NoError(encodedState.Get(&state))
+			s.Equal(constants.TransferBatchStatusPROCESSING, state.Status)
+			s.Equal(5, state.Total)
+			s.Equal(2, state.Running)
+			s.Equal(2, state.Succeeded)
+			s.Equal(1, state.Queued)
+		}, 90*time.Second)
+
+		s.env.ExecuteWorkflow(TransferBatch, TransferBatchParams{BatchID: batchID, Items: items, MaxConcurrency: 2})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.Equal(2, maxRunning)
+	})
+}
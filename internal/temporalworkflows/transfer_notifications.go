@@ -0,0 +1,94 @@
+package temporalworkflows
+
+import (
+	"errors"
+	"time"
+	"ulascansenturk/service/internal/notifications"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"github.com/google/uuid"
+	enumspb "go.temporal.io/api/enums/v1"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+// transferNotificationsVersion marks the transfers that notify their accounts once they end.
+const transferNotificationsVersion = "transfer-notifications"
+
+var notificationActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: 30 * time.Second,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    5 * time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    time.Minute,
+		MaximumAttempts:    5,
+	},
+}
+
+// TransferNotifications notifies the owners of the accounts of a transfer that ended: the sender and the receiver of
+// a completed transfer, and the sender again when it left their balance low, or the sender of a failed transfer.
+// A notification that couldn't be sent is logged, it never fails the workflow.
+func TransferNotifications(ctx workflow.Context, notice notifications.TransferNotice) error {
+	var notificationOperations *activities.NotificationOperations
+
+	ctx = workflow.WithActivityOptions(ctx, notificationActivityOptions)
+
+	if notice.FailureReason != nil {
+		err := workflow.ExecuteActivity(ctx, notificationOperations.NotifyTransferFailed, notice).Get(ctx, nil)
+		if err != nil {
+			workflow.GetLogger(ctx).Warn("Transfer failed notification failed", "ReferenceID", notice.ReferenceID, "Error", err)
+		}
+
+		return nil
+	}
+
+	futures := map[string]workflow.Future{
+		"TransferSent":     workflow.ExecuteActivity(ctx, notificationOperations.NotifyTransferSent, notice),
+		"TransferReceived": workflow.ExecuteActivity(ctx, notificationOperations.NotifyTransferReceived, notice),
+		"LowBalance":       workflow.ExecuteActivity(ctx, notificationOperations.NotifyLowBalance, notice.SourceAccountID, notice.At),
+	}
+
+	for _, kind := range []string{"TransferSent", "TransferReceived", "LowBalance"} {
+		err := futures[kind].Get(ctx, nil)
+		if err != nil {
+			workflow.GetLogger(ctx).Warn("Transfer notification failed", "Kind", kind, "ReferenceID", notice.ReferenceID, "Error", err)
+		}
+	}
+
+	return nil
+}
+
+// transferNotificationsWorkflowID is the ID of the TransferNotifications workflow of a transfer.
+func transferNotificationsWorkflowID(referenceID uuid.UUID) string {
+	return "transfer-notifications-" + referenceID.String()
+}
+
+// notifyTransfer starts the TransferNotifications workflow of the transfer and only waits for it to start, the
+// transfer never waits on its notifications nor fails with them. The child is abandoned so it outlives the transfer.
+func notifyTransfer(ctx workflow.Context, notice notifications.TransferNotice) {
+	if workflow.GetVersion(ctx, transferNotificationsVersion, workflow.DefaultVersion, 1) == workflow.DefaultVersion {
+		return
+	}
+
+	childCtx, _ := workflow.NewDisconnectedContext(ctx)
+	childCtx = workflow.WithChildOptions(childCtx, workflow.ChildWorkflowOptions{
+		WorkflowID:            transferNotificationsWorkflowID(notice.ReferenceID),
+		WorkflowIDReusePolicy: enumspb.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+		ParentClosePolicy:     enumspb.PARENT_CLOSE_POLICY_ABANDON,
+	})
+
+	err := workflow.ExecuteChildWorkflow(childCtx, TransferNotifications, notice).GetChildWorkflowExecution().Get(childCtx, nil)
+	if err != nil {
+		workflow.GetLogger(ctx).Warn("Transfer notifications didn't start", "ReferenceID", notice.ReferenceID, "Error", err)
+	}
+}
+
+// transferFailureReason is the message of the error that failed the transfer, without the activity that returned it.
+func transferFailureReason(err error) string {
+	var appErr *temporal.ApplicationError
+	if errors.As(err, &appErr) {
+		return appErr.Message()
+	}
+
+	return err.Error()
+}
@@ -0,0 +1,193 @@
+//go:build tests_unit
+
+package temporalworkflows
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"time"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/fees"
+	"ulascansenturk/service/internal/fx"
+	"ulascansenturk/service/internal/notifications"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type transferNotificationsTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (s *transferNotificationsTestSuite) SetupSubTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	s.env.RegisterWorkflow(Transfer)
+	s.env.RegisterWorkflow(TransferNotifications)
+}
+
+func (s *transferNotificationsTestSuite) TearDownSubTest() {
+	s.env.AssertExpectations(s.T())
+}
+
+func TestTransferNotifications(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(transferNotificationsTestSuite))
+}
+
+func (s *transferNotificationsTestSuite) TestTransferNotificationsWorkflow() {
+	failureReason := "insufficient funds"
+
+	notice := notifications.TransferNotice{
+		ReferenceID:          uuid.New(),
+		SourceAccountID:      uuid.New(),
+		DestinationAccountID: uuid.New(),
+		Amount:               1000,
+		DestinationAmount:    1000,
+		At:                   time.Date(2024, 10, 5, 9, 0, 0, 0, time.UTC),
+	}
+
+	s.Run("Completed transfer notifies both accounts and checks the source balance", func() {
+		var notificationOperations *activities.NotificationOperations
+
+		s.env.OnActivity(notificationOperations.NotifyTransferSent, mock.Anything, notice).Return(nil).Once()
+		s.env.OnActivity(notificationOperations.NotifyTransferReceived, mock.Anything, notice).Return(nil).Once()
+		s.env.OnActivity(notificationOperations.NotifyLowBalance, mock.Anything, notice.SourceAccountID, notice.At).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(TransferNotifications, notice)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Notification that can't be sent doesn't fail the others", func() {
+		var notificationOperations *activities.NotificationOperations
+
+		s.env.OnActivity(notificationOperations.NotifyTransferSent, mock.Anything, notice).
+			Return(errors.New("smtp server unreachable")).Times(int(notificationActivityOptions.RetryPolicy.MaximumAttempts))
+		s.env.OnActivity(notificationOperations.NotifyTransferReceived, mock.Anything, notice).Return(nil).Once()
+		s.env.OnActivity(notificationOperations.NotifyLowBalance, mock.Anything, notice.SourceAccountID, notice.At).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(TransferNotifications, notice)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Failed transfer only notifies the source account", func() {
+		var notificationOperations *activities.NotificationOperations
+
+		failed := notice
+		failed.FailureReason = &failureReason
+
+		s.env.OnActivity(notificationOperations.NotifyTransferFailed, mock.Anything, failed).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(TransferNotifications, failed)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+}
+
+func (s *transferNotificationsTestSuite) TestTransferStartsNotifications() {
+	var (
+		transactionOperations *activities.TransactionOperations
+		feeOperations         *activities.FeeOperations
+		fxOperations          *activities.FXOperations
+		approvalOperations    *activities.ApprovalOperations
+		redisActivity         *activities.MutexOperations
+		limitOperations       *activities.LimitOperations
+	)
+
+	params := &TransferParams{
+		ReferenceId:          uuid.New(),
+		SourceAccountID:      uuid.New(),
+		DestinationAccountID: uuid.New(),
+		Amount:               1000,
+	}
+
+	onTransferUntilPosting := func() {
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{Amount: 50, Currency: "USD"}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(&fx.Quote{SourceAmount: 1000, TargetAmount: 920}, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+	}
+
+	s.Run("Completed transfer starts its notifications with the fee and the converted amount", func() {
+		onTransferUntilPosting()
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil)
+
+		s.env.OnWorkflow(TransferNotifications, mock.Anything, mock.MatchedBy(func(notice notifications.TransferNotice) bool {
+			return notice.ReferenceID == params.ReferenceId &&
+				notice.SourceAccountID == params.SourceAccountID &&
+				notice.DestinationAccountID == params.DestinationAccountID &&
+				notice.Amount == 1000 && notice.FeeAmount == 50 && notice.DestinationAmount == 920 &&
+				notice.FailureReason == nil && !notice.At.IsZero()
+		})).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Failed transfer starts its notifications with the failure reason", func() {
+		onTransferUntilPosting()
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, temporal.NewNonRetryableApplicationError("insufficient funds", "post-transfer-err", nil))
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.OnWorkflow(TransferNotifications, mock.Anything, mock.MatchedBy(func(notice notifications.TransferNotice) bool {
+			return notice.FailureReason != nil && *notice.FailureReason == "insufficient funds"
+		})).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.ErrorContains(s.env.GetWorkflowError(), "insufficient funds")
+	})
+
+	s.Run("Notifications that fail don't fail the transfer", func() {
+		onTransferUntilPosting()
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil)
+
+		s.env.OnWorkflow(TransferNotifications, mock.Anything, mock.Anything).
+			Return(errors.New("notifications failed")).Once()
+
+		s.env.ExecuteWorkflow(Transfer, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Cancelled transfer isn't notified", func() {
+		startTime := time.Date(2024, 10, 5, 9, 0, 0, 0, time.UTC)
+		executeAt := startTime.Add(24 * time.Hour)
+
+		s.env.SetStartTime(startTime)
+
+		s.env.OnWorkflow(TransferNotifications, mock.Anything, mock.Anything).Return(nil).Never()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CancelTransferSignal, CancelTransferRequest{Reason: "no longer needed"})
+		}, time.Hour)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{ReferenceId: params.ReferenceId, Amount: 1000, ExecuteAt: &executeAt})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.Error(s.env.GetWorkflowError())
+	})
+}
@@ -1,10 +1,22 @@
 package temporalworkflows
 
 import (
+	"context"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+	"reflect"
 	"testing"
+	"time"
+	"ulascansenturk/service/internal/approvals"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/fees"
+	"ulascansenturk/service/internal/fx"
+	"ulascansenturk/service/internal/limits"
 	"ulascansenturk/service/internal/temporalworkflows/activities"
+	"ulascansenturk/service/internal/transactions"
 
 	temporalMocks "go.temporal.io/sdk/mocks"
 	"go.temporal.io/sdk/testsuite"
@@ -23,6 +35,9 @@ func (s *transfersTestSuite) SetupSubTest() {
 	s.temporalService = temporalMocks.Client{}
 
 	s.env.RegisterWorkflow(Transfer)
+	s.env.RegisterWorkflow(TransferNotifications)
+
+	s.env.OnWorkflow(TransferNotifications, mock.Anything, mock.Anything).Return(nil).Maybe()
 }
 
 func (s *transfersTestSuite) TearDownSubTest() {
@@ -38,14 +53,26 @@ func TestTransfer(t *testing.T) {
 func (s *transfersTestSuite) TestTransferWorkflow() {
 	s.Run("Transfer", func() {
 		var transactionOperations *activities.TransactionOperations
-		var redisActivity *activities.Mutex
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
 
+		pendingTransactions := &activities.PendingTransactions{}
 		activityResponse := &activities.TransferResult{}
 
-		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(pendingTransactions, nil)
 
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
 		s.env.OnActivity(
-			transactionOperations.Transfer,
+			transactionOperations.PostTransfer,
+			mock.Anything,
 			mock.Anything,
 			mock.Anything,
 		).Return(activityResponse, nil)
@@ -55,4 +82,712 @@ func (s *transfersTestSuite) TestTransferWorkflow() {
 		s.True(s.env.IsWorkflowCompleted())
 		s.NoError(s.env.GetWorkflowError())
 	})
+
+	s.Run("Transfer compensates the completed steps when a later step fails", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		pendingTransactions := &activities.PendingTransactions{}
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(pendingTransactions, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, temporal.NewNonRetryableApplicationError("posting failed", "post-transfer-err", nil))
+
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.ErrorContains(s.env.GetWorkflowError(), "posting failed")
+	})
+	s.Run("Transfer started before it was run in steps posts in a single activity", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+
+		s.env.OnGetVersion(transferStepsVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(transactionOperations.Transfer, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+			return params.Amount == 1000
+		})).Return(&activities.TransferResult{}, nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+	s.Run("Transfer started before it was posted in a single database transaction refunds the debit when the credit fails", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		s.env.OnGetVersion(transferPostingVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+
+		s.env.OnActivity(transactionOperations.DebitSourceAccount, mock.Anything, mock.Anything).Return(nil).Once()
+		s.env.OnActivity(transactionOperations.CreditDestinationAccount, mock.Anything, mock.Anything).
+			Return(temporal.NewNonRetryableApplicationError("credit failed", "credit-err", nil))
+
+		s.env.OnActivity(transactionOperations.RefundSourceAccount, mock.Anything, mock.Anything).Return(nil).Once()
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.ErrorContains(s.env.GetWorkflowError(), "credit failed")
+	})
+	s.Run("Transfer started before the fee schedule keeps the fee amount it was started with", func() {
+		var transactionOperations *activities.TransactionOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		feeAmount := 50
+
+		s.env.OnGetVersion(transferFeeScheduleVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+			return params.FeeAmount != nil && *params.FeeAmount == feeAmount && params.FeeRule == nil
+		})).Return(&activities.PendingTransactions{}, nil).Once()
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).Return(&activities.TransferResult{}, nil)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000, FeeAmount: &feeAmount})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+	s.Run("Transfer over a limit of the source account fails without posting", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		exceeded := limits.ExceededError{LimitType: constants.TransferLimitTypeDAILYAMOUNT, Allowed: 2000, Used: 1500, Requested: 1000}
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Once()
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.MatchedBy(func(check activities.LimitCheck) bool {
+			return check.Amount == 1000 && !check.At.IsZero()
+		})).Return(temporal.NewNonRetryableApplicationError(exceeded.Error(), activities.TransferLimitExceededErrorType, nil, exceeded)).Once()
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(s.env.GetWorkflowError(), &applicationErr)
+		s.Equal(activities.TransferLimitExceededErrorType, applicationErr.Type())
+
+		var details limits.ExceededError
+		s.NoError(applicationErr.Details(&details))
+		s.Equal(constants.TransferLimitTypeDAILYAMOUNT, details.LimitType)
+	})
+	s.Run("Transfer started before the limits posts without checking them", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+
+		s.env.OnGetVersion(transferLimitsVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+	s.Run("Transfer locks the accounts it moves money between in key order and posts with their fencing tokens", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		sourceAccountID := uuid.MustParse("cccccccc-0000-0000-0000-000000000000")
+		destinationAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+		feeAccountID := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+
+		pendingTransactions := &activities.PendingTransactions{
+			IncomingFeeTrx: &transactions.Transaction{AccountID: feeAccountID},
+		}
+
+		var acquiredKeys []string
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(func(_ context.Context, params activities.MutexParams) (int64, error) {
+			acquiredKeys = append(acquiredKeys, params.Key)
+
+			return int64(len(acquiredKeys)), nil
+		}).Times(3)
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Times(3)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{Amount: 10}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(pendingTransactions, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+			return reflect.DeepEqual(params.FencingTokens, map[uuid.UUID]int64{
+				destinationAccountID: 1,
+				feeAccountID:         2,
+				sourceAccountID:      3,
+			})
+		}), mock.Anything).Return(&activities.TransferResult{}, nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{
+			Amount:               1000,
+			SourceAccountID:      sourceAccountID,
+			DestinationAccountID: destinationAccountID,
+		})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.Equal([]string{
+			"transfers_mutex_" + destinationAccountID.String(),
+			"transfers_mutex_" + feeAccountID.String(),
+			"transfers_mutex_" + sourceAccountID.String(),
+		}, acquiredKeys)
+	})
+	s.Run("Transfer renews its locks while it holds them", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(redisActivity.ExtendLock, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil).After(10 * time.Minute)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.env.AssertActivityCalled(s.T(), "ExtendLock", mock.Anything, mock.Anything)
+	})
+	s.Run("Transfer started before the locks were renewed doesn't renew them", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		s.env.OnGetVersion(lockRenewalVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(redisActivity.ExtendLock, mock.Anything, mock.Anything).Return(nil).Maybe()
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil).After(10 * time.Minute)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.env.AssertActivityNotCalled(s.T(), "ExtendLock", mock.Anything, mock.Anything)
+	})
+	s.Run("Transfer started before every account was locked only locks its source account", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		sourceAccountID := uuid.MustParse("cccccccc-0000-0000-0000-000000000000")
+		destinationAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+
+		s.env.OnGetVersion(transferAccountLockingVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.MatchedBy(func(params activities.MutexParams) bool {
+			return params.Key == "transfers_mutex_"+sourceAccountID.String()
+		})).Return(int64(1), nil).Once()
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Once()
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{
+			Amount:               1000,
+			SourceAccountID:      sourceAccountID,
+			DestinationAccountID: destinationAccountID,
+		})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+	s.Run("Transfer cancelled while waiting for its second lock releases both", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+
+		firstAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+		secondAccountID := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+
+		isLockOf := func(accountID uuid.UUID) interface{} {
+			return mock.MatchedBy(func(params activities.MutexParams) bool {
+				return params.Key == "transfers_mutex_"+accountID.String()
+			})
+		}
+
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, isLockOf(firstAccountID)).Return(int64(1), nil).Once()
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, isLockOf(secondAccountID)).Return(int64(1), nil).After(time.Hour)
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, isLockOf(firstAccountID)).Return(nil).Once()
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, isLockOf(secondAccountID)).Return(nil).Once()
+
+		s.env.OnActivity(transactionOperations.CancelTransactions, mock.Anything, mock.Anything, "sent twice").Return(nil).Once()
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CancelTransferSignal, CancelTransferRequest{Reason: "sent twice"})
+		}, time.Minute)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{
+			Amount:               1000,
+			SourceAccountID:      secondAccountID,
+			DestinationAccountID: firstAccountID,
+		})
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(s.env.GetWorkflowError(), &applicationErr)
+		s.Equal(TransferCancelledErrorType, applicationErr.Type())
+	})
+	s.Run("Transfer charges the fee computed from the fee schedule", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		clientFee := 0
+		feeRule := &fees.RuleReference{Code: "standard", Version: 2}
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.MatchedBy(func(request fees.QuoteRequest) bool {
+			return request.Amount == 1000 && !request.At.IsZero()
+		})).Return(&fees.Quote{Amount: 15, Currency: "USD", Rule: feeRule}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+			return params.FeeAmount != nil && *params.FeeAmount == 15 && *params.FeeRule == *feeRule
+		})).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000, FeeAmount: &clientFee})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+	s.Run("Scheduled transfer waits for its execution time and can be rescheduled", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		startTime := time.Date(2024, 9, 10, 9, 0, 0, 0, time.UTC)
+		executeAt := startTime.Add(time.Hour)
+		rescheduledAt := startTime.Add(3 * time.Hour)
+
+		s.env.SetStartTime(startTime)
+
+		s.env.RegisterDelayedCallback(func() {
+			encodedState, err := s.env.QueryWorkflow(TransferStateQuery)
+			s.NoError(err)
+
+			var state TransferState
+			s.NoError(encodedState.Get(&state))
+			s.Equal(constants.TransferStateSCHEDULED, state.Status)
+
+			s.env.SignalWorkflow(RescheduleTransferSignal, RescheduleTransferRequest{ExecuteAt: rescheduledAt})
+		}, 30*time.Minute)
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(func(_ context.Context, _ activities.MutexParams) (int64, error) {
+			s.False(s.env.Now().Before(rescheduledAt))
+
+			return 1, nil
+		})
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000, ExecuteAt: &executeAt})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+	s.Run("Transfer started before transfers could be scheduled runs right away", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		startTime := time.Date(2024, 9, 10, 9, 0, 0, 0, time.UTC)
+		executeAt := startTime.Add(time.Hour)
+
+		s.env.SetStartTime(startTime)
+
+		s.env.OnGetVersion(transferScheduleVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(func(_ context.Context, _ activities.MutexParams) (int64, error) {
+			s.True(s.env.Now().Before(executeAt))
+
+			return 1, nil
+		})
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000, ExecuteAt: &executeAt})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Cross-currency transfer posts with the quoted conversion", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		fxQuote := &fx.Quote{SourceCurrency: "USD", TargetCurrency: "EUR", Rate: 0.92, SourceAmount: 1000, TargetAmount: 915}
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(fxQuote, nil).Once()
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+			return params.FXQuote != nil && *params.FXQuote == *fxQuote
+		})).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+			return params.FXQuote != nil && *params.FXQuote == *fxQuote
+		}), mock.Anything).Return(&activities.TransferResult{FXQuote: fxQuote}, nil)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+
+		var result activities.TransferResult
+		s.NoError(s.env.GetWorkflowResult(&result))
+		s.Equal(915, result.FXQuote.TargetAmount)
+	})
+	s.Run("Transfer started before cross-currency transfers posts without a quote", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		s.env.OnGetVersion(transferFXVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.MatchedBy(func(params activities.TransferParams) bool {
+			return params.FXQuote == nil
+		})).Return(&activities.PendingTransactions{}, nil).Once()
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Scheduled transfer is cancelled before it starts", func() {
+		startTime := time.Date(2024, 9, 10, 9, 0, 0, 0, time.UTC)
+		executeAt := startTime.Add(24 * time.Hour)
+
+		s.env.SetStartTime(startTime)
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CancelTransferSignal, CancelTransferRequest{Reason: "no longer needed"})
+		}, time.Hour)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000, ExecuteAt: &executeAt})
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(s.env.GetWorkflowError(), &applicationErr)
+		s.Equal(TransferCancelledErrorType, applicationErr.Type())
+	})
+	s.Run("Transfer is cancelled while waiting for the account lock", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil).After(time.Hour)
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.OnActivity(transactionOperations.CancelTransactions, mock.Anything, mock.Anything, "sent to the wrong account").Return(nil).Once()
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CancelTransferSignal, CancelTransferRequest{Reason: "sent to the wrong account"})
+		}, time.Minute)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(s.env.GetWorkflowError(), &applicationErr)
+		s.Equal(TransferCancelledErrorType, applicationErr.Type())
+
+		var reason string
+		s.NoError(applicationErr.Details(&reason))
+		s.Equal("sent to the wrong account", reason)
+	})
+
+	s.Run("Transfer started before approvals locks the accounts first and isn't held for approval", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		var steps []string
+
+		s.env.OnGetVersion(transferApprovalVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(func(_ context.Context, _ activities.MutexParams) (int64, error) {
+			steps = append(steps, "lock")
+
+			return 1, nil
+		})
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(func(_ context.Context, _ fees.QuoteRequest) (*fees.Quote, error) {
+			steps = append(steps, "fee")
+
+			return &fees.Quote{}, nil
+		})
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000, SourceAccountID: uuid.New(), DestinationAccountID: uuid.New()})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+		s.Equal([]string{"lock", "lock", "fee"}, steps)
+	})
+	s.Run("Transfer started before it could be cancelled until the balances move ignores the cancel signal", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		s.env.OnGetVersion(transferCancellationVersion, workflow.DefaultVersion, 1).Return(workflow.DefaultVersion)
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil).After(time.Hour)
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CancelTransferSignal, CancelTransferRequest{Reason: "sent to the wrong account"})
+		}, time.Minute)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Transfer cancelled after its transactions are created fails them with the reason", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(int64(1), nil).Maybe()
+		s.env.OnActivity(redisActivity.ReleaseLock, mock.Anything, mock.Anything).Return(nil).Once()
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).Return(&approvals.Requirement{}, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).
+			Return(&activities.PendingTransactions{}, nil).After(time.Minute)
+
+		s.env.OnActivity(transactionOperations.CancelTransactions, mock.Anything, mock.Anything, "duplicate payment").Return(nil).Once()
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.RegisterDelayedCallback(func() {
+			s.env.SignalWorkflow(CancelTransferSignal, CancelTransferRequest{Reason: "duplicate payment"})
+		}, 30*time.Second)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 1000})
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(s.env.GetWorkflowError(), &applicationErr)
+		s.Equal(TransferCancelledErrorType, applicationErr.Type())
+	})
+	s.Run("High-value transfer waits for the approval before it takes the lock", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+		var redisActivity *activities.MutexOperations
+		var limitOperations *activities.LimitOperations
+
+		startTime := time.Date(2024, 9, 15, 9, 0, 0, 0, time.UTC)
+		s.env.SetStartTime(startTime)
+
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{Currency: "USD"}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, activities.ApprovalCheck{Currency: "USD", Amount: 5000000}).
+			Return(&approvals.Requirement{Required: true, Currency: "USD", Threshold: 1000000}, nil)
+
+		s.env.RegisterDelayedCallback(func() {
+			encodedState, err := s.env.QueryWorkflow(TransferStateQuery)
+			s.NoError(err)
+
+			var state TransferState
+			s.NoError(encodedState.Get(&state))
+			s.Equal(constants.TransferStateAWAITINGAPPROVAL, state.Status)
+
+			s.env.SignalWorkflow(ApproveTransferSignal, TransferApprovalRequest{Approver: "jane.doe"})
+		}, time.Hour)
+
+		isApproved := mock.MatchedBy(func(decision approvals.Decision) bool {
+			return decision.Decision == constants.ApprovalDecisionAPPROVED && decision.Approver == "jane.doe" && decision.Threshold == 1000000
+		})
+		s.env.OnActivity(approvalOperations.RecordDecision, mock.Anything, isApproved).Return(nil).Once()
+		s.env.OnActivity(transactionOperations.ApplyApprovalDecision, mock.Anything, mock.Anything, isApproved).Return(nil).Once()
+
+		s.env.OnActivity(redisActivity.AcquireLock, mock.Anything, mock.Anything).Return(func(_ context.Context, _ activities.MutexParams) (int64, error) {
+			s.False(s.env.Now().Before(startTime.Add(time.Hour)))
+
+			return 1, nil
+		})
+		s.env.OnActivity(limitOperations.CheckLimits, mock.Anything, mock.Anything).Return(nil)
+		s.env.OnActivity(transactionOperations.PostTransfer, mock.Anything, mock.Anything, mock.Anything).
+			Return(&activities.TransferResult{}, nil)
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 5000000})
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("High-value transfer is rejected when the approval times out", func() {
+		var transactionOperations *activities.TransactionOperations
+		var feeOperations *activities.FeeOperations
+		var fxOperations *activities.FXOperations
+		var approvalOperations *activities.ApprovalOperations
+
+		s.env.OnActivity(feeOperations.CalculateFee, mock.Anything, mock.Anything).Return(&fees.Quote{Currency: "USD"}, nil)
+		s.env.OnActivity(fxOperations.QuoteConversion, mock.Anything, mock.Anything).Return(nil, nil)
+		s.env.OnActivity(transactionOperations.CreatePendingTransactions, mock.Anything, mock.Anything).Return(&activities.PendingTransactions{}, nil)
+		s.env.OnActivity(approvalOperations.CheckApproval, mock.Anything, mock.Anything).
+			Return(&approvals.Requirement{Required: true, Currency: "USD", Threshold: 1000000}, nil)
+
+		isTimedOut := mock.MatchedBy(func(decision approvals.Decision) bool {
+			return decision.Decision == constants.ApprovalDecisionREJECTED && decision.Approver == approvals.SystemApprover
+		})
+		s.env.OnActivity(approvalOperations.RecordDecision, mock.Anything, isTimedOut).Return(nil).Once()
+		s.env.OnActivity(transactionOperations.ApplyApprovalDecision, mock.Anything, mock.Anything, isTimedOut).Return(nil).Once()
+		s.env.OnActivity(transactionOperations.FailTransactions, mock.Anything, mock.Anything).Return(nil).Once()
+
+		s.env.ExecuteWorkflow(Transfer, &TransferParams{Amount: 5000000})
+
+		s.True(s.env.IsWorkflowCompleted())
+
+		var applicationErr *temporal.ApplicationError
+		s.ErrorAs(s.env.GetWorkflowError(), &applicationErr)
+		s.Equal(TransferRejectedErrorType, applicationErr.Type())
+	})
 }
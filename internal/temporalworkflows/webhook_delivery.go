@@ -0,0 +1,71 @@
+package temporalworkflows
+
+import (
+	"errors"
+	"time"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+	"ulascansenturk/service/internal/webhooks"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/workflow"
+)
+
+const webhookDeliveryMaxAttempts = 10
+
+// webhookDeliveryActivityOptions back off exponentially between the attempts of a delivery, the last attempt is
+// sent about three hours after the first.
+var webhookDeliveryActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: time.Minute,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    30 * time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    time.Hour,
+		MaximumAttempts:    webhookDeliveryMaxAttempts,
+	},
+}
+
+var webhookFailureActivityOptions = workflow.ActivityOptions{
+	StartToCloseTimeout: time.Minute,
+	RetryPolicy: &temporal.RetryPolicy{
+		InitialInterval:    time.Second,
+		BackoffCoefficient: 2.0,
+		MaximumInterval:    time.Minute,
+		MaximumAttempts:    10,
+	},
+}
+
+// WebhookDelivery sends a delivery until its endpoint accepts it or the attempts run out, the delivery is then
+// failed and counts against its subscription. A delivery whose subscription was deleted ends quietly.
+func WebhookDelivery(ctx workflow.Context, params webhooks.DeliveryParams) (*webhooks.Delivery, error) {
+	var webhookOperations *activities.WebhookOperations
+
+	var delivery *webhooks.Delivery
+
+	deliveryCtx := workflow.WithActivityOptions(ctx, webhookDeliveryActivityOptions)
+
+	deliverErr := workflow.ExecuteActivity(deliveryCtx, webhookOperations.DeliverWebhook, params.DeliveryID).Get(ctx, &delivery)
+	if deliverErr == nil {
+		return delivery, nil
+	}
+
+	var appErr *temporal.ApplicationError
+	if errors.As(deliverErr, &appErr) && appErr.Type() == activities.WebhookGoneErrType {
+		return nil, nil
+	}
+
+	workflow.GetLogger(ctx).Warn("Webhook delivery failed", "DeliveryID", params.DeliveryID, "Error", deliverErr)
+
+	failureCtx := workflow.WithActivityOptions(ctx, webhookFailureActivityOptions)
+
+	err := workflow.ExecuteActivity(failureCtx, webhookOperations.FailWebhookDelivery, params.DeliveryID, deliverErr.Error()).
+		Get(ctx, &delivery)
+	if err != nil {
+		if errors.As(err, &appErr) && appErr.Type() == activities.WebhookGoneErrType {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return delivery, nil
+}
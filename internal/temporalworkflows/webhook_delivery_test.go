@@ -0,0 +1,105 @@
+//go:build tests_unit
+
+package temporalworkflows
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"testing"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/temporalworkflows/activities"
+	"ulascansenturk/service/internal/webhooks"
+
+	"go.temporal.io/sdk/temporal"
+	"go.temporal.io/sdk/testsuite"
+)
+
+type webhookDeliveryTestSuite struct {
+	suite.Suite
+	testsuite.WorkflowTestSuite
+
+	env *testsuite.TestWorkflowEnvironment
+}
+
+func (s *webhookDeliveryTestSuite) SetupSubTest() {
+	s.env = s.NewTestWorkflowEnvironment()
+
+	s.env.RegisterWorkflow(WebhookDelivery)
+}
+
+func (s *webhookDeliveryTestSuite) TearDownSubTest() {
+	s.env.AssertExpectations(s.T())
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	t.Parallel()
+
+	suite.Run(t, new(webhookDeliveryTestSuite))
+}
+
+func (s *webhookDeliveryTestSuite) TestWebhookDeliveryWorkflow() {
+	params := webhooks.DeliveryParams{DeliveryID: uuid.New()}
+
+	s.Run("Delivery accepted by the endpoint", func() {
+		var webhookOperations *activities.WebhookOperations
+
+		s.env.OnActivity(webhookOperations.DeliverWebhook, mock.Anything, params.DeliveryID).
+			Return(&webhooks.Delivery{ID: params.DeliveryID, Status: constants.WebhookDeliveryStatusSUCCEEDED, Attempts: 1}, nil).Once()
+
+		s.env.ExecuteWorkflow(WebhookDelivery, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+
+		var result *webhooks.Delivery
+		s.NoError(s.env.GetWorkflowResult(&result))
+		s.Equal(constants.WebhookDeliveryStatusSUCCEEDED, result.Status)
+	})
+
+	s.Run("Delivery is failed once the attempts run out", func() {
+		var webhookOperations *activities.WebhookOperations
+
+		s.env.OnActivity(webhookOperations.DeliverWebhook, mock.Anything, params.DeliveryID).
+			Return(nil, errors.New("webhook endpoint responded 503")).Times(webhookDeliveryMaxAttempts)
+		s.env.OnActivity(webhookOperations.FailWebhookDelivery, mock.Anything, params.DeliveryID, mock.MatchedBy(func(reason string) bool {
+			return reason != ""
+		})).Return(&webhooks.Delivery{ID: params.DeliveryID, Status: constants.WebhookDeliveryStatusFAILED}, nil).Once()
+
+		s.env.ExecuteWorkflow(WebhookDelivery, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+
+		var result *webhooks.Delivery
+		s.NoError(s.env.GetWorkflowResult(&result))
+		s.Equal(constants.WebhookDeliveryStatusFAILED, result.Status)
+	})
+
+	s.Run("Delivery of a disabled subscription is failed without retries", func() {
+		var webhookOperations *activities.WebhookOperations
+
+		s.env.OnActivity(webhookOperations.DeliverWebhook, mock.Anything, params.DeliveryID).
+			Return(nil, temporal.NewNonRetryableApplicationError("subscription is disabled", "webhook-err", webhooks.ErrSubscriptionDisabled)).Once()
+		s.env.OnActivity(webhookOperations.FailWebhookDelivery, mock.Anything, params.DeliveryID, mock.Anything).
+			Return(&webhooks.Delivery{ID: params.DeliveryID, Status: constants.WebhookDeliveryStatusFAILED}, nil).Once()
+
+		s.env.ExecuteWorkflow(WebhookDelivery, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+
+	s.Run("Deleted subscription ends the delivery quietly", func() {
+		var webhookOperations *activities.WebhookOperations
+
+		s.env.OnActivity(webhookOperations.DeliverWebhook, mock.Anything, params.DeliveryID).
+			Return(nil, temporal.NewNonRetryableApplicationError("webhook delivery not found", activities.WebhookGoneErrType, webhooks.ErrDeliveryNotFound)).Once()
+
+		s.env.ExecuteWorkflow(WebhookDelivery, params)
+
+		s.True(s.env.IsWorkflowCompleted())
+		s.NoError(s.env.GetWorkflowError())
+	})
+}
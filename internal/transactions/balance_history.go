@@ -0,0 +1,116 @@
+package transactions
+
+import (
+	"context"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+)
+
+type BalanceHistory interface {
+	GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*AccountBalance, error)
+	BackfillBalanceAfter(ctx context.Context) (int64, error)
+}
+
+// AccountBalance is the balance an account had at AsOf.
+type AccountBalance struct {
+	AccountID uuid.UUID
+	Currency  string
+	Balance   int
+	AsOf      time.Time
+}
+
+type BalanceHistoryService struct {
+	transactionRepo Repository
+	accountRepo     accounts.Repository
+}
+
+func NewBalanceHistoryService(transactionRepo Repository, accountRepo accounts.Repository) *BalanceHistoryService {
+	return &BalanceHistoryService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+	}
+}
+
+// GetBalanceAsOf answers from the balance_after of the transactions of the account. The balance at asOf is the one
+// left by the last posting at or before it, or the one the first posting after it started from. An account without
+// postings around asOf still has the balance it was opened with, and had nothing before it was opened.
+func (s *BalanceHistoryService) GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*AccountBalance, error) {
+	account, err := s.accountRepo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, accounts.ErrAccountNotFound
+	}
+
+	result := &AccountBalance{
+		AccountID: account.ID,
+		Currency:  account.Currency,
+		AsOf:      asOf,
+	}
+
+	if account.CreatedAt.After(asOf) {
+		return result, nil
+	}
+
+	balance, err := s.transactionRepo.GetBalanceAfter(ctx, account.ID, asOf)
+	if err != nil {
+		return nil, err
+	}
+
+	if balance == nil {
+		balance, err = s.transactionRepo.GetBalanceBeforeNextPosting(ctx, account.ID, asOf)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if balance == nil {
+		balance = &account.Balance
+	}
+
+	result.Balance = *balance
+
+	return result, nil
+}
+
+// BackfillBalanceAfter writes the balance_after of the SUCCESS transactions posted before it was recorded, account by
+// account. Each account is locked like for a posting while its transactions are walked back from its balance.
+func (s *BalanceHistoryService) BackfillBalanceAfter(ctx context.Context) (int64, error) {
+	accountIDs, err := s.transactionRepo.ListAccountIDsWithoutBalanceAfter(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var backfilled int64
+
+	for _, accountID := range accountIDs {
+		err = s.accountRepo.Transaction(ctx, func(tx *gorm.DB) error {
+			account, lockErr := s.accountRepo.GetByIDForUpdate(ctx, accountID, tx)
+			if lockErr != nil {
+				return lockErr
+			}
+
+			if account == nil {
+				return nil
+			}
+
+			updated, backfillErr := s.transactionRepo.BackfillBalanceAfterWithTx(ctx, account.ID, account.Balance, tx)
+			if backfillErr != nil {
+				return backfillErr
+			}
+
+			backfilled += updated
+
+			return nil
+		})
+		if err != nil {
+			return backfilled, err
+		}
+	}
+
+	return backfilled, nil
+}
@@ -0,0 +1,176 @@
+//go:build tests_unit
+
+package transactions_test
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/transactions"
+)
+
+func TestBalanceHistoryService_GetBalanceAsOf(t *testing.T) {
+	accountID := uuid.New()
+	openedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	asOf := time.Date(2024, 3, 31, 23, 59, 59, 0, time.UTC)
+
+	newService := func(t *testing.T) (*transactions.BalanceHistoryService, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		gormDB, err := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		require.NoError(t, err)
+
+		return transactions.NewBalanceHistoryService(
+			transactions.NewSQLRepository(gormDB),
+			accounts.NewSQLRepository(gormDB),
+		), mock
+	}
+
+	expectAccount := func(mock sqlmock.Sqlmock, createdAt time.Time, balance int) {
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1`).
+			WithArgs(accountID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "currency", "created_at"}).
+				AddRow(accountID, balance, "USD", createdAt))
+	}
+
+	expectBalanceAfter := func(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+		mock.ExpectQuery(`SELECT "balance_after" FROM "transactions" WHERE .*posted_at <= \$3 ORDER BY posted_at DESC LIMIT \$4`).
+			WillReturnRows(rows)
+	}
+
+	expectBalanceBeforeNextPosting := func(mock sqlmock.Sqlmock, rows *sqlmock.Rows) {
+		mock.ExpectQuery(`SELECT balance_after - SUM\(.*\) FROM transactions`).
+			WillReturnRows(rows)
+	}
+
+	t.Run("Balance left by the last posting at or before as of", func(t *testing.T) {
+		service, mock := newService(t)
+
+		expectAccount(mock, openedAt, 900)
+		expectBalanceAfter(mock, sqlmock.NewRows([]string{"balance_after"}).AddRow(1200))
+
+		balance, err := service.GetBalanceAsOf(context.Background(), accountID, asOf)
+		require.NoError(t, err)
+		assert.Equal(t, &transactions.AccountBalance{
+			AccountID: accountID,
+			Currency:  "USD",
+			Balance:   1200,
+			AsOf:      asOf,
+		}, balance)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Balance before the first posting after as of", func(t *testing.T) {
+		service, mock := newService(t)
+
+		expectAccount(mock, openedAt, 900)
+		expectBalanceAfter(mock, sqlmock.NewRows([]string{"balance_after"}))
+		expectBalanceBeforeNextPosting(mock, sqlmock.NewRows([]string{"balance"}).AddRow(1000))
+
+		balance, err := service.GetBalanceAsOf(context.Background(), accountID, asOf)
+		require.NoError(t, err)
+		assert.Equal(t, 1000, balance.Balance)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Account without postings has the balance it was opened with", func(t *testing.T) {
+		service, mock := newService(t)
+
+		expectAccount(mock, openedAt, 900)
+		expectBalanceAfter(mock, sqlmock.NewRows([]string{"balance_after"}))
+		expectBalanceBeforeNextPosting(mock, sqlmock.NewRows([]string{"balance"}))
+
+		balance, err := service.GetBalanceAsOf(context.Background(), accountID, asOf)
+		require.NoError(t, err)
+		assert.Equal(t, 900, balance.Balance)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Account opened after as of had nothing", func(t *testing.T) {
+		service, mock := newService(t)
+
+		expectAccount(mock, asOf.Add(time.Hour), 900)
+
+		balance, err := service.GetBalanceAsOf(context.Background(), accountID, asOf)
+		require.NoError(t, err)
+		assert.Equal(t, 0, balance.Balance)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Unknown account", func(t *testing.T) {
+		service, mock := newService(t)
+
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1`).
+			WithArgs(accountID, 1).
+			WillReturnError(gorm.ErrRecordNotFound)
+
+		_, err := service.GetBalanceAsOf(context.Background(), accountID, asOf)
+		assert.ErrorIs(t, err, accounts.ErrAccountNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBalanceHistoryService_BackfillBalanceAfter(t *testing.T) {
+	accountID := uuid.New()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	service := transactions.NewBalanceHistoryService(
+		transactions.NewSQLRepository(gormDB),
+		accounts.NewSQLRepository(gormDB),
+	)
+
+	// The OUTGOING_FEE transactions marked SUCCESS before fees were posted have no INCOMING_FEE, they are neither
+	// listed nor walked back.
+	legacyFeeFilter := `\(transactions.transaction_type <> \$\d OR EXISTS \( SELECT 1 FROM transactions incoming_fees ` +
+		`WHERE incoming_fees.transaction_type = \$\d AND incoming_fees.status = \$\d ` +
+		`AND incoming_fees.metadata->>'OutgoingFeeReferenceID' = transactions.reference_id::text \)\)`
+
+	mock.ExpectQuery(`SELECT DISTINCT "account_id" FROM "transactions" WHERE status = \$1 AND balance_after IS NULL AND `+legacyFeeFilter).
+		WithArgs(constants.TransactionStatusSUCCESS, constants.TransactionTypeOUTGOINGFEE, constants.TransactionTypeINCOMINGFEE, constants.TransactionStatusSUCCESS).
+		WillReturnRows(sqlmock.NewRows([]string{"account_id"}).AddRow(accountID))
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1 .*FOR UPDATE`).
+		WithArgs(accountID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "currency"}).AddRow(accountID, 900, "USD"))
+	mock.ExpectExec(`WITH movements AS \(.*WHERE account_id = \$\d+ AND status = \$\d+ AND `+legacyFeeFilter+`.*UPDATE transactions`).
+		WithArgs(
+			constants.TransactionTypeINBOUND,
+			constants.TransactionTypeINCOMINGFEE,
+			constants.TransactionTypeREVERSALINBOUND,
+			constants.TransactionTypeFEEREVERSALINBOUND,
+			accountID,
+			constants.TransactionStatusSUCCESS,
+			constants.TransactionTypeOUTGOINGFEE,
+			constants.TransactionTypeINCOMINGFEE,
+			constants.TransactionStatusSUCCESS,
+			900,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	backfilled, err := service.BackfillBalanceAfter(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), backfilled)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,119 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	transactions "ulascansenturk/service/internal/transactions"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockPoster is an autogenerated mock type for the Poster type
+type MockPoster struct {
+	mock.Mock
+}
+
+// PostCapture provides a mock function with given fields: ctx, params
+func (_m *MockPoster) PostCapture(ctx context.Context, params *transactions.CapturePosting) ([]*transactions.Transaction, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostCapture")
+	}
+
+	var r0 []*transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.CapturePosting) ([]*transactions.Transaction, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.CapturePosting) []*transactions.Transaction); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *transactions.CapturePosting) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostReversal provides a mock function with given fields: ctx, params
+func (_m *MockPoster) PostReversal(ctx context.Context, params *transactions.ReversalPosting) ([]*transactions.Transaction, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostReversal")
+	}
+
+	var r0 []*transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.ReversalPosting) ([]*transactions.Transaction, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.ReversalPosting) []*transactions.Transaction); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *transactions.ReversalPosting) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PostTransfer provides a mock function with given fields: ctx, params
+func (_m *MockPoster) PostTransfer(ctx context.Context, params *transactions.TransferPosting) ([]*transactions.Transaction, error) {
+	ret := _m.Called(ctx, params)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PostTransfer")
+	}
+
+	var r0 []*transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.TransferPosting) ([]*transactions.Transaction, error)); ok {
+		return rf(ctx, params)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.TransferPosting) []*transactions.Transaction); ok {
+		r0 = rf(ctx, params)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *transactions.TransferPosting) error); ok {
+		r1 = rf(ctx, params)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockPoster creates a new instance of MockPoster. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockPoster(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockPoster {
+	mock := &MockPoster{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
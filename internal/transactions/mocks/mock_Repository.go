@@ -0,0 +1,594 @@
+// Code generated by mockery. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	time "time"
+	constants "ulascansenturk/service/internal/constants"
+	transactions "ulascansenturk/service/internal/transactions"
+
+	uuid "github.com/google/uuid"
+	mock "github.com/stretchr/testify/mock"
+	datatypes "gorm.io/datatypes"
+	gorm "gorm.io/gorm"
+)
+
+// MockRepository is an autogenerated mock type for the Repository type
+type MockRepository struct {
+	mock.Mock
+}
+
+// BackfillBalanceAfterWithTx provides a mock function with given fields: ctx, accountID, balance, tx
+func (_m *MockRepository) BackfillBalanceAfterWithTx(ctx context.Context, accountID uuid.UUID, balance int, tx *gorm.DB) (int64, error) {
+	ret := _m.Called(ctx, accountID, balance, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for BackfillBalanceAfterWithTx")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *gorm.DB) (int64, error)); ok {
+		return rf(ctx, accountID, balance, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *gorm.DB) int64); ok {
+		r0 = rf(ctx, accountID, balance, tx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, int, *gorm.DB) error); ok {
+		r1 = rf(ctx, accountID, balance, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, transaction
+func (_m *MockRepository) Create(ctx context.Context, transaction *transactions.Transaction) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Create")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.Transaction) (*transactions.Transaction, error)); ok {
+		return rf(ctx, transaction)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.Transaction) *transactions.Transaction); ok {
+		r0 = rf(ctx, transaction)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *transactions.Transaction) error); ok {
+		r1 = rf(ctx, transaction)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DB provides a mock function with given fields:
+func (_m *MockRepository) DB() *gorm.DB {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for DB")
+	}
+
+	var r0 *gorm.DB
+	if rf, ok := ret.Get(0).(func() *gorm.DB); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gorm.DB)
+		}
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, id
+func (_m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetBalanceAfter provides a mock function with given fields: ctx, accountID, asOf
+func (_m *MockRepository) GetBalanceAfter(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*int, error) {
+	ret := _m.Called(ctx, accountID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceAfter")
+	}
+
+	var r0 *int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*int, error)); ok {
+		return rf(ctx, accountID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *int); ok {
+		r0 = rf(ctx, accountID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, accountID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBalanceBeforeNextPosting provides a mock function with given fields: ctx, accountID, asOf
+func (_m *MockRepository) GetBalanceBeforeNextPosting(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*int, error) {
+	ret := _m.Called(ctx, accountID, asOf)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBalanceBeforeNextPosting")
+	}
+
+	var r0 *int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) (*int, error)); ok {
+		return rf(ctx, accountID, asOf)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, time.Time) *int); ok {
+		r0 = rf(ctx, accountID, asOf)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*int)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, time.Time) error); ok {
+		r1 = rf(ctx, accountID, asOf)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByCreatedAt provides a mock function with given fields: ctx, createdAt
+func (_m *MockRepository) GetByCreatedAt(ctx context.Context, createdAt time.Time) ([]*transactions.Transaction, error) {
+	ret := _m.Called(ctx, createdAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByCreatedAt")
+	}
+
+	var r0 []*transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]*transactions.Transaction, error)); ok {
+		return rf(ctx, createdAt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []*transactions.Transaction); ok {
+		r0 = rf(ctx, createdAt)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, createdAt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByFromAccountID provides a mock function with given fields: ctx, fromAccountID
+func (_m *MockRepository) GetByFromAccountID(ctx context.Context, fromAccountID uuid.UUID) ([]*transactions.Transaction, error) {
+	ret := _m.Called(ctx, fromAccountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByFromAccountID")
+	}
+
+	var r0 []*transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*transactions.Transaction, error)); ok {
+		return rf(ctx, fromAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*transactions.Transaction); ok {
+		r0 = rf(ctx, fromAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, fromAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *MockRepository) GetByID(ctx context.Context, id uuid.UUID) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByID")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*transactions.Transaction, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *transactions.Transaction); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByIDForUpdate provides a mock function with given fields: ctx, transactionID, tx
+func (_m *MockRepository) GetByIDForUpdate(ctx context.Context, transactionID uuid.UUID, tx *gorm.DB) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, transactionID, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByIDForUpdate")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) (*transactions.Transaction, error)); ok {
+		return rf(ctx, transactionID, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *gorm.DB) *transactions.Transaction); ok {
+		r0 = rf(ctx, transactionID, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, *gorm.DB) error); ok {
+		r1 = rf(ctx, transactionID, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByReferenceID provides a mock function with given fields: ctx, referenceID
+func (_m *MockRepository) GetByReferenceID(ctx context.Context, referenceID uuid.UUID) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, referenceID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByReferenceID")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*transactions.Transaction, error)); ok {
+		return rf(ctx, referenceID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *transactions.Transaction); ok {
+		r0 = rf(ctx, referenceID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, referenceID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByToAccountID provides a mock function with given fields: ctx, toAccountID
+func (_m *MockRepository) GetByToAccountID(ctx context.Context, toAccountID uuid.UUID) ([]*transactions.Transaction, error) {
+	ret := _m.Called(ctx, toAccountID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetByToAccountID")
+	}
+
+	var r0 []*transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]*transactions.Transaction, error)); ok {
+		return rf(ctx, toAccountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []*transactions.Transaction); ok {
+		r0 = rf(ctx, toAccountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, toAccountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListAccountIDsWithoutBalanceAfter provides a mock function with given fields: ctx
+func (_m *MockRepository) ListAccountIDsWithoutBalanceAfter(ctx context.Context) ([]uuid.UUID, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListAccountIDsWithoutBalanceAfter")
+	}
+
+	var r0 []uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]uuid.UUID, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []uuid.UUID); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkPostedWithTx provides a mock function with given fields: ctx, transaction, balanceAfter, postedAt, tx
+func (_m *MockRepository) MarkPostedWithTx(ctx context.Context, transaction transactions.Transaction, balanceAfter *int, postedAt time.Time, tx *gorm.DB) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, transaction, balanceAfter, postedAt, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkPostedWithTx")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.Transaction, *int, time.Time, *gorm.DB) (*transactions.Transaction, error)); ok {
+		return rf(ctx, transaction, balanceAfter, postedAt, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.Transaction, *int, time.Time, *gorm.DB) *transactions.Transaction); ok {
+		r0 = rf(ctx, transaction, balanceAfter, postedAt, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, transactions.Transaction, *int, time.Time, *gorm.DB) error); ok {
+		r1 = rf(ctx, transaction, balanceAfter, postedAt, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumReversedAmount provides a mock function with given fields: ctx, originalTransactionID, transactionType
+func (_m *MockRepository) SumReversedAmount(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType) (int, error) {
+	ret := _m.Called(ctx, originalTransactionID, transactionType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumReversedAmount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.TransactionType) (int, error)); ok {
+		return rf(ctx, originalTransactionID, transactionType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.TransactionType) int); ok {
+		r0 = rf(ctx, originalTransactionID, transactionType)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, constants.TransactionType) error); ok {
+		r1 = rf(ctx, originalTransactionID, transactionType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SumReversedAmountWithTx provides a mock function with given fields: ctx, originalTransactionID, transactionType, tx
+func (_m *MockRepository) SumReversedAmountWithTx(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType, tx *gorm.DB) (int, error) {
+	ret := _m.Called(ctx, originalTransactionID, transactionType, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SumReversedAmountWithTx")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.TransactionType, *gorm.DB) (int, error)); ok {
+		return rf(ctx, originalTransactionID, transactionType, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.TransactionType, *gorm.DB) int); ok {
+		r0 = rf(ctx, originalTransactionID, transactionType, tx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, constants.TransactionType, *gorm.DB) error); ok {
+		r1 = rf(ctx, originalTransactionID, transactionType, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Transaction provides a mock function with given fields: ctx, fn
+func (_m *MockRepository) Transaction(ctx context.Context, fn func(*gorm.DB) (interface{}, error)) (interface{}, error) {
+	ret := _m.Called(ctx, fn)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Transaction")
+	}
+
+	var r0 interface{}
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, func(*gorm.DB) (interface{}, error)) (interface{}, error)); ok {
+		return rf(ctx, fn)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, func(*gorm.DB) (interface{}, error)) interface{}); ok {
+		r0 = rf(ctx, fn)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(interface{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, func(*gorm.DB) (interface{}, error)) error); ok {
+		r1 = rf(ctx, fn)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, transaction
+func (_m *MockRepository) Update(ctx context.Context, transaction *transactions.Transaction) error {
+	ret := _m.Called(ctx, transaction)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Update")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *transactions.Transaction) error); ok {
+		r0 = rf(ctx, transaction)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateStatusAndMetadataWithTx provides a mock function with given fields: ctx, transaction, status, metadata, tx
+func (_m *MockRepository) UpdateStatusAndMetadataWithTx(ctx context.Context, transaction transactions.Transaction, status constants.TransactionStatus, metadata datatypes.JSONMap, tx *gorm.DB) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, transaction, status, metadata, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatusAndMetadataWithTx")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.Transaction, constants.TransactionStatus, datatypes.JSONMap, *gorm.DB) (*transactions.Transaction, error)); ok {
+		return rf(ctx, transaction, status, metadata, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.Transaction, constants.TransactionStatus, datatypes.JSONMap, *gorm.DB) *transactions.Transaction); ok {
+		r0 = rf(ctx, transaction, status, metadata, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, transactions.Transaction, constants.TransactionStatus, datatypes.JSONMap, *gorm.DB) error); ok {
+		r1 = rf(ctx, transaction, status, metadata, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateStatusWithTx provides a mock function with given fields: ctx, transaction, status, tx
+func (_m *MockRepository) UpdateStatusWithTx(ctx context.Context, transaction transactions.Transaction, status constants.TransactionStatus, tx *gorm.DB) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, transaction, status, tx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateStatusWithTx")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.Transaction, constants.TransactionStatus, *gorm.DB) (*transactions.Transaction, error)); ok {
+		return rf(ctx, transaction, status, tx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, transactions.Transaction, constants.TransactionStatus, *gorm.DB) *transactions.Transaction); ok {
+		r0 = rf(ctx, transaction, status, tx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, transactions.Transaction, constants.TransactionStatus, *gorm.DB) error); ok {
+		r1 = rf(ctx, transaction, status, tx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockRepository creates a new instance of MockRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockRepository {
+	mock := &MockRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
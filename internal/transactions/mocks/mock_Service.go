@@ -70,6 +70,64 @@ func (_m *MockService) DeleteTransaction(ctx context.Context, id uuid.UUID) erro
 	return r0
 }
 
+// FailTransaction provides a mock function with given fields: ctx, id, metadata
+func (_m *MockService) FailTransaction(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, id, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailTransaction")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) (*transactions.Transaction, error)); ok {
+		return rf(ctx, id, metadata)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) *transactions.Transaction); ok {
+		r0 = rf(ctx, id, metadata)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, metadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReversedAmount provides a mock function with given fields: ctx, originalTransactionID, transactionType
+func (_m *MockService) GetReversedAmount(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType) (int, error) {
+	ret := _m.Called(ctx, originalTransactionID, transactionType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReversedAmount")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.TransactionType) (int, error)); ok {
+		return rf(ctx, originalTransactionID, transactionType)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, constants.TransactionType) int); ok {
+		r0 = rf(ctx, originalTransactionID, transactionType)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, constants.TransactionType) error); ok {
+		r1 = rf(ctx, originalTransactionID, transactionType)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTransactionByID provides a mock function with given fields: ctx, id
 func (_m *MockService) GetTransactionByID(ctx context.Context, id uuid.UUID) (*transactions.Transaction, error) {
 	ret := _m.Called(ctx, id)
@@ -238,6 +296,36 @@ func (_m *MockService) UpdateTransaction(ctx context.Context, transaction *trans
 	return r0
 }
 
+// UpdateTransactionMetadata provides a mock function with given fields: ctx, id, metadata
+func (_m *MockService) UpdateTransactionMetadata(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) (*transactions.Transaction, error) {
+	ret := _m.Called(ctx, id, metadata)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateTransactionMetadata")
+	}
+
+	var r0 *transactions.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) (*transactions.Transaction, error)); ok {
+		return rf(ctx, id, metadata)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, map[string]interface{}) *transactions.Transaction); ok {
+		r0 = rf(ctx, id, metadata)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*transactions.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID, map[string]interface{}) error); ok {
+		r1 = rf(ctx, id, metadata)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpdateTransactionStatus provides a mock function with given fields: ctx, id, status
 func (_m *MockService) UpdateTransactionStatus(ctx context.Context, id uuid.UUID, status constants.TransactionStatus) (*transactions.Transaction, error) {
 	ret := _m.Called(ctx, id, status)
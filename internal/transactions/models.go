@@ -8,16 +8,30 @@ import (
 	"ulascansenturk/service/internal/constants"
 )
 
+// Transaction is a single balance movement of an account. OriginalTransactionID links a reversal
+// transaction to the transaction it reverses. BalanceAfter is the balance the account was left with by the posting
+// that settled the transaction at PostedAt, the transactions settled by the same posting share both.
 type Transaction struct {
-	ID              uuid.UUID                   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id,omitempty"`
-	UserID          *uuid.UUID                  `gorm:"type:uuid" json:"user_id,omitempty"`
-	Amount          int                         `gorm:"type:integer" json:"amount"`
-	AccountID       uuid.UUID                   `gorm:"type:uuid" json:"account_id"`
-	CurrencyCode    constants.CurrencyCode      `gorm:"type:varchar(3)" json:"currency_code"`
-	ReferenceID     uuid.UUID                   `gorm:"type:uuid" json:"reference_id"`
-	Metadata        datatypes.JSONMap           `gorm:"type:jsonb" json:"metadata"`
-	Status          constants.TransactionStatus `gorm:"type:varchar(50)" json:"status"`
-	TransactionType constants.TransactionType   `gorm:"type:varchar(50)" json:"transaction_type"`
-	CreatedAt       time.Time                   `gorm:"type:timestamptz;default:now()" json:"created_at,omitempty"`
-	UpdatedAt       time.Time                   `gorm:"type:timestamptz;default:now();autoUpdateTime()" json:"updated_at,omitempty"`
+	ID                    uuid.UUID                   `gorm:"type:uuid;primary_key;default:uuid_generate_v4()" json:"id,omitempty"`
+	UserID                *uuid.UUID                  `gorm:"type:uuid" json:"user_id,omitempty"`
+	Amount                int                         `gorm:"type:integer" json:"amount"`
+	AccountID             uuid.UUID                   `gorm:"type:uuid" json:"account_id"`
+	CurrencyCode          constants.CurrencyCode      `gorm:"type:varchar(3)" json:"currency_code"`
+	ReferenceID           uuid.UUID                   `gorm:"type:uuid" json:"reference_id"`
+	Metadata              datatypes.JSONMap           `gorm:"type:jsonb" json:"metadata"`
+	Status                constants.TransactionStatus `gorm:"type:varchar(50)" json:"status"`
+	TransactionType       constants.TransactionType   `gorm:"type:varchar(50)" json:"transaction_type"`
+	OriginalTransactionID *uuid.UUID                  `gorm:"type:uuid" json:"original_transaction_id,omitempty"`
+	BalanceAfter          *int                        `gorm:"type:bigint" json:"balance_after,omitempty"`
+	PostedAt              *time.Time                  `gorm:"type:timestamptz" json:"posted_at,omitempty"`
+	CreatedAt             time.Time                   `gorm:"type:timestamptz;default:now()" json:"created_at,omitempty"`
+	UpdatedAt             time.Time                   `gorm:"type:timestamptz;default:now();autoUpdateTime()" json:"updated_at,omitempty"`
+}
+
+// creditTransactionTypes are the types of transactions that add to the balance of their account.
+var creditTransactionTypes = []constants.TransactionType{
+	constants.TransactionTypeINBOUND,
+	constants.TransactionTypeINCOMINGFEE,
+	constants.TransactionTypeREVERSALINBOUND,
+	constants.TransactionTypeFEEREVERSALINBOUND,
 }
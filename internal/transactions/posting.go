@@ -0,0 +1,732 @@
+package transactions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"sort"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/holds"
+	"ulascansenturk/service/internal/ledger"
+	"ulascansenturk/service/internal/outbox"
+)
+
+var (
+	ErrInsufficientFunds      = errors.New("insufficient funds")
+	ErrTransactionNotPostable = errors.New("transaction is not postable")
+	ErrReversalExceedsAmount  = errors.New("reversal exceeds the reversible amount")
+	ErrStaleFencingToken      = errors.New("account lock was taken over by another writer")
+)
+
+type Poster interface {
+	PostTransfer(ctx context.Context, params *TransferPosting) ([]*Transaction, error)
+	PostReversal(ctx context.Context, params *ReversalPosting) ([]*Transaction, error)
+	PostCapture(ctx context.Context, params *CapturePosting) ([]*Transaction, error)
+}
+
+// TransferPosting describes the balance movements of a transfer and the transactions they settle.
+// FeeAccountID is the account credited with FeeAmount, it is only required when a fee is charged.
+// DestinationAmount is credited to the destination when set, a cross-currency transfer credits the converted amount.
+// FencingTokens are the fencing tokens of the account locks the posting is made under, see checkFencingTokens.
+type TransferPosting struct {
+	SourceAccountID      uuid.UUID
+	DestinationAccountID uuid.UUID
+	FeeAccountID         uuid.UUID
+	Amount               int
+	DestinationAmount    int
+	FeeAmount            int
+	TransactionIDs       []uuid.UUID
+	FencingTokens        map[uuid.UUID]int64
+}
+
+// ReversalPosting describes the balance movements of a reversal and the transactions they settle.
+// The principal moves back from DebitAccountID to CreditAccountID, DebitAmount and CreditAmount only differ
+// for a cross-currency transfer. A fee refund moves FeeAmount from FeeAccountID to CreditAccountID.
+// OriginalTransactionID and OriginalFeeTransactionID are the outgoing transactions of the reversed transfer,
+// the reversals linked to them can never add up to more than their amount.
+type ReversalPosting struct {
+	OriginalTransactionID    uuid.UUID
+	OriginalFeeTransactionID uuid.UUID
+	DebitAccountID           uuid.UUID
+	CreditAccountID          uuid.UUID
+	FeeAccountID             uuid.UUID
+	DebitAmount              int
+	CreditAmount             int
+	FeeAmount                int
+	TransactionIDs           []uuid.UUID
+	FencingTokens            map[uuid.UUID]int64
+}
+
+// CapturePosting describes the capture of a hold and the transactions it settles. Amount moves from the source
+// account of the hold to its destination and the rest of the held amount is released.
+type CapturePosting struct {
+	HoldID               uuid.UUID
+	SourceAccountID      uuid.UUID
+	DestinationAccountID uuid.UUID
+	Amount               int
+	TransactionIDs       []uuid.UUID
+	CapturedAt           time.Time
+	FencingTokens        map[uuid.UUID]int64
+}
+
+// PostingService records every balance movement as a balanced journal entry of the ledger, the balances of the
+// accounts are moved by the postings of the entries. The events of a posting are written to the outbox in its
+// database transaction.
+type PostingService struct {
+	transactionRepo Repository
+	accountRepo     accounts.Repository
+	holdRepo        holds.Repository
+	ledgerService   ledger.Service
+	outboxService   outbox.Service
+}
+
+func NewPostingService(
+	transactionRepo Repository,
+	accountRepo accounts.Repository,
+	holdRepo holds.Repository,
+	ledgerService ledger.Service,
+	outboxService outbox.Service,
+) *PostingService {
+	return &PostingService{
+		transactionRepo: transactionRepo,
+		accountRepo:     accountRepo,
+		holdRepo:        holdRepo,
+		ledgerService:   ledgerService,
+		outboxService:   outboxService,
+	}
+}
+
+// PostTransfer moves the balances of a transfer and flips its linked transactions to SUCCESS in a single
+// database transaction. Accounts are locked in a consistent order so concurrent postings can't deadlock.
+// Posting an already posted transfer returns its transactions without moving the balances again.
+func (s *PostingService) PostTransfer(ctx context.Context, params *TransferPosting) ([]*Transaction, error) {
+	var postedTransactions []*Transaction
+
+	err := s.accountRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		accountIDs := []uuid.UUID{params.SourceAccountID, params.DestinationAccountID}
+		if params.FeeAmount > 0 {
+			if params.FeeAccountID == uuid.Nil {
+				return errors.New("fee account is required to post a fee")
+			}
+
+			accountIDs = append(accountIDs, params.FeeAccountID)
+		}
+
+		lockedAccounts, lockErr := s.lockAccounts(ctx, tx, accountIDs...)
+		if lockErr != nil {
+			return lockErr
+		}
+
+		linkedTransactions, alreadyPosted, trxErr := s.lockTransactions(ctx, tx, params.TransactionIDs)
+		if trxErr != nil {
+			return trxErr
+		}
+
+		if alreadyPosted {
+			postedTransactions = linkedTransactions
+
+			return nil
+		}
+
+		fencingErr := s.checkFencingTokens(ctx, tx, lockedAccounts, params.FencingTokens)
+		if fencingErr != nil {
+			return fencingErr
+		}
+
+		sourceAccount := lockedAccounts[params.SourceAccountID]
+		destinationAccount := lockedAccounts[params.DestinationAccountID]
+
+		totalAmount := params.Amount + params.FeeAmount
+		if sourceAccount.AvailableBalance() < totalAmount {
+			return fmt.Errorf("%w: transfer amount: %d, available balance: %d", ErrInsufficientFunds, totalAmount, sourceAccount.AvailableBalance())
+		}
+
+		creditAmount := params.Amount
+		if params.DestinationAmount > 0 {
+			creditAmount = params.DestinationAmount
+		}
+
+		entries := []*ledger.JournalEntry{
+			ledger.NewJournalEntry(constants.JournalEntryTypeTRANSFER).Move(
+				customerLeg(sourceAccount, linkedTransactions, constants.TransactionTypeOUTBOUND),
+				customerLeg(destinationAccount, linkedTransactions, constants.TransactionTypeINBOUND),
+				params.Amount,
+				creditAmount,
+			),
+		}
+
+		updatedAccounts := []*accounts.Account{sourceAccount, destinationAccount}
+
+		if params.FeeAmount > 0 {
+			feeAccount := lockedAccounts[params.FeeAccountID]
+
+			entries = append(entries, ledger.NewJournalEntry(constants.JournalEntryTypeFEE).Move(
+				customerLeg(sourceAccount, linkedTransactions, constants.TransactionTypeOUTGOINGFEE),
+				feeIncomeLeg(feeAccount, linkedTransactions, constants.TransactionTypeINCOMINGFEE),
+				params.FeeAmount,
+				params.FeeAmount,
+			))
+
+			updatedAccounts = append(updatedAccounts, feeAccount)
+		}
+
+		previousBalances := balancesOf(lockedAccounts)
+
+		if postErr := s.postEntries(ctx, tx, lockedAccounts, entries); postErr != nil {
+			return postErr
+		}
+
+		for _, account := range updatedAccounts {
+			if updateErr := s.accountRepo.UpdateBalanceWithTx(ctx, account.ID, account.Balance, tx); updateErr != nil {
+				return updateErr
+			}
+		}
+
+		postedAt := time.Now().UTC()
+
+		var markErr error
+
+		postedTransactions, markErr = s.markPosted(ctx, tx, lockedAccounts, linkedTransactions, postedAt)
+		if markErr != nil {
+			return markErr
+		}
+
+		return s.recordPosted(ctx, tx, previousBalances, updatedAccounts, postedTransactions, postedAt, &outbox.TransferCompleted{
+			ReferenceID:          referenceID(linkedTransactions, sourceAccount.ID, constants.TransactionTypeOUTBOUND),
+			SourceAccountID:      sourceAccount.ID,
+			DestinationAccountID: destinationAccount.ID,
+			Amount:               params.Amount,
+			Currency:             sourceAccount.Currency,
+			DestinationAmount:    creditAmount,
+			DestinationCurrency:  destinationAccount.Currency,
+			FeeAmount:            params.FeeAmount,
+			TransactionIDs:       params.TransactionIDs,
+			CompletedAt:          postedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return postedTransactions, nil
+}
+
+// PostReversal moves the balances of a reversal back and flips its linked transactions to SUCCESS in a single
+// database transaction. The original transactions are locked while the already reversed amounts are checked,
+// so concurrent reversals of the same transfer can't reverse more than it moved.
+// Posting an already posted reversal returns its transactions without moving the balances again.
+func (s *PostingService) PostReversal(ctx context.Context, params *ReversalPosting) ([]*Transaction, error) {
+	var postedTransactions []*Transaction
+
+	err := s.accountRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		accountIDs := []uuid.UUID{params.DebitAccountID, params.CreditAccountID}
+		if params.FeeAmount > 0 {
+			if params.FeeAccountID == uuid.Nil || params.OriginalFeeTransactionID == uuid.Nil {
+				return errors.New("fee account and original fee transaction are required to refund a fee")
+			}
+
+			accountIDs = append(accountIDs, params.FeeAccountID)
+		}
+
+		lockedAccounts, lockErr := s.lockAccounts(ctx, tx, accountIDs...)
+		if lockErr != nil {
+			return lockErr
+		}
+
+		linkedTransactions, alreadyPosted, trxErr := s.lockTransactions(ctx, tx, params.TransactionIDs)
+		if trxErr != nil {
+			return trxErr
+		}
+
+		if alreadyPosted {
+			postedTransactions = linkedTransactions
+
+			return nil
+		}
+
+		fencingErr := s.checkFencingTokens(ctx, tx, lockedAccounts, params.FencingTokens)
+		if fencingErr != nil {
+			return fencingErr
+		}
+
+		checkErr := s.checkReversible(ctx, tx, params.OriginalTransactionID, constants.TransactionTypeREVERSALINBOUND, params.CreditAmount)
+		if checkErr != nil {
+			return checkErr
+		}
+
+		if params.FeeAmount > 0 {
+			checkErr = s.checkReversible(ctx, tx, params.OriginalFeeTransactionID, constants.TransactionTypeFEEREVERSALINBOUND, params.FeeAmount)
+			if checkErr != nil {
+				return checkErr
+			}
+		}
+
+		debitAccount := lockedAccounts[params.DebitAccountID]
+		creditAccount := lockedAccounts[params.CreditAccountID]
+
+		if debitAccount.AvailableBalance() < params.DebitAmount {
+			return fmt.Errorf("%w: reversal amount: %d, available balance: %d", ErrInsufficientFunds, params.DebitAmount, debitAccount.AvailableBalance())
+		}
+
+		entries := []*ledger.JournalEntry{
+			ledger.NewJournalEntry(constants.JournalEntryTypeREVERSAL).Move(
+				customerLeg(debitAccount, linkedTransactions, constants.TransactionTypeREVERSALOUTBOUND),
+				customerLeg(creditAccount, linkedTransactions, constants.TransactionTypeREVERSALINBOUND),
+				params.DebitAmount,
+				params.CreditAmount,
+			),
+		}
+
+		updatedAccounts := []*accounts.Account{debitAccount, creditAccount}
+
+		if params.FeeAmount > 0 {
+			feeAccount := lockedAccounts[params.FeeAccountID]
+			if feeAccount.AvailableBalance() < params.FeeAmount {
+				return fmt.Errorf("%w: fee refund: %d, fee account available balance: %d", ErrInsufficientFunds, params.FeeAmount, feeAccount.AvailableBalance())
+			}
+
+			entries = append(entries, ledger.NewJournalEntry(constants.JournalEntryTypeFEEREVERSAL).Move(
+				feeIncomeLeg(feeAccount, linkedTransactions, constants.TransactionTypeFEEREVERSALOUTBOUND),
+				customerLeg(creditAccount, linkedTransactions, constants.TransactionTypeFEEREVERSALINBOUND),
+				params.FeeAmount,
+				params.FeeAmount,
+			))
+
+			updatedAccounts = append(updatedAccounts, feeAccount)
+		}
+
+		previousBalances := balancesOf(lockedAccounts)
+
+		if postErr := s.postEntries(ctx, tx, lockedAccounts, entries); postErr != nil {
+			return postErr
+		}
+
+		for _, account := range updatedAccounts {
+			if updateErr := s.accountRepo.UpdateBalanceWithTx(ctx, account.ID, account.Balance, tx); updateErr != nil {
+				return updateErr
+			}
+		}
+
+		postedAt := time.Now().UTC()
+
+		var markErr error
+
+		postedTransactions, markErr = s.markPosted(ctx, tx, lockedAccounts, linkedTransactions, postedAt)
+		if markErr != nil {
+			return markErr
+		}
+
+		return s.recordPosted(ctx, tx, previousBalances, updatedAccounts, postedTransactions, postedAt)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return postedTransactions, nil
+}
+
+// PostCapture moves the captured amount out of the held funds and releases the hold in a single database transaction,
+// the accounts are locked before the hold like when a hold is placed or released.
+// Posting an already posted capture returns its transactions without moving the balances again.
+func (s *PostingService) PostCapture(ctx context.Context, params *CapturePosting) ([]*Transaction, error) {
+	var postedTransactions []*Transaction
+
+	err := s.accountRepo.Transaction(ctx, func(tx *gorm.DB) error {
+		lockedAccounts, lockErr := s.lockAccounts(ctx, tx, params.SourceAccountID, params.DestinationAccountID)
+		if lockErr != nil {
+			return lockErr
+		}
+
+		hold, holdErr := s.holdRepo.GetByIDForUpdate(ctx, params.HoldID, tx)
+		if holdErr != nil {
+			return holdErr
+		}
+
+		if hold == nil {
+			return fmt.Errorf("%w: %s", holds.ErrHoldNotFound, params.HoldID)
+		}
+
+		if hold.AccountID != params.SourceAccountID || hold.DestinationAccountID != params.DestinationAccountID {
+			return fmt.Errorf("capture accounts don't match hold %s", hold.ID)
+		}
+
+		linkedTransactions, alreadyPosted, trxErr := s.lockTransactions(ctx, tx, params.TransactionIDs)
+		if trxErr != nil {
+			return trxErr
+		}
+
+		if alreadyPosted {
+			postedTransactions = linkedTransactions
+
+			return nil
+		}
+
+		fencingErr := s.checkFencingTokens(ctx, tx, lockedAccounts, params.FencingTokens)
+		if fencingErr != nil {
+			return fencingErr
+		}
+
+		if !hold.IsActive() {
+			return fmt.Errorf("%w: %s, status: %s", holds.ErrHoldNotActive, hold.ID, hold.Status)
+		}
+
+		if params.Amount <= 0 || params.Amount > hold.Amount {
+			return fmt.Errorf("%w: %s, held: %d, capture: %d", holds.ErrCaptureExceedsHold, hold.ID, hold.Amount, params.Amount)
+		}
+
+		sourceAccount := lockedAccounts[params.SourceAccountID]
+		destinationAccount := lockedAccounts[params.DestinationAccountID]
+
+		entries := []*ledger.JournalEntry{
+			ledger.NewJournalEntry(constants.JournalEntryTypeCAPTURE).Move(
+				customerLeg(sourceAccount, linkedTransactions, constants.TransactionTypeOUTBOUND),
+				customerLeg(destinationAccount, linkedTransactions, constants.TransactionTypeINBOUND),
+				params.Amount,
+				params.Amount,
+			),
+		}
+
+		previousBalances := balancesOf(lockedAccounts)
+
+		if postErr := s.postEntries(ctx, tx, lockedAccounts, entries); postErr != nil {
+			return postErr
+		}
+
+		sourceAccount.HeldBalance -= hold.Amount
+
+		if updateErr := s.accountRepo.UpdateHeldBalanceWithTx(ctx, sourceAccount.ID, sourceAccount.HeldBalance, tx); updateErr != nil {
+			return updateErr
+		}
+
+		updatedAccounts := []*accounts.Account{sourceAccount, destinationAccount}
+
+		for _, account := range updatedAccounts {
+			if updateErr := s.accountRepo.UpdateBalanceWithTx(ctx, account.ID, account.Balance, tx); updateErr != nil {
+				return updateErr
+			}
+		}
+
+		hold.Status = constants.HoldStatusCAPTURED
+		hold.CapturedAmount = params.Amount
+		hold.ReleasedAt = &params.CapturedAt
+
+		if updateErr := s.holdRepo.UpdateWithTx(ctx, hold, tx); updateErr != nil {
+			return updateErr
+		}
+
+		postedAt := time.Now().UTC()
+
+		var markErr error
+
+		postedTransactions, markErr = s.markPosted(ctx, tx, lockedAccounts, linkedTransactions, postedAt)
+		if markErr != nil {
+			return markErr
+		}
+
+		return s.recordPosted(ctx, tx, previousBalances, updatedAccounts, postedTransactions, postedAt, &outbox.TransferCompleted{
+			ReferenceID:          referenceID(linkedTransactions, sourceAccount.ID, constants.TransactionTypeOUTBOUND),
+			SourceAccountID:      sourceAccount.ID,
+			DestinationAccountID: destinationAccount.ID,
+			Amount:               params.Amount,
+			Currency:             sourceAccount.Currency,
+			DestinationAmount:    params.Amount,
+			DestinationCurrency:  destinationAccount.Currency,
+			TransactionIDs:       params.TransactionIDs,
+			CompletedAt:          postedAt,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return postedTransactions, nil
+}
+
+// postEntries records the entries in the ledger and moves the balances of the locked accounts by their postings,
+// the caller writes the balances back. House accounts have no balance outside of the ledger.
+func (s *PostingService) postEntries(
+	ctx context.Context,
+	tx *gorm.DB,
+	lockedAccounts map[uuid.UUID]*accounts.Account,
+	entries []*ledger.JournalEntry,
+) error {
+	if err := s.ledgerService.PostWithTx(ctx, entries, tx); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		for _, posting := range entry.Postings {
+			if account, ok := lockedAccounts[posting.LedgerAccountID]; ok {
+				account.Balance += posting.BalanceDelta()
+			}
+		}
+	}
+
+	return nil
+}
+
+// markPosted flips the linked transactions to SUCCESS with the balance the posting left their account with, the
+// accounts are still locked so no other posting can come in between.
+func (s *PostingService) markPosted(
+	ctx context.Context,
+	tx *gorm.DB,
+	lockedAccounts map[uuid.UUID]*accounts.Account,
+	linkedTransactions []*Transaction,
+	postedAt time.Time,
+) ([]*Transaction, error) {
+	postedTransactions := make([]*Transaction, 0, len(linkedTransactions))
+
+	for _, transaction := range linkedTransactions {
+		var balanceAfter *int
+
+		if account, ok := lockedAccounts[transaction.AccountID]; ok {
+			balance := account.Balance
+			balanceAfter = &balance
+		}
+
+		updatedTrx, err := s.transactionRepo.MarkPostedWithTx(ctx, *transaction, balanceAfter, postedAt, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		postedTransactions = append(postedTransactions, updatedTrx)
+	}
+
+	return postedTransactions, nil
+}
+
+// recordPosted writes a BalanceChanged event for every account the posting updated and the other events of the
+// posting to the outbox. The accounts are still locked, so the events of an account are written in the order of its
+// postings.
+func (s *PostingService) recordPosted(
+	ctx context.Context,
+	tx *gorm.DB,
+	previousBalances map[uuid.UUID]int,
+	updatedAccounts []*accounts.Account,
+	postedTransactions []*Transaction,
+	postedAt time.Time,
+	events ...outbox.Payload,
+) error {
+	payloads := make([]outbox.Payload, 0, len(updatedAccounts)+len(events))
+
+	for _, account := range updatedAccounts {
+		transactionIDs := []uuid.UUID{}
+
+		for _, transaction := range postedTransactions {
+			if transaction.AccountID == account.ID {
+				transactionIDs = append(transactionIDs, transaction.ID)
+			}
+		}
+
+		payloads = append(payloads, &outbox.BalanceChanged{
+			AccountID:       account.ID,
+			Currency:        account.Currency,
+			PreviousBalance: previousBalances[account.ID],
+			Balance:         account.Balance,
+			HeldBalance:     account.HeldBalance,
+			TransactionIDs:  transactionIDs,
+			ChangedAt:       postedAt,
+		})
+	}
+
+	return s.outboxService.RecordWithTx(ctx, append(payloads, events...), tx)
+}
+
+func balancesOf(lockedAccounts map[uuid.UUID]*accounts.Account) map[uuid.UUID]int {
+	balances := make(map[uuid.UUID]int, len(lockedAccounts))
+	for accountID, account := range lockedAccounts {
+		balances[accountID] = account.Balance
+	}
+
+	return balances
+}
+
+// referenceID is the reference of the linked transaction of the type on the account, the reference of a transfer
+// is the one of its outgoing transaction.
+func referenceID(linkedTransactions []*Transaction, accountID uuid.UUID, transactionType constants.TransactionType) uuid.UUID {
+	for _, transaction := range linkedTransactions {
+		if transaction.AccountID == accountID && transaction.TransactionType == transactionType {
+			return transaction.ReferenceID
+		}
+	}
+
+	return uuid.Nil
+}
+
+func customerLeg(account *accounts.Account, linkedTransactions []*Transaction, transactionType constants.TransactionType) ledger.Leg {
+	return accountLeg(account, constants.LedgerAccountTypeCUSTOMER, linkedTransactions, transactionType)
+}
+
+func feeIncomeLeg(account *accounts.Account, linkedTransactions []*Transaction, transactionType constants.TransactionType) ledger.Leg {
+	return accountLeg(account, constants.LedgerAccountTypeFEEINCOME, linkedTransactions, transactionType)
+}
+
+// accountLeg is the leg of the account, its posting settles the linked transaction of the type on the account.
+func accountLeg(
+	account *accounts.Account,
+	accountType constants.LedgerAccountType,
+	linkedTransactions []*Transaction,
+	transactionType constants.TransactionType,
+) ledger.Leg {
+	leg := ledger.Leg{
+		AccountID:   account.ID,
+		AccountType: accountType,
+		Currency:    account.Currency,
+	}
+
+	for _, transaction := range linkedTransactions {
+		if transaction.AccountID == account.ID && transaction.TransactionType == transactionType {
+			leg.TransactionID = &transaction.ID
+
+			break
+		}
+	}
+
+	return leg
+}
+
+// checkFencingTokens rejects a posting made under an account lock that expired and was taken by another writer.
+// Every lock of an account gets a higher fencing token than the locks before it, so a token lower than the one
+// recorded on the account is from a lock that is no longer held. The highest token is recorded on the account.
+// Accounts without a token in the posting aren't checked.
+func (s *PostingService) checkFencingTokens(
+	ctx context.Context,
+	tx *gorm.DB,
+	lockedAccounts map[uuid.UUID]*accounts.Account,
+	fencingTokens map[uuid.UUID]int64,
+) error {
+	accountIDs := make([]uuid.UUID, 0, len(fencingTokens))
+	for accountID := range fencingTokens {
+		accountIDs = append(accountIDs, accountID)
+	}
+
+	sort.Slice(accountIDs, func(i, j int) bool {
+		return accountIDs[i].String() < accountIDs[j].String()
+	})
+
+	for _, accountID := range accountIDs {
+		account, ok := lockedAccounts[accountID]
+		if !ok {
+			continue
+		}
+
+		token := fencingTokens[accountID]
+
+		if token < account.FencingToken {
+			return fmt.Errorf("%w: account: %s, token: %d, last token: %d", ErrStaleFencingToken, accountID, token, account.FencingToken)
+		}
+
+		if token == account.FencingToken {
+			continue
+		}
+
+		if updateErr := s.accountRepo.UpdateFencingTokenWithTx(ctx, accountID, token, tx); updateErr != nil {
+			return updateErr
+		}
+
+		account.FencingToken = token
+	}
+
+	return nil
+}
+
+// checkReversible locks the original transaction and makes sure the amount fits in what is left to reverse of it.
+func (s *PostingService) checkReversible(
+	ctx context.Context,
+	tx *gorm.DB,
+	originalTransactionID uuid.UUID,
+	reversalType constants.TransactionType,
+	amount int,
+) error {
+	original, err := s.transactionRepo.GetByIDForUpdate(ctx, originalTransactionID, tx)
+	if err != nil {
+		return err
+	}
+
+	if original == nil {
+		return fmt.Errorf("transaction not found: %s", originalTransactionID)
+	}
+
+	reversedAmount, err := s.transactionRepo.SumReversedAmountWithTx(ctx, originalTransactionID, reversalType, tx)
+	if err != nil {
+		return err
+	}
+
+	if reversedAmount+amount > original.Amount {
+		return fmt.Errorf("%w: %s, amount: %d, already reversed: %d, reversal: %d",
+			ErrReversalExceedsAmount, original.ID, original.Amount, reversedAmount, amount)
+	}
+
+	return nil
+}
+
+func (s *PostingService) lockAccounts(ctx context.Context, tx *gorm.DB, accountIDs ...uuid.UUID) (map[uuid.UUID]*accounts.Account, error) {
+	sortedIDs := make([]uuid.UUID, len(accountIDs))
+	copy(sortedIDs, accountIDs)
+
+	sort.Slice(sortedIDs, func(i, j int) bool {
+		return sortedIDs[i].String() < sortedIDs[j].String()
+	})
+
+	lockedAccounts := make(map[uuid.UUID]*accounts.Account, len(sortedIDs))
+
+	for _, accountID := range sortedIDs {
+		if _, ok := lockedAccounts[accountID]; ok {
+			continue
+		}
+
+		account, err := s.accountRepo.GetByIDForUpdate(ctx, accountID, tx)
+		if err != nil {
+			return nil, err
+		}
+
+		if account == nil {
+			return nil, fmt.Errorf("account not found: %s", accountID)
+		}
+
+		if account.Status != constants.AccountStatusACTIVE {
+			return nil, fmt.Errorf("account is not active: %s", account.ID)
+		}
+
+		lockedAccounts[accountID] = account
+	}
+
+	return lockedAccounts, nil
+}
+
+// lockTransactions locks the linked transactions and reports whether all of them are already SUCCESS.
+func (s *PostingService) lockTransactions(ctx context.Context, tx *gorm.DB, transactionIDs []uuid.UUID) ([]*Transaction, bool, error) {
+	linkedTransactions := make([]*Transaction, 0, len(transactionIDs))
+	postedCount := 0
+
+	for _, transactionID := range transactionIDs {
+		transaction, err := s.transactionRepo.GetByIDForUpdate(ctx, transactionID, tx)
+		if err != nil {
+			return nil, false, err
+		}
+
+		if transaction == nil {
+			return nil, false, fmt.Errorf("transaction not found: %s", transactionID)
+		}
+
+		switch transaction.Status {
+		case constants.TransactionStatusPENDING:
+		case constants.TransactionStatusSUCCESS:
+			postedCount++
+		default:
+			return nil, false, fmt.Errorf("%w: %s, status: %s", ErrTransactionNotPostable, transaction.ID, transaction.Status)
+		}
+
+		linkedTransactions = append(linkedTransactions, transaction)
+	}
+
+	if postedCount > 0 && postedCount != len(linkedTransactions) {
+		return nil, false, fmt.Errorf("%w: transfer is partially posted", ErrTransactionNotPostable)
+	}
+
+	return linkedTransactions, postedCount > 0, nil
+}
@@ -0,0 +1,534 @@
+//go:build tests_unit
+
+package transactions_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"sort"
+	"testing"
+
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/holds"
+	"ulascansenturk/service/internal/ledger"
+	"ulascansenturk/service/internal/outbox"
+	"ulascansenturk/service/internal/transactions"
+)
+
+func newPostingService(t *testing.T) (*transactions.PostingService, sqlmock.Sqlmock) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	return transactions.NewPostingService(
+		transactions.NewSQLRepository(gormDB),
+		accounts.NewSQLRepository(gormDB),
+		holds.NewSQLRepository(gormDB),
+		ledger.NewLedgerService(ledger.NewSQLRepository(gormDB)),
+		outbox.NewOutboxService(outbox.NewSQLRepository(gormDB)),
+	), mock
+}
+
+func expectAccountsLocked(mock sqlmock.Sqlmock, balances map[uuid.UUID]int) {
+	accountIDs := make([]uuid.UUID, 0, len(balances))
+	for accountID := range balances {
+		accountIDs = append(accountIDs, accountID)
+	}
+
+	sort.Slice(accountIDs, func(i, j int) bool {
+		return accountIDs[i].String() < accountIDs[j].String()
+	})
+
+	for _, accountID := range accountIDs {
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1 ORDER BY "accounts"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(accountID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "status"}).
+				AddRow(accountID, balances[accountID], constants.AccountStatusACTIVE))
+	}
+}
+
+// expectLedgerPosted expects the entries of a posting to be written to the ledger, postings are the arguments of
+// the postings inserted: id, journal entry, ledger account, transaction, direction, amount, currency and time.
+func expectLedgerPosted(mock sqlmock.Sqlmock, entries int, postings ...[]driver.Value) {
+	mock.ExpectExec(`INSERT INTO "ledger_accounts" \("id","account_type","currency","created_at"\) VALUES .* ON CONFLICT DO NOTHING`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(`INSERT INTO "journal_entries" \("id","entry_type","created_at"\) VALUES`).
+		WillReturnResult(sqlmock.NewResult(0, int64(entries)))
+
+	var args []driver.Value
+	for _, posting := range postings {
+		args = append(args, posting...)
+	}
+
+	mock.ExpectExec(`INSERT INTO "postings" \("id","journal_entry_id","ledger_account_id","transaction_id","direction","amount","currency","created_at"\) VALUES`).
+		WithArgs(args...).
+		WillReturnResult(sqlmock.NewResult(0, int64(len(postings))))
+}
+
+func expectTransactionPosted(mock sqlmock.Sqlmock, trxID uuid.UUID, balanceAfter interface{}) {
+	mock.ExpectExec(`UPDATE "transactions" SET "balance_after"=\$1,"posted_at"=\$2,"status"=\$3,"updated_at"=\$4 WHERE "id" = \$5`).
+		WithArgs(balanceAfter, sqlmock.AnyArg(), constants.TransactionStatusSUCCESS, sqlmock.AnyArg(), trxID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "transactions"."id" = \$1 ORDER BY "transactions"."id" LIMIT \$2`).
+		WithArgs(trxID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(trxID, constants.TransactionStatusSUCCESS))
+}
+
+// expectEventsRecorded expects the events to be written to the outbox, see outboxEvent.
+func expectEventsRecorded(mock sqlmock.Sqlmock, events ...[]driver.Value) {
+	var (
+		args []driver.Value
+		rows = sqlmock.NewRows([]string{"sequence"})
+	)
+
+	for i, event := range events {
+		args = append(args, event...)
+		rows.AddRow(i + 1)
+	}
+
+	mock.ExpectQuery(`INSERT INTO "outbox_events" \("id","event_type","schema_version","aggregate_type","aggregate_id","payload","occurred_at","published_at","attempts","last_error"\) VALUES .* RETURNING "sequence"`).
+		WithArgs(args...).
+		WillReturnRows(rows)
+}
+
+// outboxEvent is the arguments of an event written to the outbox, its payload has at least the fields.
+func outboxEvent(eventType constants.OutboxEventType, aggregateID uuid.UUID, fields map[string]interface{}) []driver.Value {
+	return []driver.Value{
+		sqlmock.AnyArg(), eventType, 1, outbox.AggregateTypeAccount, aggregateID, payloadWith(fields), sqlmock.AnyArg(), nil, 0, nil,
+	}
+}
+
+type payloadWith map[string]interface{}
+
+func (p payloadWith) Match(value driver.Value) bool {
+	var raw []byte
+
+	switch v := value.(type) {
+	case string:
+		raw = []byte(v)
+	case []byte:
+		raw = v
+	default:
+		return false
+	}
+
+	var payload map[string]interface{}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return false
+	}
+
+	for key, expected := range p {
+		expectedJSON, _ := json.Marshal(expected)
+		actualJSON, _ := json.Marshal(payload[key])
+
+		if string(expectedJSON) != string(actualJSON) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func posting(accountID uuid.UUID, transactionID interface{}, direction constants.PostingDirection, amount int, currency string) []driver.Value {
+	return []driver.Value{sqlmock.AnyArg(), sqlmock.AnyArg(), accountID, transactionID, direction, amount, currency, sqlmock.AnyArg()}
+}
+
+func TestPostingService_PostTransfer(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	sourceAccountID := uuid.New()
+	destinationAccountID := uuid.New()
+	outgoingTrxID := uuid.New()
+	incomingTrxID := uuid.New()
+
+	mock.ExpectBegin()
+	expectAccountsLocked(mock, map[uuid.UUID]int{sourceAccountID: 1000, destinationAccountID: 500})
+
+	for _, trxID := range []uuid.UUID{outgoingTrxID, incomingTrxID} {
+		mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(trxID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(trxID, constants.TransactionStatusPENDING))
+	}
+
+	expectLedgerPosted(mock, 1,
+		posting(sourceAccountID, nil, constants.PostingDirectionDEBIT, 100, ""),
+		posting(destinationAccountID, nil, constants.PostingDirectionCREDIT, 100, ""),
+	)
+
+	mock.ExpectExec(`UPDATE "accounts" SET "balance"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs(900, sqlmock.AnyArg(), sourceAccountID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "accounts" SET "balance"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs(600, sqlmock.AnyArg(), destinationAccountID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// the transaction rows have no account, so there is no balance to record
+	for _, trxID := range []uuid.UUID{outgoingTrxID, incomingTrxID} {
+		expectTransactionPosted(mock, trxID, nil)
+	}
+
+	expectEventsRecorded(mock,
+		outboxEvent(constants.OutboxEventTypeBalanceChanged, sourceAccountID, map[string]interface{}{"previous_balance": 1000, "balance": 900}),
+		outboxEvent(constants.OutboxEventTypeBalanceChanged, destinationAccountID, map[string]interface{}{"previous_balance": 500, "balance": 600}),
+		outboxEvent(constants.OutboxEventTypeTransferCompleted, sourceAccountID, map[string]interface{}{
+			"destination_account_id": destinationAccountID,
+			"amount":                 100,
+			"transaction_ids":        []uuid.UUID{outgoingTrxID, incomingTrxID},
+		}),
+	)
+
+	mock.ExpectCommit()
+
+	postedTransactions, err := service.PostTransfer(ctx, &transactions.TransferPosting{
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               100,
+		TransactionIDs:       []uuid.UUID{outgoingTrxID, incomingTrxID},
+	})
+	require.NoError(t, err)
+	require.Len(t, postedTransactions, 2)
+	assert.Equal(t, constants.TransactionStatusSUCCESS, postedTransactions[0].Status)
+	assert.Equal(t, constants.TransactionStatusSUCCESS, postedTransactions[1].Status)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostingService_PostTransfer_CreditsFeeAccount(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	sourceAccountID := uuid.New()
+	destinationAccountID := uuid.New()
+	feeAccountID := uuid.New()
+	transactionIDs := []uuid.UUID{uuid.New(), uuid.New(), uuid.New(), uuid.New()}
+	linkedTransactions := []struct {
+		accountID       uuid.UUID
+		transactionType constants.TransactionType
+	}{
+		{sourceAccountID, constants.TransactionTypeOUTBOUND},
+		{destinationAccountID, constants.TransactionTypeINBOUND},
+		{sourceAccountID, constants.TransactionTypeOUTGOINGFEE},
+		{feeAccountID, constants.TransactionTypeINCOMINGFEE},
+	}
+
+	balances := map[uuid.UUID]int{sourceAccountID: 1000, destinationAccountID: 500, feeAccountID: 20}
+	expectedBalances := map[uuid.UUID]int{sourceAccountID: 890, destinationAccountID: 600, feeAccountID: 30}
+
+	mock.ExpectBegin()
+	expectAccountsLocked(mock, balances)
+
+	for i, trxID := range transactionIDs {
+		mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(trxID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "transaction_type", "status"}).
+				AddRow(trxID, linkedTransactions[i].accountID, linkedTransactions[i].transactionType, constants.TransactionStatusPENDING))
+	}
+
+	// the fee is a separate entry, every posting settles the transaction of its account
+	expectLedgerPosted(mock, 2,
+		posting(sourceAccountID, transactionIDs[0], constants.PostingDirectionDEBIT, 100, ""),
+		posting(destinationAccountID, transactionIDs[1], constants.PostingDirectionCREDIT, 100, ""),
+		posting(sourceAccountID, transactionIDs[2], constants.PostingDirectionDEBIT, 10, ""),
+		posting(feeAccountID, transactionIDs[3], constants.PostingDirectionCREDIT, 10, ""),
+	)
+
+	for _, accountID := range []uuid.UUID{sourceAccountID, destinationAccountID, feeAccountID} {
+		mock.ExpectExec(`UPDATE "accounts" SET "balance"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+			WithArgs(expectedBalances[accountID], sqlmock.AnyArg(), accountID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	// the transactions of the source account share the balance the whole posting left it with
+	for i, trxID := range transactionIDs {
+		expectTransactionPosted(mock, trxID, expectedBalances[linkedTransactions[i].accountID])
+	}
+
+	// the fee doesn't complete a transfer of its own, the fee account only gets its balance change
+	expectEventsRecorded(mock,
+		outboxEvent(constants.OutboxEventTypeBalanceChanged, sourceAccountID, map[string]interface{}{"balance": 890}),
+		outboxEvent(constants.OutboxEventTypeBalanceChanged, destinationAccountID, map[string]interface{}{"balance": 600}),
+		outboxEvent(constants.OutboxEventTypeBalanceChanged, feeAccountID, map[string]interface{}{"previous_balance": 20, "balance": 30}),
+		outboxEvent(constants.OutboxEventTypeTransferCompleted, sourceAccountID, map[string]interface{}{"fee_amount": 10}),
+	)
+
+	mock.ExpectCommit()
+
+	postedTransactions, err := service.PostTransfer(ctx, &transactions.TransferPosting{
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		FeeAccountID:         feeAccountID,
+		Amount:               100,
+		FeeAmount:            10,
+		TransactionIDs:       transactionIDs,
+	})
+	require.NoError(t, err)
+	require.Len(t, postedTransactions, 4)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostingService_PostTransfer_CrossCurrencyGoesThroughFXClearing(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	sourceAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	destinationAccountID := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+	outgoingTrxID := uuid.New()
+
+	mock.ExpectBegin()
+
+	for _, account := range []struct {
+		id       uuid.UUID
+		balance  int
+		currency string
+	}{{sourceAccountID, 1000, "EUR"}, {destinationAccountID, 0, "USD"}} {
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1 ORDER BY "accounts"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(account.id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "currency", "status"}).
+				AddRow(account.id, account.balance, account.currency, constants.AccountStatusACTIVE))
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(outgoingTrxID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(outgoingTrxID, constants.TransactionStatusPENDING))
+
+	expectLedgerPosted(mock, 1,
+		posting(sourceAccountID, nil, constants.PostingDirectionDEBIT, 100, "EUR"),
+		posting(ledger.HouseAccountID(constants.LedgerAccountTypeFXCLEARING, "EUR"), nil, constants.PostingDirectionCREDIT, 100, "EUR"),
+		posting(ledger.HouseAccountID(constants.LedgerAccountTypeFXCLEARING, "USD"), nil, constants.PostingDirectionDEBIT, 110, "USD"),
+		posting(destinationAccountID, nil, constants.PostingDirectionCREDIT, 110, "USD"),
+	)
+
+	mock.ExpectExec(`UPDATE "accounts" SET "balance"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs(900, sqlmock.AnyArg(), sourceAccountID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`UPDATE "accounts" SET "balance"=\$1,"updated_at"=\$2 WHERE id = \$3`).
+		WithArgs(110, sqlmock.AnyArg(), destinationAccountID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	expectTransactionPosted(mock, outgoingTrxID, nil)
+
+	expectEventsRecorded(mock,
+		outboxEvent(constants.OutboxEventTypeBalanceChanged, sourceAccountID, map[string]interface{}{"currency": "EUR", "balance": 900}),
+		outboxEvent(constants.OutboxEventTypeBalanceChanged, destinationAccountID, map[string]interface{}{"currency": "USD", "balance": 110}),
+		outboxEvent(constants.OutboxEventTypeTransferCompleted, sourceAccountID, map[string]interface{}{
+			"currency":             "EUR",
+			"destination_amount":   110,
+			"destination_currency": "USD",
+		}),
+	)
+
+	mock.ExpectCommit()
+
+	_, err := service.PostTransfer(ctx, &transactions.TransferPosting{
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               100,
+		DestinationAmount:    110,
+		TransactionIDs:       []uuid.UUID{outgoingTrxID},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostingService_PostTransfer_InsufficientFunds(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	sourceAccountID := uuid.New()
+	destinationAccountID := uuid.New()
+	outgoingTrxID := uuid.New()
+
+	mock.ExpectBegin()
+	expectAccountsLocked(mock, map[uuid.UUID]int{sourceAccountID: 50, destinationAccountID: 500})
+
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(outgoingTrxID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(outgoingTrxID, constants.TransactionStatusPENDING))
+
+	mock.ExpectRollback()
+
+	_, err := service.PostTransfer(ctx, &transactions.TransferPosting{
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               100,
+		TransactionIDs:       []uuid.UUID{outgoingTrxID},
+	})
+	require.ErrorIs(t, err, transactions.ErrInsufficientFunds)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostingService_PostTransfer_StaleFencingToken(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	sourceAccountID := uuid.MustParse("aaaaaaaa-0000-0000-0000-000000000000")
+	destinationAccountID := uuid.MustParse("bbbbbbbb-0000-0000-0000-000000000000")
+	outgoingTrxID := uuid.New()
+
+	mock.ExpectBegin()
+
+	// the source account was already written under the lock that took over the expired one
+	for _, account := range []struct {
+		id           uuid.UUID
+		fencingToken int64
+	}{{sourceAccountID, 5}, {destinationAccountID, 0}} {
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1 ORDER BY "accounts"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(account.id, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "status", "fencing_token"}).
+				AddRow(account.id, 1000, constants.AccountStatusACTIVE, account.fencingToken))
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(outgoingTrxID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(outgoingTrxID, constants.TransactionStatusPENDING))
+
+	mock.ExpectRollback()
+
+	_, err := service.PostTransfer(ctx, &transactions.TransferPosting{
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               100,
+		TransactionIDs:       []uuid.UUID{outgoingTrxID},
+		FencingTokens:        map[uuid.UUID]int64{sourceAccountID: 4},
+	})
+	require.ErrorIs(t, err, transactions.ErrStaleFencingToken)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostingService_PostReversal_ExceedsOriginalAmount(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	debitAccountID := uuid.New()
+	creditAccountID := uuid.New()
+	originalTrxID := uuid.New()
+	transactionIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mock.ExpectBegin()
+	expectAccountsLocked(mock, map[uuid.UUID]int{debitAccountID: 1000, creditAccountID: 0})
+
+	for _, trxID := range transactionIDs {
+		mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(trxID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(trxID, constants.TransactionStatusPENDING))
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(originalTrxID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "amount", "status"}).AddRow(originalTrxID, 100, constants.TransactionStatusSUCCESS))
+	mock.ExpectQuery(`SELECT COALESCE\(SUM\(amount\), 0\) FROM "transactions" WHERE original_transaction_id = \$1 AND transaction_type = \$2 AND status = \$3`).
+		WithArgs(originalTrxID, constants.TransactionTypeREVERSALINBOUND, constants.TransactionStatusSUCCESS).
+		WillReturnRows(sqlmock.NewRows([]string{"coalesce"}).AddRow(60))
+
+	mock.ExpectRollback()
+
+	_, err := service.PostReversal(ctx, &transactions.ReversalPosting{
+		OriginalTransactionID: originalTrxID,
+		DebitAccountID:        debitAccountID,
+		CreditAccountID:       creditAccountID,
+		DebitAmount:           50,
+		CreditAmount:          50,
+		TransactionIDs:        transactionIDs,
+	})
+	require.ErrorIs(t, err, transactions.ErrReversalExceedsAmount)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostingService_PostTransfer_HeldFundsAreNotAvailable(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	sourceAccountID := uuid.New()
+	destinationAccountID := uuid.New()
+	outgoingTrxID := uuid.New()
+
+	accountIDs := []uuid.UUID{sourceAccountID, destinationAccountID}
+	sort.Slice(accountIDs, func(i, j int) bool {
+		return accountIDs[i].String() < accountIDs[j].String()
+	})
+
+	mock.ExpectBegin()
+
+	for _, accountID := range accountIDs {
+		heldBalance := 0
+		if accountID == sourceAccountID {
+			heldBalance = 950
+		}
+
+		mock.ExpectQuery(`SELECT \* FROM "accounts" WHERE id = \$1 ORDER BY "accounts"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(accountID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "held_balance", "status"}).
+				AddRow(accountID, 1000, heldBalance, constants.AccountStatusACTIVE))
+	}
+
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(outgoingTrxID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(outgoingTrxID, constants.TransactionStatusPENDING))
+
+	mock.ExpectRollback()
+
+	_, err := service.PostTransfer(ctx, &transactions.TransferPosting{
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               100,
+		TransactionIDs:       []uuid.UUID{outgoingTrxID},
+	})
+	require.ErrorIs(t, err, transactions.ErrInsufficientFunds)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPostingService_PostCapture_ExceedsHold(t *testing.T) {
+	service, mock := newPostingService(t)
+
+	ctx := context.Background()
+	sourceAccountID := uuid.New()
+	destinationAccountID := uuid.New()
+	holdID := uuid.New()
+	transactionIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	mock.ExpectBegin()
+	expectAccountsLocked(mock, map[uuid.UUID]int{sourceAccountID: 1000, destinationAccountID: 0})
+
+	mock.ExpectQuery(`SELECT \* FROM "holds" WHERE id = \$1 ORDER BY "holds"."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(holdID, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "destination_account_id", "amount", "status"}).
+			AddRow(holdID, sourceAccountID, destinationAccountID, 500, constants.HoldStatusACTIVE))
+
+	for _, trxID := range transactionIDs {
+		mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+			WithArgs(trxID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "status"}).AddRow(trxID, constants.TransactionStatusPENDING))
+	}
+
+	mock.ExpectRollback()
+
+	_, err := service.PostCapture(ctx, &transactions.CapturePosting{
+		HoldID:               holdID,
+		SourceAccountID:      sourceAccountID,
+		DestinationAccountID: destinationAccountID,
+		Amount:               600,
+		TransactionIDs:       transactionIDs,
+	})
+	require.ErrorIs(t, err, holds.ErrCaptureExceedsHold)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
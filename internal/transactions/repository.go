@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 	"time"
@@ -21,6 +22,14 @@ type Repository interface {
 	Transaction(ctx context.Context, fn func(*gorm.DB) (interface{}, error)) (interface{}, error)
 	GetByIDForUpdate(ctx context.Context, transactionID uuid.UUID, tx *gorm.DB) (*Transaction, error)
 	UpdateStatusWithTx(ctx context.Context, transaction Transaction, status constants.TransactionStatus, tx *gorm.DB) (*Transaction, error)
+	UpdateStatusAndMetadataWithTx(ctx context.Context, transaction Transaction, status constants.TransactionStatus, metadata datatypes.JSONMap, tx *gorm.DB) (*Transaction, error)
+	SumReversedAmount(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType) (int, error)
+	SumReversedAmountWithTx(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType, tx *gorm.DB) (int, error)
+	MarkPostedWithTx(ctx context.Context, transaction Transaction, balanceAfter *int, postedAt time.Time, tx *gorm.DB) (*Transaction, error)
+	GetBalanceAfter(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*int, error)
+	GetBalanceBeforeNextPosting(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*int, error)
+	ListAccountIDsWithoutBalanceAfter(ctx context.Context) ([]uuid.UUID, error)
+	BackfillBalanceAfterWithTx(ctx context.Context, accountID uuid.UUID, balance int, tx *gorm.DB) (int64, error)
 	Delete(ctx context.Context, id uuid.UUID) error
 	DB() *gorm.DB
 }
@@ -123,6 +132,23 @@ func (r *SQLRepository) UpdateStatusWithTx(ctx context.Context, transaction Tran
 
 	return &updatedTransaction, nil
 }
+func (r *SQLRepository) UpdateStatusAndMetadataWithTx(ctx context.Context, transaction Transaction, status constants.TransactionStatus, metadata datatypes.JSONMap, tx *gorm.DB) (*Transaction, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+
+	if err := tx.WithContext(ctx).Model(&transaction).Updates(map[string]interface{}{"status": status, "metadata": metadata}).Error; err != nil {
+		return nil, err
+	}
+
+	var updatedTransaction Transaction
+	if err := tx.WithContext(ctx).First(&updatedTransaction, transaction.ID).Error; err != nil {
+		return nil, err
+	}
+
+	return &updatedTransaction, nil
+}
+
 func (r *SQLRepository) Transaction(ctx context.Context, fn func(tx *gorm.DB) (interface{}, error)) (interface{}, error) {
 	var result interface{}
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
@@ -148,3 +174,182 @@ func (r *SQLRepository) GetByIDForUpdate(ctx context.Context, transactionID uuid
 
 	return &transaction, nil
 }
+
+// SumReversedAmount sums the SUCCESS reversal transactions of the type linked to the original transaction.
+func (r *SQLRepository) SumReversedAmount(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType) (int, error) {
+	return r.sumReversedAmount(r.db.WithContext(ctx), originalTransactionID, transactionType)
+}
+
+func (r *SQLRepository) SumReversedAmountWithTx(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType, tx *gorm.DB) (int, error) {
+	if tx == nil {
+		return 0, errors.New("transaction is required")
+	}
+
+	return r.sumReversedAmount(tx.WithContext(ctx), originalTransactionID, transactionType)
+}
+
+func (r *SQLRepository) sumReversedAmount(db *gorm.DB, originalTransactionID uuid.UUID, transactionType constants.TransactionType) (int, error) {
+	var reversedAmount int
+
+	err := db.Model(&Transaction{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("original_transaction_id = ? AND transaction_type = ? AND status = ?", originalTransactionID, transactionType, constants.TransactionStatusSUCCESS).
+		Scan(&reversedAmount).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return reversedAmount, nil
+}
+
+// MarkPostedWithTx flips the transaction to SUCCESS with the balance its posting left the account with.
+func (r *SQLRepository) MarkPostedWithTx(ctx context.Context, transaction Transaction, balanceAfter *int, postedAt time.Time, tx *gorm.DB) (*Transaction, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+
+	err := tx.WithContext(ctx).Model(&transaction).Updates(map[string]interface{}{
+		"status":        constants.TransactionStatusSUCCESS,
+		"balance_after": balanceAfter,
+		"posted_at":     postedAt,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var updatedTransaction Transaction
+	if err := tx.WithContext(ctx).First(&updatedTransaction, transaction.ID).Error; err != nil {
+		return nil, err
+	}
+
+	return &updatedTransaction, nil
+}
+
+// GetBalanceAfter returns the balance left by the last posting of the account at or before asOf, nil when there
+// was none.
+func (r *SQLRepository) GetBalanceAfter(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*int, error) {
+	var balances []int
+
+	err := r.db.WithContext(ctx).Model(&Transaction{}).
+		Where("account_id = ? AND status = ? AND balance_after IS NOT NULL AND posted_at <= ?", accountID, constants.TransactionStatusSUCCESS, asOf).
+		Order("posted_at DESC").
+		Limit(1).
+		Pluck("balance_after", &balances).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(balances) == 0 {
+		return nil, nil
+	}
+
+	return &balances[0], nil
+}
+
+// movedBalanceSQL keeps the SUCCESS transactions that moved the balance of their account. The OUTGOING_FEE
+// transactions written before fees were posted were marked SUCCESS without being deducted, only the ones credited
+// to a fee collection account by an INCOMING_FEE were.
+const movedBalanceSQL = `(transactions.transaction_type <> @outgoing_fee_type OR EXISTS (
+    SELECT 1 FROM transactions incoming_fees
+    WHERE incoming_fees.transaction_type = @incoming_fee_type AND incoming_fees.status = @status
+        AND incoming_fees.metadata->>'OutgoingFeeReferenceID' = transactions.reference_id::text
+))`
+
+// balanceBeforeNextPostingSQL takes the movements of the first posting of the account after asOf off the balance
+// it left, what is left is the balance the account had before it.
+const balanceBeforeNextPostingSQL = `
+SELECT balance_after - SUM(CASE WHEN transaction_type IN @credit_types THEN amount ELSE -amount END)
+FROM transactions
+WHERE account_id = @account_id AND status = @status AND balance_after IS NOT NULL AND ` + movedBalanceSQL + ` AND posted_at = (
+    SELECT MIN(posted_at) FROM transactions
+    WHERE account_id = @account_id AND status = @status AND balance_after IS NOT NULL AND ` + movedBalanceSQL + `
+        AND posted_at > @as_of
+)
+GROUP BY balance_after`
+
+// GetBalanceBeforeNextPosting returns the balance the account had before its first posting after asOf, nil when
+// there was none.
+func (r *SQLRepository) GetBalanceBeforeNextPosting(ctx context.Context, accountID uuid.UUID, asOf time.Time) (*int, error) {
+	var balances []int
+
+	err := r.db.WithContext(ctx).Raw(balanceBeforeNextPostingSQL, map[string]interface{}{
+		"credit_types":      creditTransactionTypes,
+		"outgoing_fee_type": constants.TransactionTypeOUTGOINGFEE,
+		"incoming_fee_type": constants.TransactionTypeINCOMINGFEE,
+		"account_id":        accountID,
+		"status":            constants.TransactionStatusSUCCESS,
+		"as_of":             asOf,
+	}).Scan(&balances).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if len(balances) == 0 {
+		return nil, nil
+	}
+
+	return &balances[0], nil
+}
+
+func (r *SQLRepository) ListAccountIDsWithoutBalanceAfter(ctx context.Context) ([]uuid.UUID, error) {
+	var accountIDs []uuid.UUID
+
+	err := r.db.WithContext(ctx).Model(&Transaction{}).
+		Distinct("account_id").
+		Where("status = @status AND balance_after IS NULL AND "+movedBalanceSQL, map[string]interface{}{
+			"outgoing_fee_type": constants.TransactionTypeOUTGOINGFEE,
+			"incoming_fee_type": constants.TransactionTypeINCOMINGFEE,
+			"status":            constants.TransactionStatusSUCCESS,
+		}).
+		Pluck("account_id", &accountIDs).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return accountIDs, nil
+}
+
+// backfillBalanceAfterSQL walks the SUCCESS transactions of the account back from its current balance. The
+// transactions posted before balance_after existed are taken as posted at their last update. The balance after a
+// posting is the current balance less the movements of every posting after it. The fees that were never deducted
+// are left without a balance_after.
+const backfillBalanceAfterSQL = `
+WITH movements AS (
+    SELECT id,
+           COALESCE(posted_at, updated_at) AS posted_at,
+           CASE WHEN transaction_type IN @credit_types THEN amount ELSE -amount END AS movement
+    FROM transactions
+    WHERE account_id = @account_id AND status = @status AND ` + movedBalanceSQL + `
+), later AS (
+    SELECT id,
+           posted_at,
+           SUM(movement) OVER (ORDER BY posted_at DESC RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW)
+               - SUM(movement) OVER (PARTITION BY posted_at) AS later_movement
+    FROM movements
+)
+UPDATE transactions
+SET balance_after = @balance - later.later_movement, posted_at = later.posted_at
+FROM later
+WHERE transactions.id = later.id AND transactions.balance_after IS NULL`
+
+// BackfillBalanceAfterWithTx writes the balance_after of the SUCCESS transactions of the account that have none, the
+// account must be locked so no posting moves its balance meanwhile.
+func (r *SQLRepository) BackfillBalanceAfterWithTx(ctx context.Context, accountID uuid.UUID, balance int, tx *gorm.DB) (int64, error) {
+	if tx == nil {
+		return 0, errors.New("transaction is required")
+	}
+
+	result := tx.WithContext(ctx).Exec(backfillBalanceAfterSQL, map[string]interface{}{
+		"credit_types":      creditTransactionTypes,
+		"outgoing_fee_type": constants.TransactionTypeOUTGOINGFEE,
+		"incoming_fee_type": constants.TransactionTypeINCOMINGFEE,
+		"account_id":        accountID,
+		"status":            constants.TransactionStatusSUCCESS,
+		"balance":           balance,
+	})
+	if result.Error != nil {
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,99 @@
+//go:build tests_unit
+
+package transactions_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/api/testutils/support"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/transactions"
+)
+
+func TestSQLRepository_BackfillBalanceAfter_LegacyFees(t *testing.T) {
+	ctx := context.Background()
+
+	migrations, err := filepath.Glob("../../db/migrations/*.up.sql")
+	require.NoError(t, err)
+
+	pg := support.NewPostgres()
+	pg.SetUp(migrations...)
+	defer pg.TearDown()
+
+	var accountID uuid.UUID
+
+	// 1000 credited, 300 and 100 sent, only the fee of the second transfer was deducted.
+	err = pg.DB.WithContext(ctx).
+		Raw("INSERT INTO accounts (user_id, balance, currency) VALUES (?, 595, 'USD') RETURNING id", uuid.New()).
+		Row().Scan(&accountID)
+	require.NoError(t, err)
+
+	settledAt := time.Date(2024, 9, 1, 9, 0, 0, 0, time.UTC)
+
+	createSettled := func(accountID uuid.UUID, transactionType constants.TransactionType, amount int, at time.Time, metadata datatypes.JSONMap) *transactions.Transaction {
+		trx := &transactions.Transaction{
+			AccountID:       accountID,
+			Amount:          amount,
+			CurrencyCode:    "USD",
+			ReferenceID:     uuid.New(),
+			Metadata:        metadata,
+			Status:          constants.TransactionStatusSUCCESS,
+			TransactionType: transactionType,
+		}
+
+		require.NoError(t, pg.DB.WithContext(ctx).Create(trx).Error)
+		require.NoError(t, pg.DB.WithContext(ctx).Exec("UPDATE transactions SET updated_at = ? WHERE id = ?", at, trx.ID).Error)
+
+		return trx
+	}
+
+	deposit := createSettled(accountID, constants.TransactionTypeINBOUND, 1000, settledAt, nil)
+	// Marked SUCCESS before fees were posted, the balance never moved by it.
+	legacyTransfer := createSettled(accountID, constants.TransactionTypeOUTBOUND, 300, settledAt.Add(time.Hour), nil)
+	legacyFee := createSettled(accountID, constants.TransactionTypeOUTGOINGFEE, 10, settledAt.Add(time.Hour), nil)
+	transfer := createSettled(accountID, constants.TransactionTypeOUTBOUND, 100, settledAt.Add(2*time.Hour), nil)
+	fee := createSettled(accountID, constants.TransactionTypeOUTGOINGFEE, 5, settledAt.Add(2*time.Hour), nil)
+	createSettled(uuid.New(), constants.TransactionTypeINCOMINGFEE, 5, settledAt.Add(2*time.Hour), datatypes.JSONMap{
+		"OutgoingFeeReferenceID": fee.ReferenceID.String(),
+	})
+
+	repo := transactions.NewSQLRepository(pg.DB)
+
+	err = accounts.NewSQLRepository(pg.DB).Transaction(ctx, func(tx *gorm.DB) error {
+		_, backfillErr := repo.BackfillBalanceAfterWithTx(ctx, accountID, 595, tx)
+
+		return backfillErr
+	})
+	require.NoError(t, err)
+
+	balanceAfter := func(trx *transactions.Transaction) *int {
+		var balances []*int
+
+		require.NoError(t, pg.DB.WithContext(ctx).Model(&transactions.Transaction{}).
+			Where("id = ?", trx.ID).Pluck("balance_after", &balances).Error)
+		require.Len(t, balances, 1)
+
+		return balances[0]
+	}
+
+	assert.Equal(t, 595, *balanceAfter(transfer))
+	assert.Equal(t, 595, *balanceAfter(fee))
+	assert.Equal(t, 700, *balanceAfter(legacyTransfer))
+	assert.Equal(t, 1000, *balanceAfter(deposit))
+	assert.Nil(t, balanceAfter(legacyFee))
+
+	// The legacy fee is never backfilled, its account isn't listed again.
+	accountIDs, err := repo.ListAccountIDsWithoutBalanceAfter(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, accountIDs, accountID)
+}
@@ -5,11 +5,15 @@ import (
 	"errors"
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"gorm.io/datatypes"
 	"gorm.io/gorm"
 	"time"
 	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/outbox"
 )
 
+var ErrTransactionNotFound = errors.New("transaction not found")
+
 type Service interface {
 	GetTransactionByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
 	GetTransactionByReferenceID(ctx context.Context, referenceID uuid.UUID) (*Transaction, error)
@@ -19,16 +23,20 @@ type Service interface {
 	UpdateTransaction(ctx context.Context, transaction *Transaction, tx *gorm.DB) error
 	DeleteTransaction(ctx context.Context, id uuid.UUID) error
 	UpdateTransactionStatus(ctx context.Context, id uuid.UUID, status constants.TransactionStatus) (*Transaction, error)
+	FailTransaction(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) (*Transaction, error)
+	UpdateTransactionMetadata(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) (*Transaction, error)
+	GetReversedAmount(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType) (int, error)
 	BeginTransaction(ctx context.Context) (*gorm.DB, error) // New method
 }
 
 type TransactionServiceImpl struct {
-	repo     Repository
-	validate *validator.Validate
+	repo          Repository
+	outboxService outbox.Service
+	validate      *validator.Validate
 }
 
-func NewTransactionService(repo Repository, validate *validator.Validate) *TransactionServiceImpl {
-	return &TransactionServiceImpl{repo: repo, validate: validate}
+func NewTransactionService(repo Repository, outboxService outbox.Service, validate *validator.Validate) *TransactionServiceImpl {
+	return &TransactionServiceImpl{repo: repo, outboxService: outboxService, validate: validate}
 }
 
 func (s *TransactionServiceImpl) GetTransactionByID(ctx context.Context, id uuid.UUID) (*Transaction, error) {
@@ -48,7 +56,7 @@ func (s *TransactionServiceImpl) GetTransactionByReferenceID(ctx context.Context
 		return nil, err
 	}
 	if transaction == nil {
-		return nil, errors.New("transaction not found")
+		return nil, ErrTransactionNotFound
 	}
 	return transaction, nil
 }
@@ -125,6 +133,10 @@ func (s *TransactionServiceImpl) UpdateTransactionStatus(ctx context.Context, id
 			return nil, updateTrxErr
 		}
 
+		if recordErr := s.recordFailure(ctx, transaction, updatedTrx, tx); recordErr != nil {
+			return nil, recordErr
+		}
+
 		return updatedTrx, nil
 	})
 
@@ -139,3 +151,110 @@ func (s *TransactionServiceImpl) UpdateTransactionStatus(ctx context.Context, id
 
 	return updatedTransaction, nil
 }
+
+// FailTransaction marks a PENDING transaction as FAILURE and merges the metadata into its own, e.g. to record
+// why it failed. A transaction that is no longer PENDING is returned unchanged.
+func (s *TransactionServiceImpl) FailTransaction(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) (*Transaction, error) {
+	return s.updateWithMetadata(ctx, id, metadata, func(transaction *Transaction) (constants.TransactionStatus, bool) {
+		return constants.TransactionStatusFAILURE, transaction.Status == constants.TransactionStatusPENDING
+	})
+}
+
+// UpdateTransactionMetadata merges the metadata into the metadata of the transaction, its status is kept.
+func (s *TransactionServiceImpl) UpdateTransactionMetadata(ctx context.Context, id uuid.UUID, metadata map[string]interface{}) (*Transaction, error) {
+	return s.updateWithMetadata(ctx, id, metadata, func(transaction *Transaction) (constants.TransactionStatus, bool) {
+		return transaction.Status, true
+	})
+}
+
+// GetReversedAmount returns how much of the original transaction is already reversed by SUCCESS reversal
+// transactions of the type.
+func (s *TransactionServiceImpl) GetReversedAmount(ctx context.Context, originalTransactionID uuid.UUID, transactionType constants.TransactionType) (int, error) {
+	return s.repo.SumReversedAmount(ctx, originalTransactionID, transactionType)
+}
+
+// updateWithMetadata locks the transaction and merges the metadata into its own, statusFn picks the new status
+// and whether the transaction is updated at all.
+func (s *TransactionServiceImpl) updateWithMetadata(
+	ctx context.Context,
+	id uuid.UUID,
+	metadata map[string]interface{},
+	statusFn func(transaction *Transaction) (constants.TransactionStatus, bool),
+) (*Transaction, error) {
+	result, err := s.repo.Transaction(ctx, func(tx *gorm.DB) (interface{}, error) {
+		transaction, err := s.repo.GetByIDForUpdate(ctx, id, tx)
+		if err != nil {
+			return nil, err
+		}
+		if transaction == nil {
+			return nil, errors.New("transaction not found")
+		}
+
+		status, update := statusFn(transaction)
+		if !update {
+			return transaction, nil
+		}
+
+		mergedMetadata := datatypes.JSONMap{}
+		for key, value := range transaction.Metadata {
+			mergedMetadata[key] = value
+		}
+		for key, value := range metadata {
+			mergedMetadata[key] = value
+		}
+
+		updatedTrx, updateTrxErr := s.repo.UpdateStatusAndMetadataWithTx(ctx, *transaction, status, mergedMetadata, tx)
+		if updateTrxErr != nil {
+			return nil, updateTrxErr
+		}
+
+		if recordErr := s.recordFailure(ctx, transaction, updatedTrx, tx); recordErr != nil {
+			return nil, recordErr
+		}
+
+		return updatedTrx, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updatedTransaction, ok := result.(*Transaction)
+	if !ok {
+		return nil, errors.New("unexpected result type")
+	}
+
+	return updatedTransaction, nil
+}
+
+// failureReasonKeys are the metadata keys a failed transaction records why it failed under, the first one set is
+// the reason of its TransferFailed event.
+var failureReasonKeys = []string{"CancellationReason", "ApprovalReason"}
+
+// recordFailure writes a TransferFailed event when the outgoing transaction of a transfer fails while PENDING, in
+// the database transaction of the status update.
+func (s *TransactionServiceImpl) recordFailure(ctx context.Context, before, after *Transaction, tx *gorm.DB) error {
+	if before.Status != constants.TransactionStatusPENDING ||
+		after.Status != constants.TransactionStatusFAILURE ||
+		after.TransactionType != constants.TransactionTypeOUTBOUND {
+		return nil
+	}
+
+	event := &outbox.TransferFailed{
+		ReferenceID:     after.ReferenceID,
+		SourceAccountID: after.AccountID,
+		TransactionID:   after.ID,
+		Amount:          after.Amount,
+		Currency:        after.CurrencyCode.String(),
+		FailedAt:        time.Now().UTC(),
+	}
+
+	for _, key := range failureReasonKeys {
+		if reason, ok := after.Metadata[key].(string); ok && reason != "" {
+			event.Reason = &reason
+
+			break
+		}
+	}
+
+	return s.outboxService.RecordWithTx(ctx, []outbox.Payload{event}, tx)
+}
@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/outbox"
 	"ulascansenturk/service/internal/transactions"
 )
 
@@ -26,7 +27,7 @@ func TestTransactionService_GetTransactionByID(t *testing.T) {
 	require.NoError(t, err)
 
 	repo := transactions.NewSQLRepository(gormDB)
-	service := transactions.NewTransactionService(repo, validator.New())
+	service := transactions.NewTransactionService(repo, outbox.NewOutboxService(outbox.NewSQLRepository(gormDB)), validator.New())
 
 	ctx := context.Background()
 	transactionID := uuid.New()
@@ -60,7 +61,7 @@ func TestTransactionService_GetTransactionByReferenceID(t *testing.T) {
 	require.NoError(t, err)
 
 	repo := transactions.NewSQLRepository(gormDB)
-	service := transactions.NewTransactionService(repo, validator.New())
+	service := transactions.NewTransactionService(repo, outbox.NewOutboxService(outbox.NewSQLRepository(gormDB)), validator.New())
 
 	ctx := context.Background()
 	referenceID := uuid.New()
@@ -94,7 +95,7 @@ func TestTransactionService_BeginTransaction(t *testing.T) {
 	require.NoError(t, err)
 
 	repo := transactions.NewSQLRepository(gormDB)
-	service := transactions.NewTransactionService(repo, validator.New())
+	service := transactions.NewTransactionService(repo, outbox.NewOutboxService(outbox.NewSQLRepository(gormDB)), validator.New())
 
 	ctx := context.Background()
 
@@ -108,3 +109,52 @@ func TestTransactionService_BeginTransaction(t *testing.T) {
 	// Ensure all expectations were met
 	require.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestTransactionService_FailTransaction_RecordsTransferFailed(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	gormDB, err := gorm.Open(postgres.New(postgres.Config{
+		Conn: db,
+	}), &gorm.Config{})
+	require.NoError(t, err)
+
+	repo := transactions.NewSQLRepository(gormDB)
+	service := transactions.NewTransactionService(repo, outbox.NewOutboxService(outbox.NewSQLRepository(gormDB)), validator.New())
+
+	ctx := context.Background()
+	transactionID := uuid.New()
+	accountID := uuid.New()
+	referenceID := uuid.New()
+
+	columns := []string{"id", "account_id", "reference_id", "amount", "currency_code", "transaction_type", "metadata", "status"}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE id = \$1 ORDER BY "transactions"."id" LIMIT \$2 FOR UPDATE`).
+		WithArgs(transactionID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(
+			transactionID, accountID, referenceID, 100, "USD", constants.TransactionTypeOUTBOUND, `{}`, constants.TransactionStatusPENDING,
+		))
+	mock.ExpectExec(`UPDATE "transactions" SET "metadata"=\$1,"status"=\$2,"updated_at"=\$3 WHERE "id" = \$4`).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery(`SELECT \* FROM "transactions" WHERE "transactions"."id" = \$1 ORDER BY "transactions"."id" LIMIT \$2`).
+		WithArgs(transactionID, 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(
+			transactionID, accountID, referenceID, 100, "USD", constants.TransactionTypeOUTBOUND,
+			`{"CancellationReason":"duplicate payment"}`, constants.TransactionStatusFAILURE,
+		))
+	mock.ExpectQuery(`INSERT INTO "outbox_events" .* VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9,\$10\) RETURNING "sequence"`).
+		WithArgs(
+			sqlmock.AnyArg(), constants.OutboxEventTypeTransferFailed, 1, outbox.AggregateTypeAccount, accountID,
+			sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 0, nil,
+		).
+		WillReturnRows(sqlmock.NewRows([]string{"sequence"}).AddRow(1))
+	mock.ExpectCommit()
+
+	transaction, err := service.FailTransaction(ctx, transactionID, map[string]interface{}{"CancellationReason": "duplicate payment"})
+	require.NoError(t, err)
+	assert.Equal(t, constants.TransactionStatusFAILURE, transaction.Status)
+
+	require.NoError(t, mock.ExpectationsWereMet())
+}
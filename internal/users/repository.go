@@ -9,10 +9,12 @@ import (
 
 type Repository interface {
 	Create(ctx context.Context, user *User) (*User, error)
+	CreateWithTx(ctx context.Context, user *User, tx *gorm.DB) (*User, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	Update(ctx context.Context, user *User) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	Transaction(ctx context.Context, fn func(*gorm.DB) error) error
 }
 
 type SQLRepository struct {
@@ -30,6 +32,16 @@ func (r *SQLRepository) Create(ctx context.Context, user *User) (*User, error) {
 	return user, nil
 }
 
+func (r *SQLRepository) CreateWithTx(ctx context.Context, user *User, tx *gorm.DB) (*User, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+	if err := tx.WithContext(ctx).Create(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
 func (r *SQLRepository) GetByID(ctx context.Context, id uuid.UUID) (*User, error) {
 	var user User
 	if err := r.db.WithContext(ctx).First(&user, "id = ?", id).Error; err != nil {
@@ -65,3 +77,7 @@ func (r *SQLRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	return nil
 }
+
+func (r *SQLRepository) Transaction(ctx context.Context, fn func(*gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
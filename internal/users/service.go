@@ -5,6 +5,8 @@ import (
 	"errors"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+	"ulascansenturk/service/internal/outbox"
 )
 
 type Service interface {
@@ -16,13 +18,15 @@ type Service interface {
 }
 
 type UserServiceImpl struct {
-	repo Repository
+	repo          Repository
+	outboxService outbox.Service
 }
 
-func NewUserService(repo Repository) *UserServiceImpl {
-	return &UserServiceImpl{repo: repo}
+func NewUserService(repo Repository, outboxService outbox.Service) *UserServiceImpl {
+	return &UserServiceImpl{repo: repo, outboxService: outboxService}
 }
 
+// CreateUser creates the user and writes a UserCreated event with it.
 func (s *UserServiceImpl) CreateUser(ctx context.Context, user *User, password string) (*User, error) {
 	if user.Email == "" {
 		return nil, errors.New("email is required")
@@ -35,7 +39,26 @@ func (s *UserServiceImpl) CreateUser(ctx context.Context, user *User, password s
 
 	user.PasswordHash = hashedPassword
 
-	return s.repo.Create(ctx, user)
+	var createdUser *User
+
+	err = s.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		var createErr error
+
+		createdUser, createErr = s.repo.CreateWithTx(ctx, user, tx)
+		if createErr != nil {
+			return createErr
+		}
+
+		return s.outboxService.RecordWithTx(ctx, []outbox.Payload{&outbox.UserCreated{
+			UserID:    createdUser.ID,
+			CreatedAt: createdUser.CreatedAt.UTC(),
+		}}, tx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdUser, nil
 }
 
 func hashPassword(password string) (string, error) {
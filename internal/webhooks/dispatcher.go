@@ -0,0 +1,88 @@
+package webhooks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"go.temporal.io/api/enums/v1"
+	"go.temporal.io/api/serviceerror"
+	"go.temporal.io/sdk/client"
+	"ulascansenturk/service/internal/outbox"
+)
+
+// DeliveryWorkflowType is the name the WebhookDelivery workflow is registered with.
+const DeliveryWorkflowType = "WebhookDelivery"
+
+// DeliveryParams starts the WebhookDelivery workflow of a delivery.
+type DeliveryParams struct {
+	DeliveryID uuid.UUID
+}
+
+// DeliveryWorkflowID is the ID of the WebhookDelivery workflow of a delivery, a redelivery gets a new one so it
+// isn't rejected as a duplicate of the run that ended.
+func DeliveryWorkflowID(delivery *Delivery) string {
+	if delivery.Redeliveries == 0 {
+		return "webhook-delivery-" + delivery.ID.String()
+	}
+
+	return fmt.Sprintf("webhook-delivery-%s-%d", delivery.ID, delivery.Redeliveries)
+}
+
+// Dispatcher is the outbox publisher of the webhooks, it records the deliveries of an event and starts their
+// WebhookDelivery workflows. Publishing an event again starts only the workflows that didn't start.
+type Dispatcher struct {
+	service                Service
+	temporalClient         client.Client
+	transfersTaskQueueName string
+}
+
+func NewDispatcher(service Service, temporalClient client.Client, transfersTaskQueueName string) *Dispatcher {
+	return &Dispatcher{
+		service:                service,
+		temporalClient:         temporalClient,
+		transfersTaskQueueName: transfersTaskQueueName,
+	}
+}
+
+func (d *Dispatcher) Publish(ctx context.Context, envelope outbox.Envelope) error {
+	deliveries, err := d.service.RecordDeliveries(ctx, envelope)
+	if err != nil {
+		return fmt.Errorf("webhook deliveries recording failed: %w", err)
+	}
+
+	for _, delivery := range deliveries {
+		if !delivery.IsPending() {
+			continue
+		}
+
+		if err = d.StartDelivery(ctx, delivery); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// StartDelivery starts the WebhookDelivery workflow of the delivery, nothing happens when it was started already.
+func (d *Dispatcher) StartDelivery(ctx context.Context, delivery *Delivery) error {
+	_, err := d.temporalClient.ExecuteWorkflow(
+		ctx,
+		client.StartWorkflowOptions{
+			ID:                    DeliveryWorkflowID(delivery),
+			TaskQueue:             d.transfersTaskQueueName,
+			WorkflowIDReusePolicy: enums.WORKFLOW_ID_REUSE_POLICY_REJECT_DUPLICATE,
+		},
+		DeliveryWorkflowType,
+		DeliveryParams{DeliveryID: delivery.ID},
+	)
+	if err != nil {
+		var alreadyStartedErr *serviceerror.WorkflowExecutionAlreadyStarted
+		if !errors.As(err, &alreadyStartedErr) {
+			return fmt.Errorf("webhook delivery start failed: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+package webhooks
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/datatypes"
+	"time"
+	"ulascansenturk/service/internal/constants"
+)
+
+// Subscription sends the events of EventTypes about AccountID to URL, every request is signed with Secret.
+// ConsecutiveFailures counts the deliveries that failed since the last one that went through, the subscription
+// is disabled once it reaches the limit.
+type Subscription struct {
+	ID                  uuid.UUID                                      `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID           uuid.UUID                                      `gorm:"type:uuid;not null"`
+	URL                 string                                         `gorm:"type:text;not null"`
+	Secret              string                                         `gorm:"type:varchar(100);not null"`
+	EventTypes          datatypes.JSONSlice[constants.OutboxEventType] `gorm:"type:jsonb;not null"`
+	Description         *string                                        `gorm:"type:text"`
+	Status              constants.WebhookSubscriptionStatus            `gorm:"type:varchar(20);not null"`
+	ConsecutiveFailures int                                            `gorm:"not null;default:0"`
+	DisabledAt          *time.Time                                     `gorm:"type:timestamp with time zone"`
+	CreatedAt           time.Time                                      `gorm:"type:timestamp with time zone;not null"`
+	UpdatedAt           time.Time                                      `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Subscription) TableName() string {
+	return "webhook_subscriptions"
+}
+
+func (s *Subscription) IsActive() bool {
+	return s.Status == constants.WebhookSubscriptionStatusACTIVE
+}
+
+// Delivery is the delivery of an outbox event to a subscription, Payload is the envelope of the event as it is
+// sent. Attempts counts the requests sent, Redeliveries the times the delivery was started again by hand.
+type Delivery struct {
+	ID                 uuid.UUID                       `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SubscriptionID     uuid.UUID                       `gorm:"type:uuid;not null"`
+	EventID            uuid.UUID                       `gorm:"type:uuid;not null"`
+	EventType          constants.OutboxEventType       `gorm:"type:varchar(50);not null"`
+	Payload            datatypes.JSON                  `gorm:"type:jsonb;not null"`
+	Status             constants.WebhookDeliveryStatus `gorm:"type:varchar(20);not null"`
+	Attempts           int                             `gorm:"not null;default:0"`
+	Redeliveries       int                             `gorm:"not null;default:0"`
+	LastResponseStatus *int
+	LastError          *string    `gorm:"type:text"`
+	LastAttemptedAt    *time.Time `gorm:"type:timestamp with time zone"`
+	DeliveredAt        *time.Time `gorm:"type:timestamp with time zone"`
+	CreatedAt          time.Time  `gorm:"type:timestamp with time zone;not null"`
+	UpdatedAt          time.Time  `gorm:"type:timestamp with time zone;not null"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+func (d *Delivery) IsPending() bool {
+	return d.Status == constants.WebhookDeliveryStatusPENDING
+}
+
+// Attempt is the outcome of one request of a delivery. ResponseStatus is nil when no response came back.
+type Attempt struct {
+	ResponseStatus *int
+	Err            error
+	AttemptedAt    time.Time
+}
+
+func (a Attempt) Succeeded() bool {
+	return a.Err == nil && a.ResponseStatus != nil && *a.ResponseStatus >= 200 && *a.ResponseStatus < 300
+}
@@ -0,0 +1,205 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"ulascansenturk/service/internal/constants"
+)
+
+type Repository interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) error
+	GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	GetSubscriptionForUpdate(ctx context.Context, id uuid.UUID, tx *gorm.DB) (*Subscription, error)
+	ListSubscriptionsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Subscription, error)
+	ListActiveSubscriptions(ctx context.Context, accountIDs []uuid.UUID, eventType constants.OutboxEventType) ([]*Subscription, error)
+	UpdateSubscriptionWithTx(ctx context.Context, subscription *Subscription, tx *gorm.DB) error
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	FindOrCreateDeliveries(ctx context.Context, deliveries []*Delivery) ([]*Delivery, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	GetDeliveryForUpdate(ctx context.Context, id uuid.UUID, tx *gorm.DB) (*Delivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*Delivery, error)
+	UpdateDeliveryWithTx(ctx context.Context, delivery *Delivery, tx *gorm.DB) error
+	Transaction(ctx context.Context, fn func(*gorm.DB) error) error
+}
+
+type SQLRepository struct {
+	db *gorm.DB
+}
+
+// NewSQLRepository creates a new SQLRepository
+func NewSQLRepository(db *gorm.DB) *SQLRepository {
+	return &SQLRepository{db: db}
+}
+
+func (r *SQLRepository) CreateSubscription(ctx context.Context, subscription *Subscription) error {
+	return r.db.WithContext(ctx).Create(subscription).Error
+}
+
+func (r *SQLRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error) {
+	var subscription Subscription
+	if err := r.db.WithContext(ctx).First(&subscription, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+func (r *SQLRepository) GetSubscriptionForUpdate(ctx context.Context, id uuid.UUID, tx *gorm.DB) (*Subscription, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+
+	var subscription Subscription
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&subscription, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &subscription, nil
+}
+
+// ListSubscriptionsByAccountID returns the subscriptions of the account, newest first.
+func (r *SQLRepository) ListSubscriptionsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Subscription, error) {
+	var subscriptions []*Subscription
+
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("created_at DESC").Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+// ListActiveSubscriptions returns the active subscriptions of the accounts to the event type.
+func (r *SQLRepository) ListActiveSubscriptions(
+	ctx context.Context,
+	accountIDs []uuid.UUID,
+	eventType constants.OutboxEventType,
+) ([]*Subscription, error) {
+	var subscriptions []*Subscription
+
+	if len(accountIDs) == 0 {
+		return subscriptions, nil
+	}
+
+	eventTypes, err := json.Marshal([]constants.OutboxEventType{eventType})
+	if err != nil {
+		return nil, err
+	}
+
+	err = r.db.WithContext(ctx).
+		Where("account_id IN ?", accountIDs).
+		Where("status = ?", constants.WebhookSubscriptionStatusACTIVE).
+		Where("event_types @> ?::jsonb", string(eventTypes)).
+		Order("created_at").
+		Find(&subscriptions).Error
+	if err != nil {
+		return nil, err
+	}
+	return subscriptions, nil
+}
+
+func (r *SQLRepository) UpdateSubscriptionWithTx(ctx context.Context, subscription *Subscription, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	return tx.WithContext(ctx).Save(subscription).Error
+}
+
+// DeleteSubscription deletes the subscription with its deliveries.
+func (r *SQLRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	return r.db.WithContext(ctx).Delete(&Subscription{}, "id = ?", id).Error
+}
+
+// FindOrCreateDeliveries creates the deliveries that don't exist yet and returns the deliveries of their events to
+// their subscriptions, the ones created before included. The deliveries must be of a single event.
+func (r *SQLRepository) FindOrCreateDeliveries(ctx context.Context, deliveries []*Delivery) ([]*Delivery, error) {
+	var found []*Delivery
+
+	if len(deliveries) == 0 {
+		return found, nil
+	}
+
+	subscriptionIDs := make([]uuid.UUID, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		subscriptionIDs = append(subscriptionIDs, delivery.SubscriptionID)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "subscription_id"}, {Name: "event_id"}},
+			DoNothing: true,
+		}).Create(deliveries).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("event_id = ? AND subscription_id IN ?", deliveries[0].EventID, subscriptionIDs).
+			Order("created_at").
+			Find(&found).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return found, nil
+}
+
+func (r *SQLRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	var delivery Delivery
+	if err := r.db.WithContext(ctx).First(&delivery, "id = ?", id).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+func (r *SQLRepository) GetDeliveryForUpdate(ctx context.Context, id uuid.UUID, tx *gorm.DB) (*Delivery, error) {
+	if tx == nil {
+		return nil, errors.New("transaction is required")
+	}
+
+	var delivery Delivery
+	err := tx.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&delivery, "id = ?", id).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// ListDeliveries returns the latest deliveries of the subscription, newest first.
+func (r *SQLRepository) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*Delivery, error) {
+	var deliveries []*Delivery
+
+	err := r.db.WithContext(ctx).
+		Where("subscription_id = ?", subscriptionID).
+		Order("created_at DESC").
+		Limit(limit).
+		Find(&deliveries).Error
+	if err != nil {
+		return nil, err
+	}
+	return deliveries, nil
+}
+
+func (r *SQLRepository) UpdateDeliveryWithTx(ctx context.Context, delivery *Delivery, tx *gorm.DB) error {
+	if tx == nil {
+		return errors.New("transaction is required")
+	}
+	return tx.WithContext(ctx).Save(delivery).Error
+}
+
+func (r *SQLRepository) Transaction(ctx context.Context, fn func(*gorm.DB) error) error {
+	return r.db.WithContext(ctx).Transaction(fn)
+}
@@ -0,0 +1,81 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	SignatureHeader  = "X-Webhook-Signature"
+	DeliveryIDHeader = "X-Webhook-Delivery-ID"
+	EventIDHeader    = "X-Event-ID"
+	EventTypeHeader  = "X-Event-Type"
+)
+
+// Sign returns the value of the signature header of a request sent at timestamp: t=<unix seconds>,v1=<hex
+// HMAC-SHA256 of "<unix seconds>.<body>" keyed with the secret>. Receivers recompute it and reject the requests with
+// an old timestamp so a captured request can't be replayed.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%s,v1=%s", unix, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// Sender sends the deliveries to the URLs of their subscriptions.
+type Sender struct {
+	client *http.Client
+}
+
+func NewSender(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// Send posts the payload of the delivery signed with the secret of the subscription, it never returns an error,
+// a request that didn't get a 2xx response is a failed attempt.
+func (s *Sender) Send(ctx context.Context, subscription *Subscription, delivery *Delivery, now time.Time) Attempt {
+	attempt := Attempt{AttemptedAt: now}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		attempt.Err = err
+
+		return attempt
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(subscription.Secret, now, delivery.Payload))
+	req.Header.Set(DeliveryIDHeader, delivery.ID.String())
+	req.Header.Set(EventIDHeader, delivery.EventID.String())
+	req.Header.Set(EventTypeHeader, delivery.EventType.String())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		attempt.Err = err
+
+		return attempt
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	attempt.ResponseStatus = &resp.StatusCode
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		attempt.Err = fmt.Errorf("webhook endpoint responded %d", resp.StatusCode)
+	}
+
+	return attempt
+}
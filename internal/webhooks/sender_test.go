@@ -0,0 +1,102 @@
+//go:build tests_unit
+
+package webhooks_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/webhooks"
+)
+
+func TestSign(t *testing.T) {
+	body := []byte(`{"id":"8c1f0c4e-7c36-4a8e-9a55-2f6f3c1f2b11"}`)
+	timestamp := time.Unix(1727773200, 0)
+
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	mac.Write([]byte("1727773200." + string(body)))
+
+	assert.Equal(t, "t=1727773200,v1="+hex.EncodeToString(mac.Sum(nil)), webhooks.Sign("whsec_test", timestamp, body))
+	assert.NotEqual(t, webhooks.Sign("whsec_test", timestamp, body), webhooks.Sign("whsec_other", timestamp, body))
+	assert.NotEqual(t, webhooks.Sign("whsec_test", timestamp, body), webhooks.Sign("whsec_test", timestamp.Add(time.Second), body))
+}
+
+func TestSender_Send(t *testing.T) {
+	now := time.Date(2024, 10, 4, 9, 0, 0, 0, time.UTC)
+
+	delivery := &webhooks.Delivery{
+		ID:        uuid.New(),
+		EventID:   uuid.New(),
+		EventType: constants.OutboxEventTypeTransferCompleted,
+		Payload:   []byte(`{"type":"TransferCompleted"}`),
+	}
+
+	t.Run("Signed request accepted by the endpoint", func(t *testing.T) {
+		var received *http.Request
+		var receivedBody []byte
+
+		endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			received = r
+			receivedBody, _ = io.ReadAll(r.Body)
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer endpoint.Close()
+
+		subscription := &webhooks.Subscription{URL: endpoint.URL, Secret: "whsec_test"}
+
+		attempt := webhooks.NewSender(endpoint.Client()).Send(context.Background(), subscription, delivery, now)
+
+		require.NoError(t, attempt.Err)
+		assert.True(t, attempt.Succeeded())
+		assert.Equal(t, http.StatusNoContent, *attempt.ResponseStatus)
+		assert.Equal(t, now, attempt.AttemptedAt)
+
+		assert.Equal(t, http.MethodPost, received.Method)
+		assert.Equal(t, []byte(delivery.Payload), receivedBody)
+		assert.Equal(t, webhooks.Sign("whsec_test", now, receivedBody), received.Header.Get(webhooks.SignatureHeader))
+		assert.Equal(t, delivery.ID.String(), received.Header.Get(webhooks.DeliveryIDHeader))
+		assert.Equal(t, delivery.EventID.String(), received.Header.Get(webhooks.EventIDHeader))
+		assert.Equal(t, "TransferCompleted", received.Header.Get(webhooks.EventTypeHeader))
+	})
+
+	t.Run("Response other than 2xx fails the attempt", func(t *testing.T) {
+		endpoint := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer endpoint.Close()
+
+		subscription := &webhooks.Subscription{URL: endpoint.URL, Secret: "whsec_test"}
+
+		attempt := webhooks.NewSender(endpoint.Client()).Send(context.Background(), subscription, delivery, now)
+
+		assert.EqualError(t, attempt.Err, "webhook endpoint responded 503")
+		assert.False(t, attempt.Succeeded())
+		assert.Equal(t, http.StatusServiceUnavailable, *attempt.ResponseStatus)
+	})
+
+	t.Run("Unreachable endpoint fails the attempt without a status", func(t *testing.T) {
+		endpoint := httptest.NewServer(http.NotFoundHandler())
+		endpoint.Close()
+
+		subscription := &webhooks.Subscription{URL: endpoint.URL, Secret: "whsec_test"}
+
+		attempt := webhooks.NewSender(http.DefaultClient).Send(context.Background(), subscription, delivery, now)
+
+		assert.Error(t, attempt.Err)
+		assert.False(t, attempt.Succeeded())
+		assert.Nil(t, attempt.ResponseStatus)
+	})
+}
@@ -0,0 +1,442 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"net/url"
+	"time"
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/outbox"
+)
+
+const secretPrefix = "whsec_"
+
+var (
+	ErrInvalidSubscription  = errors.New("invalid webhook subscription")
+	ErrSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrSubscriptionDisabled = errors.New("webhook subscription is disabled")
+	ErrDeliveryNotFound     = errors.New("webhook delivery not found")
+	ErrDeliveryInProgress   = errors.New("webhook delivery is still in progress")
+)
+
+// SubscribableEventTypes are the event types a subscription can pick, the events about an account.
+var SubscribableEventTypes = []constants.OutboxEventType{
+	constants.OutboxEventTypeTransferCompleted,
+	constants.OutboxEventTypeTransferFailed,
+	constants.OutboxEventTypeAccountCreated,
+	constants.OutboxEventTypeAccountStatusChanged,
+	constants.OutboxEventTypeBalanceChanged,
+}
+
+type Service interface {
+	CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error)
+	GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	ListSubscriptions(ctx context.Context, accountID uuid.UUID) ([]*Subscription, error)
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	EnableSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error)
+	RecordDeliveries(ctx context.Context, envelope outbox.Envelope) ([]*Delivery, error)
+	GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*Delivery, error)
+	RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt Attempt) (*Delivery, error)
+	FailDelivery(ctx context.Context, deliveryID uuid.UUID, reason string) (*Delivery, error)
+	Redeliver(ctx context.Context, deliveryID uuid.UUID) (*Delivery, error)
+}
+
+type WebhookServiceImpl struct {
+	repo                   Repository
+	accountRepo            accounts.Repository
+	maxConsecutiveFailures int
+}
+
+func NewWebhookService(repo Repository, accountRepo accounts.Repository, maxConsecutiveFailures int) *WebhookServiceImpl {
+	return &WebhookServiceImpl{
+		repo:                   repo,
+		accountRepo:            accountRepo,
+		maxConsecutiveFailures: maxConsecutiveFailures,
+	}
+}
+
+// CreateSubscription subscribes the account of the subscription, the secret the requests are signed with is
+// generated here and only returned with the created subscription.
+func (s *WebhookServiceImpl) CreateSubscription(ctx context.Context, subscription *Subscription) (*Subscription, error) {
+	if err := validateSubscription(subscription); err != nil {
+		return nil, err
+	}
+
+	account, err := s.accountRepo.GetByID(ctx, subscription.AccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account == nil {
+		return nil, accounts.ErrAccountNotFound
+	}
+
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	subscription.Secret = secret
+	subscription.Status = constants.WebhookSubscriptionStatusACTIVE
+	subscription.ConsecutiveFailures = 0
+	subscription.DisabledAt = nil
+
+	if err = s.repo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+func (s *WebhookServiceImpl) GetSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error) {
+	subscription, err := s.repo.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscription == nil {
+		return nil, ErrSubscriptionNotFound
+	}
+	return subscription, nil
+}
+
+func (s *WebhookServiceImpl) ListSubscriptions(ctx context.Context, accountID uuid.UUID) ([]*Subscription, error) {
+	return s.repo.ListSubscriptionsByAccountID(ctx, accountID)
+}
+
+// DeleteSubscription deletes the subscription and its delivery log, the deliveries still being retried stop.
+func (s *WebhookServiceImpl) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.GetSubscription(ctx, id); err != nil {
+		return err
+	}
+
+	return s.repo.DeleteSubscription(ctx, id)
+}
+
+// EnableSubscription activates a disabled subscription again with a clean failure count, the deliveries that
+// failed while it was disabled are only sent again when they are redelivered.
+func (s *WebhookServiceImpl) EnableSubscription(ctx context.Context, id uuid.UUID) (*Subscription, error) {
+	var enabled *Subscription
+
+	err := s.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		subscription, err := s.repo.GetSubscriptionForUpdate(ctx, id, tx)
+		if err != nil {
+			return err
+		}
+
+		if subscription == nil {
+			return ErrSubscriptionNotFound
+		}
+
+		if !subscription.IsActive() {
+			subscription.Status = constants.WebhookSubscriptionStatusACTIVE
+			subscription.ConsecutiveFailures = 0
+			subscription.DisabledAt = nil
+
+			if err = s.repo.UpdateSubscriptionWithTx(ctx, subscription, tx); err != nil {
+				return err
+			}
+		}
+
+		enabled = subscription
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return enabled, nil
+}
+
+// RecordDeliveries records a delivery of the event for every active subscription of the accounts it is about and
+// returns them. Recording the deliveries of an event again returns the deliveries recorded the first time.
+func (s *WebhookServiceImpl) RecordDeliveries(ctx context.Context, envelope outbox.Envelope) ([]*Delivery, error) {
+	accountIDs, err := accountsOf(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptions, err := s.repo.ListActiveSubscriptions(ctx, accountIDs, envelope.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(subscriptions) == 0 {
+		return nil, nil
+	}
+
+	payload, err := json.Marshal(envelope)
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*Delivery, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		deliveries = append(deliveries, &Delivery{
+			ID:             uuid.New(),
+			SubscriptionID: subscription.ID,
+			EventID:        envelope.ID,
+			EventType:      envelope.Type,
+			Payload:        payload,
+			Status:         constants.WebhookDeliveryStatusPENDING,
+		})
+	}
+
+	return s.repo.FindOrCreateDeliveries(ctx, deliveries)
+}
+
+func (s *WebhookServiceImpl) GetDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error) {
+	delivery, err := s.repo.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery == nil {
+		return nil, ErrDeliveryNotFound
+	}
+	return delivery, nil
+}
+
+func (s *WebhookServiceImpl) ListDeliveries(ctx context.Context, subscriptionID uuid.UUID, limit int) ([]*Delivery, error) {
+	return s.repo.ListDeliveries(ctx, subscriptionID, limit)
+}
+
+// RecordAttempt records the outcome of a request of the delivery. A successful request completes the delivery and
+// resets the failure count of its subscription.
+func (s *WebhookServiceImpl) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt Attempt) (*Delivery, error) {
+	var recorded *Delivery
+
+	err := s.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		delivery, err := s.repo.GetDeliveryForUpdate(ctx, deliveryID, tx)
+		if err != nil {
+			return err
+		}
+
+		if delivery == nil {
+			return ErrDeliveryNotFound
+		}
+
+		attemptedAt := attempt.AttemptedAt.UTC()
+
+		delivery.Attempts++
+		delivery.LastResponseStatus = attempt.ResponseStatus
+		delivery.LastAttemptedAt = &attemptedAt
+		delivery.LastError = nil
+
+		if attempt.Err != nil {
+			lastError := attempt.Err.Error()
+			delivery.LastError = &lastError
+		}
+
+		if attempt.Succeeded() {
+			delivery.Status = constants.WebhookDeliveryStatusSUCCEEDED
+			delivery.DeliveredAt = &attemptedAt
+
+			subscription, err := s.repo.GetSubscriptionForUpdate(ctx, delivery.SubscriptionID, tx)
+			if err != nil {
+				return err
+			}
+
+			if subscription != nil && subscription.ConsecutiveFailures > 0 {
+				subscription.ConsecutiveFailures = 0
+
+				if err = s.repo.UpdateSubscriptionWithTx(ctx, subscription, tx); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err = s.repo.UpdateDeliveryWithTx(ctx, delivery, tx); err != nil {
+			return err
+		}
+
+		recorded = delivery
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return recorded, nil
+}
+
+// FailDelivery gives up on the delivery once its retries are exhausted. The failure counts against its subscription,
+// which is disabled when maxConsecutiveFailures deliveries failed in a row.
+func (s *WebhookServiceImpl) FailDelivery(ctx context.Context, deliveryID uuid.UUID, reason string) (*Delivery, error) {
+	var failed *Delivery
+
+	err := s.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		delivery, err := s.repo.GetDeliveryForUpdate(ctx, deliveryID, tx)
+		if err != nil {
+			return err
+		}
+
+		if delivery == nil {
+			return ErrDeliveryNotFound
+		}
+
+		if !delivery.IsPending() {
+			failed = delivery
+
+			return nil
+		}
+
+		delivery.Status = constants.WebhookDeliveryStatusFAILED
+		if delivery.LastError == nil {
+			delivery.LastError = &reason
+		}
+
+		if err = s.repo.UpdateDeliveryWithTx(ctx, delivery, tx); err != nil {
+			return err
+		}
+
+		subscription, err := s.repo.GetSubscriptionForUpdate(ctx, delivery.SubscriptionID, tx)
+		if err != nil {
+			return err
+		}
+
+		if subscription != nil && subscription.IsActive() {
+			subscription.ConsecutiveFailures++
+
+			if subscription.ConsecutiveFailures >= s.maxConsecutiveFailures {
+				disabledAt := time.Now().UTC()
+
+				subscription.Status = constants.WebhookSubscriptionStatusDISABLED
+				subscription.DisabledAt = &disabledAt
+			}
+
+			if err = s.repo.UpdateSubscriptionWithTx(ctx, subscription, tx); err != nil {
+				return err
+			}
+		}
+
+		failed = delivery
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return failed, nil
+}
+
+// Redeliver puts a delivery that succeeded or failed back to PENDING so it is sent again, the subscription must be
+// active.
+func (s *WebhookServiceImpl) Redeliver(ctx context.Context, deliveryID uuid.UUID) (*Delivery, error) {
+	var redelivered *Delivery
+
+	err := s.repo.Transaction(ctx, func(tx *gorm.DB) error {
+		delivery, err := s.repo.GetDeliveryForUpdate(ctx, deliveryID, tx)
+		if err != nil {
+			return err
+		}
+
+		if delivery == nil {
+			return ErrDeliveryNotFound
+		}
+
+		if delivery.IsPending() {
+			return ErrDeliveryInProgress
+		}
+
+		subscription, err := s.repo.GetSubscriptionForUpdate(ctx, delivery.SubscriptionID, tx)
+		if err != nil {
+			return err
+		}
+
+		if subscription == nil {
+			return ErrSubscriptionNotFound
+		}
+
+		if !subscription.IsActive() {
+			return ErrSubscriptionDisabled
+		}
+
+		delivery.Status = constants.WebhookDeliveryStatusPENDING
+		delivery.Redeliveries++
+
+		if err = s.repo.UpdateDeliveryWithTx(ctx, delivery, tx); err != nil {
+			return err
+		}
+
+		redelivered = delivery
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return redelivered, nil
+}
+
+func validateSubscription(subscription *Subscription) error {
+	target, err := url.Parse(subscription.URL)
+	if err != nil || (target.Scheme != "https" && target.Scheme != "http") || target.Host == "" {
+		return fmt.Errorf("%w: url must be an absolute http or https URL", ErrInvalidSubscription)
+	}
+
+	if len(subscription.EventTypes) == 0 {
+		return fmt.Errorf("%w: at least one event type is required", ErrInvalidSubscription)
+	}
+
+	for _, eventType := range subscription.EventTypes {
+		if !isSubscribable(eventType) {
+			return fmt.Errorf("%w: event type %q can't be subscribed to", ErrInvalidSubscription, eventType)
+		}
+	}
+
+	return nil
+}
+
+func isSubscribable(eventType constants.OutboxEventType) bool {
+	for _, subscribable := range SubscribableEventTypes {
+		if eventType == subscribable {
+			return true
+		}
+	}
+
+	return false
+}
+
+func generateSecret() (string, error) {
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return secretPrefix + hex.EncodeToString(secret), nil
+}
+
+// accountsOf returns the accounts an event is about, a completed transfer is about both of its accounts.
+func accountsOf(envelope outbox.Envelope) ([]uuid.UUID, error) {
+	if envelope.AggregateType != outbox.AggregateTypeAccount {
+		return nil, nil
+	}
+
+	var data struct {
+		DestinationAccountID *uuid.UUID `json:"destination_account_id"`
+	}
+
+	if err := json.Unmarshal(envelope.Data, &data); err != nil {
+		return nil, err
+	}
+
+	accountIDs := []uuid.UUID{envelope.AggregateID}
+	if data.DestinationAccountID != nil && *data.DestinationAccountID != envelope.AggregateID {
+		accountIDs = append(accountIDs, *data.DestinationAccountID)
+	}
+
+	return accountIDs, nil
+}
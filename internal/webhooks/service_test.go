@@ -0,0 +1,108 @@
+//go:build tests_unit
+
+package webhooks_test
+
+import (
+	"context"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"testing"
+	"time"
+
+	"ulascansenturk/service/internal/accounts"
+	"ulascansenturk/service/internal/constants"
+	"ulascansenturk/service/internal/webhooks"
+)
+
+func TestWebhookService_FailDelivery(t *testing.T) {
+	ctx := context.Background()
+	deliveryID := uuid.New()
+	subscriptionID := uuid.New()
+	createdAt := time.Date(2024, 10, 4, 9, 0, 0, 0, time.UTC)
+
+	newService := func(t *testing.T) (*webhooks.WebhookServiceImpl, sqlmock.Sqlmock) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { db.Close() })
+
+		gormDB, err := gorm.Open(postgres.New(postgres.Config{
+			Conn: db,
+		}), &gorm.Config{})
+		require.NoError(t, err)
+
+		return webhooks.NewWebhookService(webhooks.NewSQLRepository(gormDB), accounts.NewSQLRepository(gormDB), 5), mock
+	}
+
+	expectDelivery := func(mock sqlmock.Sqlmock, status constants.WebhookDeliveryStatus) {
+		mock.ExpectQuery(`SELECT \* FROM "webhook_deliveries" WHERE id = \$1 ORDER BY .* FOR UPDATE`).
+			WithArgs(deliveryID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "subscription_id", "event_id", "event_type", "payload", "status", "attempts", "created_at"}).
+				AddRow(deliveryID, subscriptionID, uuid.New(), "TransferCompleted", []byte(`{}`), status, 10, createdAt))
+	}
+
+	expectSubscription := func(mock sqlmock.Sqlmock, consecutiveFailures int) {
+		mock.ExpectQuery(`SELECT \* FROM "webhook_subscriptions" WHERE id = \$1 ORDER BY .* FOR UPDATE`).
+			WithArgs(subscriptionID, 1).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "account_id", "url", "secret", "event_types", "status", "consecutive_failures", "created_at"}).
+				AddRow(subscriptionID, uuid.New(), "https://example.com/hooks", "whsec_test", []byte(`["TransferCompleted"]`),
+					constants.WebhookSubscriptionStatusACTIVE, consecutiveFailures, createdAt))
+	}
+
+	expectSubscriptionSaved := func(mock sqlmock.Sqlmock, status constants.WebhookSubscriptionStatus, consecutiveFailures int) {
+		mock.ExpectExec(`UPDATE "webhook_subscriptions" SET .*"status"=\$6,"consecutive_failures"=\$7,"disabled_at"=\$8`).
+			WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(),
+				status, consecutiveFailures, sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), subscriptionID).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+
+	t.Run("Failure counts against the subscription", func(t *testing.T) {
+		service, mock := newService(t)
+
+		mock.ExpectBegin()
+		expectDelivery(mock, constants.WebhookDeliveryStatusPENDING)
+		mock.ExpectExec(`UPDATE "webhook_deliveries" SET .*"status"=\$5`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectSubscription(mock, 1)
+		expectSubscriptionSaved(mock, constants.WebhookSubscriptionStatusACTIVE, 2)
+		mock.ExpectCommit()
+
+		delivery, err := service.FailDelivery(ctx, deliveryID, "webhook endpoint responded 503")
+		require.NoError(t, err)
+		assert.Equal(t, constants.WebhookDeliveryStatusFAILED, delivery.Status)
+		assert.Equal(t, "webhook endpoint responded 503", *delivery.LastError)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Subscription is disabled at the consecutive failure limit", func(t *testing.T) {
+		service, mock := newService(t)
+
+		mock.ExpectBegin()
+		expectDelivery(mock, constants.WebhookDeliveryStatusPENDING)
+		mock.ExpectExec(`UPDATE "webhook_deliveries"`).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		expectSubscription(mock, 4)
+		expectSubscriptionSaved(mock, constants.WebhookSubscriptionStatusDISABLED, 5)
+		mock.ExpectCommit()
+
+		_, err := service.FailDelivery(ctx, deliveryID, "webhook endpoint responded 503")
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Delivery that already ended is left as is", func(t *testing.T) {
+		service, mock := newService(t)
+
+		mock.ExpectBegin()
+		expectDelivery(mock, constants.WebhookDeliveryStatusSUCCEEDED)
+		mock.ExpectCommit()
+
+		delivery, err := service.FailDelivery(ctx, deliveryID, "webhook endpoint responded 503")
+		require.NoError(t, err)
+		assert.Equal(t, constants.WebhookDeliveryStatusSUCCEEDED, delivery.Status)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}